@@ -30,7 +30,7 @@ var (
 )
 
 type config struct {
-	Network          string `long:"network" description:"Network to use"`
+	Network          string `long:"network" description:"Network to use {mainnet, testnet, simnet, regnet}"`
 	HomeDir          string `long:"appdata" description:"Directory where the app configuration file and wallet data is stored"`
 	ConfigFile       string `long:"configfile" description:"Filename of the config file in the app directory"`
 	ShowVersion      bool   `short:"V" long:"version" description:"Display version information and exit"`
@@ -3,6 +3,7 @@ module github.com/crypto-power/cryptopower
 go 1.19
 
 require (
+	decred.org/cspp/v2 v2.1.0
 	decred.org/dcrwallet/v3 v3.0.1
 	gioui.org v0.1.0
 	github.com/JohannesKaufmann/html-to-markdown v1.2.1
@@ -30,11 +31,13 @@ require (
 	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.2.0
 	github.com/decred/dcrd/dcrutil/v3 v3.0.0
 	github.com/decred/dcrd/dcrutil/v4 v4.0.1
+	github.com/decred/dcrd/gcs/v4 v4.0.0
 	github.com/decred/dcrd/hdkeychain/v3 v3.1.1
 	github.com/decred/dcrd/rpc/jsonrpc/types/v4 v4.0.0
 	github.com/decred/dcrd/txscript/v4 v4.1.0
 	github.com/decred/dcrd/wire v1.6.0
 	github.com/decred/dcrdata/v8 v8.0.0-20230617164141-fa4d8e1b4e8e
+	github.com/decred/go-socks v1.1.0
 	github.com/decred/politeia v1.4.0
 	github.com/decred/slog v1.2.0
 	github.com/dgraph-io/badger v1.6.2
@@ -66,7 +69,6 @@ require (
 )
 
 require (
-	decred.org/cspp/v2 v2.1.0 // indirect
 	decred.org/dcrwallet v1.7.0 // indirect
 	gioui.org/cpu v0.0.0-20210817075930-8d6a761490d2 // indirect
 	gioui.org/shader v1.0.6 // indirect
@@ -95,11 +97,9 @@ require (
 	github.com/decred/dcrd/dcrec/secp256k1/v3 v3.0.0 // indirect
 	github.com/decred/dcrd/dcrjson/v4 v4.0.1 // indirect
 	github.com/decred/dcrd/gcs/v2 v2.1.0 // indirect
-	github.com/decred/dcrd/gcs/v4 v4.0.0 // indirect
 	github.com/decred/dcrd/lru v1.1.2 // indirect
 	github.com/decred/dcrd/txscript/v3 v3.0.0 // indirect
 	github.com/decred/dcrtime v0.0.0-20191018193024-8d8b4ef0458e // indirect
-	github.com/decred/go-socks v1.1.0 // indirect
 	github.com/decred/vspd/client/v2 v2.0.0 // indirect
 	github.com/decred/vspd/types/v2 v2.0.0 // indirect
 	github.com/dgraph-io/ristretto v0.0.2 // indirect
@@ -0,0 +1,380 @@
+package btc
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/chain"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/internal/electrum"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// ElectrumBackend is the name of the Electrum chain backend.
+const ElectrumBackend = "electrum"
+
+// Verify that ElectrumClient implements the chain interface used by the
+// wallet.
+var _ chain.Interface = (*ElectrumClient)(nil)
+
+// ElectrumClient implements chain.Interface on top of an Electrum server. The
+// chain logic is shared with LTC in electrum.ChainClient, ElectrumClient
+// converts its data to and from the btcwallet types.
+type ElectrumClient struct {
+	*electrum.ChainClient
+	chainParams *chaincfg.Params
+}
+
+// NewElectrumClient creates a chain client that syncs through the Electrum
+// server described by cfg. The connection is throttled and metered by
+// limiter, if not nil.
+func NewElectrumClient(chainParams *chaincfg.Params, cfg *sharedW.ElectrumServerConfig, limiter *utils.NetLimiter) *ElectrumClient {
+	electrumCfg := &electrum.Config{
+		Addr:    cfg.Host,
+		TLS:     cfg.TLS,
+		CertPEM: []byte(cfg.CertPEM),
+		Proxy:   cfg.Proxy,
+		Limiter: limiter,
+	}
+	ntfns := &electrum.ChainNotifications{
+		ClientConnected: func() interface{} {
+			return chain.ClientConnected{}
+		},
+		BlockConnected: func(height int32, header *electrum.BlockHeader) interface{} {
+			return chain.BlockConnected(blockMeta(height, header))
+		},
+		BlockDisconnected: func(height int32, header *electrum.BlockHeader) interface{} {
+			return chain.BlockDisconnected(blockMeta(height, header))
+		},
+		RelevantTx: relevantTx,
+		RescanFinished: func(height int32, header *electrum.BlockHeader) interface{} {
+			hash := chainhash.Hash(header.Hash)
+			return &chain.RescanFinished{
+				Hash:   &hash,
+				Height: height,
+				Time:   header.Timestamp,
+			}
+		},
+	}
+	return &ElectrumClient{
+		ChainClient: electrum.NewChainClient(electrumCfg, ntfns),
+		chainParams: chainParams,
+	}
+}
+
+// GetBestBlock returns the hash and height of the server's chain tip. It is
+// part of the chain.Interface interface.
+func (c *ElectrumClient) GetBestBlock() (*chainhash.Hash, int32, error) {
+	stamp, err := c.BlockStamp()
+	if err != nil {
+		return nil, 0, err
+	}
+	return &stamp.Hash, stamp.Height, nil
+}
+
+// BlockStamp returns the server's chain tip. It is part of the
+// chain.Interface interface.
+func (c *ElectrumClient) BlockStamp() (*waddrmgr.BlockStamp, error) {
+	height, header, err := c.Tip()
+	if err != nil {
+		return nil, errors.New(utils.ErrNotConnected)
+	}
+	return &waddrmgr.BlockStamp{
+		Hash:      chainhash.Hash(header.Hash),
+		Height:    height,
+		Timestamp: header.Timestamp,
+	}, nil
+}
+
+// GetBlock returns the block with the wallet's transactions only. Electrum
+// servers don't serve full blocks, the block holds the header and the
+// transactions of the watched addresses confirmed in it. It is part of the
+// chain.Interface interface.
+func (c *ElectrumClient) GetBlock(hash *chainhash.Hash) (*wire.MsgBlock, error) {
+	header, err := c.GetBlockHeader(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	height, err := c.GetBlockHeight(hash)
+	if err != nil {
+		return nil, err
+	}
+	rawTxs, err := c.BlockTransactions(height)
+	if err != nil {
+		return nil, err
+	}
+
+	block := wire.NewMsgBlock(header)
+	for _, rawTx := range rawTxs {
+		tx, err := parseTx(rawTx)
+		if err != nil {
+			return nil, err
+		}
+		block.AddTransaction(tx)
+	}
+	return block, nil
+}
+
+// GetBlockHash returns the hash of the block at height. It is part of the
+// chain.Interface interface.
+func (c *ElectrumClient) GetBlockHash(height int64) (*chainhash.Hash, error) {
+	header, err := c.HeaderAt(int32(height))
+	if err != nil {
+		return nil, err
+	}
+	hash := chainhash.Hash(header.Hash)
+	return &hash, nil
+}
+
+// GetBlockHeader returns the header of the block. Only the headers of the
+// blocks recently looked up by height are known. It is part of the
+// chain.Interface interface.
+func (c *ElectrumClient) GetBlockHeader(hash *chainhash.Hash) (*wire.BlockHeader, error) {
+	height, err := c.GetBlockHeight(hash)
+	if err != nil {
+		return nil, err
+	}
+	header, err := c.HeaderAt(height)
+	if err != nil {
+		return nil, err
+	}
+	return parseHeader(header)
+}
+
+// GetBlockHeight returns the height of the block. Only the blocks recently
+// looked up by height are known.
+func (c *ElectrumClient) GetBlockHeight(hash *chainhash.Hash) (int32, error) {
+	return c.BlockHeight(*hash)
+}
+
+// SendRawTransaction broadcasts the transaction through the server. It is
+// part of the chain.Interface interface.
+func (c *ElectrumClient) SendRawTransaction(tx *wire.MsgTx, _ bool) (*chainhash.Hash, error) {
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+
+	txHash, err := c.Broadcast(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return chainhash.NewHashFromStr(txHash)
+}
+
+// NotifyBlocks starts reporting the connected and disconnected blocks. It is
+// part of the chain.Interface interface.
+func (c *ElectrumClient) NotifyBlocks() error {
+	c.ChainClient.NotifyBlocks()
+	return nil
+}
+
+// NotifyReceived starts watching the addresses for transactions. It is part
+// of the chain.Interface interface.
+func (c *ElectrumClient) NotifyReceived(addrs []btcutil.Address) error {
+	pkScripts, err := payToAddrScripts(addrs)
+	if err != nil {
+		return err
+	}
+	return c.Watch(pkScripts)
+}
+
+// Rescan reports the transactions of the addresses and outpoints confirmed
+// since the start block, then watches the addresses for new transactions. It
+// is part of the chain.Interface interface.
+func (c *ElectrumClient) Rescan(startHash *chainhash.Hash, addrs []btcutil.Address,
+	outPoints map[wire.OutPoint]btcutil.Address,
+) error {
+	for _, addr := range outPoints {
+		addrs = append(addrs, addr)
+	}
+	pkScripts, err := payToAddrScripts(addrs)
+	if err != nil {
+		return err
+	}
+
+	c.ChainClient.Rescan(*startHash, pkScripts)
+	return nil
+}
+
+// FilterBlocks scans the blocks of the request for transactions paying to the
+// addresses or spending the outpoints of the request using the address
+// histories, and returns the matches of the first block that has any. It is
+// part of the chain.Interface interface.
+func (c *ElectrumClient) FilterBlocks(req *chain.FilterBlocksRequest) (*chain.FilterBlocksResponse, error) {
+	heights := make([]int32, len(req.Blocks))
+	for i, block := range req.Blocks {
+		heights[i] = block.Height
+	}
+
+	addrs := make([]btcutil.Address, 0, len(req.ExternalAddrs)+len(req.InternalAddrs)+len(req.WatchedOutPoints))
+	external := make(map[string]waddrmgr.ScopedIndex, len(req.ExternalAddrs))
+	for index, addr := range req.ExternalAddrs {
+		external[addr.EncodeAddress()] = index
+		addrs = append(addrs, addr)
+	}
+	internal := make(map[string]waddrmgr.ScopedIndex, len(req.InternalAddrs))
+	for index, addr := range req.InternalAddrs {
+		internal[addr.EncodeAddress()] = index
+		addrs = append(addrs, addr)
+	}
+	for _, addr := range req.WatchedOutPoints {
+		addrs = append(addrs, addr)
+	}
+
+	var pkScripts [][]byte
+	for _, addr := range addrs {
+		if pkScript, err := txscript.PayToAddrScript(addr); err == nil {
+			pkScripts = append(pkScripts, pkScript)
+		}
+	}
+
+	batchIndex, rawTxs, err := c.ChainClient.FilterBlocks(heights, pkScripts)
+	if err != nil || batchIndex == -1 {
+		return nil, err
+	}
+
+	resp := &chain.FilterBlocksResponse{
+		BatchIndex:         uint32(batchIndex),
+		BlockMeta:          req.Blocks[batchIndex],
+		FoundExternalAddrs: make(map[waddrmgr.KeyScope]map[uint32]struct{}),
+		FoundInternalAddrs: make(map[waddrmgr.KeyScope]map[uint32]struct{}),
+		FoundOutPoints:     make(map[wire.OutPoint]btcutil.Address),
+	}
+
+	markFound := func(found map[waddrmgr.KeyScope]map[uint32]struct{}, index waddrmgr.ScopedIndex) {
+		if found[index.Scope] == nil {
+			found[index.Scope] = make(map[uint32]struct{})
+		}
+		found[index.Scope][index.Index] = struct{}{}
+	}
+
+	for _, rawTx := range rawTxs {
+		tx, err := parseTx(rawTx)
+		if err != nil {
+			return nil, err
+		}
+
+		relevant := false
+		for _, in := range tx.TxIn {
+			if _, ok := req.WatchedOutPoints[in.PreviousOutPoint]; ok {
+				relevant = true
+			}
+		}
+
+		for i, out := range tx.TxOut {
+			_, outAddrs, _, err := txscript.ExtractPkScriptAddrs(out.PkScript, c.chainParams)
+			if err != nil {
+				continue
+			}
+			for _, addr := range outAddrs {
+				encoded := addr.EncodeAddress()
+				if index, ok := external[encoded]; ok {
+					markFound(resp.FoundExternalAddrs, index)
+				} else if index, ok := internal[encoded]; ok {
+					markFound(resp.FoundInternalAddrs, index)
+				} else {
+					continue
+				}
+				relevant = true
+				outPoint := wire.OutPoint{Hash: tx.TxHash(), Index: uint32(i)}
+				resp.FoundOutPoints[outPoint] = addr
+			}
+		}
+
+		if relevant {
+			resp.RelevantTxns = append(resp.RelevantTxns, tx)
+		}
+	}
+	return resp, nil
+}
+
+// BackEnd returns the name of the backend. It is part of the chain.Interface
+// interface.
+func (c *ElectrumClient) BackEnd() string {
+	return ElectrumBackend
+}
+
+// EstimateFee returns the fee rate in Sat/kvB the server estimates is needed
+// for a confirmation within blocks.
+func (c *ElectrumClient) EstimateFee(blocks int32) (int64, error) {
+	feeRate, err := c.ChainClient.EstimateFee(blocks)
+	if err != nil {
+		return 0, err
+	}
+
+	amount, err := btcutil.NewAmount(feeRate)
+	if err != nil {
+		return 0, err
+	}
+	return int64(amount), nil
+}
+
+// relevantTx builds the notification of a transaction of a watched address.
+func relevantTx(rawTx []byte, height int32, header *electrum.BlockHeader) (interface{}, error) {
+	tx, err := parseTx(rawTx)
+	if err != nil {
+		return nil, err
+	}
+
+	var block *wtxmgr.BlockMeta
+	received := time.Now()
+	if header != nil {
+		meta := blockMeta(height, header)
+		block = &meta
+		received = header.Timestamp
+	}
+
+	rec, err := wtxmgr.NewTxRecordFromMsgTx(tx, received)
+	if err != nil {
+		return nil, err
+	}
+	return chain.RelevantTx{TxRecord: rec, Block: block}, nil
+}
+
+func payToAddrScripts(addrs []btcutil.Address) ([][]byte, error) {
+	pkScripts := make([][]byte, 0, len(addrs))
+	for _, addr := range addrs {
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			return nil, err
+		}
+		pkScripts = append(pkScripts, pkScript)
+	}
+	return pkScripts, nil
+}
+
+func parseTx(rawTx []byte) (*wire.MsgTx, error) {
+	tx := new(wire.MsgTx)
+	if err := tx.Deserialize(bytes.NewReader(rawTx)); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func parseHeader(header *electrum.BlockHeader) (*wire.BlockHeader, error) {
+	h := new(wire.BlockHeader)
+	if err := h.Deserialize(bytes.NewReader(header.Raw)); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func blockMeta(height int32, header *electrum.BlockHeader) wtxmgr.BlockMeta {
+	return wtxmgr.BlockMeta{
+		Block: wtxmgr.Block{
+			Hash:   chainhash.Hash(header.Hash),
+			Height: height,
+		},
+		Time: header.Timestamp,
+	}
+}
@@ -0,0 +1,352 @@
+package btc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/chain"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/internal/electrum"
+	"github.com/crypto-power/cryptopower/libwallet/internal/electrum/electrumtest"
+)
+
+var testParams = &chaincfg.RegressionNetParams
+
+// fakeChain is the chain served by the fake electrum server.
+type fakeChain struct {
+	mu        sync.Mutex
+	headers   []*wire.BlockHeader
+	histories map[string][]*electrum.History
+	txs       map[string]*wire.MsgTx
+}
+
+// buildHeaders extends the headers up to tipHeight. salt tells forks apart.
+func buildHeaders(headers []*wire.BlockHeader, tipHeight int, salt uint32) []*wire.BlockHeader {
+	for height := len(headers); height <= tipHeight; height++ {
+		header := &wire.BlockHeader{
+			Version:   1,
+			Timestamp: time.Unix(1600000000+int64(height)*600, 0),
+			Bits:      0x207fffff,
+			Nonce:     uint32(height) + salt,
+		}
+		if height > 0 {
+			header.PrevBlock = headers[height-1].BlockHash()
+		}
+		headers = append(headers, header)
+	}
+	return headers
+}
+
+func serialize(t *testing.T, s interface{ Serialize(w io.Writer) error }) string {
+	var buf bytes.Buffer
+	if err := s.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(buf.Bytes())
+}
+
+func newFakeElectrumServer(t *testing.T, fc *fakeChain) *electrumtest.Server {
+	s := electrumtest.NewServer(t)
+
+	headerHex := func(height int) string {
+		return serialize(t, fc.headers[height])
+	}
+	s.Handle("blockchain.headers.subscribe", func([]json.RawMessage) (interface{}, *electrumtest.Error) {
+		fc.mu.Lock()
+		defer fc.mu.Unlock()
+		tip := len(fc.headers) - 1
+		return &electrum.Header{Height: int32(tip), Hex: headerHex(tip)}, nil
+	})
+	s.Handle("blockchain.block.header", func(params []json.RawMessage) (interface{}, *electrumtest.Error) {
+		var height int
+		json.Unmarshal(params[0], &height)
+		fc.mu.Lock()
+		defer fc.mu.Unlock()
+		return headerHex(height), nil
+	})
+	s.Handle("blockchain.block.headers", func(params []json.RawMessage) (interface{}, *electrumtest.Error) {
+		var start, count int
+		json.Unmarshal(params[0], &start)
+		json.Unmarshal(params[1], &count)
+		fc.mu.Lock()
+		defer fc.mu.Unlock()
+		var hexes string
+		n := 0
+		for height := start; height < start+count && height < len(fc.headers); height++ {
+			hexes += headerHex(height)
+			n++
+		}
+		return map[string]interface{}{"count": n, "hex": hexes, "max": 2016}, nil
+	})
+	s.Handle("blockchain.scripthash.subscribe", func([]json.RawMessage) (interface{}, *electrumtest.Error) {
+		return nil, nil
+	})
+	s.Handle("blockchain.scripthash.get_history", func(params []json.RawMessage) (interface{}, *electrumtest.Error) {
+		var scriptHash string
+		json.Unmarshal(params[0], &scriptHash)
+		fc.mu.Lock()
+		defer fc.mu.Unlock()
+		return fc.histories[scriptHash], nil
+	})
+	s.Handle("blockchain.transaction.get", func(params []json.RawMessage) (interface{}, *electrumtest.Error) {
+		var txHash string
+		json.Unmarshal(params[0], &txHash)
+		fc.mu.Lock()
+		defer fc.mu.Unlock()
+		tx, ok := fc.txs[txHash]
+		if !ok {
+			return nil, &electrumtest.Error{Code: 2, Message: "unknown transaction"}
+		}
+		return serialize(t, tx), nil
+	})
+	return s
+}
+
+func testAddress(t *testing.T, id byte) btcutil.Address {
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(bytes.Repeat([]byte{id}, 20), testParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return addr
+}
+
+// addTx adds a transaction paying to addr to the chain at height, 0 for the
+// mempool.
+func (fc *fakeChain) addTx(t *testing.T, addr btcutil.Address, value int64, height int32) *wire.MsgTx {
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: uint32(len(fc.txs))}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(value, pkScript))
+
+	txHash := tx.TxHash().String()
+	scriptHash := electrum.ScriptHash(pkScript)
+	fc.txs[txHash] = tx
+	fc.histories[scriptHash] = append(fc.histories[scriptHash], &electrum.History{Height: height, TxHash: txHash})
+	return tx
+}
+
+func newTestElectrumClient(t *testing.T, fc *fakeChain) (*ElectrumClient, *electrumtest.Server) {
+	s := newFakeElectrumServer(t, fc)
+	c := NewElectrumClient(testParams, &sharedW.ElectrumServerConfig{Host: s.Addr()}, nil)
+	if err := c.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		c.Stop()
+		c.WaitForShutdown()
+	})
+
+	if _, ok := nextNotification(t, c).(chain.ClientConnected); !ok {
+		t.Fatal("client connected notification not received")
+	}
+	return c, s
+}
+
+func nextNotification(t *testing.T, c *ElectrumClient) interface{} {
+	t.Helper()
+	select {
+	case n := <-c.Notifications():
+		return n
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification not received")
+		return nil
+	}
+}
+
+func TestElectrumFilterBlocks(t *testing.T) {
+	fc := &fakeChain{
+		headers:   buildHeaders(nil, 10, 0),
+		histories: make(map[string][]*electrum.History),
+		txs:       make(map[string]*wire.MsgTx),
+	}
+	external, internal, other := testAddress(t, 1), testAddress(t, 2), testAddress(t, 3)
+	// The transactions before and after the first matching block are left
+	// for the next requests.
+	fc.addTx(t, external, 1000, 4)
+	tx6 := fc.addTx(t, external, 2000, 6)
+	change6 := fc.addTx(t, internal, 3000, 6)
+	fc.addTx(t, internal, 4000, 7)
+	fc.addTx(t, other, 5000, 6)
+
+	c, _ := newTestElectrumClient(t, fc)
+
+	scope := waddrmgr.KeyScopeBIP0084
+	req := &chain.FilterBlocksRequest{
+		ExternalAddrs: map[waddrmgr.ScopedIndex]btcutil.Address{{Scope: scope, Index: 3}: external},
+		InternalAddrs: map[waddrmgr.ScopedIndex]btcutil.Address{{Scope: scope, Index: 1}: internal},
+	}
+	for height := int32(5); height <= 8; height++ {
+		req.Blocks = append(req.Blocks, wtxmgr.BlockMeta{Block: wtxmgr.Block{
+			Hash:   fc.headers[height].BlockHash(),
+			Height: height,
+		}})
+	}
+
+	resp, err := c.FilterBlocks(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp == nil || resp.BatchIndex != 1 {
+		t.Fatalf("got response %+v, want the block at height 6", resp)
+	}
+
+	wantFound := map[waddrmgr.KeyScope]map[uint32]struct{}{scope: {3: {}}}
+	if !reflect.DeepEqual(resp.FoundExternalAddrs, wantFound) {
+		t.Errorf("got external addresses %v, want %v", resp.FoundExternalAddrs, wantFound)
+	}
+	wantFound = map[waddrmgr.KeyScope]map[uint32]struct{}{scope: {1: {}}}
+	if !reflect.DeepEqual(resp.FoundInternalAddrs, wantFound) {
+		t.Errorf("got internal addresses %v, want %v", resp.FoundInternalAddrs, wantFound)
+	}
+	if len(resp.RelevantTxns) != 2 || len(resp.FoundOutPoints) != 2 {
+		t.Fatalf("got %d transactions and %d outpoints, want 2", len(resp.RelevantTxns), len(resp.FoundOutPoints))
+	}
+	for _, tx := range []*wire.MsgTx{tx6, change6} {
+		if _, ok := resp.FoundOutPoints[wire.OutPoint{Hash: tx.TxHash()}]; !ok {
+			t.Errorf("output of %v not found", tx.TxHash())
+		}
+	}
+
+	// Blocks without matches return no response.
+	req.Blocks = req.Blocks[3:]
+	if resp, err := c.FilterBlocks(req); err != nil || resp != nil {
+		t.Errorf("got response %+v (%v) without matches", resp, err)
+	}
+}
+
+func TestElectrumRescan(t *testing.T) {
+	fc := &fakeChain{
+		headers:   buildHeaders(nil, 10, 0),
+		histories: make(map[string][]*electrum.History),
+		txs:       make(map[string]*wire.MsgTx),
+	}
+	addr := testAddress(t, 1)
+	// The history is not in chain order.
+	mempool := fc.addTx(t, addr, 1000, 0)
+	tx6 := fc.addTx(t, addr, 2000, 6)
+	fc.addTx(t, addr, 3000, 2)
+	tx4 := fc.addTx(t, addr, 4000, 4)
+
+	c, _ := newTestElectrumClient(t, fc)
+
+	startHash, err := c.GetBlockHash(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Rescan(startHash, []btcutil.Address{addr}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// The transactions confirmed before the start block are not reported,
+	// the mempool ones are reported last.
+	for _, want := range []struct {
+		tx     *wire.MsgTx
+		height int32
+	}{{tx4, 4}, {tx6, 6}, {mempool, -1}} {
+		n, ok := nextNotification(t, c).(chain.RelevantTx)
+		if !ok {
+			t.Fatalf("got %T, want chain.RelevantTx", n)
+		}
+		if n.TxRecord.Hash != want.tx.TxHash() {
+			t.Errorf("got tx %v, want %v", n.TxRecord.Hash, want.tx.TxHash())
+		}
+		if want.height == -1 {
+			if n.Block != nil {
+				t.Errorf("mempool tx reported in block %d", n.Block.Height)
+			}
+		} else if n.Block == nil || n.Block.Height != want.height || n.Block.Hash != fc.headers[want.height].BlockHash() {
+			t.Errorf("tx %v reported in block %+v, want height %d", want.tx.TxHash(), n.Block, want.height)
+		}
+	}
+
+	finished, ok := nextNotification(t, c).(*chain.RescanFinished)
+	if !ok || finished.Height != 10 || *finished.Hash != fc.headers[10].BlockHash() {
+		t.Fatalf("got %+v, want the rescan to finish at the tip", finished)
+	}
+
+	// The blocks served hold the wallet's transactions.
+	hash := fc.headers[6].BlockHash()
+	block, err := c.GetBlock(&hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.BlockHash() != hash || len(block.Transactions) != 1 || block.Transactions[0].TxHash() != tx6.TxHash() {
+		t.Errorf("got block %v with %d transactions, want block %v with %v", block.BlockHash(), len(block.Transactions), hash, tx6.TxHash())
+	}
+}
+
+func TestElectrumReorg(t *testing.T) {
+	fc := &fakeChain{
+		headers:   buildHeaders(nil, 10, 0),
+		histories: make(map[string][]*electrum.History),
+		txs:       make(map[string]*wire.MsgTx),
+	}
+	c, s := newTestElectrumClient(t, fc)
+
+	// The wallet looks the blocks up while syncing.
+	if _, err := c.GetBlockHash(0); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.NotifyBlocks(); err != nil {
+		t.Fatal(err)
+	}
+	oldHeaders := fc.headers
+
+	// A block extending the tip is connected.
+	fc.mu.Lock()
+	fc.headers = buildHeaders(fc.headers, 11, 0)
+	tip := fc.headers[11]
+	fc.mu.Unlock()
+	s.Notify("blockchain.headers.subscribe", &electrum.Header{Height: 11, Hex: serialize(t, tip)})
+
+	n, ok := nextNotification(t, c).(chain.BlockConnected)
+	if !ok || n.Height != 11 || n.Hash != tip.BlockHash() {
+		t.Fatalf("got %+v, want block 11 connected", n)
+	}
+
+	// A longer chain forking after block 8 replaces blocks 9 to 11.
+	fc.mu.Lock()
+	fc.headers = buildHeaders(append([]*wire.BlockHeader(nil), oldHeaders[:9]...), 12, 1000)
+	newHeaders := fc.headers
+	fc.mu.Unlock()
+	s.Notify("blockchain.headers.subscribe", &electrum.Header{Height: 12, Hex: serialize(t, newHeaders[12])})
+
+	disconnected := []*wire.BlockHeader{tip, oldHeaders[10], oldHeaders[9]}
+	for i, header := range disconnected {
+		n, ok := nextNotification(t, c).(chain.BlockDisconnected)
+		if want := int32(11 - i); !ok || n.Height != want || n.Hash != header.BlockHash() {
+			t.Fatalf("got %+v, want block %d disconnected", n, want)
+		}
+	}
+	for height := int32(9); height <= 12; height++ {
+		n, ok := nextNotification(t, c).(chain.BlockConnected)
+		if !ok || n.Height != height || n.Hash != newHeaders[height].BlockHash() {
+			t.Fatalf("got %+v, want block %d of the new chain connected", n, height)
+		}
+	}
+
+	bestHash, bestHeight, err := c.GetBestBlock()
+	if err != nil || bestHeight != 12 || *bestHash != newHeaders[12].BlockHash() {
+		t.Errorf("got best block %v at %d (%v), want the new tip", bestHash, bestHeight, err)
+	}
+	stale := oldHeaders[10].BlockHash()
+	if _, err := c.GetBlockHeight(&stale); err == nil {
+		t.Error("disconnected block still known")
+	}
+}
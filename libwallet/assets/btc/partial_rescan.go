@@ -0,0 +1,220 @@
+package btc
+
+import (
+	"sync"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/lightninglabs/neutrino"
+	"github.com/lightninglabs/neutrino/headerfs"
+)
+
+// partialRescan rescans the blocks defined by opts for the chosen addresses
+// only. It runs a neutrino rescan of its own that stops at the end height, so
+// the addresses watched by the chain client are left untouched. The
+// transactions found are added to the wallet as if the chain client had
+// notified them.
+func (asset *Asset) partialRescan(opts *sharedW.RescanOptions, addrs []btcutil.Address,
+	start *waddrmgr.BlockStamp, bestHeight int32,
+) error {
+	endHeight := opts.EndHeight
+	if endHeight <= 0 {
+		endHeight = bestHeight
+	}
+	end, err := asset.getblockStamp(endHeight)
+	if err != nil {
+		return err
+	}
+
+	quit := make(chan struct{})
+	var quitOnce sync.Once
+	stop := func() { quitOnce.Do(func() { close(quit) }) }
+
+	tracker := asset.NewRescanTracker(opts, bestHeight, true)
+	asset.syncData.mu.Lock()
+	asset.syncData.isRescan = true
+	asset.syncData.isPartialRescan = true
+	asset.syncData.rescanTracker = tracker
+	asset.syncData.cancelRescan = stop
+	asset.syncData.mu.Unlock()
+
+	if asset.blocksRescanProgressListener != nil {
+		asset.blocksRescanProgressListener.OnBlocksRescanStarted(asset.ID)
+	}
+
+	// addErr is only read once the rescan has exited.
+	var addErr error
+	rescan := neutrino.NewRescan(
+		&neutrino.RescanChainSource{ChainService: asset.chainClient.CS},
+		neutrino.StartBlock(&headerfs.BlockStamp{Hash: start.Hash, Height: start.Height, Timestamp: start.Timestamp}),
+		neutrino.EndBlock(&headerfs.BlockStamp{Hash: end.Hash, Height: end.Height, Timestamp: end.Timestamp}),
+		neutrino.WatchAddrs(addrs...),
+		neutrino.QuitChan(quit),
+		neutrino.NotificationHandlers(rpcclient.NotificationHandlers{
+			OnFilteredBlockConnected: func(height int32, header *wire.BlockHeader, txs []*btcutil.Tx) {
+				if err := asset.addRescannedTxs(height, header, txs); err != nil {
+					log.Errorf("Failed to add the transactions of block %d: %v", height, err)
+					addErr = err
+					stop()
+					return
+				}
+
+				if asset.isCurrentRescan(tracker) && asset.blocksRescanProgressListener != nil {
+					asset.blocksRescanProgressListener.OnBlocksRescanProgress(tracker.Progress(height))
+				}
+			},
+		}),
+	)
+
+	errChan := rescan.Start()
+	go func() {
+		err := <-errChan
+		if addErr != nil {
+			err = addErr
+		}
+		// A canceled rescan has already been ended by CancelRescan.
+		if asset.isCurrentRescan(tracker) {
+			asset.endRescan(err)
+		}
+	}()
+
+	return nil
+}
+
+// isCurrentRescan returns true if tracker tracks the running rescan.
+func (asset *Asset) isCurrentRescan(tracker *sharedW.RescanTracker) bool {
+	asset.syncData.mu.RLock()
+	defer asset.syncData.mu.RUnlock()
+
+	return asset.syncData.isRescan && asset.syncData.rescanTracker == tracker
+}
+
+// isChainRescan returns true if the running rescan is the one of the chain
+// client, whose notifications report its progress.
+func (asset *Asset) isChainRescan() bool {
+	asset.syncData.mu.RLock()
+	defer asset.syncData.mu.RUnlock()
+
+	return asset.syncData.isRescan && !asset.syncData.isPartialRescan
+}
+
+// addRescannedTxs adds the transactions of a rescanned block to the wallet.
+// The outputs paying to the wallet addresses are added as credits like the
+// wallet does for the transactions notified by the chain client.
+func (asset *Asset) addRescannedTxs(height int32, header *wire.BlockHeader, txs []*btcutil.Tx) error {
+	if len(txs) == 0 {
+		return nil
+	}
+
+	wallet := asset.Internal().BTC
+	block := &wtxmgr.BlockMeta{
+		Block: wtxmgr.Block{Hash: header.BlockHash(), Height: height},
+		Time:  header.Timestamp,
+	}
+
+	return walletdb.Update(wallet.Database(), func(dbtx walletdb.ReadWriteTx) error {
+		addrmgrNs := dbtx.ReadWriteBucket(wAddrMgrBkt)
+		txmgrNs := dbtx.ReadWriteBucket(wTxMgrBkt)
+
+		for _, tx := range txs {
+			rec, err := wtxmgr.NewTxRecordFromMsgTx(tx.MsgTx(), header.Timestamp)
+			if err != nil {
+				return err
+			}
+
+			exists, err := wallet.TxStore.InsertTxCheckIfExists(txmgrNs, rec, block)
+			if err != nil {
+				return err
+			}
+			if exists {
+				continue
+			}
+
+			for i, output := range tx.MsgTx().TxOut {
+				_, addrs, _, err := txscript.ExtractPkScriptAddrs(output.PkScript, asset.chainParams)
+				if err != nil {
+					// Non-standard outputs are skipped.
+					continue
+				}
+
+				for _, addr := range addrs {
+					ma, err := wallet.Manager.Address(addrmgrNs, addr)
+					if waddrmgr.IsError(err, waddrmgr.ErrAddressNotFound) {
+						continue
+					}
+					if err != nil {
+						return err
+					}
+
+					// The wallet only watches the addresses of the default
+					// scopes.
+					scopedManager, _, err := wallet.Manager.AddrAccount(addrmgrNs, addr)
+					if err != nil {
+						return err
+					}
+					if !waddrmgr.IsDefaultScope(scopedManager.Scope()) {
+						continue
+					}
+
+					err = wallet.TxStore.AddCredit(txmgrNs, rec, block, uint32(i), ma.Internal())
+					if err != nil {
+						return err
+					}
+					if err = wallet.Manager.MarkUsed(addrmgrNs, addr); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// stopChainRescan stops the rescan run by the chain client. A neutrino rescan
+// can't be stopped, it is replaced by a rescan starting at the chain tip that
+// watches all the wallet addresses and unspent outputs as the wallet does.
+func (asset *Asset) stopChainRescan() {
+	wallet := asset.Internal().BTC
+	addrs, err := asset.rescanAddresses(&sharedW.RescanOptions{})
+	if err != nil {
+		log.Errorf("Failed to stop the rescan: %v", err)
+		return
+	}
+
+	outPoints := make(map[wire.OutPoint]btcutil.Address)
+	err = walletdb.View(wallet.Database(), func(dbtx walletdb.ReadTx) error {
+		credits, err := wallet.TxStore.UnspentOutputs(dbtx.ReadBucket(wTxMgrBkt))
+		if err != nil {
+			return err
+		}
+
+		for _, credit := range credits {
+			_, creditAddrs, _, err := txscript.ExtractPkScriptAddrs(credit.PkScript, asset.chainParams)
+			if err != nil || len(creditAddrs) == 0 {
+				continue
+			}
+			outPoints[credit.OutPoint] = creditAddrs[0]
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("Failed to stop the rescan: %v", err)
+		return
+	}
+
+	tip, err := asset.chainClient.CS.BestBlock()
+	if err != nil {
+		log.Errorf("Failed to stop the rescan: %v", err)
+		return
+	}
+
+	if err := asset.chainClient.Rescan(&tip.Hash, addrs, outPoints); err != nil {
+		log.Errorf("Failed to stop the rescan: %v", err)
+	}
+}
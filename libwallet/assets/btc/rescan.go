@@ -2,13 +2,10 @@ package btc
 
 import (
 	"fmt"
-	"math"
 	"sync/atomic"
-	"time"
 
 	"decred.org/dcrwallet/v3/errors"
 	"github.com/btcsuite/btcd/btcutil"
-	"github.com/btcsuite/btcwallet/chain"
 	"github.com/btcsuite/btcwallet/waddrmgr"
 	w "github.com/btcsuite/btcwallet/wallet"
 	"github.com/btcsuite/btcwallet/walletdb"
@@ -23,16 +20,22 @@ func (asset *Asset) SetBlocksRescanProgressListener(blocksRescanProgressListener
 
 // RescanBlocks rescans the blockchain for all addresses in the wallet.
 func (asset *Asset) RescanBlocks() error {
-	return asset.RescanBlocksFromHeight(0)
+	return asset.RescanBlocksWithOptions(&sharedW.RescanOptions{})
 }
 
 // RescanBlocksFromHeight rescans the blockchain for all addresses in the wallet
 // starting from the provided block height.
 func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
-	return asset.rescanBlocks(startHeight, nil)
+	return asset.RescanBlocksWithOptions(&sharedW.RescanOptions{StartHeight: startHeight})
 }
 
-func (asset *Asset) rescanBlocks(startHeight int32, addrs []btcutil.Address) error {
+// RescanBlocksWithOptions rescans the blocks defined by opts. The progress is
+// checkpointed so that a canceled or interrupted rescan can be resumed.
+// Over neutrino, a rescan limited to some accounts or addresses runs apart
+// from the chain client so that its watched addresses are left untouched.
+// Otherwise the chain client scans past the end height and the rescan is
+// reported as complete once the end height is scanned.
+func (asset *Asset) RescanBlocksWithOptions(opts *sharedW.RescanOptions) error {
 	if !asset.IsConnectedToBitcoinNetwork() {
 		return errors.E(utils.ErrNotConnected)
 	}
@@ -49,20 +52,37 @@ func (asset *Asset) rescanBlocks(startHeight int32, addrs []btcutil.Address) err
 		return errors.E(utils.ErrSyncAlreadyInProgress)
 	}
 
-	bs, err := asset.getblockStamp(startHeight)
+	bestHeight := asset.GetBestBlockHeight()
+	if err := opts.Validate(bestHeight); err != nil {
+		return err
+	}
+
+	bs, err := asset.getblockStamp(opts.StartHeight)
 	if err != nil {
 		return err
 	}
 
-	if addrs == nil {
-		addrs = []btcutil.Address{}
+	addrs, err := asset.rescanAddresses(opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.IsPartial() && asset.electrumClient == nil {
+		return asset.partialRescan(opts, addrs, bs, bestHeight)
 	}
 
 	asset.syncData.mu.Lock()
 	asset.syncData.isRescan = true
-	asset.syncData.rescanStartTime = time.Now()
+	asset.syncData.rescanTracker = asset.NewRescanTracker(opts, bestHeight, true)
+	if asset.electrumClient == nil {
+		asset.syncData.cancelRescan = asset.stopChainRescan
+	}
 	asset.syncData.mu.Unlock()
 
+	if asset.blocksRescanProgressListener != nil {
+		asset.blocksRescanProgressListener.OnBlocksRescanStarted(asset.ID)
+	}
+
 	job := &w.RescanJob{
 		Addrs:      addrs,
 		OutPoints:  nil,
@@ -70,21 +90,14 @@ func (asset *Asset) rescanBlocks(startHeight int32, addrs []btcutil.Address) err
 	}
 
 	// It submits a rescan job without blocking on finishing the rescan.
-	// The rescan success or failure is logged elsewhere, and the channel
-	// is not required to be read, so discard the return value.
+	// The rescan progress and completion are reported through the chain
+	// notifications, only a failure to start the rescan is handled here.
 	errChan := asset.Internal().BTC.SubmitRescan(job)
-
-	// Listen for the rescan finish event and update it.
 	go func() {
-		for err := range errChan {
-			if err != nil {
-				log.Errorf("rescan job failed: %v", err)
-			}
+		if err := <-errChan; err != nil {
+			log.Errorf("rescan job failed: %v", err)
+			asset.endRescan(err)
 		}
-
-		asset.syncData.mu.Lock()
-		asset.syncData.isRescan = false
-		asset.syncData.mu.Unlock()
 	}()
 
 	// Attempt to start up the notifications handler.
@@ -95,6 +108,90 @@ func (asset *Asset) rescanBlocks(startHeight int32, addrs []btcutil.Address) err
 	return nil
 }
 
+// rescanAddresses returns the addresses watched during a rescan.
+func (asset *Asset) rescanAddresses(opts *sharedW.RescanOptions) ([]btcutil.Address, error) {
+	addrs := make([]btcutil.Address, 0, len(opts.Addresses))
+	if opts.IsPartial() {
+		for _, address := range opts.Addresses {
+			addr, err := btcutil.DecodeAddress(address, asset.chainParams)
+			if err != nil {
+				return nil, errors.E(utils.ErrInvalidAddress)
+			}
+			addrs = append(addrs, addr)
+		}
+		for _, account := range opts.Accounts {
+			accountAddrs, err := asset.Internal().BTC.AccountAddresses(uint32(account))
+			if err != nil {
+				return nil, err
+			}
+			addrs = append(addrs, accountAddrs...)
+		}
+		return addrs, nil
+	}
+
+	err := walletdb.View(asset.Internal().BTC.Database(), func(dbtx walletdb.ReadTx) error {
+		ns := dbtx.ReadBucket(wAddrMgrBkt)
+		return asset.Internal().BTC.Manager.ForEachActiveAddress(ns, func(addr btcutil.Address) error {
+			addrs = append(addrs, addr)
+			return nil
+		})
+	})
+	return addrs, err
+}
+
+// ResumeRescan resumes the rescan that was canceled or interrupted by a
+// restart from the last checkpointed block.
+func (asset *Asset) ResumeRescan() error {
+	cp := asset.RescanCheckpoint()
+	if cp == nil {
+		return errors.New(utils.ErrNotExist)
+	}
+	return asset.RescanBlocksWithOptions(cp.ResumeOptions())
+}
+
+// resumeInterruptedRescan resumes the checkpointed rescan, if any, once the
+// wallet is synced.
+func (asset *Asset) resumeInterruptedRescan() {
+	if asset.IsRescanning() || asset.RescanCheckpoint() == nil {
+		return
+	}
+
+	log.Infof("Resuming the rescan of wallet (%s)", asset.GetWalletName())
+	if err := asset.ResumeRescan(); err != nil {
+		log.Errorf("Failed to resume the rescan: %v", err)
+	}
+}
+
+// endRescan marks the current rescan as complete. The rescan checkpoint is
+// deleted unless the rescan failed. It does nothing if no rescan is running,
+// the chain client reports the end of a rescan ended at its end height.
+func (asset *Asset) endRescan(err error) {
+	asset.syncData.mu.Lock()
+	rescanning := asset.syncData.isRescan
+	tracker := asset.syncData.rescanTracker
+	asset.syncData.rescanTracker = nil
+	asset.syncData.isRescan = false
+	asset.syncData.isPartialRescan = false
+	asset.syncData.cancelRescan = nil
+	asset.syncData.mu.Unlock()
+
+	if !rescanning {
+		return
+	}
+
+	if tracker != nil {
+		if err == nil {
+			tracker.Finish()
+		} else {
+			tracker.SaveCheckpoint()
+		}
+	}
+
+	if asset.blocksRescanProgressListener != nil {
+		asset.blocksRescanProgressListener.OnBlocksRescanEnded(asset.ID, err)
+	}
+}
+
 // IsRescanning returns true if the wallet is currently rescanning the blockchain.
 func (asset *Asset) IsRescanning() bool {
 	asset.syncData.mu.RLock()
@@ -103,12 +200,33 @@ func (asset *Asset) IsRescanning() bool {
 	return asset.syncData.isRescan
 }
 
-// CancelRescan cancels the current rescan.
+// CancelRescan stops the current rescan. Its progress is kept so that it can
+// be resumed with ResumeRescan. An Electrum rescan only queries the address
+// histories, it is left to end on its own like the address discovery of a
+// restored wallet.
 func (asset *Asset) CancelRescan() {
 	asset.syncData.mu.Lock()
+	rescanning := asset.syncData.isRescan
+	tracker := asset.syncData.rescanTracker
+	cancelRescan := asset.syncData.cancelRescan
 	asset.syncData.isRescan = false
+	asset.syncData.isPartialRescan = false
+	asset.syncData.rescanTracker = nil
+	asset.syncData.cancelRescan = nil
 	asset.syncData.mu.Unlock()
 
+	if !rescanning {
+		return
+	}
+
+	if cancelRescan != nil {
+		cancelRescan()
+	}
+
+	if tracker != nil {
+		tracker.SaveCheckpoint()
+	}
+
 	if asset.blocksRescanProgressListener != nil {
 		asset.blocksRescanProgressListener.OnBlocksRescanEnded(asset.ID, nil)
 	}
@@ -136,7 +254,7 @@ func (asset *Asset) rescanAsync() error {
 
 	asset.Internal().BTC.Stop() // stops Wallet and chainClient (not chainService)
 	asset.Internal().BTC.WaitForShutdown()
-	asset.chainClient.WaitForShutdown()
+	asset.chainSource().WaitForShutdown()
 
 	// Attempt to drop the the tx history. See the btcwallet/cmd/dropwtxmgr app
 	// for more information. Because of how often a forces rescan will be triggered,
@@ -154,12 +272,12 @@ func (asset *Asset) rescanAsync() error {
 	log.Info("Starting wallet...")
 	asset.Internal().BTC.Start()
 
-	if err := asset.chainClient.Start(); err != nil {
-		return fmt.Errorf("couldn't start Neutrino client: %v", err)
+	if err := asset.chainSource().Start(); err != nil {
+		return fmt.Errorf("couldn't start chain client: %v", err)
 	}
 
 	log.Infof("Synchronizing wallet (%s) with network...", asset.GetWalletName())
-	asset.Internal().BTC.SynchronizeRPC(asset.chainClient)
+	asset.Internal().BTC.SynchronizeRPC(asset.chainSource())
 	return nil
 }
 
@@ -171,14 +289,9 @@ func (asset *Asset) forceRescan() {
 		ns := dbtx.ReadWriteBucket(wAddrMgrBkt)
 
 		if asset.IsRestored && !asset.ContainsDiscoveredAccounts() {
-			// Force restored wallets on initial run to restore from genesis block.
-			block := asset.Internal().BTC.ChainParams().GenesisBlock
-
-			bs := waddrmgr.BlockStamp{
-				Height:    0,
-				Hash:      block.BlockHash(),
-				Timestamp: block.Header.Timestamp,
-			}
+			// Force restored wallets on initial run to restore from the
+			// checkpoint preceding the birthday or the genesis block.
+			bs := asset.restoreStartBlock()
 
 			// Setting the verification to true, requests the upstream not to
 			// attempt checking for a better birthday block. This check causes
@@ -333,42 +446,26 @@ func (asset *Asset) getBirthdayBlock() (int32, bool, error) {
 	return birthdayblock, isverified, err
 }
 
-func (asset *Asset) updateRescanProgress(progress *chain.RescanProgress) {
-	if asset.syncData.rescanStartHeight == nil {
-		asset.syncData.rescanStartHeight = &progress.Height
-	}
-
-	headersFetchedSoFar := float64(progress.Height - *asset.syncData.rescanStartHeight)
-	if headersFetchedSoFar < 1 {
-		headersFetchedSoFar = 1
-	}
-
-	remainingHeaders := float64(asset.GetBestBlockHeight() - progress.Height)
-	if remainingHeaders < 1 {
-		remainingHeaders = 1
-	}
-
-	allHeadersToFetch := headersFetchedSoFar + remainingHeaders
+// updateRescanProgress publishes the progress of the current rescan once the
+// blocks up to height are scanned.
+func (asset *Asset) updateRescanProgress(height int32) {
+	bestHeight := asset.GetBestBlockHeight()
 
-	rescanProgressReport := &sharedW.HeadersRescanProgressReport{
-		CurrentRescanHeight: progress.Height,
-		TotalHeadersToScan:  int32(allHeadersToFetch),
+	asset.syncData.mu.Lock()
+	if asset.syncData.rescanTracker == nil {
+		// Address discovery of a restored wallet, its progress is not
+		// checkpointed as it restarts from the birthday block anyway.
+		asset.syncData.rescanTracker = asset.NewRescanTracker(&sharedW.RescanOptions{StartHeight: height}, bestHeight, false)
 	}
+	tracker := asset.syncData.rescanTracker
+	asset.syncData.mu.Unlock()
 
-	elapsedRescanTime := time.Now().Unix() - asset.syncData.rescanStartTime.Unix()
-	rescanRate := headersFetchedSoFar / float64(rescanProgressReport.TotalHeadersToScan)
-
-	rescanProgressReport.RescanProgress = int32((headersFetchedSoFar * 100) / allHeadersToFetch)
-	estimatedTotalRescanTime := int64(math.Round(float64(elapsedRescanTime) / rescanRate))
-	rescanProgressReport.RescanTimeRemaining = estimatedTotalRescanTime - elapsedRescanTime
-
-	rescanProgressReport.GeneralSyncProgress = &sharedW.GeneralSyncProgress{
-		TotalSyncProgress:         rescanProgressReport.RescanProgress,
-		TotalTimeRemainingSeconds: rescanProgressReport.RescanTimeRemaining,
+	if asset.blocksRescanProgressListener != nil {
+		asset.blocksRescanProgressListener.OnBlocksRescanProgress(tracker.Progress(height))
 	}
 
-	if asset.blocksRescanProgressListener != nil {
-		asset.blocksRescanProgressListener.OnBlocksRescanProgress(rescanProgressReport)
+	if tracker.Done(height) {
+		asset.endRescan(nil)
 	}
 }
 
@@ -378,15 +475,15 @@ func (asset *Asset) getblockStamp(height int32) (*waddrmgr.BlockStamp, error) {
 		return nil, fmt.Errorf("invalid block height provided: Error: %v", err)
 	}
 
-	block, err := asset.chainClient.GetBlock(startHash)
+	header, err := asset.chainSource().GetBlockHeader(startHash)
 	if err != nil {
 		return nil, fmt.Errorf("invalid block hash provided: Error: %v", err)
 	}
 
 	return &waddrmgr.BlockStamp{
-		Hash:      block.BlockHash(),
+		Hash:      header.BlockHash(),
 		Height:    height,
-		Timestamp: block.Header.Timestamp,
+		Timestamp: header.Timestamp,
 	}, nil
 }
 
@@ -396,7 +493,7 @@ func (asset *Asset) getblockStamp(height int32) (*waddrmgr.BlockStamp, error) {
 // the syncedto store trigger event.
 func (asset *Asset) updateSyncedToBlock(height int32) {
 	// Ignore blocks notifications recieved during the wallet recovery phase.
-	if !asset.IsSynced() || asset.IsRescanning() {
+	if !asset.IsSynced() || asset.isChainRescan() {
 		return
 	}
 
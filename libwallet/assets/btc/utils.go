@@ -23,7 +23,10 @@ const (
 	MainnetHDPath = "m / 84' / 0' / "
 )
 
-var wAddrMgrBkt = []byte("waddrmgr")
+var (
+	wAddrMgrBkt = []byte("waddrmgr")
+	wTxMgrBkt   = []byte("wtxmgr")
+)
 
 // GetScope returns the key scope that will be used within the waddrmgr to
 // create an HD chain for deriving all of our required keys. A different
@@ -1,17 +1,23 @@
 package dcr
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"net"
+	"strconv"
+	"time"
 
 	"decred.org/dcrwallet/v3/ticketbuyer"
 	w "decred.org/dcrwallet/v3/wallet"
 	"decred.org/dcrwallet/v3/wallet/udb"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/internal/certs"
+	"github.com/crypto-power/cryptopower/libwallet/txhelper"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/decred/dcrd/chaincfg/v3"
 	"github.com/decred/dcrd/dcrutil/v4"
@@ -143,7 +149,94 @@ func (asset *Asset) ReadyToMix(_ int) (bool, error) {
 	return asset.accountHasMixableOutput(unmixedAccount), nil
 }
 
-// StartAccountMixer starts the automatic account mixer
+// SetCSPPServerConfig persists a custom CoinShuffle++ coordination server to
+// be used by this wallet's account mixer in place of the default server.
+func (asset *Asset) SetCSPPServerConfig(cfg *CSPPServerConfig) error {
+	if cfg == nil || cfg.Host == "" {
+		return errors.New(utils.ErrInvalid)
+	}
+
+	if port, err := strconv.ParseUint(cfg.Port, 10, 16); err != nil || port == 0 {
+		return errors.New(utils.ErrInvalid)
+	}
+
+	if cfg.CertPEM != "" {
+		if !x509.NewCertPool().AppendCertsFromPEM([]byte(cfg.CertPEM)) {
+			return errors.New(utils.ErrInvalid)
+		}
+	}
+
+	if cfg.CertFingerprint != "" {
+		fingerprint, err := hex.DecodeString(cfg.CertFingerprint)
+		if err != nil || len(fingerprint) != sha256.Size {
+			return errors.New(utils.ErrInvalid)
+		}
+	}
+
+	asset.SaveUserConfigValue(sharedW.AccountMixerCSPPServer, cfg)
+	return nil
+}
+
+// CSPPServerConfig returns the coordination server used by this wallet's
+// account mixer. The default server for the active network is returned if no
+// custom server has been set.
+func (asset *Asset) CSPPServerConfig() *CSPPServerConfig {
+	cfg := new(CSPPServerConfig)
+	err := asset.ReadUserConfigValue(sharedW.AccountMixerCSPPServer, cfg)
+	if err == nil && cfg.Host != "" {
+		return cfg
+	}
+
+	port := TestnetShufflePort
+	if asset.chainParams.Net == chaincfg.MainNetParams().Net {
+		port = MainnetShufflePort
+	}
+	return &CSPPServerConfig{Host: ShuffleServer, Port: port}
+}
+
+// ResetCSPPServerConfig drops any custom coordination server so that the
+// default server is used by the account mixer.
+func (asset *Asset) ResetCSPPServerConfig() {
+	asset.DeleteUserConfigValueForKey(sharedW.AccountMixerCSPPServer)
+}
+
+// SetMixerSchedule persists the schedule applied to account mixer sessions.
+// Changes take effect the next time the mixer is started.
+func (asset *Asset) SetMixerSchedule(schedule *MixerSchedule) error {
+	if schedule == nil || !schedule.isValid() {
+		return errors.New(utils.ErrInvalid)
+	}
+
+	asset.SaveUserConfigValue(sharedW.AccountMixerSchedule, schedule)
+	return nil
+}
+
+// MixerSchedule returns the schedule applied to account mixer sessions. An
+// empty schedule (no restrictions) is returned if none has been set.
+func (asset *Asset) MixerSchedule() *MixerSchedule {
+	schedule := new(MixerSchedule)
+	if err := asset.ReadUserConfigValue(sharedW.AccountMixerSchedule, schedule); err != nil {
+		return new(MixerSchedule)
+	}
+	return schedule
+}
+
+// MixerStats returns a copy of the statistics of the current or most recent
+// account mixer session. Nil is returned if the mixer has not been run since
+// the wallet was loaded.
+func (asset *Asset) MixerStats() *MixerStats {
+	asset.mixerStatsMu.RLock()
+	defer asset.mixerStatsMu.RUnlock()
+
+	if asset.mixerStats == nil {
+		return nil
+	}
+	return asset.mixerStats.copy()
+}
+
+// StartAccountMixer starts the automatic account mixer. The mixer only mixes
+// within the configured schedule window and is stopped once any of the
+// schedule's limits is reached.
 func (asset *Asset) StartAccountMixer(walletPassphrase string) error {
 	if !asset.IsConnectedToDecredNetwork() {
 		return errors.New(utils.ErrNotConnected)
@@ -153,6 +246,10 @@ func (asset *Asset) StartAccountMixer(walletPassphrase string) error {
 		return errors.New(utils.ErrNotExist)
 	}
 
+	if asset.IsAccountMixerActive() {
+		return errors.New(utils.ErrInvalid)
+	}
+
 	cfg := asset.readCSPPConfig()
 	if cfg == nil {
 		return errors.New(utils.ErrFailedPrecondition)
@@ -163,38 +260,105 @@ func (asset *Asset) StartAccountMixer(walletPassphrase string) error {
 		return errors.New(utils.ErrNoMixableOutput)
 	}
 
-	tb := ticketbuyer.New(asset.Internal().DCR)
-	tb.AccessConfig(func(c *ticketbuyer.Config) {
-		c.MixedAccountBranch = cfg.MixedAccountBranch
-		c.MixedAccount = cfg.MixedAccount
-		c.ChangeAccount = cfg.ChangeAccount
-		c.CSPPServer = cfg.CSPPServer
-		c.DialCSPPServer = cfg.DialCSPPServer
-		c.TicketSplitAccount = cfg.TicketSplitAccount
-		c.BuyTickets = false
-		c.MixChange = true
-		// c.VotingAccount = 0 // TODO: VotingAccount should be configurable.
-	})
+	schedule := asset.MixerSchedule()
+	if schedule.balanceBelowThreshold(asset, int32(cfg.ChangeAccount)) {
+		return errors.New(utils.ErrNoMixableOutput)
+	}
 
 	err := asset.UnlockWallet(walletPassphrase)
 	if err != nil {
 		return utils.TranslateError(err)
 	}
 
+	asset.mixerStatsMu.Lock()
+	asset.mixerStats = &MixerStats{
+		StartedAt:     time.Now().Unix(),
+		Denominations: make(map[int64]int),
+	}
+	asset.mixerStatsMu.Unlock()
+
+	// Count every dial to the coordination server as a mix round attempt and
+	// record the number of peers the server pairs for the round.
+	dialCSPPServer := cfg.DialCSPPServer
+	cfg.DialCSPPServer = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		asset.updateMixerStats(func(stats *MixerStats) {
+			stats.RoundsAttempted++
+		})
+		conn, err := dialCSPPServer(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		return newPeerCountingConn(conn, asset.recordMixPeers), nil
+	}
+
+	ctx, cancel := asset.ShutdownContextWithCancel()
+	asset.cancelAccountMixerMu.Lock()
+	if asset.cancelAccountMixer != nil {
+		asset.cancelAccountMixerMu.Unlock()
+		cancel()
+		return errors.New(utils.ErrInvalid)
+	}
+	asset.accountMixerSession++
+	session := asset.accountMixerSession
+	asset.cancelAccountMixer = cancel
+	asset.cancelAccountMixerMu.Unlock()
+
 	go func() {
 		log.Info("Running account mixer")
 		if asset.accountMixerNotificationListener != nil {
 			asset.publishAccountMixerStarted(asset.ID)
 		}
 
-		ctx, cancel := asset.ShutdownContextWithCancel()
-		asset.cancelAccountMixer = cancel
-		err = tb.Run(ctx, []byte(walletPassphrase))
-		if err != nil {
-			log.Errorf("AccountMixer instance errored: %v", err)
+		for {
+			if !schedule.inWindow(time.Now()) {
+				log.Infof("[%d] Account mixer waiting for the next mixing window", asset.ID)
+				select {
+				case <-time.After(schedule.untilWindowStart(time.Now())):
+				case <-ctx.Done():
+				}
+
+				if ctx.Err() != nil {
+					break
+				}
+
+				// The wallet may have been locked while waiting for the window.
+				if err := asset.UnlockWallet(walletPassphrase); err != nil {
+					log.Errorf("[%d] Unable to unlock wallet for mixing: %v", asset.ID, err)
+					break
+				}
+			}
+
+			runCtx, endRun := context.WithCancel(ctx)
+			var windowEnd *time.Timer
+			if schedule.hasWindow() {
+				windowEnd = time.AfterFunc(schedule.untilWindowEnd(time.Now()), endRun)
+			}
+
+			err := asset.newMixerTicketBuyer(cfg).Run(runCtx, []byte(walletPassphrase))
+			if err != nil && !errors.Is(err, context.Canceled) {
+				log.Errorf("AccountMixer instance errored: %v", err)
+			}
+
+			windowClosed := runCtx.Err() != nil && ctx.Err() == nil
+			if windowEnd != nil {
+				windowEnd.Stop()
+			}
+			endRun()
+			if !windowClosed {
+				break
+			}
+			log.Infof("[%d] Account mixer paused until the next mixing window", asset.ID)
 		}
 
-		asset.cancelAccountMixer = nil
+		// The mixer may have been stopped and started again meanwhile, only
+		// clear the cancel func of this session.
+		asset.cancelAccountMixerMu.Lock()
+		if asset.accountMixerSession == session {
+			asset.cancelAccountMixer = nil
+		}
+		asset.cancelAccountMixerMu.Unlock()
+		cancel()
+
 		if asset.accountMixerNotificationListener != nil {
 			asset.publishAccountMixerEnded(asset.ID)
 		}
@@ -203,6 +367,22 @@ func (asset *Asset) StartAccountMixer(walletPassphrase string) error {
 	return nil
 }
 
+func (asset *Asset) newMixerTicketBuyer(cfg *CSPPConfig) *ticketbuyer.TB {
+	tb := ticketbuyer.New(asset.Internal().DCR)
+	tb.AccessConfig(func(c *ticketbuyer.Config) {
+		c.MixedAccountBranch = cfg.MixedAccountBranch
+		c.MixedAccount = cfg.MixedAccount
+		c.ChangeAccount = cfg.ChangeAccount
+		c.CSPPServer = cfg.CSPPServer
+		c.DialCSPPServer = cfg.DialCSPPServer
+		c.TicketSplitAccount = cfg.TicketSplitAccount
+		c.BuyTickets = false
+		c.MixChange = true
+		// c.VotingAccount = 0 // TODO: VotingAccount should be configurable.
+	})
+	return tb
+}
+
 func (asset *Asset) readCSPPConfig() *CSPPConfig {
 	mixedAccount := asset.ReadInt32ConfigValueForKey(sharedW.AccountMixerMixedAccount, -1)
 	unmixedAccount := asset.ReadInt32ConfigValueForKey(sharedW.AccountMixerUnmixedAccount, -1)
@@ -212,32 +392,57 @@ func (asset *Asset) readCSPPConfig() *CSPPConfig {
 		return nil
 	}
 
-	shufflePort := TestnetShufflePort
-	var dialCSPPServer func(ctx context.Context, network, addr string) (net.Conn, error)
-	if asset.chainParams.Net == chaincfg.MainNetParams().Net {
-		shufflePort = MainnetShufflePort
-
-		pool := x509.NewCertPool()
-		pool.AppendCertsFromPEM([]byte(certs.CSPP))
+	serverCfg := asset.CSPPServerConfig()
+	csppTLSConfig := &tls.Config{
+		ServerName: serverCfg.Host,
+		MinVersion: tls.VersionTLS12,
+	}
 
-		csppTLSConfig := new(tls.Config)
-		csppTLSConfig.ServerName = ShuffleServer
+	// The compiled-in certificate is only trusted for the default mainnet
+	// server and the provided certificate for the custom server, on top of
+	// the system roots.
+	isMainnet := asset.chainParams.Net == chaincfg.MainNetParams().Net
+	isDefaultServer := isMainnet && serverCfg.Host == ShuffleServer
+	if isDefaultServer || serverCfg.CertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			log.Warnf("[%d] Unable to load the system roots: %v", asset.ID, err)
+			pool = x509.NewCertPool()
+		}
+		if isDefaultServer {
+			pool.AppendCertsFromPEM([]byte(certs.CSPP))
+		}
+		pool.AppendCertsFromPEM([]byte(serverCfg.CertPEM))
 		csppTLSConfig.RootCAs = pool
+	}
 
-		dailer := new(net.Dialer)
-		dialCSPPServer = func(ctx context.Context, network, addr string) (net.Conn, error) {
-			conn, err := dailer.DialContext(context.Background(), network, addr)
-			if err != nil {
-				return nil, err
+	// The fingerprint is validated when the server config is saved.
+	if pinned, _ := hex.DecodeString(serverCfg.CertFingerprint); len(pinned) > 0 {
+		csppTLSConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 {
+				return errors.New("cspp server presented no certificate")
+			}
+			fingerprint := sha256.Sum256(state.PeerCertificates[0].Raw)
+			if !bytes.Equal(fingerprint[:], pinned) {
+				return errors.New("cspp server certificate does not match the pinned fingerprint")
 			}
+			return nil
+		}
+	}
 
-			conn = tls.Client(conn, csppTLSConfig)
-			return conn, nil
+	dailer := new(net.Dialer)
+	dialCSPPServer := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dailer.DialContext(ctx, network, addr)
+		if err != nil {
+			return nil, err
 		}
+
+		conn = tls.Client(conn, csppTLSConfig)
+		return conn, nil
 	}
 
 	return &CSPPConfig{
-		CSPPServer:         ShuffleServer + ":" + shufflePort,
+		CSPPServer:         net.JoinHostPort(serverCfg.Host, serverCfg.Port),
 		DialCSPPServer:     dialCSPPServer,
 		MixedAccount:       uint32(mixedAccount),
 		MixedAccountBranch: uint32(MixedAccountBranch),
@@ -252,6 +457,9 @@ func (asset *Asset) StopAccountMixer() error {
 		return errors.New(utils.ErrNotExist)
 	}
 
+	asset.cancelAccountMixerMu.Lock()
+	defer asset.cancelAccountMixerMu.Unlock()
+
 	if asset.cancelAccountMixer == nil {
 		return errors.New(utils.ErrInvalid)
 	}
@@ -294,7 +502,14 @@ func (asset *Asset) accountHasMixableOutput(accountNumber int32) bool {
 		if err != nil {
 			return hasMixableOutput
 		}
-		hasMixableOutput = len(lockedOutpoints) > 0
+		// Outputs locked because they are frozen are not mixable.
+		for _, outpoint := range lockedOutpoints {
+			metadata, err := asset.UTXOMetadata(outpoint.Txid, outpoint.Vout)
+			if err != nil || !metadata.Frozen {
+				hasMixableOutput = true
+				break
+			}
+		}
 	}
 
 	return hasMixableOutput
@@ -302,6 +517,8 @@ func (asset *Asset) accountHasMixableOutput(accountNumber int32) bool {
 
 // IsAccountMixerActive returns true if account mixer is active
 func (asset *Asset) IsAccountMixerActive() bool {
+	asset.cancelAccountMixerMu.RLock()
+	defer asset.cancelAccountMixerMu.RUnlock()
 	return asset.cancelAccountMixer != nil
 }
 
@@ -322,3 +539,137 @@ func (asset *Asset) publishAccountMixerEnded(walletID int) {
 		accountMixerNotificationListener.OnAccountMixerEnded(walletID)
 	}
 }
+
+func (asset *Asset) publishAccountMixerStats(walletID int, stats *MixerStats) {
+	asset.notificationListenersMu.RLock()
+	defer asset.notificationListenersMu.RUnlock()
+
+	for _, accountMixerNotificationListener := range asset.accountMixerNotificationListener {
+		accountMixerNotificationListener.OnAccountMixerStats(walletID, stats.copy())
+	}
+}
+
+// updateMixerStats applies update to the current mixer session statistics
+// and publishes the result to the mixer listeners.
+func (asset *Asset) updateMixerStats(update func(stats *MixerStats)) {
+	asset.mixerStatsMu.Lock()
+	if asset.mixerStats == nil {
+		asset.mixerStatsMu.Unlock()
+		return
+	}
+	update(asset.mixerStats)
+	stats := asset.mixerStats.copy()
+	asset.mixerStatsMu.Unlock()
+
+	asset.publishAccountMixerStats(asset.ID, stats)
+}
+
+// recordMixTransaction updates the mixer statistics with a newly seen mix
+// transaction that paid into the mixed account.
+func (asset *Asset) recordMixTransaction(tx *sharedW.Transaction) {
+	if !asset.IsAccountMixerActive() || tx.Type != txhelper.TxTypeMixed || tx.MixCount == 0 {
+		return
+	}
+
+	asset.updateMixerStats(func(stats *MixerStats) {
+		stats.RoundsSucceeded++
+		stats.Denominations[tx.MixDenomination] += int(tx.MixCount)
+	})
+}
+
+// recordMixPeers records the number of peers paired for a mix round by the
+// coordination server.
+func (asset *Asset) recordMixPeers(peers int) {
+	asset.updateMixerStats(func(stats *MixerStats) {
+		stats.PeersPerRound = append(stats.PeersPerRound, peers)
+	})
+}
+
+// mixerLimitReached returns true if the running mixer session reached any of
+// the limits set in the mixer schedule.
+func (asset *Asset) mixerLimitReached() bool {
+	schedule := asset.MixerSchedule()
+	if schedule.MaxMixes > 0 {
+		asset.mixerStatsMu.RLock()
+		succeeded := 0
+		if asset.mixerStats != nil {
+			succeeded = asset.mixerStats.RoundsSucceeded
+		}
+		asset.mixerStatsMu.RUnlock()
+
+		if succeeded >= schedule.MaxMixes {
+			return true
+		}
+	}
+
+	unmixedAccount := asset.ReadInt32ConfigValueForKey(sharedW.AccountMixerUnmixedAccount, -1)
+	return schedule.balanceBelowThreshold(asset, unmixedAccount)
+}
+
+func (stats *MixerStats) copy() *MixerStats {
+	statsCopy := *stats
+	statsCopy.Denominations = make(map[int64]int, len(stats.Denominations))
+	for denomination, count := range stats.Denominations {
+		statsCopy.Denominations[denomination] = count
+	}
+	statsCopy.PeersPerRound = append([]int(nil), stats.PeersPerRound...)
+	return &statsCopy
+}
+
+func (schedule *MixerSchedule) isValid() bool {
+	const minutesPerDay = 24 * 60
+	return schedule.WindowStart >= 0 && schedule.WindowStart < minutesPerDay &&
+		schedule.WindowEnd >= 0 && schedule.WindowEnd < minutesPerDay &&
+		schedule.MaxMixes >= 0 && schedule.MinUnmixedBalance >= 0
+}
+
+func (schedule *MixerSchedule) hasWindow() bool {
+	return schedule.WindowStart != schedule.WindowEnd
+}
+
+// inWindow returns true if mixing is allowed at time t.
+func (schedule *MixerSchedule) inWindow(t time.Time) bool {
+	if !schedule.hasWindow() {
+		return true
+	}
+
+	minute := t.Hour()*60 + t.Minute()
+	if schedule.WindowStart < schedule.WindowEnd {
+		return minute >= schedule.WindowStart && minute < schedule.WindowEnd
+	}
+	// The window wraps past midnight.
+	return minute >= schedule.WindowStart || minute < schedule.WindowEnd
+}
+
+// untilWindowStart returns the duration from t to the next opening of the
+// mixing window.
+func (schedule *MixerSchedule) untilWindowStart(t time.Time) time.Duration {
+	return untilMinuteOfDay(t, schedule.WindowStart)
+}
+
+// untilWindowEnd returns the duration from t to the next closing of the
+// mixing window.
+func (schedule *MixerSchedule) untilWindowEnd(t time.Time) time.Duration {
+	return untilMinuteOfDay(t, schedule.WindowEnd)
+}
+
+func (schedule *MixerSchedule) balanceBelowThreshold(asset *Asset, unmixedAccount int32) bool {
+	if schedule.MinUnmixedBalance <= 0 {
+		return false
+	}
+
+	balance, err := asset.GetAccountBalance(unmixedAccount)
+	if err != nil {
+		log.Errorf("[%d] Unable to read unmixed account balance: %v", asset.ID, err)
+		return false
+	}
+	return balance.Spendable.ToInt() < schedule.MinUnmixedBalance
+}
+
+func untilMinuteOfDay(t time.Time, minuteOfDay int) time.Duration {
+	next := time.Date(t.Year(), t.Month(), t.Day(), 0, minuteOfDay, 0, 0, t.Location())
+	if !next.After(t) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next.Sub(t)
+}
@@ -0,0 +1,116 @@
+package dcr
+
+import (
+	"encoding/gob"
+	"net"
+	"testing"
+	"time"
+
+	"decred.org/cspp/v2/messages"
+	"golang.org/x/crypto/ed25519"
+)
+
+func TestMixerScheduleIsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule MixerSchedule
+		valid    bool
+	}{
+		{"empty", MixerSchedule{}, true},
+		{"window", MixerSchedule{WindowStart: 22 * 60, WindowEnd: 6 * 60}, true},
+		{"limits", MixerSchedule{MaxMixes: 10, MinUnmixedBalance: 1e8}, true},
+		{"negative start", MixerSchedule{WindowStart: -1}, false},
+		{"end past midnight", MixerSchedule{WindowEnd: 24 * 60}, false},
+		{"negative max mixes", MixerSchedule{MaxMixes: -1}, false},
+		{"negative balance", MixerSchedule{MinUnmixedBalance: -1}, false},
+	}
+
+	for _, test := range tests {
+		if valid := test.schedule.isValid(); valid != test.valid {
+			t.Errorf("%s: got valid %v, want %v", test.name, valid, test.valid)
+		}
+	}
+}
+
+func TestMixerScheduleInWindow(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		schedule MixerSchedule
+		t        time.Time
+		inWindow bool
+	}{
+		{"no window", MixerSchedule{}, at(3, 0), true},
+		{"inside", MixerSchedule{WindowStart: 60, WindowEnd: 120}, at(1, 30), true},
+		{"at start", MixerSchedule{WindowStart: 60, WindowEnd: 120}, at(1, 0), true},
+		{"at end", MixerSchedule{WindowStart: 60, WindowEnd: 120}, at(2, 0), false},
+		{"before", MixerSchedule{WindowStart: 60, WindowEnd: 120}, at(0, 59), false},
+		{"wrapping late", MixerSchedule{WindowStart: 22 * 60, WindowEnd: 6 * 60}, at(23, 0), true},
+		{"wrapping early", MixerSchedule{WindowStart: 22 * 60, WindowEnd: 6 * 60}, at(5, 59), true},
+		{"wrapping outside", MixerSchedule{WindowStart: 22 * 60, WindowEnd: 6 * 60}, at(12, 0), false},
+	}
+
+	for _, test := range tests {
+		if inWindow := test.schedule.inWindow(test.t); inWindow != test.inWindow {
+			t.Errorf("%s: got in window %v, want %v", test.name, inWindow, test.inWindow)
+		}
+	}
+}
+
+func TestMixerScheduleUntilWindow(t *testing.T) {
+	schedule := MixerSchedule{WindowStart: 22 * 60, WindowEnd: 6 * 60}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if got, want := schedule.untilWindowStart(now), 10*time.Hour; got != want {
+		t.Errorf("until start: got %v, want %v", got, want)
+	}
+	if got, want := schedule.untilWindowEnd(now), 18*time.Hour; got != want {
+		t.Errorf("until end: got %v, want %v", got, want)
+	}
+
+	// At the start minute the window opens again the next day.
+	atStart := time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC)
+	if got, want := schedule.untilWindowStart(atStart), 24*time.Hour; got != want {
+		t.Errorf("until start at start: got %v, want %v", got, want)
+	}
+}
+
+func TestPeerCountingConn(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+
+	var peers []int
+	conn := newPeerCountingConn(client, func(n int) { peers = append(peers, n) })
+	defer conn.Close()
+
+	// The begin run message is followed by the other messages of the run,
+	// which are passed through without being counted.
+	vk := make([]ed25519.PublicKey, 3)
+	for i := range vk {
+		vk[i] = make(ed25519.PublicKey, ed25519.PublicKeySize)
+		vk[i][0] = byte(i + 1)
+	}
+	go func() {
+		enc := gob.NewEncoder(server)
+		enc.Encode(messages.BeginRun(vk, []int{1, 2, 1}, []byte("sid")))
+		enc.Encode(messages.BeginRun(vk[:2], []int{1, 1}, []byte("rerun")))
+	}()
+
+	dec := gob.NewDecoder(conn)
+	for _, want := range []int{3, 2} {
+		br := new(messages.BR)
+		if err := dec.Decode(br); err != nil {
+			t.Fatal(err)
+		}
+		if len(br.Vk) != want {
+			t.Errorf("expected the client to read %d peers, got %d", want, len(br.Vk))
+		}
+	}
+
+	if len(peers) != 1 || peers[0] != 3 {
+		t.Errorf("expected a round of 3 peers to be recorded, got %v", peers)
+	}
+}
@@ -5,13 +5,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"decred.org/dcrwallet/v3/errors"
 	w "decred.org/dcrwallet/v3/wallet"
+	"decred.org/dcrwallet/v3/wallet/udb"
 	"github.com/crypto-power/cryptopower/libwallet/addresshelper"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
 	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/txscript/v4/stdscript"
 )
 
 func (asset *Asset) GetAccounts() (string, error) {
@@ -141,6 +145,32 @@ func (asset *Asset) SpendableForAccount(account int32) (int64, error) {
 	return int64(bals.Spendable), nil
 }
 
+// utxoOrigin returns the origin of funds paid to the script. Outputs paid to
+// internal addresses of the unmixed accounts are change, outputs of the mixed
+// account keep the provided accountOrigin.
+func (asset *Asset) utxoOrigin(scriptVersion uint16, pkScript []byte, accountOrigin sharedW.UTXOOrigin) sharedW.UTXOOrigin {
+	if accountOrigin != sharedW.UTXOOriginUnknown {
+		return accountOrigin
+	}
+
+	_, addrs := stdscript.ExtractAddrs(scriptVersion, pkScript, asset.chainParams)
+	if len(addrs) == 0 {
+		return accountOrigin
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	known, err := asset.Internal().DCR.KnownAddress(ctx, addrs[0])
+	if err != nil {
+		return accountOrigin
+	}
+	if bip44Addr, ok := known.(w.BIP0044Address); ok {
+		if _, branch, _ := bip44Addr.Path(); branch == udb.InternalBranch {
+			return sharedW.UTXOOriginChange
+		}
+	}
+	return accountOrigin
+}
+
 func (asset *Asset) UnspentOutputs(account int32) ([]*sharedW.UnspentOutput, error) {
 	if !asset.WalletOpened() {
 		return nil, utils.ErrDCRNotInitialized
@@ -157,6 +187,11 @@ func (asset *Asset) UnspentOutputs(account int32) ([]*sharedW.UnspentOutput, err
 		return nil, err
 	}
 
+	origin := sharedW.UTXOOriginUnknown
+	if mixedAccount := asset.MixedAccountNumber(); mixedAccount != -1 && mixedAccount == account {
+		origin = sharedW.UTXOOriginMixed
+	}
+
 	unspentOutputs := make([]*sharedW.UnspentOutput, 0, len(unspents))
 	for _, utxo := range unspents {
 		addresses := addresshelper.PkScriptAddresses(asset.chainParams, utxo.Output.PkScript)
@@ -182,12 +217,80 @@ func (asset *Asset) UnspentOutputs(account int32) ([]*sharedW.UnspentOutput, err
 			Confirmations: confirmations,
 			Spendable:     true,
 			Tree:          utxo.OutPoint.Tree,
+			Origin:        asset.utxoOrigin(utxo.Output.Version, utxo.Output.PkScript, origin),
 		})
 	}
 
+	if err := asset.ApplyUTXOMetadata(unspentOutputs); err != nil {
+		return nil, err
+	}
+
 	return unspentOutputs, nil
 }
 
+// SetUTXOFrozen freezes or unfreezes the provided output. Frozen outputs are
+// also locked in the upstream wallet so that the account mixer and the ticket
+// buyer never spend them.
+func (asset *Asset) SetUTXOFrozen(txID string, vout uint32, frozen bool) error {
+	if !asset.WalletOpened() {
+		return utils.ErrDCRNotInitialized
+	}
+
+	hash, err := chainhash.NewHashFromStr(txID)
+	if err != nil {
+		return errors.New(utils.ErrInvalid)
+	}
+
+	if err = asset.Wallet.SetUTXOFrozen(txID, vout, frozen); err != nil {
+		return err
+	}
+
+	if frozen {
+		asset.Internal().DCR.LockOutpoint(hash, vout)
+	} else {
+		asset.Internal().DCR.UnlockOutpoint(hash, vout)
+	}
+	return nil
+}
+
+// lockFrozenUTXOs locks all the frozen outputs in the upstream wallet. The
+// upstream outpoint locks are not persisted hence the need to restore them
+// every time the wallet is synced.
+func (asset *Asset) lockFrozenUTXOs() {
+	frozen, err := asset.FrozenUTXOs()
+	if err != nil {
+		log.Errorf("unable to read frozen outputs: %v", err)
+		return
+	}
+
+	for _, metadata := range frozen {
+		txID, vout, err := parseUTXOOutPoint(metadata.OutPoint)
+		if err != nil {
+			log.Warnf("invalid frozen output %s: %v", metadata.OutPoint, err)
+			continue
+		}
+		asset.Internal().DCR.LockOutpoint(txID, vout)
+	}
+}
+
+func parseUTXOOutPoint(outpoint string) (*chainhash.Hash, uint32, error) {
+	txID, index, found := strings.Cut(outpoint, ":")
+	if !found {
+		return nil, 0, fmt.Errorf("missing output index")
+	}
+
+	hash, err := chainhash.NewHashFromStr(txID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	vout, err := strconv.ParseUint(index, 10, 32)
+	if err != nil {
+		return nil, 0, err
+	}
+	return hash, uint32(vout), nil
+}
+
 func (asset *Asset) CreateNewAccount(accountName, privPass string) (int32, error) {
 	err := asset.UnlockWallet(privPass)
 	if err != nil {
@@ -0,0 +1,102 @@
+package dcr
+
+import (
+	"net"
+	"os"
+	"sync"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/addrmgr/v2"
+)
+
+// Verify that ChainBackend implements the shared chain backend interface.
+var _ sharedW.ChainBackend = (*ChainBackend)(nil)
+
+// ChainBackend shares a single address manager between the SPV syncers of all
+// the DCR wallets. The peer addresses are discovered and ranked once for all
+// the wallets and are saved across restarts. The block headers and cfilters
+// are still stored by dcrwallet in each wallet database.
+type ChainBackend struct {
+	dataDir string
+
+	mu    sync.Mutex
+	amgr  *addrmgr.AddrManager
+	users map[int]struct{}
+}
+
+// NewChainBackend creates a chain backend that stores the known peers in
+// dataDir. The address manager is only started once a wallet needs it.
+func NewChainBackend(dataDir string) *ChainBackend {
+	return &ChainBackend{
+		dataDir: dataDir,
+		users:   make(map[int]struct{}),
+	}
+}
+
+// acquire returns the shared address manager, starting it if no other wallet
+// is using it.
+func (b *ChainBackend) acquire(walletID int) (*addrmgr.AddrManager, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.amgr == nil {
+		if err := os.MkdirAll(b.dataDir, utils.UserFilePerm); err != nil {
+			return nil, err
+		}
+		b.amgr = addrmgr.New(b.dataDir, net.LookupIP) // TODO: be mindful of tor
+		b.amgr.Start()
+		log.Info("Shared DCR address manager started")
+	}
+
+	b.users[walletID] = struct{}{}
+	return b.amgr, nil
+}
+
+// Release removes the wallet from the address manager users. The address
+// manager is stopped, saving the known peers, once no wallet uses it anymore.
+// Releasing a wallet that is not using it does nothing.
+func (b *ChainBackend) Release(walletID int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.users[walletID]; !ok {
+		return
+	}
+
+	delete(b.users, walletID)
+	if len(b.users) == 0 {
+		b.stop()
+	}
+}
+
+// Close stops the address manager. It must only be called once all the
+// wallets have shut down.
+func (b *ChainBackend) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.stop()
+	return nil
+}
+
+// stop stops the address manager if it is running. It must be called with mu
+// held.
+func (b *ChainBackend) stop() {
+	if b.amgr == nil {
+		return
+	}
+
+	if err := b.amgr.Stop(); err != nil {
+		log.Errorf("Stopping shared address manager failed: %v", err)
+	}
+	b.amgr = nil
+	log.Info("Shared DCR address manager stopped")
+}
+
+// sharedChainBackend returns the DCR chain backend provided by the assets
+// manager, if any.
+func sharedChainBackend(params *sharedW.InitParams) *ChainBackend {
+	backend, _ := params.ChainBackends[utils.DCRWalletAsset].(*ChainBackend)
+	return backend
+}
@@ -0,0 +1,44 @@
+package dcr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChainBackendSharesAddrManager(t *testing.T) {
+	dataDir := filepath.Join(t.TempDir(), "chain")
+	backend := NewChainBackend(dataDir)
+
+	amgr1, err := backend.acquire(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	amgr2, err := backend.acquire(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amgr1 != amgr2 {
+		t.Fatal("expected the wallets to share the address manager")
+	}
+
+	// Releasing a wallet that is not using it does nothing.
+	backend.Release(3)
+	backend.Release(1)
+	if backend.amgr == nil {
+		t.Fatal("address manager stopped while a wallet still uses it")
+	}
+
+	backend.Release(2)
+	if backend.amgr != nil {
+		t.Fatal("address manager not stopped once released by all the wallets")
+	}
+	// The known peers are saved when the address manager stops.
+	if _, err := os.Stat(filepath.Join(dataDir, "peers.json")); err != nil {
+		t.Errorf("expected the known peers to be saved: %v", err)
+	}
+
+	if err := backend.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -240,7 +240,6 @@ func (asset *Asset) AllVoteAgendas(hash string, newestFirst bool) ([]*Agenda, er
 	}
 
 	agendas := make([]*Agenda, len(deployments))
-	var status string
 	for i := range deployments {
 		d := &deployments[i]
 
@@ -252,6 +251,7 @@ func (asset *Asset) AllVoteAgendas(hash string, newestFirst bool) ([]*Agenda, er
 			}
 		}
 
+		status := UnknownStatus.String()
 		for j := range dcrdataAgenda {
 			if dcrdataAgenda[j].Name == d.Vote.Id {
 				status = AgendaStatusFromStr(dcrdataAgenda[j].Status).String()
@@ -277,3 +277,74 @@ func (asset *Asset) AllVoteAgendas(hash string, newestFirst bool) ([]*Agenda, er
 	}
 	return agendas, nil
 }
+
+// CurrentAgendas returns the agendas of the current stake version, whose vote
+// choices can be set. Unlike AllVoteAgendas, it does not query the agendas'
+// status from dcrdata.
+func (asset *Asset) CurrentAgendas() []*Agenda {
+	_, deployments := w.CurrentAgendas(asset.chainParams)
+	agendas := make([]*Agenda, len(deployments))
+	for i := range deployments {
+		d := &deployments[i]
+		agendas[i] = &Agenda{
+			AgendaID:    d.Vote.Id,
+			Description: d.Vote.Description,
+			Mask:        uint32(d.Vote.Mask),
+			Choices:     d.Vote.Choices,
+			StartTime:   int64(d.StartTime),
+			ExpireTime:  int64(d.ExpireTime),
+		}
+	}
+	return agendas
+}
+
+// AgendaTicketChoices returns the number of the wallet's unspent, unexpired
+// tickets and how many of them have no vote choice other than abstain set for
+// the specified agenda. Tickets without a ticket-specific choice use the
+// wallet's default vote choice.
+func (asset *Asset) AgendaTicketChoices(agendaID string) (eligible, unvoted int, err error) {
+	tickets, err := asset.UnspentUnexpiredTickets()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	for _, ticket := range tickets {
+		ticketHash, err := chainhash.NewHashFromStr(ticket.Hash)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		choices, _, err := asset.Internal().DCR.AgendaChoices(ctx, ticketHash)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		eligible++
+		choiceID := "abstain"
+		for i := range choices {
+			if choices[i].AgendaID == agendaID {
+				choiceID = choices[i].ChoiceID
+				break
+			}
+		}
+		if choiceID == "abstain" {
+			unvoted++
+		}
+	}
+
+	return eligible, unvoted, nil
+}
+
+// RuleChangeIntervalBlocksRemaining returns the number of blocks left until
+// the end of the current rule change interval, at which the votes on the
+// agendas in progress are tallied.
+func (asset *Asset) RuleChangeIntervalBlocksRemaining() int32 {
+	interval := int32(asset.chainParams.RuleChangeActivationInterval)
+	height := asset.GetBestBlockHeight() - int32(asset.chainParams.StakeValidationHeight)
+	if interval <= 0 || height < 0 {
+		return 0
+	}
+
+	return interval - height%interval
+}
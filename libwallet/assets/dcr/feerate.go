@@ -0,0 +1,87 @@
+package dcr
+
+import (
+	"fmt"
+	"sync"
+
+	"decred.org/dcrwallet/v3/wallet/txrules"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/decred/dcrd/dcrutil/v4"
+)
+
+const (
+	// MinFeeRatePerkB defines the minimum fee rate a user can set on a tx. It
+	// equals the default relay fee of 10,000 Atoms/kB (0.0001 DCR/kB).
+	MinFeeRatePerkB = txrules.DefaultRelayFeePerKb
+
+	// MaxFeeRatePerkB defines the maximum fee rate a user can set on a tx.
+	// Fee rates above this are considered insanely high by the network nodes.
+	// 1,000,000 Atoms/kB = 0.01 DCR/kB.
+	MaxFeeRatePerkB dcrutil.Amount = 100 * MinFeeRatePerkB
+)
+
+// feeRateConfig holds the fee rate the user wants applied to all his
+// transactions.
+type feeRateConfig struct {
+	// SetFeeRatePerkB defines the fee rate in Atoms/kB. If nil the default
+	// relay fee is used.
+	SetFeeRatePerkB sharedW.AssetAmount
+
+	mu sync.RWMutex
+}
+
+// SetUserFeeRate sets the fee rate in Atoms/kB units. The fee rate must lie
+// between MinFeeRatePerkB and MaxFeeRatePerkB.
+func (asset *Asset) SetUserFeeRate(feeRatePerkB sharedW.AssetAmount) error {
+	if feeRatePerkB.ToInt() < int64(MinFeeRatePerkB) {
+		return fmt.Errorf("minimum rate is %d Atoms/kB", int64(MinFeeRatePerkB))
+	}
+	if feeRatePerkB.ToInt() > int64(MaxFeeRatePerkB) {
+		return fmt.Errorf("maximum rate is %d Atoms/kB", int64(MaxFeeRatePerkB))
+	}
+
+	asset.fees.mu.Lock()
+	asset.fees.SetFeeRatePerkB = feeRatePerkB
+	asset.fees.mu.Unlock()
+
+	if asset.TxAuthoredInfo != nil {
+		asset.TxAuthoredInfo.mu.Lock()
+		asset.TxAuthoredInfo.needsConstruct = true
+		asset.TxAuthoredInfo.mu.Unlock()
+	}
+	return nil
+}
+
+// GetUserFeeRate returns the fee rate in Atoms/kB units. If not set it
+// defaults to MinFeeRatePerkB.
+func (asset *Asset) GetUserFeeRate() sharedW.AssetAmount {
+	asset.fees.mu.RLock()
+	defer asset.fees.mu.RUnlock()
+
+	if asset.fees.SetFeeRatePerkB == nil {
+		return asset.ToAmount(int64(MinFeeRatePerkB))
+	}
+	return asset.fees.SetFeeRatePerkB
+}
+
+// FeeTargets returns the low, medium and high priority fee rates. dcrd's
+// mining policy includes every transaction paying at least the default relay
+// fee while the block has space left, and its fee estimator returns that rate
+// until blocks fill up. Decred blocks are far from full, so every priority
+// uses the relay fee and targets the next block; a higher rate can still be
+// set with SetUserFeeRate.
+func (asset *Asset) FeeTargets() ([]*sharedW.FeeTarget, error) {
+	var txSize int
+	if asset.TxAuthoredInfo != nil {
+		if feeAndSize, err := asset.EstimateFeeAndSize(); err == nil {
+			txSize = feeAndSize.EstimatedSignedSize
+		}
+	}
+
+	targets := make([]*sharedW.FeeTarget, 0, len(sharedW.FeePriorities))
+	for _, priority := range sharedW.FeePriorities {
+		targets = append(targets, sharedW.NewFeeTarget(priority, int64(MinFeeRatePerkB), 1,
+			asset.TargetTimePerBlockMinutes(), txSize, asset.ToAmount))
+	}
+	return targets, nil
+}
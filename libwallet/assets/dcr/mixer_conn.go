@@ -0,0 +1,55 @@
+package dcr
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"io"
+	"net"
+
+	"decred.org/cspp/v2/messages"
+)
+
+// maxBeginRunSize bounds the data buffered while waiting for the begin run
+// message of a mix round.
+const maxBeginRunSize = 1 << 20
+
+// peerCountingConn is a connection to the coordination server that decodes
+// the begin run message the server sends first, which lists the peers paired
+// for the mix round. The data read is passed through unchanged.
+type peerCountingConn struct {
+	net.Conn
+	buf     []byte
+	onPeers func(peers int)
+}
+
+func newPeerCountingConn(conn net.Conn, onPeers func(peers int)) net.Conn {
+	return &peerCountingConn{Conn: conn, buf: make([]byte, 0, 512), onPeers: onPeers}
+}
+
+func (c *peerCountingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if c.buf == nil || n == 0 {
+		return n, err
+	}
+
+	c.buf = append(c.buf, b[:n]...)
+	br := new(messages.BR)
+	decodeErr := gob.NewDecoder(bytes.NewReader(c.buf)).Decode(br)
+	switch {
+	case decodeErr == nil:
+		if len(br.Vk) > 0 {
+			c.onPeers(len(br.Vk))
+		}
+		c.buf = nil
+	case errors.Is(decodeErr, io.EOF) || errors.Is(decodeErr, io.ErrUnexpectedEOF):
+		// Wait for the rest of the message.
+		if len(c.buf) > maxBeginRunSize {
+			c.buf = nil
+		}
+	default:
+		log.Debugf("Unable to decode the mix round peers: %v", decodeErr)
+		c.buf = nil
+	}
+	return n, err
+}
@@ -0,0 +1,208 @@
+package dcr
+
+import (
+	"context"
+	"math"
+
+	"decred.org/dcrwallet/v3/errors"
+	w "decred.org/dcrwallet/v3/wallet"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/gcs/v4"
+	"github.com/decred/dcrd/hdkeychain/v3"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/txscript/v4/stdscript"
+	"github.com/decred/dcrd/wire"
+)
+
+// partialRescanBatchSize is the number of block filters matched before the
+// matching blocks are fetched and the rescan progress is reported.
+const partialRescanBatchSize = 2000
+
+// partialRescan rescans the blocks defined by opts for the chosen accounts and
+// addresses only. The committed filters saved by the wallet are matched
+// against the addresses, only the matching blocks are fetched from the
+// network. The progress is sent on p, which is closed once the rescan ends.
+func (asset *Asset) partialRescan(ctx context.Context, n w.NetworkBackend, opts *sharedW.RescanOptions, p chan<- w.RescanProgress) {
+	defer close(p)
+
+	dcrWallet := asset.Internal().DCR
+	addrs, err := asset.rescanAddresses(ctx, opts)
+	if err != nil {
+		p <- w.RescanProgress{Err: err}
+		return
+	}
+
+	scripts := make([][]byte, 0, len(addrs))
+	watched := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		_, script := addr.PaymentScript()
+		scripts = append(scripts, script)
+		watched[addr.String()] = struct{}{}
+	}
+	if len(scripts) == 0 {
+		return
+	}
+
+	// The spends of the outputs paying to the addresses are identified by
+	// their outpoints.
+	unspents, err := dcrWallet.ListUnspent(ctx, 0, math.MaxInt32, watched, "")
+	if err != nil {
+		p <- w.RescanProgress{Err: err}
+		return
+	}
+	outPoints := make(map[wire.OutPoint]struct{}, len(unspents))
+	for _, unspent := range unspents {
+		hash, err := chainhash.NewHashFromStr(unspent.TxID)
+		if err != nil {
+			p <- w.RescanProgress{Err: err}
+			return
+		}
+		outPoints[wire.OutPoint{Hash: *hash, Index: unspent.Vout, Tree: unspent.Tree}] = struct{}{}
+	}
+
+	endHeight := opts.EndHeight
+	if endHeight <= 0 {
+		_, endHeight = dcrWallet.MainChainTip(ctx)
+	}
+
+	for start := opts.StartHeight; start <= endHeight; start += partialRescanBatchSize {
+		end := start + partialRescanBatchSize - 1
+		if end > endHeight {
+			end = endHeight
+		}
+
+		var matches []*chainhash.Hash
+		err := dcrWallet.RangeCFiltersV2(ctx, w.NewBlockIdentifierFromHeight(start), w.NewBlockIdentifierFromHeight(end),
+			func(hash chainhash.Hash, key [gcs.KeySize]byte, filter *gcs.FilterV2) (bool, error) {
+				if filter.MatchAny(key, scripts) {
+					matches = append(matches, &hash)
+				}
+				return false, nil
+			})
+		if err == nil && len(matches) > 0 {
+			err = asset.addRescannedTxs(ctx, n, matches, watched, outPoints)
+		}
+		if err != nil {
+			p <- w.RescanProgress{Err: err}
+			return
+		}
+
+		select {
+		case p <- w.RescanProgress{ScannedThrough: end}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// rescanAddresses returns the addresses watched during a partial rescan. The
+// addresses of an account are the ones it returned so far.
+func (asset *Asset) rescanAddresses(ctx context.Context, opts *sharedW.RescanOptions) ([]stdaddr.Address, error) {
+	dcrWallet := asset.Internal().DCR
+	addrs := make([]stdaddr.Address, 0, len(opts.Addresses))
+	for _, address := range opts.Addresses {
+		addr, err := stdaddr.DecodeAddress(address, asset.chainParams)
+		if err != nil {
+			return nil, errors.E(utils.ErrInvalidAddress)
+		}
+		addrs = append(addrs, addr)
+	}
+
+	for _, account := range opts.Accounts {
+		xpub, err := dcrWallet.AccountXpub(ctx, uint32(account))
+		if err != nil {
+			return nil, err
+		}
+		extChild, intChild, err := dcrWallet.BIP0044BranchNextIndexes(ctx, uint32(account))
+		if err != nil {
+			return nil, err
+		}
+
+		for branch, next := range []uint32{extChild, intChild} {
+			branchKey, err := xpub.Child(uint32(branch))
+			if err != nil {
+				return nil, err
+			}
+			for i := uint32(0); i < next; i++ {
+				child, err := branchKey.Child(i)
+				if errors.Is(err, hdkeychain.ErrInvalidChild) {
+					continue
+				}
+				if err != nil {
+					return nil, err
+				}
+
+				pkHash := dcrutil.Hash160(child.SerializedPubKey())
+				addr, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(pkHash, asset.chainParams)
+				if err != nil {
+					return nil, err
+				}
+				addrs = append(addrs, addr)
+			}
+		}
+	}
+	return addrs, nil
+}
+
+// addRescannedTxs adds the transactions of the blocks that pay to the watched
+// addresses or spend the watched outpoints to the wallet. The outputs paying
+// to the watched addresses are watched for spends in the later blocks.
+func (asset *Asset) addRescannedTxs(ctx context.Context, n w.NetworkBackend, blockHashes []*chainhash.Hash,
+	watched map[string]struct{}, outPoints map[wire.OutPoint]struct{},
+) error {
+	blocks, err := n.Blocks(ctx, blockHashes)
+	if err != nil {
+		return err
+	}
+
+	for i, block := range blocks {
+		trees := []struct {
+			tree int8
+			txs  []*wire.MsgTx
+		}{
+			{wire.TxTreeRegular, block.Transactions},
+			{wire.TxTreeStake, block.STransactions},
+		}
+		for _, tree := range trees {
+			for _, tx := range tree.txs {
+				if !asset.isRescanRelevant(tx, tree.tree, watched, outPoints) {
+					continue
+				}
+				if err := asset.Internal().DCR.AddTransaction(ctx, tx, blockHashes[i]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isRescanRelevant returns true if tx pays to the watched addresses or spends
+// the watched outpoints. The outputs of tx paying to the watched addresses
+// are added to the watched outpoints.
+func (asset *Asset) isRescanRelevant(tx *wire.MsgTx, tree int8, watched map[string]struct{},
+	outPoints map[wire.OutPoint]struct{},
+) bool {
+	var relevant bool
+	for _, in := range tx.TxIn {
+		if _, ok := outPoints[in.PreviousOutPoint]; ok {
+			relevant = true
+		}
+	}
+
+	txHash := tx.TxHash()
+	for i, out := range tx.TxOut {
+		_, addrs := stdscript.ExtractAddrs(out.Version, out.PkScript, asset.chainParams)
+		for _, addr := range addrs {
+			if _, ok := watched[addr.String()]; ok {
+				relevant = true
+				outPoints[wire.OutPoint{Hash: txHash, Index: uint32(i), Tree: tree}] = struct{}{}
+				break
+			}
+		}
+	}
+	return relevant
+}
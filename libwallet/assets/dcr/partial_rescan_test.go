@@ -0,0 +1,57 @@
+package dcr
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/wire"
+)
+
+func TestIsRescanRelevant(t *testing.T) {
+	params := chaincfg.MainNetParams()
+	asset := &Asset{chainParams: params}
+
+	newAddr := func(b byte) stdaddr.Address {
+		addr, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(bytes.Repeat([]byte{b}, 20), params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return addr
+	}
+	payTo := func(addr stdaddr.Address, prevOut wire.OutPoint) *wire.MsgTx {
+		_, script := addr.PaymentScript()
+		tx := wire.NewMsgTx()
+		tx.AddTxIn(wire.NewTxIn(&prevOut, 0, nil))
+		tx.AddTxOut(wire.NewTxOut(1000, script))
+		return tx
+	}
+
+	watchedAddr, otherAddr := newAddr(0x01), newAddr(0x02)
+	watched := map[string]struct{}{watchedAddr.String(): {}}
+	outPoints := make(map[wire.OutPoint]struct{})
+
+	// A payment to the watched address is relevant and its output watched.
+	credit := payTo(watchedAddr, wire.OutPoint{Hash: chainhash.Hash{0x01}})
+	if !asset.isRescanRelevant(credit, wire.TxTreeRegular, watched, outPoints) {
+		t.Fatal("payment to the watched address not relevant")
+	}
+	creditOut := wire.OutPoint{Hash: credit.TxHash(), Index: 0, Tree: wire.TxTreeRegular}
+	if _, ok := outPoints[creditOut]; !ok {
+		t.Fatal("output paying to the watched address not watched")
+	}
+
+	// A payment to another address is not relevant.
+	other := payTo(otherAddr, wire.OutPoint{Hash: chainhash.Hash{0x02}})
+	if asset.isRescanRelevant(other, wire.TxTreeRegular, watched, outPoints) {
+		t.Fatal("payment to another address is relevant")
+	}
+
+	// Spending the watched output is relevant.
+	spend := payTo(otherAddr, creditOut)
+	if !asset.isRescanRelevant(spend, wire.TxTreeRegular, watched, outPoints) {
+		t.Fatal("spend of the watched output not relevant")
+	}
+}
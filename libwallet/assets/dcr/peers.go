@@ -0,0 +1,77 @@
+package dcr
+
+import (
+	"errors"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+var (
+	errBannedPeer       = errors.New("peer banned by the user")
+	errDisconnectedPeer = errors.New("peer disconnected by the user")
+)
+
+// ConnectPeer adds addr to the persistent peers and restarts the sync so that
+// the wallet connects to it.
+func (asset *Asset) ConnectPeer(addr string) error {
+	peerAddress, err := utils.NormalizeAddress(addr, asset.chainParams.DefaultPort)
+	if err != nil {
+		return errors.New(utils.ErrInvalidAddress)
+	}
+	if asset.IsPeerBanned(peerAddress) {
+		return errors.New(utils.ErrPeerBanned)
+	}
+
+	asset.AddPersistentPeer(peerAddress)
+	asset.restartSyncIfConnected()
+	return nil
+}
+
+// DisconnectPeer drops the connection to the peer at addr. The syncer may
+// connect to the peer again, ban it to prevent that.
+func (asset *Asset) DisconnectPeer(addr string) error {
+	if !asset.disconnectPeer(addr, errDisconnectedPeer) {
+		return errors.New(utils.ErrNotExist)
+	}
+	return nil
+}
+
+// BanPeer adds the host of addr to the banned peers and disconnects the peers
+// connected from it. The banned peers are not dialed anymore.
+func (asset *Asset) BanPeer(addr string) error {
+	if err := asset.SaveBannedPeer(addr); err != nil {
+		return err
+	}
+
+	syncer := asset.syncData.syncer
+	if syncer == nil {
+		return nil
+	}
+	for raddr := range syncer.GetRemotePeers() {
+		if asset.IsPeerBanned(raddr) {
+			asset.disconnectPeer(raddr, errBannedPeer)
+		}
+	}
+	return nil
+}
+
+// UnbanPeer removes the host of addr from the banned peers.
+func (asset *Asset) UnbanPeer(addr string) error {
+	return asset.DeleteBannedPeer(addr)
+}
+
+// disconnectPeer disconnects the SPV peer at addr. False is returned if the
+// wallet isn't connected to the peer.
+func (asset *Asset) disconnectPeer(addr string, reason error) bool {
+	syncer := asset.syncData.syncer
+	if syncer == nil {
+		return false
+	}
+	rp, ok := syncer.GetRemotePeers()[addr]
+	if !ok {
+		return false
+	}
+	log.Infof("Disconnecting peer %s: %v", addr, reason)
+	rp.Disconnect(reason)
+	return true
+}
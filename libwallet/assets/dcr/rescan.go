@@ -2,8 +2,6 @@ package dcr
 
 import (
 	"context"
-	"math"
-	"time"
 
 	"decred.org/dcrwallet/v3/errors"
 	w "decred.org/dcrwallet/v3/wallet"
@@ -12,10 +10,18 @@ import (
 )
 
 func (asset *Asset) RescanBlocks() error {
-	return asset.RescanBlocksFromHeight(0)
+	return asset.RescanBlocksWithOptions(&sharedW.RescanOptions{})
 }
 
 func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
+	return asset.RescanBlocksWithOptions(&sharedW.RescanOptions{StartHeight: startHeight})
+}
+
+// RescanBlocksWithOptions rescans the blocks defined by opts. The progress is
+// checkpointed so that a canceled or interrupted rescan can be resumed.
+// dcrwallet always rescans all the wallet addresses, a rescan limited to some
+// accounts or addresses matches the saved block filters against them instead.
+func (asset *Asset) RescanBlocksWithOptions(opts *sharedW.RescanOptions) error {
 	netBackend, err := asset.Internal().DCR.NetworkBackend()
 	if err != nil {
 		return errors.E(utils.ErrNotConnected)
@@ -25,6 +31,10 @@ func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
 		return errors.E(utils.ErrInvalid)
 	}
 
+	if err := opts.Validate(asset.GetBestBlockHeight()); err != nil {
+		return err
+	}
+
 	go func() {
 		defer func() {
 			asset.syncData.mu.Lock()
@@ -44,64 +54,71 @@ func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
 			asset.blocksRescanProgressListener.OnBlocksRescanStarted(asset.ID)
 		}
 
-		progress := make(chan w.RescanProgress, 1)
-		go asset.Internal().DCR.RescanProgressFromHeight(ctx, netBackend, startHeight, progress)
+		// The rescan runs up to the chain tip, it is stopped once the end of
+		// the range is scanned.
+		scanCtx, stopScan := context.WithCancel(ctx)
+		defer stopScan()
 
-		rescanStartTime := time.Now().Unix()
+		progress := make(chan w.RescanProgress, 1)
+		if opts.IsPartial() {
+			go asset.partialRescan(scanCtx, netBackend, opts, progress)
+		} else {
+			go asset.Internal().DCR.RescanProgressFromHeight(scanCtx, netBackend, opts.StartHeight, progress)
+		}
 
+		tracker := asset.NewRescanTracker(opts, asset.GetBestBlockHeight(), true)
+		var scanErr error
+		var done bool
 		for p := range progress {
+			if done {
+				continue
+			}
 			if p.Err != nil {
-				log.Error(p.Err)
-				if asset.blocksRescanProgressListener != nil {
-					asset.blocksRescanProgressListener.OnBlocksRescanEnded(asset.ID, p.Err)
-				}
-				return
+				scanErr = p.Err
+				continue
 			}
 
-			rescanProgressReport := &sharedW.HeadersRescanProgressReport{
-				CurrentRescanHeight: p.ScannedThrough,
-				TotalHeadersToScan:  asset.GetBestBlockHeight(),
+			if asset.blocksRescanProgressListener != nil {
+				asset.blocksRescanProgressListener.OnBlocksRescanProgress(tracker.Progress(p.ScannedThrough))
 			}
 
-			elapsedRescanTime := time.Now().Unix() - rescanStartTime
-			rescanRate := float64(p.ScannedThrough) / float64(rescanProgressReport.TotalHeadersToScan)
-
-			rescanProgressReport.RescanProgress = int32(math.Round(rescanRate * 100))
-			estimatedTotalRescanTime := int64(math.Round(float64(elapsedRescanTime) / rescanRate))
-			rescanProgressReport.RescanTimeRemaining = estimatedTotalRescanTime - elapsedRescanTime
-
-			rescanProgressReport.GeneralSyncProgress = &sharedW.GeneralSyncProgress{
-				TotalSyncProgress:         rescanProgressReport.RescanProgress,
-				TotalTimeRemainingSeconds: rescanProgressReport.RescanTimeRemaining,
-			}
-
-			if asset.blocksRescanProgressListener != nil {
-				asset.blocksRescanProgressListener.OnBlocksRescanProgress(rescanProgressReport)
+			if tracker.Done(p.ScannedThrough) {
+				done = true
+				stopScan()
 			}
+		}
 
-			select {
-			case <-ctx.Done():
-				log.Info("Rescan canceled through context")
+		if !done && ctx.Err() != nil {
+			// The rescan checkpoint is kept to resume the rescan later.
+			tracker.SaveCheckpoint()
+			log.Info("Rescan canceled through context")
 
-				if asset.blocksRescanProgressListener != nil {
-					if ctx.Err() != nil && ctx.Err() != context.Canceled {
-						asset.blocksRescanProgressListener.OnBlocksRescanEnded(asset.ID, ctx.Err())
-					} else {
-						asset.blocksRescanProgressListener.OnBlocksRescanEnded(asset.ID, nil)
-					}
+			if asset.blocksRescanProgressListener != nil {
+				if ctx.Err() != context.Canceled {
+					asset.blocksRescanProgressListener.OnBlocksRescanEnded(asset.ID, ctx.Err())
+				} else {
+					asset.blocksRescanProgressListener.OnBlocksRescanEnded(asset.ID, nil)
 				}
+			}
+			return
+		}
 
-				return
-			default:
-				continue
+		if !done && scanErr != nil {
+			tracker.SaveCheckpoint()
+			log.Error(scanErr)
+			if asset.blocksRescanProgressListener != nil {
+				asset.blocksRescanProgressListener.OnBlocksRescanEnded(asset.ID, scanErr)
 			}
+			return
 		}
 
+		tracker.Finish()
+
 		var err error
-		if startHeight == 0 {
+		if opts.StartHeight == 0 {
 			err = asset.reindexTransactions()
 		} else {
-			err = asset.GetWalletDataDb().SaveLastIndexPoint(startHeight)
+			err = asset.GetWalletDataDb().SaveLastIndexPoint(opts.StartHeight)
 			if err != nil {
 				if asset.blocksRescanProgressListener != nil {
 					asset.blocksRescanProgressListener.OnBlocksRescanEnded(asset.ID, err)
@@ -119,6 +136,31 @@ func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
 	return nil
 }
 
+// ResumeRescan resumes the rescan that was canceled or interrupted by a
+// restart from the last checkpointed block.
+func (asset *Asset) ResumeRescan() error {
+	cp := asset.RescanCheckpoint()
+	if cp == nil {
+		return errors.New(utils.ErrNotExist)
+	}
+	return asset.RescanBlocksWithOptions(cp.ResumeOptions())
+}
+
+// resumeInterruptedRescan resumes the checkpointed rescan, if any, once the
+// wallet is synced.
+func (asset *Asset) resumeInterruptedRescan() {
+	if asset.IsRescanning() || asset.RescanCheckpoint() == nil {
+		return
+	}
+
+	log.Infof("Resuming the rescan of wallet (%s)", asset.GetWalletName())
+	if err := asset.ResumeRescan(); err != nil {
+		log.Errorf("Failed to resume the rescan: %v", err)
+	}
+}
+
+// CancelRescan stops the current rescan. Its progress is kept so that it can
+// be resumed with ResumeRescan.
 func (asset *Asset) CancelRescan() {
 	asset.syncData.mu.Lock()
 	defer asset.syncData.mu.Unlock()
@@ -0,0 +1,40 @@
+package dcr
+
+import (
+	"context"
+
+	w "decred.org/dcrwallet/v3/wallet"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+)
+
+// restoreBirthdayHeight returns the height estimated from the birthday
+// provided on restore, 0 if none was provided.
+func (asset *Asset) restoreBirthdayHeight() int32 {
+	birthday := asset.RestoreBirthday()
+	if birthday == nil {
+		return 0
+	}
+	genesis := asset.chainParams.GenesisBlock
+	return birthday.EstimatedHeight(genesis.Header.Timestamp, asset.chainParams.TargetTimePerBlock)
+}
+
+// discoveryStartBlock returns the block from which the usage discovery and
+// the rescan that follows it begin. It is the block at the height estimated
+// from the birthday provided on restore if the wallet has it, the genesis
+// block otherwise.
+func (asset *Asset) discoveryStartBlock(ctx context.Context) (chainhash.Hash, int32) {
+	genesis := asset.chainParams.GenesisHash
+
+	height := asset.restoreBirthdayHeight()
+	if height == 0 {
+		return genesis, 0
+	}
+
+	info, err := asset.Internal().DCR.BlockInfo(ctx, w.NewBlockIdentifierFromHeight(height))
+	if err != nil {
+		// The birthday may be past the synced chain tip.
+		log.Warnf("Birthday block %d not found, discovering from the genesis block: %v", height, err)
+		return genesis, 0
+	}
+	return info.Hash, height
+}
@@ -0,0 +1,306 @@
+package dcr
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+
+	"decred.org/dcrwallet/v3/chain"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/wire"
+)
+
+// dcrdRPCPorts maps each network to the default dcrd JSON-RPC port.
+var dcrdRPCPorts = map[wire.CurrencyNet]string{
+	wire.MainNet:  "9109",
+	wire.TestNet3: "19109",
+	wire.SimNet:   "19556",
+	wire.RegNet:   "19334",
+}
+
+// storedDcrdRPCConfig is the persisted DcrdRPCConfig. LegacyPass holds the
+// plaintext password saved by earlier versions until it is encrypted.
+type storedDcrdRPCConfig struct {
+	DcrdRPCConfig
+	LegacyPass string `json:"pass,omitempty"`
+}
+
+// SetDcrdRPCConfig persists the connection details of a trusted dcrd node.
+// The RPC password is encrypted with the wallet's private passphrase, the
+// stored password is kept if cfg.Pass is empty and the node is unchanged.
+// The wallet syncs through the node's JSON-RPC interface instead of SPV from
+// the next sync onwards. A running sync is restarted to apply the change.
+func (asset *Asset) SetDcrdRPCConfig(cfg *DcrdRPCConfig, privatePass string) error {
+	if cfg == nil || cfg.Host == "" || cfg.User == "" {
+		return errors.New(utils.ErrInvalid)
+	}
+
+	if _, err := utils.NormalizeAddress(cfg.Host, asset.dcrdRPCPort()); err != nil {
+		return errors.New(utils.ErrInvalidAddress)
+	}
+
+	if cfg.CertPEM != "" {
+		if !x509.NewCertPool().AppendCertsFromPEM([]byte(cfg.CertPEM)) {
+			return errors.New(utils.ErrInvalid)
+		}
+	}
+
+	pass := cfg.Pass
+	if stored := asset.storedDcrdRPCConfig(); pass == "" && stored != nil && stored.Host == cfg.Host {
+		var err error
+		if pass, err = asset.decryptDcrdRPCPass(stored, privatePass); err != nil {
+			return err
+		}
+	} else if err := asset.verifyPrivatePassphrase(privatePass); err != nil {
+		return err
+	}
+
+	encryptedPass, err := sharedW.EncryptWithPassphrase(privatePass, pass)
+	if err != nil {
+		return err
+	}
+
+	newCfg := *cfg
+	newCfg.EncryptedPass = encryptedPass
+	asset.SaveUserConfigValue(sharedW.DcrdRPCConfigKey, &newCfg)
+	asset.setDcrdRPCPass(pass)
+	asset.restartSyncIfConnected()
+	return nil
+}
+
+// DcrdRPCConfig returns the trusted dcrd node the wallet syncs through. Nil
+// is returned if the wallet uses SPV. Pass is empty while the RPC password
+// is locked, see UnlockDcrdRPCConfig.
+func (asset *Asset) DcrdRPCConfig() *DcrdRPCConfig {
+	stored := asset.storedDcrdRPCConfig()
+	if stored == nil {
+		return nil
+	}
+
+	cfg := stored.DcrdRPCConfig
+	asset.dcrdRPCPassMu.RLock()
+	cfg.Pass = asset.dcrdRPCPass
+	asset.dcrdRPCPassMu.RUnlock()
+	return &cfg
+}
+
+// IsDcrdRPCLocked returns true if the wallet syncs through a trusted dcrd
+// node whose password must be unlocked with UnlockDcrdRPCConfig before the
+// wallet can sync.
+func (asset *Asset) IsDcrdRPCLocked() bool {
+	stored := asset.storedDcrdRPCConfig()
+	if stored == nil {
+		return false
+	}
+	if stored.LegacyPass != "" {
+		// The plaintext password must be encrypted first.
+		return true
+	}
+
+	asset.dcrdRPCPassMu.RLock()
+	defer asset.dcrdRPCPassMu.RUnlock()
+	return len(stored.EncryptedPass) > 0 && asset.dcrdRPCPass == ""
+}
+
+// UnlockDcrdRPCConfig decrypts the password of the trusted dcrd node with the
+// wallet's private passphrase. The password is held in memory until the app
+// exits, the wallet itself is left locked. A plaintext password saved by an
+// earlier version is encrypted.
+func (asset *Asset) UnlockDcrdRPCConfig(privatePass string) error {
+	stored := asset.storedDcrdRPCConfig()
+	if stored == nil {
+		return errors.New(utils.ErrNotExist)
+	}
+
+	pass, err := asset.decryptDcrdRPCPass(stored, privatePass)
+	if err != nil {
+		return err
+	}
+
+	if stored.LegacyPass != "" {
+		encryptedPass, err := sharedW.EncryptWithPassphrase(privatePass, pass)
+		if err != nil {
+			return err
+		}
+		cfg := stored.DcrdRPCConfig
+		cfg.EncryptedPass = encryptedPass
+		asset.SaveUserConfigValue(sharedW.DcrdRPCConfigKey, &cfg)
+	}
+
+	asset.setDcrdRPCPass(pass)
+	return nil
+}
+
+// ResetDcrdRPCConfig drops the trusted dcrd node so that the wallet syncs
+// through SPV again. A running sync is restarted to apply the change.
+func (asset *Asset) ResetDcrdRPCConfig() {
+	asset.DeleteUserConfigValueForKey(sharedW.DcrdRPCConfigKey)
+	asset.setDcrdRPCPass("")
+	asset.restartSyncIfConnected()
+}
+
+// ChangePrivatePassphraseForWallet changes the wallet's private passphrase
+// and re-encrypts the password of the trusted dcrd node with it.
+func (asset *Asset) ChangePrivatePassphraseForWallet(oldPrivatePassphrase, newPrivatePassphrase string, privatePassphraseType int32) error {
+	stored := asset.storedDcrdRPCConfig()
+	var pass string
+	if stored != nil {
+		var err error
+		if pass, err = asset.decryptDcrdRPCPass(stored, oldPrivatePassphrase); err != nil {
+			return err
+		}
+	}
+
+	err := asset.Wallet.ChangePrivatePassphraseForWallet(oldPrivatePassphrase, newPrivatePassphrase, privatePassphraseType)
+	if err != nil || stored == nil {
+		return err
+	}
+
+	encryptedPass, err := sharedW.EncryptWithPassphrase(newPrivatePassphrase, pass)
+	if err != nil {
+		log.Errorf("encrypting the dcrd RPC password failed: %v", err)
+		return nil
+	}
+	cfg := stored.DcrdRPCConfig
+	cfg.EncryptedPass = encryptedPass
+	asset.SaveUserConfigValue(sharedW.DcrdRPCConfigKey, &cfg)
+	return nil
+}
+
+func (asset *Asset) storedDcrdRPCConfig() *storedDcrdRPCConfig {
+	cfg := new(storedDcrdRPCConfig)
+	err := asset.ReadUserConfigValue(sharedW.DcrdRPCConfigKey, cfg)
+	if err != nil || cfg.Host == "" {
+		return nil
+	}
+	return cfg
+}
+
+// decryptDcrdRPCPass returns the password of the stored dcrd node config. A
+// plaintext password is only returned if privatePass is the wallet's
+// passphrase.
+func (asset *Asset) decryptDcrdRPCPass(stored *storedDcrdRPCConfig, privatePass string) (string, error) {
+	if len(stored.EncryptedPass) > 0 {
+		return sharedW.DecryptWithPassphrase(privatePass, stored.EncryptedPass)
+	}
+	if err := asset.verifyPrivatePassphrase(privatePass); err != nil {
+		return "", err
+	}
+	return stored.LegacyPass, nil
+}
+
+// verifyPrivatePassphrase returns an error if privatePass isn't the wallet's
+// private passphrase. The wallet's lock state is left unchanged.
+func (asset *Asset) verifyPrivatePassphrase(privatePass string) error {
+	if asset.IsWatchingOnlyWallet() {
+		return errors.New(utils.ErrWalletIsWatchOnly)
+	}
+
+	wasLocked := asset.IsLocked()
+	if err := asset.UnlockWallet(privatePass); err != nil {
+		return err
+	}
+	if wasLocked {
+		asset.LockWallet()
+	}
+	return nil
+}
+
+func (asset *Asset) setDcrdRPCPass(pass string) {
+	asset.dcrdRPCPassMu.Lock()
+	asset.dcrdRPCPass = pass
+	asset.dcrdRPCPassMu.Unlock()
+}
+
+// IsRPCSync returns true if the wallet syncs through a trusted dcrd node.
+func (asset *Asset) IsRPCSync() bool {
+	return asset.DcrdRPCConfig() != nil
+}
+
+func (asset *Asset) restartSyncIfConnected() {
+	if !asset.IsConnectedToDecredNetwork() {
+		return
+	}
+	if err := asset.RestartSpvSync(); err != nil {
+		log.Errorf("restarting sync failed: %v", err)
+	}
+}
+
+func (asset *Asset) dcrdRPCPort() string {
+	if port, ok := dcrdRPCPorts[asset.chainParams.Net]; ok {
+		return port
+	}
+	return dcrdRPCPorts[wire.MainNet]
+}
+
+// rpcSync syncs the wallet through the JSON-RPC interface of the dcrd node
+// described by cfg.
+func (asset *Asset) rpcSync(cfg *DcrdRPCConfig) error {
+	if asset.IsDcrdRPCLocked() {
+		return errors.New(utils.ErrDcrdRPCLocked)
+	}
+
+	syncer := chain.NewSyncer(asset.Internal().DCR, &chain.RPCOptions{
+		Address:     cfg.Host,
+		DefaultPort: asset.dcrdRPCPort(),
+		User:        cfg.User,
+		Pass:        cfg.Pass,
+		Dial:        asset.NewNetLimiter().DialContext,
+		CA:          []byte(cfg.CertPEM),
+	})
+	syncer.SetCallbacks(asset.rpcSyncNotificationCallbacks())
+
+	log.Infof("Syncing %s through dcrd at %s", asset.GetWalletName(), cfg.Host)
+	run := func(ctx context.Context) error {
+		err := syncer.Run(ctx)
+		// The connection to the dcrd node is closed once the syncer stops.
+		asset.handlePeerCountUpdate(0)
+		return err
+	}
+	return asset.runSyncer(run, nil)
+}
+
+// rpcSyncNotificationCallbacks mirrors spvSyncNotificationCallbacks. The RPC
+// syncer doesn't report peers, the dcrd node is reported as the single peer
+// once the wallet has connected to it.
+func (asset *Asset) rpcSyncNotificationCallbacks() *chain.Callbacks {
+	connected := func() {
+		asset.syncData.mu.RLock()
+		connectedPeers := asset.syncData.connectedPeers
+		asset.syncData.mu.RUnlock()
+		if connectedPeers == 0 {
+			asset.handlePeerCountUpdate(1)
+		}
+	}
+
+	return &chain.Callbacks{
+		Synced: func(synced bool) {
+			connected()
+			asset.syncedWallet(synced)
+		},
+		FetchHeadersStarted: func() {
+			connected()
+			asset.fetchHeadersStarted()
+		},
+		FetchHeadersProgress: asset.fetchHeadersProgress,
+		FetchHeadersFinished: asset.fetchHeadersFinished,
+		FetchMissingCFiltersStarted: func() {
+			connected()
+			asset.fetchCFiltersStarted()
+		},
+		FetchMissingCFiltersProgress: asset.fetchCFiltersProgress,
+		FetchMissingCFiltersFinished: asset.fetchCFiltersEnded,
+		DiscoverAddressesStarted:     asset.discoverAddressesStarted,
+		DiscoverAddressesFinished:    asset.discoverAddressesFinished,
+		RescanStarted:                asset.rescanStarted,
+		RescanProgress:               asset.rescanProgress,
+		RescanFinished:               asset.rescanFinished,
+	}
+}
+
+// SetElectrumServerConfig is not supported, DCR wallets sync through SPV or a
+// trusted dcrd node. See SetDcrdRPCConfig.
+func (asset *Asset) SetElectrumServerConfig(_ *sharedW.ElectrumServerConfig) error {
+	return utils.ErrDCRMethodNotImplemented("SetElectrumServerConfig")
+}
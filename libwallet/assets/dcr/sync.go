@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"net"
 	"sort"
-	"strings"
 	"sync"
 
 	"decred.org/dcrwallet/v3/errors"
@@ -203,28 +202,69 @@ func (asset *Asset) SpvSync() error {
 		return errors.New(utils.ErrSyncAlreadyInProgress)
 	}
 
-	addr := &net.TCPAddr{IP: net.ParseIP("::1"), Port: 0}
-	addrManager := addrmgr.New(asset.DataDir(), net.LookupIP) // TODO: be mindful of tor
-	lp := p2p.NewLocalPeer(asset.chainParams, addr, addrManager)
+	// Respect the sync schedule and the daily data limit.
+	if err := asset.SyncAllowed(); err != nil {
+		return err
+	}
+
+	// Ensure frozen outputs are not spent by the mixer or the ticket buyer
+	// once the wallet is synced.
+	asset.lockFrozenUTXOs()
+
+	if cfg := asset.DcrdRPCConfig(); cfg != nil {
+		return asset.rpcSync(cfg)
+	}
 
-	var validPeerAddresses []string
+	validPeerAddresses := asset.PersistentPeers(asset.chainParams.DefaultPort)
 	peerAddresses := asset.ReadStringConfigValueForKey(sharedW.SpvPersistentPeerAddressesConfigKey, "")
-	if peerAddresses != "" {
-		addresses := strings.Split(peerAddresses, ";")
-		for _, address := range addresses {
-			peerAddress, err := utils.NormalizeAddress(address, asset.chainParams.DefaultPort)
-			if err != nil {
-				log.Errorf("SPV peer address(%s) is invalid: %v", peerAddress, err)
-			} else {
-				validPeerAddresses = append(validPeerAddresses, peerAddress)
-			}
+	if peerAddresses != "" && len(validPeerAddresses) == 0 {
+		return errors.New(utils.ErrInvalidPeers)
+	}
+
+	// The wallets share the known peers through the chain backend's address
+	// manager.
+	var addrManager *addrmgr.AddrManager
+	if asset.chainBackend != nil {
+		var err error
+		if addrManager, err = asset.chainBackend.acquire(asset.ID); err != nil {
+			return err
 		}
+	} else {
+		addrManager = addrmgr.New(asset.DataDir(), net.LookupIP) // TODO: be mindful of tor
+	}
+
+	addr := &net.TCPAddr{IP: net.ParseIP("::1"), Port: 0}
+	lp := p2p.NewLocalPeer(asset.chainParams, addr, addrManager)
+	// The limiter enforces the sync limits and meters the data usage. The
+	// banned peers are rejected before they are dialed.
+	limiter := asset.NewNetLimiter()
+	lp.SetDialFunc(func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if asset.IsPeerBanned(addr) {
+			return nil, errBannedPeer
+		}
+		return limiter.DialContext(ctx, network, addr)
+	})
+
+	syncer := spv.NewSyncer(asset.Internal().DCR, lp)
+	syncer.SetNotifications(asset.spvSyncNotificationCallbacks())
+	if len(validPeerAddresses) > 0 {
+		syncer.SetPersistentPeers(validPeerAddresses)
+	}
 
-		if len(validPeerAddresses) == 0 {
-			return errors.New(utils.ErrInvalidPeers)
+	run := func(ctx context.Context) error {
+		if asset.chainBackend != nil {
+			// Let the other wallets keep using the address manager.
+			defer asset.chainBackend.Release(asset.ID)
 		}
+		return syncer.Run(ctx)
 	}
+	return asset.runSyncer(run, syncer)
+}
 
+// runSyncer runs the network backend sync in a goroutine and notifies the
+// sync progress listeners when it ends. spvSyncer is nil if the wallet is not
+// synced through SPV.
+func (asset *Asset) runSyncer(run func(ctx context.Context) error, spvSyncer *spv.Syncer) error {
 	// init activeSyncData to be used to hold data used
 	// to calculate sync estimates only during sync
 	asset.initActiveSyncData()
@@ -232,12 +272,6 @@ func (asset *Asset) SpvSync() error {
 	asset.waitingForHeaders = true
 	asset.syncing = true
 
-	syncer := spv.NewSyncer(asset.Internal().DCR, lp)
-	syncer.SetNotifications(asset.spvSyncNotificationCallbacks())
-	if len(validPeerAddresses) > 0 {
-		syncer.SetPersistentPeers(validPeerAddresses)
-	}
-
 	ctx, cancel := asset.ShutdownContextWithCancel()
 
 	asset.syncData.mu.Lock()
@@ -245,7 +279,7 @@ func (asset *Asset) SpvSync() error {
 	asset.syncData.syncing = true
 	asset.syncData.cancelSync = cancel
 	asset.syncData.syncCanceled = make(chan struct{})
-	asset.syncData.syncer = syncer
+	asset.syncData.syncer = spvSyncer
 	asset.syncData.mu.Unlock()
 
 	for _, listener := range asset.syncProgressListeners() {
@@ -256,11 +290,11 @@ func (asset *Asset) SpvSync() error {
 	// expires or is canceled or some other error occurs such as
 	// losing connection to all persistent peers.
 	go func() {
-		syncError := syncer.Run(ctx)
+		syncError := run(ctx)
 		// sync has ended or errored
 		if syncError != nil {
 			if syncError == context.DeadlineExceeded {
-				asset.notifySyncError(errors.Errorf("SPV synchronization deadline exceeded: %v", syncError))
+				asset.notifySyncError(errors.Errorf("synchronization deadline exceeded: %v", syncError))
 			} else if syncError == context.Canceled {
 				asset.notifySyncCanceled()
 			} else {
@@ -383,6 +417,10 @@ func (asset *Asset) PeerInfoRaw() ([]sharedW.PeerInfo, error) {
 	}
 
 	syncer := asset.syncData.syncer
+	if syncer == nil {
+		// The trusted dcrd node is the only peer in RPC mode.
+		return []sharedW.PeerInfo{}, nil
+	}
 
 	infos := make([]sharedW.PeerInfo, 0, len(syncer.GetRemotePeers()))
 	for _, rp := range syncer.GetRemotePeers() {
@@ -460,6 +498,9 @@ func (asset *Asset) GetBestBlockTimeStamp() int64 {
 	return info.Timestamp
 }
 
+// DiscoverUsage discovers the addresses and accounts used by the wallet, then
+// rescans the blocks for their transactions. Both begin at the birthday
+// provided on restore if any, at the genesis block otherwise.
 func (asset *Asset) DiscoverUsage(gapLimit uint32) error {
 	if !asset.WalletOpened() {
 		return utils.ErrDCRNotInitialized
@@ -480,19 +521,26 @@ func (asset *Asset) DiscoverUsage(gapLimit uint32) error {
 		return errors.New(utils.ErrNotSynced)
 	}
 
-	// rescan from genesis block. Todo: Allow users to supply rescanpoint.
-	startBlock := asset.Internal().DCR.ChainParams().GenesisHash
-
 	go func() {
+		var discovered bool
+		ctx, cancel := asset.ShutdownContextWithCancel()
+		startBlock, startHeight := asset.discoveryStartBlock(ctx)
+
 		defer func() {
 			asset.syncData.mu.Lock()
 			asset.syncData.syncing = false
 			asset.syncData.cancelSync = nil
 			asset.syncData.mu.Unlock()
 			asset.discoverAddressesFinished()
-		}()
 
-		ctx, cancel := asset.ShutdownContextWithCancel()
+			// The transactions of the newly discovered addresses are only
+			// found by a rescan.
+			if discovered {
+				if err := asset.RescanBlocksFromHeight(startHeight); err != nil {
+					log.Errorf("Failed to rescan after the usage discovery: %v", err)
+				}
+			}
+		}()
 
 		asset.syncData.mu.Lock()
 		asset.syncData.syncing = true
@@ -504,7 +552,9 @@ func (asset *Asset) DiscoverUsage(gapLimit uint32) error {
 		err := asset.Internal().DCR.DiscoverActiveAddresses(ctx, netBackend, &startBlock, !asset.Internal().DCR.Locked(), gapLimit)
 		if err != nil {
 			log.Error(err)
+			return
 		}
+		discovered = true
 	}()
 
 	return nil
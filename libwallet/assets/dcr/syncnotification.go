@@ -630,6 +630,10 @@ func (asset *Asset) syncedWallet(synced bool) {
 					syncProgressListener.OnSyncCanceled(false)
 				}
 			}
+
+			if synced {
+				asset.resumeInterruptedRescan()
+			}
 		}()
 	}
 
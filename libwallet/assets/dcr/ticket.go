@@ -218,6 +218,7 @@ func (asset *Asset) VSPTicketInfo(hash string) (*VSPTicketInfo, error) {
 
 	ticketInfo.FeeTxHash = vspTicketStatus.FeeTxHash
 	ticketInfo.ConfirmedByVSP = vspTicketStatus.TicketConfirmed
+	ticketInfo.VoteChoices = vspTicketStatus.VoteChoices
 
 	return ticketInfo, nil
 }
@@ -543,6 +544,17 @@ func (asset *Asset) ClearTicketBuyerConfig(_ int) error {
 	return nil
 }
 
+// SetAutoVoteEnabled enables or disables auto-casting votes on proposals
+// with this wallet's tickets according to the saved politeia auto-vote policy.
+func (asset *Asset) SetAutoVoteEnabled(enabled bool) {
+	asset.SetBoolConfigValueForKey(sharedW.AutoVoteConfigKey, enabled)
+}
+
+// IsAutoVoteEnabled returns true if auto-voting is enabled for the wallet.
+func (asset *Asset) IsAutoVoteEnabled() bool {
+	return asset.ReadBoolConfigValueForKey(sharedW.AutoVoteConfigKey, false)
+}
+
 // NextTicketPriceRemaining returns the remaning time in seconds of a ticket for the next block,
 // if secs equal 0 is imminent
 func (asset *Asset) NextTicketPriceRemaining() (secs int64, err error) {
@@ -1,15 +1,20 @@
 package dcr
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
+	"sort"
 
 	"decred.org/dcrwallet/v3/errors"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 
 	"github.com/decred/dcrd/blockchain/stake/v5"
 	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/txscript/v4/stdscript"
+	"github.com/decred/dcrd/wire"
 )
 
 // SetTreasuryPolicy saves the voting policy for treasury spends by a particular
@@ -40,16 +45,9 @@ func (asset *Asset) SetTreasuryPolicy(PiKey, newVotingPolicy, tixHash string, pa
 		return fmt.Errorf("treasury pikey must be %d bytes", secp256k1.PubKeyBytesLenCompressed)
 	}
 
-	var policy stake.TreasuryVoteT
-	switch newVotingPolicy {
-	case "abstain", "invalid", "":
-		policy = stake.TreasuryVoteInvalid
-	case "yes":
-		policy = stake.TreasuryVoteYes
-	case "no":
-		policy = stake.TreasuryVoteNo
-	default:
-		return fmt.Errorf("invalid policy: unknown policy %q", newVotingPolicy)
+	policy, err := parseTreasuryVote(newVotingPolicy)
+	if err != nil {
+		return err
 	}
 
 	// The wallet will need to be unlocked to sign the API
@@ -80,55 +78,12 @@ func (asset *Asset) SetTreasuryPolicy(PiKey, newVotingPolicy, tixHash string, pa
 		}
 	}()
 
-	// If a ticket hash is provided, set the specified vote policy with
-	// the VSP associated with the provided ticket. Otherwise, set the
-	// vote policy with the VSPs associated with all "votable" tickets.
-	ticketHashes := make([]*chainhash.Hash, 0)
-	if ticketHash != nil {
-		ticketHashes = append(ticketHashes, ticketHash)
-	} else {
-		err = asset.Internal().DCR.ForUnspentUnexpiredTickets(ctx, func(hash *chainhash.Hash) error {
-			ticketHashes = append(ticketHashes, hash)
-			return nil
-		})
-		if err != nil {
-			return fmt.Errorf("unable to fetch hashes for all unspent, unexpired tickets: %v", err)
-		}
-	}
-
-	// Never return errors from this for loop, so all tickets are tried.
-	// The first error will be returned to the caller.
-	var firstErr error
-	// Update voting preferences on VSPs if required.
 	policyMap := map[string]string{
 		PiKey: newVotingPolicy,
 	}
-	for _, tHash := range ticketHashes {
-		vspTicketInfo, err := asset.Internal().DCR.VSPTicketInfo(ctx, tHash)
-		if err != nil {
-			// Ignore NotExist error, just means the ticket is not
-			// registered with a VSP, nothing more to do here.
-			if firstErr == nil && !errors.Is(err, errors.NotExist) {
-				firstErr = err
-			}
-			continue // try next tHash
-		}
-
-		// Update the vote policy for the ticket with the associated VSP.
-		vspClient, err := asset.VSPClient(vspTicketInfo.Host, vspTicketInfo.PubKey)
-		if err != nil && firstErr == nil {
-			firstErr = err
-			continue // try next tHash
-		}
-		err = vspClient.SetVoteChoice(ctx, tHash, nil, nil, policyMap)
-		if err != nil && firstErr == nil {
-			firstErr = err
-			continue // try next tHash
-		}
-	}
-
-	vspPreferenceUpdateSuccess = firstErr == nil
-	return firstErr
+	err = asset.updateVSPTreasuryPolicies(ctx, ticketHash, nil, policyMap)
+	vspPreferenceUpdateSuccess = err == nil
+	return err
 }
 
 // TreasuryPolicies returns saved voting policies for treasury spends
@@ -195,3 +150,303 @@ func (asset *Asset) TreasuryPolicies(PiKey, tixHash string) ([]*TreasuryKeyPolic
 	}
 	return res, nil
 }
+
+// updateVSPTreasuryPolicies sets the tspend and treasury key policies with the
+// VSP associated with the ticket, or with the VSPs associated with all the
+// unspent, unexpired tickets if no ticket hash is provided.
+func (asset *Asset) updateVSPTreasuryPolicies(ctx context.Context, ticketHash *chainhash.Hash,
+	tspendPolicy, treasuryPolicy map[string]string,
+) error {
+	// If a ticket hash is provided, set the specified vote policy with
+	// the VSP associated with the provided ticket. Otherwise, set the
+	// vote policy with the VSPs associated with all "votable" tickets.
+	ticketHashes := make([]*chainhash.Hash, 0)
+	if ticketHash != nil {
+		ticketHashes = append(ticketHashes, ticketHash)
+	} else {
+		err := asset.Internal().DCR.ForUnspentUnexpiredTickets(ctx, func(hash *chainhash.Hash) error {
+			ticketHashes = append(ticketHashes, hash)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("unable to fetch hashes for all unspent, unexpired tickets: %v", err)
+		}
+	}
+
+	// Never return errors from this for loop, so all tickets are tried.
+	// The first error will be returned to the caller.
+	var firstErr error
+	// Update voting preferences on VSPs if required.
+	for _, tHash := range ticketHashes {
+		err := asset.updateVSPTicketVoteChoices(ctx, tHash, nil, tspendPolicy, treasuryPolicy)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// parseTreasuryVote returns the treasury vote of the policy.
+func parseTreasuryVote(policy string) (stake.TreasuryVoteT, error) {
+	switch policy {
+	case "abstain", "invalid", "":
+		return stake.TreasuryVoteInvalid, nil
+	case "yes":
+		return stake.TreasuryVoteYes, nil
+	case "no":
+		return stake.TreasuryVoteNo, nil
+	default:
+		return 0, fmt.Errorf("invalid policy: unknown policy %q", policy)
+	}
+}
+
+// treasuryVoteString returns the policy of the treasury vote.
+func treasuryVoteString(vote stake.TreasuryVoteT) string {
+	switch vote {
+	case stake.TreasuryVoteYes:
+		return "yes"
+	case stake.TreasuryVoteNo:
+		return "no"
+	default:
+		return "abstain"
+	}
+}
+
+// TSpends returns the unexpired treasury spends known to the wallet, sorted by
+// expiry. The synced wallet receives the tspends from the network.
+func (asset *Asset) TSpends() ([]*TSpend, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	txs := asset.Internal().DCR.GetAllTSpends(ctx)
+	tspends := make([]*TSpend, 0, len(txs))
+	for _, tx := range txs {
+		tspend, err := asset.decodeTSpend(tx)
+		if err != nil {
+			log.Errorf("invalid tspend %s: %v", tx.TxHash(), err)
+			continue
+		}
+		tspends = append(tspends, tspend)
+	}
+
+	sortTSpends(tspends)
+	return tspends, nil
+}
+
+// sortTSpends sorts the tspends by expiry, the tspends whose voting ends
+// first are listed first.
+func sortTSpends(tspends []*TSpend) {
+	sort.Slice(tspends, func(i, j int) bool {
+		return tspends[i].Expiry < tspends[j].Expiry
+	})
+}
+
+// AddTSpend adds the hex encoded treasury spend transaction to the tspends
+// known to the wallet, so that the tspend can be voted on before the wallet
+// receives it from the network.
+func (asset *Asset) AddTSpend(txHex string) (*TSpend, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+
+	serializedTx, err := hex.DecodeString(txHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction: %w", err)
+	}
+	tx := new(wire.MsgTx)
+	if err := tx.FromBytes(serializedTx); err != nil {
+		return nil, fmt.Errorf("invalid transaction: %w", err)
+	}
+
+	tspend, err := asset.decodeTSpend(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateTSpend(tspend, uint32(asset.GetBestBlockHeight()), asset.chainParams.PiKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	txHash := tx.TxHash()
+	if !asset.Internal().DCR.IsTSpendCached(&txHash) {
+		if err := asset.Internal().DCR.AddTSpend(*tx); err != nil {
+			return nil, err
+		}
+	}
+	return tspend, nil
+}
+
+// validateTSpend returns an error if the tspend expired at the best block
+// height or is not signed by one of the sanctioned pi keys.
+func validateTSpend(tspend *TSpend, bestBlockHeight uint32, piKeys [][]byte) error {
+	if bestBlockHeight > tspend.Expiry {
+		return errors.E(errors.Invalid, "treasury spend has expired")
+	}
+
+	for _, pikey := range piKeys {
+		if hex.EncodeToString(pikey) == tspend.PiKey {
+			return nil
+		}
+	}
+	return errors.E(errors.Invalid, "treasury spend is not signed by a sanctioned pi key")
+}
+
+// decodeTSpend returns the details of the treasury spend transaction and the
+// wallet vote policy for it.
+func (asset *Asset) decodeTSpend(tx *wire.MsgTx) (*TSpend, error) {
+	tspend, err := parseTSpend(tx, asset.chainParams)
+	if err != nil {
+		return nil, err
+	}
+
+	txHash := tx.TxHash()
+	tspend.Policy = treasuryVoteString(asset.Internal().DCR.TSpendPolicy(&txHash, nil))
+	return tspend, nil
+}
+
+// parseTSpend returns the details of the treasury spend transaction.
+func parseTSpend(tx *wire.MsgTx, params *chaincfg.Params) (*TSpend, error) {
+	if !stake.IsTSpend(tx) {
+		return nil, errors.E(errors.Invalid, "not a treasury spend transaction")
+	}
+
+	// The signature script of a tspend is a signature followed by the pi key
+	// that signed it.
+	sigScript := tx.TxIn[0].SignatureScript
+	pikey := sigScript[len(sigScript)-secp256k1.PubKeyBytesLenCompressed-1 : len(sigScript)-1]
+
+	txHash := tx.TxHash()
+	tspend := &TSpend{
+		Hash:   txHash.String(),
+		PiKey:  hex.EncodeToString(pikey),
+		Expiry: tx.Expiry,
+	}
+
+	// The first output commits to the amount of the tspend, the payees are
+	// paid by the other outputs.
+	for _, txOut := range tx.TxOut[1:] {
+		var address string
+		_, addrs := stdscript.ExtractAddrs(txOut.Version, txOut.PkScript, params)
+		if len(addrs) > 0 {
+			address = addrs[0].String()
+		}
+		tspend.Payees = append(tspend.Payees, &TSpendPayee{
+			Address: address,
+			Amount:  txOut.Value,
+		})
+		tspend.Amount += txOut.Value
+	}
+	return tspend, nil
+}
+
+// SetTSpendPolicy saves the voting policy for a particular treasury spend
+// transaction.
+// If a ticket hash is provided, the voting policy is also updated with the VSP
+// controlling the ticket. If a ticket hash isn't provided, the vote choice is
+// saved to the local wallet database and the VSPs controlling all unspent,
+// unexpired tickets are updated to use the specified vote policy.
+func (asset *Asset) SetTSpendPolicy(tspendHash, newVotingPolicy, tixHash, passphrase string) error {
+	if !asset.WalletOpened() {
+		return utils.ErrDCRNotInitialized
+	}
+
+	hash, err := chainhash.NewHashFromStr(tspendHash)
+	if err != nil {
+		return fmt.Errorf("invalid tspend hash: %w", err)
+	}
+
+	var ticketHash *chainhash.Hash
+	if tixHash != "" {
+		ticketHash, err = chainhash.NewHashFromStr(tixHash)
+		if err != nil {
+			return fmt.Errorf("invalid ticket hash: %w", err)
+		}
+	}
+
+	policy, err := parseTreasuryVote(newVotingPolicy)
+	if err != nil {
+		return err
+	}
+
+	// The wallet will need to be unlocked to sign the API
+	// request(s) for setting this voting policy with the VSP.
+	err = asset.UnlockWallet(passphrase)
+	if err != nil {
+		return utils.TranslateError(err)
+	}
+	defer asset.LockWallet()
+
+	currentVotingPolicy := asset.Internal().DCR.TSpendPolicy(hash, ticketHash)
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	err = asset.Internal().DCR.SetTSpendPolicy(ctx, hash, policy, ticketHash)
+	if err != nil {
+		return err
+	}
+
+	var vspPreferenceUpdateSuccess bool
+	defer func() {
+		if !vspPreferenceUpdateSuccess {
+			// Updating the tspend voting preference with the vsp failed,
+			// revert the locally saved voting preference for the tspend.
+			revertError := asset.Internal().DCR.SetTSpendPolicy(ctx, hash, currentVotingPolicy, ticketHash)
+			if revertError != nil {
+				log.Errorf("unable to revert locally saved voting preference: %v", revertError)
+			}
+		}
+	}()
+
+	policyMap := map[string]string{
+		tspendHash: newVotingPolicy,
+	}
+	err = asset.updateVSPTreasuryPolicies(ctx, ticketHash, policyMap, nil)
+	vspPreferenceUpdateSuccess = err == nil
+	return err
+}
+
+// TSpendPolicies returns the voting policies set for the treasury spends. If
+// a ticket hash is provided, the policies set for that ticket are returned;
+// otherwise the wallet policies for the known tspends are returned.
+func (asset *Asset) TSpendPolicies(tixHash string) ([]*TSpendPolicy, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+
+	if tixHash != "" {
+		ticketHash, err := chainhash.NewHashFromStr(tixHash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ticket hash: %w", err)
+		}
+
+		policies := asset.Internal().DCR.TSpendPolicyForTicket(ticketHash)
+		res := make([]*TSpendPolicy, 0, len(policies))
+		for tspendHash, policy := range policies {
+			res = append(res, &TSpendPolicy{
+				TSpendHash: tspendHash,
+				TicketHash: tixHash,
+				Policy:     policy,
+			})
+		}
+		sort.Slice(res, func(i, j int) bool {
+			return res[i].TSpendHash < res[j].TSpendHash
+		})
+		return res, nil
+	}
+
+	tspends, err := asset.TSpends()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*TSpendPolicy, len(tspends))
+	for i, tspend := range tspends {
+		res[i] = &TSpendPolicy{
+			TSpendHash: tspend.Hash,
+			Policy:     tspend.Policy,
+		}
+	}
+	return res, nil
+}
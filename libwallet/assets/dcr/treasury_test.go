@@ -0,0 +1,146 @@
+package dcr
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"decred.org/dcrwallet/v3/errors"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/wire"
+)
+
+// testTSpend returns a treasury spend signed with the pi key that pays the
+// amount to the payee address.
+func testTSpend(t *testing.T, pikey []byte, payee stdaddr.StakeAddress, amount int64, expiry uint32) *wire.MsgTx {
+	t.Helper()
+
+	// OP_DATA_64 <signature> OP_DATA_33 <pi key> OP_TSPEND
+	sigScript := make([]byte, 0, 100)
+	sigScript = append(sigScript, txscript.OP_DATA_64)
+	sigScript = append(sigScript, bytes.Repeat([]byte{0x01}, 64)...)
+	sigScript = append(sigScript, txscript.OP_DATA_33)
+	sigScript = append(sigScript, pikey...)
+	sigScript = append(sigScript, txscript.OP_TSPEND)
+
+	// OP_RETURN OP_DATA_32 <amount and random bytes>
+	nullData := append([]byte{txscript.OP_RETURN, txscript.OP_DATA_32}, bytes.Repeat([]byte{0x02}, 32)...)
+	_, payeeScript := payee.PayFromTreasuryScript()
+
+	tx := wire.NewMsgTx()
+	tx.Version = wire.TxVersionTreasury
+	tx.Expiry = expiry
+	tx.AddTxIn(&wire.TxIn{SignatureScript: sigScript})
+	tx.AddTxOut(&wire.TxOut{PkScript: nullData})
+	tx.AddTxOut(&wire.TxOut{Value: amount, PkScript: payeeScript})
+	return tx
+}
+
+func testStakeAddress(t *testing.T, params *chaincfg.Params) stdaddr.StakeAddress {
+	t.Helper()
+	addr, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(bytes.Repeat([]byte{0x03}, 20), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return addr
+}
+
+func TestParseTSpend(t *testing.T) {
+	params := chaincfg.MainNetParams()
+	pikey := params.PiKeys[0]
+	payee := testStakeAddress(t, params)
+
+	tx := testTSpend(t, pikey, payee, 1000, 500)
+	tspend, err := parseTSpend(tx, params)
+	if err != nil {
+		t.Fatalf("unexpected error parsing tspend: %v", err)
+	}
+
+	if tspend.PiKey != hex.EncodeToString(pikey) {
+		t.Errorf("expected pi key %x, got %s", pikey, tspend.PiKey)
+	}
+	if tspend.Hash != tx.TxHash().String() {
+		t.Errorf("expected hash %s, got %s", tx.TxHash(), tspend.Hash)
+	}
+	if tspend.Expiry != 500 {
+		t.Errorf("expected expiry 500, got %d", tspend.Expiry)
+	}
+	if tspend.Amount != 1000 || len(tspend.Payees) != 1 {
+		t.Fatalf("expected a single payee of 1000 atoms, got %d atoms paid to %d payees", tspend.Amount, len(tspend.Payees))
+	}
+	if tspend.Payees[0].Address != payee.String() || tspend.Payees[0].Amount != 1000 {
+		t.Errorf("expected %s to be paid 1000 atoms, got %+v", payee, tspend.Payees[0])
+	}
+}
+
+func TestParseTSpendInvalid(t *testing.T) {
+	params := chaincfg.MainNetParams()
+	payee := testStakeAddress(t, params)
+
+	tests := []struct {
+		name   string
+		modify func(tx *wire.MsgTx)
+	}{
+		{"regular transaction", func(tx *wire.MsgTx) { tx.Version = wire.TxVersion }},
+		{"missing tspend opcode", func(tx *wire.MsgTx) {
+			sigScript := tx.TxIn[0].SignatureScript
+			sigScript[len(sigScript)-1] = txscript.OP_CHECKSIG
+		}},
+		{"untagged payee", func(tx *wire.MsgTx) { tx.TxOut[1].PkScript = tx.TxOut[1].PkScript[1:] }},
+		{"single output", func(tx *wire.MsgTx) { tx.TxOut = tx.TxOut[:1] }},
+	}
+
+	for _, test := range tests {
+		tx := testTSpend(t, params.PiKeys[0], payee, 1000, 500)
+		test.modify(tx)
+		if _, err := parseTSpend(tx, params); !errors.Is(err, errors.Invalid) {
+			t.Errorf("%s: expected an invalid tspend error, got %v", test.name, err)
+		}
+	}
+}
+
+func TestValidateTSpend(t *testing.T) {
+	params := chaincfg.MainNetParams()
+	sanctioned := hex.EncodeToString(params.PiKeys[0])
+	unsanctioned := hex.EncodeToString(chaincfg.TestNet3Params().PiKeys[0])
+
+	tests := []struct {
+		name       string
+		tspend     *TSpend
+		bestHeight uint32
+		wantErr    bool
+	}{
+		{"unexpired", &TSpend{PiKey: sanctioned, Expiry: 500}, 499, false},
+		{"expires at the best block", &TSpend{PiKey: sanctioned, Expiry: 500}, 500, false},
+		{"expired", &TSpend{PiKey: sanctioned, Expiry: 500}, 501, true},
+		{"unsanctioned pi key", &TSpend{PiKey: unsanctioned, Expiry: 500}, 499, true},
+	}
+
+	for _, test := range tests {
+		err := validateTSpend(test.tspend, test.bestHeight, params.PiKeys)
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.wantErr, err)
+		}
+		if err != nil && !errors.Is(err, errors.Invalid) {
+			t.Errorf("%s: expected an invalid tspend error, got %v", test.name, err)
+		}
+	}
+}
+
+func TestSortTSpends(t *testing.T) {
+	tspends := []*TSpend{
+		{Hash: "c", Expiry: 300},
+		{Hash: "a", Expiry: 100},
+		{Hash: "b", Expiry: 200},
+	}
+
+	sortTSpends(tspends)
+
+	for i, hash := range []string{"a", "b", "c"} {
+		if tspends[i].Hash != hash {
+			t.Errorf("expected tspend %s at position %d, got %s", hash, i, tspends[i].Hash)
+		}
+	}
+}
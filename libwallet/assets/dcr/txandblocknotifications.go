@@ -33,6 +33,7 @@ func (asset *Asset) listenForTransactions() {
 
 					if !overwritten {
 						log.Infof("[%d] New Transaction %s", asset.ID, tempTransaction.Hash)
+						asset.recordMixTransaction(tempTransaction)
 
 						result, err := json.Marshal(tempTransaction)
 						if err != nil {
@@ -52,11 +53,15 @@ func (asset *Asset) listenForTransactions() {
 							return
 						}
 
-						_, err = asset.GetWalletDataDb().SaveOrUpdate(&sharedW.Transaction{}, tempTransaction)
+						overwritten, err := asset.GetWalletDataDb().SaveOrUpdate(&sharedW.Transaction{}, tempTransaction)
 						if err != nil {
 							log.Errorf("[%d] Incoming block replace tx error :%v", asset.ID, err)
 							return
 						}
+
+						if !overwritten {
+							asset.recordMixTransaction(tempTransaction)
+						}
 						asset.publishTransactionConfirmed(transaction.Hash.String(), int32(block.Header.Height))
 					}
 
@@ -121,6 +126,15 @@ func (asset *Asset) checkWalletMixers() {
 			if err != nil {
 				log.Errorf("Error stopping account mixer: %v", err)
 			}
+			return
+		}
+
+		if asset.mixerLimitReached() {
+			log.Infof("[%d] account mixer schedule limit reached, stopping account mixer", asset.ID)
+			err := asset.StopAccountMixer()
+			if err != nil {
+				log.Errorf("Error stopping account mixer: %v", err)
+			}
 		}
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"sync"
 	"time"
 
 	"decred.org/dcrwallet/v3/errors"
@@ -31,6 +32,8 @@ type TxAuthor struct {
 	utxos          []*sharedW.UnspentOutput
 	unsignedTx     *txauthor.AuthoredTx
 	needsConstruct bool
+
+	mu sync.RWMutex
 }
 
 func (asset *Asset) NewUnsignedTx(sourceAccountNumber int32, utxos []*sharedW.UnspentOutput) error {
@@ -88,6 +91,9 @@ func (asset *Asset) IsUnsignedTxExist() bool {
 }
 
 func (asset *Asset) AddSendDestination(address string, atomAmount int64, sendMax bool) error {
+	asset.TxAuthoredInfo.mu.Lock()
+	defer asset.TxAuthoredInfo.mu.Unlock()
+
 	_, err := stdaddr.DecodeAddress(address, asset.chainParams)
 	if err != nil {
 		return utils.TranslateError(err)
@@ -108,6 +114,9 @@ func (asset *Asset) AddSendDestination(address string, atomAmount int64, sendMax
 }
 
 func (asset *Asset) UpdateSendDestination(index int, address string, atomAmount int64, sendMax bool) error {
+	asset.TxAuthoredInfo.mu.Lock()
+	defer asset.TxAuthoredInfo.mu.Unlock()
+
 	if err := asset.validateSendAmount(sendMax, atomAmount); err != nil {
 		return err
 	}
@@ -126,6 +135,9 @@ func (asset *Asset) UpdateSendDestination(index int, address string, atomAmount
 }
 
 func (asset *Asset) RemoveSendDestination(index int) {
+	asset.TxAuthoredInfo.mu.Lock()
+	defer asset.TxAuthoredInfo.mu.Unlock()
+
 	if len(asset.TxAuthoredInfo.destinations) > index {
 		asset.TxAuthoredInfo.destinations = append(asset.TxAuthoredInfo.destinations[:index], asset.TxAuthoredInfo.destinations[index+1:]...)
 		asset.TxAuthoredInfo.needsConstruct = true
@@ -133,10 +145,16 @@ func (asset *Asset) RemoveSendDestination(index int) {
 }
 
 func (asset *Asset) SendDestination(atIndex int) *sharedW.TransactionDestination {
+	asset.TxAuthoredInfo.mu.RLock()
+	defer asset.TxAuthoredInfo.mu.RUnlock()
+
 	return &asset.TxAuthoredInfo.destinations[atIndex]
 }
 
 func (asset *Asset) SetChangeDestination(address string) {
+	asset.TxAuthoredInfo.mu.Lock()
+	defer asset.TxAuthoredInfo.mu.Unlock()
+
 	asset.TxAuthoredInfo.changeDestination = &sharedW.TransactionDestination{
 		Address: address,
 	}
@@ -144,11 +162,17 @@ func (asset *Asset) SetChangeDestination(address string) {
 }
 
 func (asset *Asset) RemoveChangeDestination() {
+	asset.TxAuthoredInfo.mu.Lock()
+	defer asset.TxAuthoredInfo.mu.Unlock()
+
 	asset.TxAuthoredInfo.changeDestination = nil
 	asset.TxAuthoredInfo.needsConstruct = true
 }
 
 func (asset *Asset) TotalSendAmount() *sharedW.Amount {
+	asset.TxAuthoredInfo.mu.RLock()
+	defer asset.TxAuthoredInfo.mu.RUnlock()
+
 	var totalSendAmountAtom int64
 	for _, destination := range asset.TxAuthoredInfo.destinations {
 		totalSendAmountAtom += destination.UnitAmount
@@ -161,12 +185,16 @@ func (asset *Asset) TotalSendAmount() *sharedW.Amount {
 }
 
 func (asset *Asset) EstimateFeeAndSize() (*sharedW.TxFeeAndSize, error) {
+	asset.TxAuthoredInfo.mu.Lock()
+	defer asset.TxAuthoredInfo.mu.Unlock()
+
 	unsignedTx, err := asset.unsignedTransaction()
 	if err != nil {
 		return nil, utils.TranslateError(err)
 	}
 
-	feeToSendTx := txrules.FeeForSerializeSize(txrules.DefaultRelayFeePerKb, unsignedTx.EstimatedSignedSerializeSize)
+	feeRate := asset.GetUserFeeRate().ToInt()
+	feeToSendTx := txrules.FeeForSerializeSize(dcrutil.Amount(feeRate), unsignedTx.EstimatedSignedSerializeSize)
 	feeAmount := &sharedW.Amount{
 		UnitValue: int64(feeToSendTx),
 		CoinValue: feeToSendTx.ToCoin(),
@@ -185,6 +213,7 @@ func (asset *Asset) EstimateFeeAndSize() (*sharedW.TxFeeAndSize, error) {
 		EstimatedSignedSize: unsignedTx.EstimatedSignedSerializeSize,
 		Fee:                 feeAmount,
 		Change:              change,
+		FeeRate:             feeRate,
 	}, nil
 }
 
@@ -218,6 +247,9 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) ([]byt
 		return nil, err
 	}
 
+	asset.TxAuthoredInfo.mu.Lock()
+	defer asset.TxAuthoredInfo.mu.Unlock()
+
 	unsignedTx, err := asset.unsignedTransaction()
 	if err != nil {
 		return nil, utils.TranslateError(err)
@@ -376,6 +408,8 @@ func (asset *Asset) constructTransaction() (*txauthor.AuthoredTx, error) {
 		if err != nil {
 			return nil, err
 		}
+		// Frozen outputs can only be spent by selecting them manually.
+		unspents = sharedW.FilterFrozenUTXOs(unspents)
 	}
 
 	// Use the custom input source function instead of querying the same data from the
@@ -383,7 +417,7 @@ func (asset *Asset) constructTransaction() (*txauthor.AuthoredTx, error) {
 	inputsSourceFunc := asset.makeInputSource(sendMax, unspents)
 
 	requiredConfirmations := asset.RequiredConfirmations()
-	return asset.Internal().DCR.NewUnsignedTransaction(ctx, outputs, txrules.DefaultRelayFeePerKb, asset.TxAuthoredInfo.sourceAccountNumber,
+	return asset.Internal().DCR.NewUnsignedTransaction(ctx, outputs, dcrutil.Amount(asset.GetUserFeeRate().ToInt()), asset.TxAuthoredInfo.sourceAccountNumber,
 		requiredConfirmations, outputSelectionAlgorithm, changeSource, inputsSourceFunc)
 }
 
@@ -1,8 +1,13 @@
 package dcr
 
 import (
+	"math"
+	"sort"
+
+	"decred.org/dcrwallet/v3/errors"
 	w "decred.org/dcrwallet/v3/wallet"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/assets/wallet/walletdata"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/decred/dcrd/chaincfg/chainhash"
 )
@@ -97,3 +102,192 @@ func (asset *Asset) reindexTransactions() error {
 
 	return asset.IndexTransactions()
 }
+
+// walletTx is a transaction of the wallet transaction store.
+type walletTx struct {
+	summary   *w.TransactionSummary
+	blockHash *chainhash.Hash
+	tx        *sharedW.Transaction
+}
+
+// VerifyTxIndex compares the transaction index with the wallet transaction
+// store and reports the missing, orphaned and mismatched transactions.
+func (asset *Asset) VerifyTxIndex() (*sharedW.TxIndexReport, error) {
+	return asset.checkTxIndex(false)
+}
+
+// RepairTxIndex compares the transaction index with the wallet transaction
+// store and updates the index to match the store. The report describes the
+// index before it was repaired.
+func (asset *Asset) RepairTxIndex() (*sharedW.TxIndexReport, error) {
+	return asset.checkTxIndex(true)
+}
+
+func (asset *Asset) checkTxIndex(repair bool) (*sharedW.TxIndexReport, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+
+	// The index is updated while the wallet syncs or rescans.
+	if asset.IsSyncing() || asset.IsRescanning() {
+		return nil, errors.E(utils.ErrSyncAlreadyInProgress)
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+
+	walletTxs := make(map[string]*walletTx)
+	// spenders maps the hash of the spent tickets to the hash of the vote or
+	// revocation spending them.
+	spenders := make(map[string]string)
+	rangeFn := func(block *w.Block) (bool, error) {
+		var blockHash *chainhash.Hash
+		if block.Header != nil {
+			hash := block.Header.BlockHash()
+			blockHash = &hash
+		}
+
+		for i := range block.Transactions {
+			summary := &block.Transactions[i]
+			tx, _, err := asset.decodeTxSummary(summary, blockHash)
+			if err != nil {
+				return false, err
+			}
+			walletTxs[tx.Hash] = &walletTx{summary: summary, blockHash: blockHash, tx: tx}
+			if tx.TicketSpentHash != "" {
+				spenders[tx.TicketSpentHash] = tx.Hash
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return true, ctx.Err()
+		default:
+			return false, nil
+		}
+	}
+
+	// A nil end block includes the unmined transactions.
+	err := asset.Internal().DCR.GetTransactions(ctx, rangeFn, w.NewBlockIdentifierFromHeight(0), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report, orphaned, err := compareTxIndex(asset.GetWalletDataDb(), walletTxs, spenders)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.IsConsistent() {
+		log.Infof("[%d] Transaction index verified, %d transaction(s) indexed", asset.ID, report.IndexedTxs)
+		return report, nil
+	}
+
+	log.Warnf("[%d] Transaction index is inconsistent: %d missing, %d orphaned, %d mismatched transaction(s)",
+		asset.ID, len(report.Missing), len(report.Orphaned), len(report.Mismatched))
+	if !repair {
+		return report, nil
+	}
+
+	for _, tx := range orphaned {
+		if err := asset.GetWalletDataDb().DeleteRecord(tx); err != nil {
+			return nil, err
+		}
+	}
+
+	outdated := make([]*walletTx, 0, len(report.Missing)+len(report.Mismatched))
+	for _, hash := range report.Missing {
+		outdated = append(outdated, walletTxs[hash])
+	}
+	for _, mismatch := range report.Mismatched {
+		outdated = append(outdated, walletTxs[mismatch.Hash])
+	}
+
+	// Tickets must be indexed before the votes and revocations spending them
+	// are decoded, unmined transactions are indexed last.
+	indexOrder := func(tx *sharedW.Transaction) int32 {
+		if tx.BlockHeight == sharedW.UnminedTxHeight {
+			return math.MaxInt32
+		}
+		return tx.BlockHeight
+	}
+	sort.SliceStable(outdated, func(i, j int) bool {
+		return indexOrder(outdated[i].tx) < indexOrder(outdated[j].tx)
+	})
+
+	for _, wtx := range outdated {
+		tx, err := asset.decodeTransactionWithTxSummary(wtx.summary, wtx.blockHash)
+		if err != nil {
+			return nil, err
+		}
+		tx.TicketSpender = spenders[tx.Hash]
+
+		if _, err := asset.GetWalletDataDb().SaveOrUpdate(&sharedW.Transaction{}, tx); err != nil {
+			return nil, err
+		}
+	}
+
+	report.Repaired = true
+	log.Infof("[%d] Transaction index repaired", asset.ID)
+	return report, nil
+}
+
+// compareTxIndex compares the transactions indexed in db with the wallet
+// transactions. spenders maps the hash of the spent tickets to the hash of the
+// vote or revocation spending them. It returns the report of the comparison
+// and the orphaned transactions of the index.
+func compareTxIndex(db *walletdata.DB, walletTxs map[string]*walletTx, spenders map[string]string) (*sharedW.TxIndexReport, []*sharedW.Transaction, error) {
+	var indexedTxs []*sharedW.Transaction
+	if err := db.All(&indexedTxs); err != nil {
+		return nil, nil, err
+	}
+
+	report := &sharedW.TxIndexReport{
+		WalletTxs:  len(walletTxs),
+		IndexedTxs: len(indexedTxs),
+	}
+
+	var orphaned []*sharedW.Transaction
+	indexed := make(map[string]bool, len(indexedTxs))
+	for _, tx := range indexedTxs {
+		indexed[tx.Hash] = true
+
+		expected, ok := walletTxs[tx.Hash]
+		if !ok {
+			report.Orphaned = append(report.Orphaned, tx.Hash)
+			orphaned = append(orphaned, tx)
+			continue
+		}
+
+		expected.tx.TicketSpender = spenders[tx.Hash]
+		if fields := txIndexMismatches(tx, expected.tx); len(fields) > 0 {
+			report.Mismatched = append(report.Mismatched, sharedW.TxIndexMismatch{Hash: tx.Hash, Fields: fields})
+		}
+	}
+
+	for hash := range walletTxs {
+		if !indexed[hash] {
+			report.Missing = append(report.Missing, hash)
+		}
+	}
+	sort.Strings(report.Missing)
+	return report, orphaned, nil
+}
+
+// txIndexMismatches returns the fields of the indexed transaction that differ
+// from the wallet transaction.
+func txIndexMismatches(indexed, expected *sharedW.Transaction) []string {
+	var fields []string
+	if indexed.Amount != expected.Amount {
+		fields = append(fields, sharedW.TxIndexFieldAmount)
+	}
+	if indexed.BlockHeight != expected.BlockHeight {
+		fields = append(fields, sharedW.TxIndexFieldHeight)
+	}
+	if indexed.Type != expected.Type {
+		fields = append(fields, sharedW.TxIndexFieldType)
+	}
+	if indexed.TicketSpender != expected.TicketSpender {
+		fields = append(fields, sharedW.TxIndexFieldTicketSpender)
+	}
+	return fields
+}
@@ -0,0 +1,145 @@
+package dcr
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/assets/wallet/walletdata"
+	"github.com/crypto-power/cryptopower/libwallet/txhelper"
+)
+
+func TestTxIndexMismatches(t *testing.T) {
+	expected := &sharedW.Transaction{
+		Hash:          "a",
+		Amount:        100,
+		BlockHeight:   10,
+		Type:          txhelper.TxTypeTicketPurchase,
+		TicketSpender: "b",
+	}
+
+	tests := []struct {
+		name   string
+		modify func(tx *sharedW.Transaction)
+		fields []string
+	}{
+		{"same", func(tx *sharedW.Transaction) {}, nil},
+		{"amount", func(tx *sharedW.Transaction) { tx.Amount = 99 }, []string{sharedW.TxIndexFieldAmount}},
+		{"unmined", func(tx *sharedW.Transaction) { tx.BlockHeight = sharedW.UnminedTxHeight }, []string{sharedW.TxIndexFieldHeight}},
+		{"type", func(tx *sharedW.Transaction) { tx.Type = txhelper.TxTypeRegular }, []string{sharedW.TxIndexFieldType}},
+		{"unspent ticket", func(tx *sharedW.Transaction) { tx.TicketSpender = "" }, []string{sharedW.TxIndexFieldTicketSpender}},
+		{"all", func(tx *sharedW.Transaction) {
+			tx.Amount, tx.BlockHeight, tx.Type, tx.TicketSpender = 0, 0, "", ""
+		}, []string{
+			sharedW.TxIndexFieldAmount,
+			sharedW.TxIndexFieldHeight,
+			sharedW.TxIndexFieldType,
+			sharedW.TxIndexFieldTicketSpender,
+		}},
+	}
+
+	for _, test := range tests {
+		indexed := *expected
+		test.modify(&indexed)
+		if fields := txIndexMismatches(&indexed, expected); !reflect.DeepEqual(fields, test.fields) {
+			t.Errorf("%s: got fields %v, want %v", test.name, fields, test.fields)
+		}
+	}
+}
+
+func TestCompareTxIndex(t *testing.T) {
+	db, err := walletdata.Initialize(filepath.Join(t.TempDir(), "walletdata.db"), &sharedW.Transaction{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	walletTxs := map[string]*walletTx{
+		"regular":  {tx: &sharedW.Transaction{Hash: "regular", Amount: 100, BlockHeight: 10, Type: txhelper.TxTypeRegular}},
+		"ticket":   {tx: &sharedW.Transaction{Hash: "ticket", Amount: 200, BlockHeight: 11, Type: txhelper.TxTypeTicketPurchase}},
+		"vote":     {tx: &sharedW.Transaction{Hash: "vote", Amount: 210, BlockHeight: 20, Type: txhelper.TxTypeVote, TicketSpentHash: "ticket"}},
+		"unmined":  {tx: &sharedW.Transaction{Hash: "unmined", Amount: 50, BlockHeight: sharedW.UnminedTxHeight, Type: txhelper.TxTypeRegular}},
+		"missing1": {tx: &sharedW.Transaction{Hash: "missing1", Amount: 1, BlockHeight: 30, Type: txhelper.TxTypeRegular}},
+		"missing2": {tx: &sharedW.Transaction{Hash: "missing2", Amount: 2, BlockHeight: 31, Type: txhelper.TxTypeRegular}},
+	}
+	spenders := map[string]string{"ticket": "vote"}
+
+	// The index has drifted from the wallet: a transaction is indexed with a
+	// wrong amount, the ticket is indexed before its vote was and a mined
+	// transaction is still indexed as unmined. The missing transactions are
+	// not indexed and a transaction the wallet does not know of is.
+	indexed := []*sharedW.Transaction{
+		{Hash: "regular", Amount: 90, BlockHeight: 10, Type: txhelper.TxTypeRegular},
+		{Hash: "ticket", Amount: 200, BlockHeight: 11, Type: txhelper.TxTypeTicketPurchase},
+		{Hash: "vote", Amount: 210, BlockHeight: sharedW.UnminedTxHeight, Type: txhelper.TxTypeVote, TicketSpentHash: "ticket"},
+		{Hash: "unmined", Amount: 50, BlockHeight: sharedW.UnminedTxHeight, Type: txhelper.TxTypeRegular},
+		{Hash: "orphan", Amount: 10, BlockHeight: 12, Type: txhelper.TxTypeRegular},
+	}
+	for _, tx := range indexed {
+		if _, err := db.SaveOrUpdate(&sharedW.Transaction{}, tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, orphaned, err := compareTxIndex(db, walletTxs, spenders)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.WalletTxs != len(walletTxs) || report.IndexedTxs != len(indexed) {
+		t.Errorf("got %d wallet and %d indexed txs, want %d and %d",
+			report.WalletTxs, report.IndexedTxs, len(walletTxs), len(indexed))
+	}
+	if want := []string{"missing1", "missing2"}; !reflect.DeepEqual(report.Missing, want) {
+		t.Errorf("got missing %v, want %v", report.Missing, want)
+	}
+	if want := []string{"orphan"}; !reflect.DeepEqual(report.Orphaned, want) {
+		t.Errorf("got orphaned %v, want %v", report.Orphaned, want)
+	}
+	if len(orphaned) != 1 || orphaned[0].Hash != "orphan" {
+		t.Errorf("got orphaned txs %v, want the orphan tx", orphaned)
+	}
+
+	mismatched := make(map[string][]string)
+	for _, mismatch := range report.Mismatched {
+		mismatched[mismatch.Hash] = mismatch.Fields
+	}
+	wantMismatched := map[string][]string{
+		"regular": {sharedW.TxIndexFieldAmount},
+		"ticket":  {sharedW.TxIndexFieldTicketSpender},
+		"vote":    {sharedW.TxIndexFieldHeight},
+	}
+	if !reflect.DeepEqual(mismatched, wantMismatched) {
+		t.Errorf("got mismatched %v, want %v", mismatched, wantMismatched)
+	}
+	if report.IsConsistent() {
+		t.Error("the drifted index is reported consistent")
+	}
+
+	// Once the index matches the wallet, it is consistent.
+	if err := db.DeleteRecord(orphaned[0]); err != nil {
+		t.Fatal(err)
+	}
+	hashes := make([]string, 0, len(walletTxs))
+	for hash := range walletTxs {
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+	for _, hash := range hashes {
+		tx := *walletTxs[hash].tx
+		tx.TicketSpender = spenders[hash]
+		if _, err := db.SaveOrUpdate(&sharedW.Transaction{}, &tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, _, err = compareTxIndex(db, walletTxs, spenders)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.IsConsistent() {
+		t.Errorf("the repaired index is inconsistent: %+v", report)
+	}
+}
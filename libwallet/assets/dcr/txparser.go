@@ -11,6 +11,50 @@ import (
 func (asset *Asset) decodeTransactionWithTxSummary(txSummary *w.TransactionSummary,
 	blockHash *chainhash.Hash,
 ) (*sharedW.Transaction, error) {
+	decodedTx, walletTx, err := asset.decodeTxSummary(txSummary, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if decodedTx.TicketSpentHash != "" {
+		ticketPurchaseTx, err := asset.GetTransactionRaw(decodedTx.TicketSpentHash)
+		if err != nil {
+			return nil, err
+		}
+
+		timeDifferenceInSeconds := decodedTx.Timestamp - ticketPurchaseTx.Timestamp
+		decodedTx.DaysToVoteOrRevoke = int32(timeDifferenceInSeconds / 86400) // seconds to days conversion
+
+		// calculate reward
+		var ticketInvestment int64
+		for _, input := range ticketPurchaseTx.Inputs {
+			if input.AccountNumber > -1 {
+				ticketInvestment += input.Amount
+			}
+		}
+
+		var ticketOutput int64
+		for _, output := range walletTx.Outputs {
+			if output.AccountNumber > -1 {
+				ticketOutput += output.AmountOut
+			}
+		}
+
+		decodedTx.VoteReward = ticketOutput - ticketInvestment
+
+		// update ticket with spender hash
+		ticketPurchaseTx.TicketSpender = decodedTx.Hash
+		asset.GetWalletDataDb().SaveOrUpdate(&sharedW.Transaction{}, ticketPurchaseTx)
+	}
+
+	return decodedTx, nil
+}
+
+// decodeTxSummary decodes the transaction summary without reading or updating
+// the ticket spent by the transaction.
+func (asset *Asset) decodeTxSummary(txSummary *w.TransactionSummary,
+	blockHash *chainhash.Hash,
+) (*sharedW.Transaction, *sharedW.TxInfoFromWallet, error) {
 	var blockHeight int32 = sharedW.UnminedTxHeight
 	if blockHash != nil {
 		blockIdentifier := w.NewBlockIdentifierFromHash(blockHash)
@@ -72,39 +116,8 @@ func (asset *Asset) decodeTransactionWithTxSummary(txSummary *w.TransactionSumma
 
 	decodedTx, err := asset.DecodeTransaction(walletTx, asset.chainParams)
 	if err != nil {
-		return nil, err
-	}
-
-	if decodedTx.TicketSpentHash != "" {
-		ticketPurchaseTx, err := asset.GetTransactionRaw(decodedTx.TicketSpentHash)
-		if err != nil {
-			return nil, err
-		}
-
-		timeDifferenceInSeconds := decodedTx.Timestamp - ticketPurchaseTx.Timestamp
-		decodedTx.DaysToVoteOrRevoke = int32(timeDifferenceInSeconds / 86400) // seconds to days conversion
-
-		// calculate reward
-		var ticketInvestment int64
-		for _, input := range ticketPurchaseTx.Inputs {
-			if input.AccountNumber > -1 {
-				ticketInvestment += input.Amount
-			}
-		}
-
-		var ticketOutput int64
-		for _, output := range walletTx.Outputs {
-			if output.AccountNumber > -1 {
-				ticketOutput += output.AmountOut
-			}
-		}
-
-		decodedTx.VoteReward = ticketOutput - ticketInvestment
-
-		// update ticket with spender hash
-		ticketPurchaseTx.TicketSpender = decodedTx.Hash
-		asset.GetWalletDataDb().SaveOrUpdate(&sharedW.Transaction{}, ticketPurchaseTx)
+		return nil, nil, err
 	}
 
-	return decodedTx, nil
+	return decodedTx, walletTx, nil
 }
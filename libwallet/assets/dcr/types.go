@@ -60,9 +60,78 @@ type CSPPConfig struct {
 	ChangeAccount      uint32
 }
 
+// CSPPServerConfig holds a user provided CoinShuffle++ coordination server.
+// CertPEM is an optional certificate (or CA) trusted in addition to the
+// compiled-in certs.CSPP and CertFingerprint is an optional hex encoded
+// SHA-256 hash of the server's leaf certificate that the connection must match.
+type CSPPServerConfig struct {
+	Host            string `json:"host"`
+	Port            string `json:"port"`
+	CertPEM         string `json:"cert_pem"`
+	CertFingerprint string `json:"cert_fingerprint"`
+}
+
+// DcrdRPCConfig holds the connection details of a trusted dcrd node used as
+// the wallet's network backend in place of SPV. Host may include a port, the
+// network's default RPC port is used otherwise. CertPEM is the dcrd RPC
+// certificate (or its CA) that the TLS connection must be verified against.
+// Pass is never persisted, EncryptedPass holds it encrypted with the wallet's
+// private passphrase.
+type DcrdRPCConfig struct {
+	Host          string `json:"host"`
+	User          string `json:"user"`
+	Pass          string `json:"-"`
+	EncryptedPass []byte `json:"encrypted_pass"`
+	CertPEM       string `json:"cert_pem"`
+}
+
+// MixerSchedule restricts when and for how long an account mixer session
+// runs. Zero values disable the matching restriction.
+type MixerSchedule struct {
+	// WindowStart and WindowEnd define a daily window, in minutes past local
+	// midnight, within which mixing is allowed. A window that ends before it
+	// starts wraps past midnight. Equal values allow mixing at any time.
+	WindowStart int `json:"window_start"`
+	WindowEnd   int `json:"window_end"`
+	// MaxMixes ends the session after the given number of successful mixes.
+	MaxMixes int `json:"max_mixes"`
+	// MinUnmixedBalance ends the session once the spendable balance of the
+	// unmixed account drops below this amount (in atoms).
+	MinUnmixedBalance int64 `json:"min_unmixed_balance"`
+}
+
+// MixerStats holds the statistics of the current (or last) account mixer
+// session.
+type MixerStats struct {
+	StartedAt       int64 `json:"started_at"`
+	RoundsAttempted int   `json:"rounds_attempted"`
+	RoundsSucceeded int   `json:"rounds_succeeded"`
+	// Denominations maps each mixed output value (in atoms) to the number
+	// of such outputs received by the mixed account.
+	Denominations map[int64]int `json:"denominations"`
+	// PeersPerRound records the number of peers, this wallet included,
+	// paired by the coordination server for each mix round, in the order
+	// the rounds started.
+	PeersPerRound []int `json:"peers_per_round"`
+}
+
+// AveragePeers returns the mean number of peers per mix round.
+func (stats *MixerStats) AveragePeers() float64 {
+	if len(stats.PeersPerRound) == 0 {
+		return 0
+	}
+
+	total := 0
+	for _, peers := range stats.PeersPerRound {
+		total += peers
+	}
+	return float64(total) / float64(len(stats.PeersPerRound))
+}
+
 type AccountMixerNotificationListener interface {
 	OnAccountMixerStarted(walletID int)
 	OnAccountMixerEnded(walletID int)
+	OnAccountMixerStats(walletID int, stats *MixerStats)
 }
 
 /** begin ticket-related types */
@@ -206,3 +275,81 @@ type TreasuryKeyPolicy struct {
 	TicketHash string `json:"ticket_hash"` // nil unless for per-ticket VSP policies
 	Policy     string `json:"policy"`
 }
+
+// TSpend is a treasury spend transaction being voted on by the stakeholders.
+type TSpend struct {
+	Hash   string         `json:"hash"`
+	PiKey  string         `json:"pi_key"`
+	Amount int64          `json:"amount"`
+	Payees []*TSpendPayee `json:"payees"`
+	// Expiry is the height after which the tspend can no longer be mined.
+	Expiry uint32 `json:"expiry"`
+	// Policy is the wallet vote policy for the tspend. A policy set for the
+	// tspend overrides the policy set for its pi key.
+	Policy string `json:"policy"`
+}
+
+// TSpendPayee is an output of a treasury spend transaction.
+type TSpendPayee struct {
+	Address string `json:"address"`
+	Amount  int64  `json:"amount"`
+}
+
+// TSpendPolicy records the voting policy for a treasury spend transaction,
+// and possibly for a particular ticket being voted on by a VSP.
+type TSpendPolicy struct {
+	TSpendHash string `json:"tspend_hash"`
+	TicketHash string `json:"ticket_hash"` // empty unless for per-ticket VSP policies
+	Policy     string `json:"policy"`
+}
+
+// VoteChoiceType identifies what a ticket vote choice is cast on.
+type VoteChoiceType int
+
+const (
+	// AgendaVoteChoice is a vote choice on a consensus agenda.
+	AgendaVoteChoice VoteChoiceType = iota
+	// TreasuryVoteChoice is a voting policy for the treasury spends signed by
+	// a pi key.
+	TreasuryVoteChoice
+	// TSpendVoteChoice is a voting policy for a treasury spend transaction.
+	TSpendVoteChoice
+)
+
+// TicketVoteChoices records the vote choices of an unspent, unexpired ticket.
+// Choices that are not set for the ticket are the wallet's default choices.
+type TicketVoteChoices struct {
+	TicketHash string `json:"ticket_hash"`
+	// VSP is the host of the VSP the ticket is registered with, if any.
+	VSP string `json:"vsp"`
+	// AgendaChoices maps the current agendas' IDs to the choice IDs.
+	AgendaChoices map[string]string `json:"agenda_choices"`
+	// TreasuryPolicies maps the hex encoded pi keys to the policies.
+	TreasuryPolicies map[string]string `json:"treasury_policies"`
+	// TSpendPolicies maps the unexpired tspends' hashes to the policies.
+	TSpendPolicies map[string]string `json:"tspend_policies"`
+}
+
+// VoteChoiceMismatch is a ticket vote choice recorded by the VSP the ticket
+// is registered with that differs from the wallet's choice.
+type VoteChoiceMismatch struct {
+	TicketHash string         `json:"ticket_hash"`
+	VSP        string         `json:"vsp"`
+	Type       VoteChoiceType `json:"type"`
+	// ID is the agenda ID, the pi key or the tspend hash.
+	ID          string `json:"id"`
+	LocalChoice string `json:"local_choice"`
+	VSPChoice   string `json:"vsp_choice"`
+}
+
+// AgendaDeployment is a past consensus deployment and the votes the wallet's
+// tickets cast on it.
+type AgendaDeployment struct {
+	*Agenda
+	StakeVersion uint32 `json:"stake_version"`
+	// Votes maps the agenda's choice IDs to the number of the wallet's votes
+	// cast for them between the deployment's start and expire times. The
+	// votes cast after the agenda locked in or failed still carry the
+	// choice, so the counts are an upper bound of the votes that decided it.
+	Votes map[string]int `json:"votes"`
+}
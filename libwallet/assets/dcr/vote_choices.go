@@ -0,0 +1,514 @@
+package dcr
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"decred.org/dcrwallet/v3/errors"
+	w "decred.org/dcrwallet/v3/wallet"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/internal/vsp"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+
+	"github.com/decred/dcrd/blockchain/stake/v5"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+// TicketsVoteChoices returns the agenda choices, the treasury key policies
+// and the tspend policies of all unspent, unexpired tickets.
+func (asset *Asset) TicketsVoteChoices() ([]*TicketVoteChoices, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+
+	tickets, err := asset.UnspentUnexpiredTickets()
+	if err != nil {
+		return nil, err
+	}
+
+	tspends, err := asset.TSpends()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	ticketsChoices := make([]*TicketVoteChoices, 0, len(tickets))
+	for _, ticket := range tickets {
+		ticketHash, err := chainhash.NewHashFromStr(ticket.Hash)
+		if err != nil {
+			return nil, err
+		}
+
+		choices, err := asset.ticketVoteChoices(ctx, ticketHash, tspends)
+		if err != nil {
+			return nil, err
+		}
+		ticketsChoices = append(ticketsChoices, choices)
+	}
+
+	return ticketsChoices, nil
+}
+
+// ticketVoteChoices returns the vote choices of the ticket for the current
+// agendas, the pi keys and the provided tspends.
+func (asset *Asset) ticketVoteChoices(ctx context.Context, ticketHash *chainhash.Hash, tspends []*TSpend) (*TicketVoteChoices, error) {
+	agendaChoices, _, err := asset.Internal().DCR.AgendaChoices(ctx, ticketHash)
+	if err != nil {
+		return nil, err
+	}
+
+	choices := &TicketVoteChoices{
+		TicketHash:       ticketHash.String(),
+		AgendaChoices:    make(map[string]string, len(agendaChoices)),
+		TreasuryPolicies: make(map[string]string, len(asset.chainParams.PiKeys)),
+		TSpendPolicies:   make(map[string]string, len(tspends)),
+	}
+
+	for _, choice := range agendaChoices {
+		choices.AgendaChoices[choice.AgendaID] = choice.ChoiceID
+	}
+
+	for _, pikey := range asset.chainParams.PiKeys {
+		policy := asset.Internal().DCR.TreasuryKeyPolicy(pikey, ticketHash)
+		if policy == stake.TreasuryVoteInvalid {
+			// No policy is set for this ticket, the wallet's policy applies.
+			policy = asset.Internal().DCR.TreasuryKeyPolicy(pikey, nil)
+		}
+		choices.TreasuryPolicies[hex.EncodeToString(pikey)] = treasuryVoteString(policy)
+	}
+
+	for _, tspend := range tspends {
+		tspendHash, err := chainhash.NewHashFromStr(tspend.Hash)
+		if err != nil {
+			return nil, err
+		}
+		policy := asset.Internal().DCR.TSpendPolicy(tspendHash, ticketHash)
+		choices.TSpendPolicies[tspend.Hash] = treasuryVoteString(policy)
+	}
+
+	vspTicketInfo, err := asset.Internal().DCR.VSPTicketInfo(ctx, ticketHash)
+	if err == nil {
+		choices.VSP = vspTicketInfo.Host
+	} else if !errors.Is(err, errors.NotExist) {
+		return nil, err
+	}
+
+	return choices, nil
+}
+
+// SetTicketsVoteChoice sets the vote choice of the specified tickets for an
+// agenda, a pi key or a tspend, and updates it with the VSPs controlling the
+// tickets. The id is the agenda ID, the hex encoded pi key or the tspend hash.
+// A ticket's choice is reverted if its VSP could not be updated. All tickets
+// are tried and the first error is returned.
+func (asset *Asset) SetTicketsVoteChoice(choiceType VoteChoiceType, id, choice string, ticketHashes []string, passphrase string) error {
+	if !asset.WalletOpened() {
+		return utils.ErrDCRNotInitialized
+	}
+
+	hashes := make([]*chainhash.Hash, len(ticketHashes))
+	for i := range ticketHashes {
+		hash, err := chainhash.NewHashFromStr(ticketHashes[i])
+		if err != nil {
+			return fmt.Errorf("invalid ticket hash: %w", err)
+		}
+		hashes[i] = hash
+	}
+
+	// The wallet will need to be unlocked to sign the API
+	// request(s) for setting this vote choice with the VSP.
+	err := asset.UnlockWallet(passphrase)
+	if err != nil {
+		return utils.TranslateError(err)
+	}
+	defer asset.LockWallet()
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+
+	// Never return errors from this for loop, so all tickets are tried.
+	// The first error will be returned to the caller.
+	var firstErr error
+	for _, ticketHash := range hashes {
+		err := asset.setTicketVoteChoice(ctx, choiceType, id, choice, ticketHash)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// setTicketVoteChoice sets the vote choice of a single ticket locally and with
+// its VSP, reverting the local choice if the VSP could not be updated.
+func (asset *Asset) setTicketVoteChoice(ctx context.Context, choiceType VoteChoiceType, id, choice string, ticketHash *chainhash.Hash) error {
+	switch choiceType {
+	case AgendaVoteChoice:
+		currentChoices, _, err := asset.Internal().DCR.AgendaChoices(ctx, ticketHash)
+		if err != nil {
+			return err
+		}
+		// The current choices are the default ones if the ticket has none of
+		// its own, they are deleted rather than saved for the ticket on revert.
+		hasChoices, err := asset.agendaPrefs.TicketHasAgendaChoices(ctx, ticketHash)
+		if err != nil {
+			return err
+		}
+
+		// All the current choices are saved for the ticket, otherwise the
+		// other agendas would no longer use the wallet's default choices.
+		var found bool
+		newChoices := make([]w.AgendaChoice, len(currentChoices))
+		copy(newChoices, currentChoices)
+		for i := range newChoices {
+			if newChoices[i].AgendaID == id {
+				newChoices[i].ChoiceID = strings.ToLower(choice)
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown agenda %s", id)
+		}
+
+		if _, err = asset.Internal().DCR.SetAgendaChoices(ctx, ticketHash, newChoices...); err != nil {
+			return err
+		}
+
+		err = asset.updateVSPTicketVoteChoices(ctx, ticketHash, newChoices, nil, nil)
+		if err != nil {
+			var revertError error
+			if hasChoices {
+				_, revertError = asset.Internal().DCR.SetAgendaChoices(ctx, ticketHash, currentChoices...)
+			} else {
+				revertError = asset.agendaPrefs.DeleteTicketAgendaChoices(ctx, ticketHash)
+			}
+			if revertError != nil {
+				log.Errorf("unable to revert locally saved voting preference: %v", revertError)
+			}
+		}
+		return err
+
+	case TreasuryVoteChoice:
+		pikey, err := hex.DecodeString(id)
+		if err != nil {
+			return fmt.Errorf("invalid pikey: %w", err)
+		}
+		policy, err := parseTreasuryVote(choice)
+		if err != nil {
+			return err
+		}
+
+		// The policy of the ticket is invalid if it has none of its own, which
+		// deletes it on revert.
+		currentPolicy := asset.Internal().DCR.TreasuryKeyPolicy(pikey, ticketHash)
+		if err = asset.Internal().DCR.SetTreasuryKeyPolicy(ctx, pikey, policy, ticketHash); err != nil {
+			return err
+		}
+
+		err = asset.updateVSPTicketVoteChoices(ctx, ticketHash, nil, nil, map[string]string{id: choice})
+		if err != nil {
+			revertError := asset.Internal().DCR.SetTreasuryKeyPolicy(ctx, pikey, currentPolicy, ticketHash)
+			if revertError != nil {
+				log.Errorf("unable to revert locally saved voting preference: %v", revertError)
+			}
+		}
+		return err
+
+	case TSpendVoteChoice:
+		tspendHash, err := chainhash.NewHashFromStr(id)
+		if err != nil {
+			return fmt.Errorf("invalid tspend hash: %w", err)
+		}
+		policy, err := parseTreasuryVote(choice)
+		if err != nil {
+			return err
+		}
+
+		// TSpendPolicy falls back to the wallet's policy, only the policy of
+		// the ticket is reverted. Reverting to an invalid policy deletes it.
+		currentPolicy := stake.TreasuryVoteInvalid
+		ticketPolicies := asset.Internal().DCR.TSpendPolicyForTicket(ticketHash)
+		if ticketPolicy, ok := ticketPolicies[tspendHash.String()]; ok {
+			if currentPolicy, err = parseTreasuryVote(ticketPolicy); err != nil {
+				return err
+			}
+		}
+		if err = asset.Internal().DCR.SetTSpendPolicy(ctx, tspendHash, policy, ticketHash); err != nil {
+			return err
+		}
+
+		err = asset.updateVSPTicketVoteChoices(ctx, ticketHash, nil, map[string]string{id: choice}, nil)
+		if err != nil {
+			revertError := asset.Internal().DCR.SetTSpendPolicy(ctx, tspendHash, currentPolicy, ticketHash)
+			if revertError != nil {
+				log.Errorf("unable to revert locally saved voting preference: %v", revertError)
+			}
+		}
+		return err
+
+	default:
+		return fmt.Errorf("unknown vote choice type %d", choiceType)
+	}
+}
+
+// updateVSPTicketVoteChoices sets the vote choices with the VSP associated
+// with the ticket. Tickets not registered with a VSP are ignored.
+func (asset *Asset) updateVSPTicketVoteChoices(ctx context.Context, ticketHash *chainhash.Hash,
+	choices []w.AgendaChoice, tspendPolicy, treasuryPolicy map[string]string,
+) error {
+	vspTicketInfo, err := asset.Internal().DCR.VSPTicketInfo(ctx, ticketHash)
+	if err != nil {
+		// Ignore NotExist error, just means the ticket is not
+		// registered with a VSP, nothing more to do here.
+		if errors.Is(err, errors.NotExist) {
+			return nil
+		}
+		return err
+	}
+
+	vspClient, err := asset.VSPClient(vspTicketInfo.Host, vspTicketInfo.PubKey)
+	if err != nil {
+		return err
+	}
+	return vspClient.SetVoteChoice(ctx, ticketHash, choices, tspendPolicy, treasuryPolicy)
+}
+
+// VSPVoteChoiceMismatches requests the vote choices of the unspent, unexpired
+// tickets from the VSPs controlling them and returns the choices that differ
+// from the wallet's. The tickets whose VSP could not be reached are skipped
+// and the first such error is returned along with the mismatches found.
+func (asset *Asset) VSPVoteChoiceMismatches(passphrase string) ([]*VoteChoiceMismatch, error) {
+	tickets, err := asset.TicketsVoteChoices()
+	if err != nil {
+		return nil, err
+	}
+
+	// The wallet will need to be unlocked to sign the ticket status
+	// requests sent to the VSPs.
+	err = asset.UnlockWallet(passphrase)
+	if err != nil {
+		return nil, utils.TranslateError(err)
+	}
+	defer asset.LockWallet()
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+
+	var mismatches []*VoteChoiceMismatch
+	var firstErr error
+	for _, ticket := range tickets {
+		if ticket.VSP == "" {
+			continue
+		}
+
+		ticketHash, err := chainhash.NewHashFromStr(ticket.TicketHash)
+		if err != nil {
+			return nil, err
+		}
+
+		status, err := asset.vspTicketStatus(ctx, ticketHash)
+		if err != nil {
+			log.Warnf("unable to get vsp ticket: %s Error: %v", ticket.TicketHash, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		mismatches = append(mismatches, ticketVoteChoiceMismatches(ticket, status)...)
+	}
+
+	return mismatches, firstErr
+}
+
+// ticketVoteChoiceMismatches returns the vote choices of the ticket recorded
+// by its VSP that differ from the wallet's, ordered by type and ID. The VSP
+// abstains on the agendas, pi keys and tspends it has no choice for.
+func ticketVoteChoiceMismatches(ticket *TicketVoteChoices, status *vsp.TicketStatus) []*VoteChoiceMismatch {
+	var mismatches []*VoteChoiceMismatch
+	compare := func(choiceType VoteChoiceType, local, vsp map[string]string) {
+		ids := make([]string, 0, len(local))
+		for id := range local {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			vspChoice, ok := vsp[id]
+			if !ok {
+				vspChoice = "abstain"
+			}
+			if vspChoice == local[id] {
+				continue
+			}
+			mismatches = append(mismatches, &VoteChoiceMismatch{
+				TicketHash:  ticket.TicketHash,
+				VSP:         ticket.VSP,
+				Type:        choiceType,
+				ID:          id,
+				LocalChoice: local[id],
+				VSPChoice:   vspChoice,
+			})
+		}
+	}
+	compare(AgendaVoteChoice, ticket.AgendaChoices, status.VoteChoices)
+	compare(TreasuryVoteChoice, ticket.TreasuryPolicies, status.TreasuryPolicy)
+	compare(TSpendVoteChoice, ticket.TSpendPolicies, status.TSpendPolicy)
+
+	return mismatches
+}
+
+// vspTicketStatus returns the status of the ticket recorded by the VSP
+// controlling it.
+func (asset *Asset) vspTicketStatus(ctx context.Context, ticketHash *chainhash.Hash) (*vsp.TicketStatus, error) {
+	vspTicketInfo, err := asset.Internal().DCR.VSPTicketInfo(ctx, ticketHash)
+	if err != nil {
+		return nil, err
+	}
+
+	vspClient, err := asset.VSPClient(vspTicketInfo.Host, vspTicketInfo.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	return vspClient.GetTicketStatus(ctx, ticketHash)
+}
+
+// SyncVSPVoteChoices updates the VSPs controlling the specified tickets with
+// the wallet's vote choices for them. All tickets are tried and the first
+// error is returned.
+func (asset *Asset) SyncVSPVoteChoices(ticketHashes []string, passphrase string) error {
+	if !asset.WalletOpened() {
+		return utils.ErrDCRNotInitialized
+	}
+
+	tspends, err := asset.TSpends()
+	if err != nil {
+		return err
+	}
+
+	// The wallet will need to be unlocked to sign the API
+	// request(s) for setting the vote choices with the VSP.
+	err = asset.UnlockWallet(passphrase)
+	if err != nil {
+		return utils.TranslateError(err)
+	}
+	defer asset.LockWallet()
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+
+	// Never return errors from this for loop, so all tickets are tried.
+	// The first error will be returned to the caller.
+	var firstErr error
+	for _, hash := range ticketHashes {
+		ticketHash, err := chainhash.NewHashFromStr(hash)
+		if err != nil {
+			return fmt.Errorf("invalid ticket hash: %w", err)
+		}
+
+		choices, err := asset.ticketVoteChoices(ctx, ticketHash, tspends)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		agendaChoices := make([]w.AgendaChoice, 0, len(choices.AgendaChoices))
+		for agendaID, choiceID := range choices.AgendaChoices {
+			agendaChoices = append(agendaChoices, w.AgendaChoice{AgendaID: agendaID, ChoiceID: choiceID})
+		}
+
+		err = asset.updateVSPTicketVoteChoices(ctx, ticketHash, agendaChoices, choices.TSpendPolicies, choices.TreasuryPolicies)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// AgendaDeploymentHistory returns the consensus deployments that are no
+// longer upcoming or being voted on, newest first, along with the votes cast
+// on them by the wallet's tickets.
+func (asset *Asset) AgendaDeploymentHistory() ([]*AgendaDeployment, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+
+	agendas, err := asset.AllVoteAgendas("", true)
+	if err != nil {
+		return nil, err
+	}
+
+	votes, err := asset.GetTransactionsRaw(0, 0, TxFilterVoted, true)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]*AgendaDeployment, 0)
+	for version, deployments := range asset.chainParams.Deployments {
+		for i := range deployments {
+			d := &deployments[i]
+
+			var agenda *Agenda
+			for _, a := range agendas {
+				if a.AgendaID == d.Vote.Id {
+					agenda = a
+					break
+				}
+			}
+			if agenda == nil {
+				continue
+			}
+
+			switch AgendaStatusFromStr(agenda.Status) {
+			case AgendaStatusUpcoming, AgendaStatusInProgress:
+				continue
+			}
+
+			history = append(history, &AgendaDeployment{
+				Agenda:       agenda,
+				StakeVersion: version,
+				Votes:        countDeploymentVotes(d, version, votes),
+			})
+		}
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].StartTime > history[j].StartTime
+	})
+
+	return history, nil
+}
+
+// countDeploymentVotes counts the votes cast for each choice of the
+// deployment of the stake version. Only the votes of that stake version
+// cast between the deployment's start and expire times carry a choice for
+// it, including the ones cast after it was decided.
+func countDeploymentVotes(d *chaincfg.ConsensusDeployment, version uint32, votes []sharedW.Transaction) map[string]int {
+	counts := make(map[string]int)
+	for _, vote := range votes {
+		if uint32(vote.VoteVersion) != version || vote.Timestamp < int64(d.StartTime) ||
+			vote.Timestamp > int64(d.ExpireTime) {
+			continue
+		}
+
+		bits, err := strconv.ParseUint(vote.VoteBits, 0, 16)
+		if err != nil {
+			log.Errorf("invalid vote bits %q of vote %s: %v", vote.VoteBits, vote.Hash, err)
+			continue
+		}
+
+		for _, choice := range d.Vote.Choices {
+			if choice.Bits == uint16(bits)&d.Vote.Mask {
+				counts[choice.Id]++
+				break
+			}
+		}
+	}
+
+	return counts
+}
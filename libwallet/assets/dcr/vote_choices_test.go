@@ -0,0 +1,133 @@
+package dcr
+
+import (
+	"reflect"
+	"testing"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/internal/vsp"
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+func TestTicketVoteChoiceMismatches(t *testing.T) {
+	ticket := &TicketVoteChoices{
+		TicketHash:       "ticket",
+		VSP:              "https://vsp",
+		AgendaChoices:    map[string]string{"agenda": "yes"},
+		TreasuryPolicies: map[string]string{"pikey": "no"},
+		TSpendPolicies:   map[string]string{"tspend": "abstain"},
+	}
+
+	mismatch := func(choiceType VoteChoiceType, id, local, vspChoice string) *VoteChoiceMismatch {
+		return &VoteChoiceMismatch{
+			TicketHash:  "ticket",
+			VSP:         "https://vsp",
+			Type:        choiceType,
+			ID:          id,
+			LocalChoice: local,
+			VSPChoice:   vspChoice,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		status   *vsp.TicketStatus
+		expected []*VoteChoiceMismatch
+	}{
+		{"same choices", &vsp.TicketStatus{
+			VoteChoices:    map[string]string{"agenda": "yes"},
+			TreasuryPolicy: map[string]string{"pikey": "no"},
+			TSpendPolicy:   map[string]string{"tspend": "abstain"},
+		}, nil},
+		{"different choices", &vsp.TicketStatus{
+			VoteChoices:    map[string]string{"agenda": "no"},
+			TreasuryPolicy: map[string]string{"pikey": "yes"},
+			TSpendPolicy:   map[string]string{"tspend": "yes"},
+		}, []*VoteChoiceMismatch{
+			mismatch(AgendaVoteChoice, "agenda", "yes", "no"),
+			mismatch(TreasuryVoteChoice, "pikey", "no", "yes"),
+			mismatch(TSpendVoteChoice, "tspend", "abstain", "yes"),
+		}},
+		// The VSP abstains on the choices it has no record of.
+		{"missing vsp choices", &vsp.TicketStatus{}, []*VoteChoiceMismatch{
+			mismatch(AgendaVoteChoice, "agenda", "yes", "abstain"),
+			mismatch(TreasuryVoteChoice, "pikey", "no", "abstain"),
+		}},
+		{"vsp choices unknown to the wallet", &vsp.TicketStatus{
+			VoteChoices:    map[string]string{"agenda": "yes", "other": "no"},
+			TreasuryPolicy: map[string]string{"pikey": "no"},
+		}, nil},
+	}
+
+	for _, test := range tests {
+		mismatches := ticketVoteChoiceMismatches(ticket, test.status)
+		if !reflect.DeepEqual(mismatches, test.expected) {
+			t.Errorf("%s: expected mismatches %+v, got %+v", test.name, test.expected, mismatches)
+		}
+	}
+}
+
+func TestCountDeploymentVotes(t *testing.T) {
+	const version = 9
+	deployment := &chaincfg.ConsensusDeployment{
+		Vote: chaincfg.Vote{
+			Id:   "agenda",
+			Mask: 0x0006,
+			Choices: []chaincfg.Choice{
+				{Id: "abstain", Bits: 0x0000, IsAbstain: true},
+				{Id: "no", Bits: 0x0002, IsNo: true},
+				{Id: "yes", Bits: 0x0004},
+			},
+		},
+		StartTime:  1000,
+		ExpireTime: 2000,
+	}
+
+	vote := func(voteVersion int32, voteBits string, timestamp int64) sharedW.Transaction {
+		return sharedW.Transaction{VoteVersion: voteVersion, VoteBits: voteBits, Timestamp: timestamp}
+	}
+
+	tests := []struct {
+		name     string
+		votes    []sharedW.Transaction
+		expected map[string]int
+	}{
+		{"no votes", nil, map[string]int{}},
+		{"choices", []sharedW.Transaction{
+			vote(version, "0x0005", 1500),
+			vote(version, "0x0005", 1600),
+			vote(version, "0x0003", 1700),
+			vote(version, "0x0001", 1800),
+		}, map[string]int{"yes": 2, "no": 1, "abstain": 1}},
+		// Only the deployment's bits are compared, the bits of the other
+		// agendas and the block validity bit are ignored.
+		{"other agenda bits", []sharedW.Transaction{
+			vote(version, "0x0015", 1500),
+			vote(version, "0x0018", 1500),
+		}, map[string]int{"yes": 1, "abstain": 1}},
+		{"other vote versions", []sharedW.Transaction{
+			vote(version-1, "0x0005", 1500),
+			vote(version+1, "0x0005", 1500),
+		}, map[string]int{}},
+		{"voting window", []sharedW.Transaction{
+			vote(version, "0x0005", 999),
+			vote(version, "0x0005", 1000),
+			vote(version, "0x0005", 2000),
+			vote(version, "0x0005", 2001),
+		}, map[string]int{"yes": 2}},
+		{"invalid vote bits", []sharedW.Transaction{
+			vote(version, "", 1500),
+			vote(version, "0x10000", 1500),
+		}, map[string]int{}},
+		{"unknown choice bits", []sharedW.Transaction{
+			vote(version, "0x0007", 1500),
+		}, map[string]int{}},
+	}
+
+	for _, test := range tests {
+		counts := countDeploymentVotes(deployment, version, test.votes)
+		if !reflect.DeepEqual(counts, test.expected) {
+			t.Errorf("%s: expected counts %v, got %v", test.name, test.expected, counts)
+		}
+	}
+}
@@ -16,7 +16,9 @@ import (
 )
 
 const (
-	defaultVSPsURL = "https://api.decred.org/?c=vsp"
+	// DefaultVSPListURL is the source of the list of known VSPs used if
+	// none is configured.
+	DefaultVSPListURL = "https://api.decred.org/?c=vsp"
 )
 
 // VSPClient loads or creates a VSP client instance for the specified host.
@@ -136,7 +138,7 @@ func (asset *Asset) ReloadVSPList(ctx context.Context) {
 		}
 	}
 
-	otherVSPHosts, err := defaultVSPs(string(asset.NetType()))
+	otherVSPHosts, err := asset.defaultVSPs()
 	if err != nil {
 		log.Debugf("get default vsp list error: %v", err)
 	}
@@ -194,15 +196,24 @@ func vspInfo(vspHost string) (*VspInfoResponse, error) {
 	return vspInfoResponse, nil
 }
 
-// defaultVSPs returns a list of known VSPs.
-func defaultVSPs(network string) ([]string, error) {
+// defaultVSPs returns a list of known VSPs from the configured VSP list
+// sources, or from the default one if none is configured.
+func (asset *Asset) defaultVSPs() ([]string, error) {
+	network := string(asset.NetType())
 	var vspInfoResponse map[string]*VspInfoResponse
 	req := &utils.ReqConfig{
 		Method:  http.MethodGet,
-		HTTPURL: defaultVSPsURL,
+		HTTPURL: DefaultVSPListURL,
 	}
 
-	if _, err := utils.HTTPRequest(req, &vspInfoResponse); err != nil {
+	var err error
+	if endpoints := asset.Endpoints(utils.VSPListEndpoint); len(endpoints) > 0 {
+		req.HTTPURL = ""
+		_, err = utils.EndpointRequest(endpoints, req, &vspInfoResponse)
+	} else {
+		_, err = utils.HTTPRequest(req, &vspInfoResponse)
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -12,6 +12,7 @@ import (
 	"github.com/crypto-power/cryptopower/libwallet/internal/loader/dcr"
 	"github.com/crypto-power/cryptopower/libwallet/internal/vsp"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
 	"github.com/decred/dcrd/chaincfg/v3"
 )
 
@@ -25,10 +26,15 @@ type Asset struct {
 	chainParams *chaincfg.Params
 
 	cancelAccountMixer      context.CancelFunc `json:"-"`
+	cancelAccountMixerMu    sync.RWMutex
+	accountMixerSession     int
+	mixerStatsMu            sync.RWMutex
+	mixerStats              *MixerStats
 	cancelAutoTicketBuyer   context.CancelFunc `json:"-"`
 	cancelAutoTicketBuyerMu sync.RWMutex
 
 	TxAuthoredInfo *TxAuthor
+	fees           feeRateConfig
 
 	vspClientsMu sync.Mutex
 	vspClients   map[string]*vsp.Client
@@ -40,6 +46,25 @@ type Asset struct {
 	accountMixerNotificationListener map[string]AccountMixerNotificationListener
 	txAndBlockNotificationListeners  map[string]sharedW.TxAndBlockNotificationListener
 	blocksRescanProgressListener     sharedW.BlocksRescanProgressListener
+
+	// dcrdRPCPass is the decrypted password of the trusted dcrd node. It is
+	// only held in memory.
+	dcrdRPCPassMu sync.RWMutex
+	dcrdRPCPass   string
+
+	// chainBackend holds the address manager shared with the other wallets
+	// of the asset.
+	chainBackend *ChainBackend
+
+	// agendaPrefs manages the agenda choices saved for the tickets.
+	agendaPrefs ticketAgendaPrefs
+}
+
+// ticketAgendaPrefs is implemented by the loader to manage the agenda choices
+// saved for the tickets, which the wallet has no way to delete.
+type ticketAgendaPrefs interface {
+	TicketHasAgendaChoices(ctx context.Context, ticketHash *chainhash.Hash) (bool, error)
+	DeleteTicketAgendaChoices(ctx context.Context, ticketHash *chainhash.Hash) error
 }
 
 // Verify that DCR implements the shared assets interface.
@@ -113,8 +138,10 @@ func CreateNewWallet(pass *sharedW.AuthInfo, params *sharedW.InitParams) (shared
 	}
 
 	dcrWallet := &Asset{
-		Wallet:      w,
-		chainParams: chainParams,
+		Wallet:       w,
+		chainParams:  chainParams,
+		agendaPrefs:  ldr.(ticketAgendaPrefs),
+		chainBackend: sharedChainBackend(params),
 		syncData: &SyncData{
 			syncProgressListeners: make(map[string]sharedW.SyncProgressListener),
 		},
@@ -150,8 +177,10 @@ func CreateWatchOnlyWallet(walletName, extendedPublicKey string, params *sharedW
 	}
 
 	dcrWallet := &Asset{
-		Wallet:      w,
-		chainParams: chainParams,
+		Wallet:       w,
+		chainParams:  chainParams,
+		agendaPrefs:  ldr.(ticketAgendaPrefs),
+		chainBackend: sharedChainBackend(params),
 		syncData: &SyncData{
 			syncProgressListeners: make(map[string]sharedW.SyncProgressListener),
 		},
@@ -183,8 +212,10 @@ func RestoreWallet(seedMnemonic string, pass *sharedW.AuthInfo, params *sharedW.
 	}
 
 	dcrWallet := &Asset{
-		Wallet:      w,
-		chainParams: chainParams,
+		Wallet:       w,
+		chainParams:  chainParams,
+		agendaPrefs:  ldr.(ticketAgendaPrefs),
+		chainBackend: sharedChainBackend(params),
 		syncData: &SyncData{
 			syncProgressListeners: make(map[string]sharedW.SyncProgressListener),
 		},
@@ -211,11 +242,18 @@ func LoadExisting(w *sharedW.Wallet, params *sharedW.InitParams) (sharedW.Asset,
 		return nil, err
 	}
 
-	ldr := initWalletLoader(chainParams, params.RootDir, params.DbDriver)
+	dbDriver := w.DbDriver
+	if dbDriver == "" {
+		dbDriver = params.DbDriver
+	}
+
+	ldr := initWalletLoader(chainParams, params.RootDir, dbDriver)
 	dcrWallet := &Asset{
-		Wallet:      w,
-		vspClients:  make(map[string]*vsp.Client),
-		chainParams: chainParams,
+		Wallet:       w,
+		vspClients:   make(map[string]*vsp.Client),
+		chainParams:  chainParams,
+		agendaPrefs:  ldr.(ticketAgendaPrefs),
+		chainBackend: sharedChainBackend(params),
 		syncData: &SyncData{
 			syncProgressListeners: make(map[string]sharedW.SyncProgressListener),
 		},
@@ -169,12 +169,32 @@ func (asset *Asset) UnspentOutputs(account int32) ([]*sharedW.UnspentOutput, err
 			Confirmations: int32(utxo.Confirmations),
 			Spendable:     utxo.Spendable,
 			ReceiveTime:   time.Unix(txInfo.Timestamp, 0),
+			Origin:        asset.utxoOrigin(utxo.Address),
 		})
 	}
 
+	if err := asset.ApplyUTXOMetadata(resp); err != nil {
+		return nil, err
+	}
+
 	return resp, nil
 }
 
+// utxoOrigin returns the origin of funds paid to the address, outputs paid to
+// internal addresses are change.
+func (asset *Asset) utxoOrigin(address string) sharedW.UTXOOrigin {
+	addr, err := ltcutil.DecodeAddress(address, asset.chainParams)
+	if err != nil {
+		return sharedW.UTXOOriginUnknown
+	}
+
+	info, err := asset.Internal().LTC.AddressInfo(addr)
+	if err != nil || !info.Internal() {
+		return sharedW.UTXOOriginUnknown
+	}
+	return sharedW.UTXOOriginChange
+}
+
 // CreateNewAccount creates a new account with the provided account name.
 func (asset *Asset) CreateNewAccount(accountName, privPass string) (int32, error) {
 	err := asset.UnlockWallet(privPass)
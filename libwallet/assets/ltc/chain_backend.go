@@ -0,0 +1,298 @@
+package ltc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/assets/wallet/walletdata"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	neutrino "github.com/dcrlabs/neutrino-ltc"
+	"go.etcd.io/bbolt"
+)
+
+// Verify that ChainBackend implements the shared chain backend interface.
+var _ sharedW.ChainBackend = (*ChainBackend)(nil)
+
+// bannedPeersBucket holds the peers the user banned from the shared chain
+// service, keyed by host.
+var bannedPeersBucket = []byte("banned-peers")
+
+// ChainBackend shares a single neutrino chain service between all the BTC
+// wallets. Block headers and cfilters are downloaded and stored once, a single
+// peer set is maintained and each wallet runs its own rescans and receives its
+// own notifications through its chain client.
+type ChainBackend struct {
+	dataDir string
+
+	mu          sync.Mutex
+	db          *walletdata.LTCDB
+	cs          *neutrino.ChainService
+	dialerCtx   context.Context
+	cancelDial  context.CancelFunc
+	subscribers map[int]*chainSubscriber
+
+	// limiter enforces on the chain service the strictest sync limits of the
+	// subscribed wallets.
+	limiter *utils.NetLimiter
+
+	// meters record the data usage of the subscribed wallets. They are
+	// guarded by metersMu rather than mu as they are called from the
+	// connections of the chain service which may be closed while mu is held.
+	metersMu sync.RWMutex
+	meters   []func(sent, received int64)
+}
+
+// chainSubscriber is a wallet using the shared chain service.
+type chainSubscriber struct {
+	limits    *sharedW.SyncLimits
+	onTraffic func(sent, received int64)
+}
+
+// NewChainBackend creates a chain backend that stores its data in dataDir.
+// The chain service is only started once a wallet needs it.
+func NewChainBackend(dataDir string) *ChainBackend {
+	return &ChainBackend{
+		dataDir:     dataDir,
+		subscribers: make(map[int]*chainSubscriber),
+	}
+}
+
+// acquire returns the shared chain service, creating it if no other wallet is
+// using it. cfg is used to create the chain service, its DataDir, Database
+// and Dialer fields are overridden.
+//
+// A single limit applies to the chain service: the strictest peers and
+// bandwidth limits of the wallets using it. Its traffic is shared evenly
+// between the data usage of the wallets using it.
+func (b *ChainBackend) acquire(walletID int, cfg neutrino.Config, limits *sharedW.SyncLimits, onTraffic func(sent, received int64)) (*neutrino.ChainService, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.cs == nil {
+		if err := b.openDB(); err != nil {
+			return nil, err
+		}
+
+		b.dialerCtx, b.cancelDial = context.WithCancel(context.Background())
+		b.limiter = utils.NewNetLimiter(0, 0, b.recordTraffic)
+		cfg.DataDir = b.dataDir
+		cfg.Database = b.db
+		cfg.Dialer = b.limiter.Dialer(b.dialerCtx)
+
+		cs, err := neutrino.NewChainService(cfg)
+		if err != nil {
+			b.cancelDial()
+			return nil, fmt.Errorf("couldn't create shared Neutrino ChainService: %v", err)
+		}
+		b.cs = cs
+		log.Info("Shared LTC chain service created")
+	}
+
+	b.subscribers[walletID] = &chainSubscriber{limits: limits, onTraffic: onTraffic}
+	b.subscribersChanged()
+	return b.cs, nil
+}
+
+// Release removes the wallet from the chain service subscribers. The chain
+// service is stopped once no wallet uses it anymore. Releasing a wallet that
+// is not subscribed does nothing.
+func (b *ChainBackend) Release(walletID int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[walletID]; !ok {
+		return
+	}
+
+	delete(b.subscribers, walletID)
+	b.subscribersChanged()
+	if len(b.subscribers) > 0 || b.cs == nil {
+		return
+	}
+
+	b.cancelDial()
+	if err := b.cs.Stop(); err != nil {
+		log.Errorf("Stopping shared chain service failed: %v", err)
+	}
+	b.cs = nil
+	log.Info("Shared LTC chain service stopped")
+}
+
+// subscribersChanged applies the strictest sync limits of the subscribed
+// wallets to the chain service and updates the data usage meters. It must be
+// called with mu held.
+func (b *ChainBackend) subscribersChanged() {
+	var maxPeers, bandwidthKBps int32
+	meters := make([]func(sent, received int64), 0, len(b.subscribers))
+	for _, sub := range b.subscribers {
+		maxPeers = strictestLimit(maxPeers, sub.limits.MaxPeers)
+		bandwidthKBps = strictestLimit(bandwidthKBps, sub.limits.BandwidthKBps)
+		if sub.onTraffic != nil {
+			meters = append(meters, sub.onTraffic)
+		}
+	}
+
+	if b.limiter != nil {
+		b.limiter.SetLimits(int(maxPeers), int64(bandwidthKBps)*1000)
+	}
+
+	b.metersMu.Lock()
+	b.meters = meters
+	b.metersMu.Unlock()
+}
+
+// strictestLimit returns the lowest of two limits, a limit of zero is not
+// enforced.
+func strictestLimit(a, b int32) int32 {
+	if a <= 0 || (b > 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// recordTraffic shares the traffic of the chain service evenly between the
+// data usage of the subscribed wallets.
+func (b *ChainBackend) recordTraffic(sent, received int64) {
+	b.metersMu.RLock()
+	defer b.metersMu.RUnlock()
+
+	n := int64(len(b.meters))
+	for i, meter := range b.meters {
+		share := func(bytes int64) int64 {
+			// The first wallet gets the bytes that can't be shared evenly.
+			if i == 0 {
+				return bytes/n + bytes%n
+			}
+			return bytes / n
+		}
+		meter(share(sent), share(received))
+	}
+}
+
+// BannedPeers returns the peers banned from the shared chain service.
+func (b *ChainBackend) BannedPeers() ([]*sharedW.BannedPeer, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.openDB(); err != nil {
+		return nil, err
+	}
+
+	var peers []*sharedW.BannedPeer
+	err := b.db.Bolt.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(bannedPeersBucket)
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(_, v []byte) error {
+			peer := new(sharedW.BannedPeer)
+			if err := json.Unmarshal(v, peer); err != nil {
+				return err
+			}
+			peers = append(peers, peer)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].BannedAt < peers[j].BannedAt
+	})
+	return peers, nil
+}
+
+// SetPeerBan bans or unbans host from the shared chain service for all the
+// wallets using it. Neutrino rejects the banned peers when connecting, the
+// peers already connected from host are disconnected.
+func (b *ChainBackend) SetPeerBan(host string, banned bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.openDB(); err != nil {
+		return err
+	}
+	if err := setPeerBan(b.db, host, banned); err != nil {
+		return err
+	}
+
+	err := b.db.Bolt.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(bannedPeersBucket)
+		if err != nil {
+			return err
+		}
+		if !banned {
+			return bucket.Delete([]byte(host))
+		}
+
+		v, err := json.Marshal(&sharedW.BannedPeer{Host: host, BannedAt: time.Now().Unix()})
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(host), v)
+	})
+	if err != nil {
+		return err
+	}
+
+	if banned && b.cs != nil {
+		disconnectPeersFrom(b.cs, host)
+	}
+	return nil
+}
+
+// openDB opens the database holding the shared headers and cfilters.
+func (b *ChainBackend) openDB() error {
+	if b.db != nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(b.dataDir, utils.UserFilePerm); err != nil {
+		return err
+	}
+
+	db, err := bbolt.Open(filepath.Join(b.dataDir, walletdata.LTCDBName), 0600,
+		&bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return fmt.Errorf("opening shared chain database failed: %v", err)
+	}
+	b.db = &walletdata.LTCDB{Bolt: db}
+	return nil
+}
+
+// Close stops the chain service and closes the database. It must only be
+// called once all the wallets have shut down.
+func (b *ChainBackend) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.cs != nil {
+		b.cancelDial()
+		if err := b.cs.Stop(); err != nil {
+			log.Errorf("Stopping shared chain service failed: %v", err)
+		}
+		b.cs = nil
+	}
+
+	if b.db == nil {
+		return nil
+	}
+	err := b.db.Close()
+	b.db = nil
+	return err
+}
+
+// sharedChainBackend returns the LTC chain backend provided by the assets
+// manager, if any.
+func sharedChainBackend(params *sharedW.InitParams) *ChainBackend {
+	backend, _ := params.ChainBackends[utils.LTCWalletAsset].(*ChainBackend)
+	return backend
+}
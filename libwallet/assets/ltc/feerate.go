@@ -2,24 +2,27 @@ package ltc
 
 import (
 	"fmt"
-	"net/http"
-	"sort"
-	"strconv"
 	"sync"
 
 	"decred.org/dcrwallet/v3/errors"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/ltcsuite/ltcd/blockchain"
+	"github.com/ltcsuite/ltcd/chaincfg"
+	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
 	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcwallet/wallet/txsizes"
 )
 
-// TODO: Update this file for Litecoin
-
 const (
 	// MainnetAPIFeeRateURL defines the URL to fetch the mainnet fee rate from.
-	MainnetAPIFeeRateURL = "https://blockstream.info/api/fee-estimates" // TODO: Update for Litecoin
+	MainnetAPIFeeRateURL = "https://litecoinspace.org/api/v1/fees/recommended"
 	// TestnetAPIFeeRateURL defines the URL to fetch the testnet fee rate from.
-	TestnetAPIFeeRateURL = "https://blockstream.info/testnet/api/fee-estimates" // TODO: Update for Litecoin
+	TestnetAPIFeeRateURL = "https://litecoinspace.org/testnet/api/v1/fees/recommended"
+
+	// localFeeEstimatorBlocks is the number of recent blocks the local fee
+	// estimator derives the fee rates from.
+	localFeeEstimatorBlocks = 6
 
 	// Since the introduction of segwit account, a different tx size measument was
 	// introduced (Lit/VB). When sending a transaction from the legacy account,
@@ -42,6 +45,16 @@ const (
 
 	// MinFeeRatePerkvB defines the minimum fee rate a user can set on a tx.
 	MinFeeRatePerkvB ltcutil.Amount = 1000 // Equals to 1 lit/vB.
+
+	// MaxConsolidationFeeRatePerkvB defines the highest fee rate at which the
+	// consolidation coin selection strategy spends all the available utxos.
+	// Fee rate in lit/kvB => 5,000 lit/kvB = 5 lit/vB.
+	MaxConsolidationFeeRatePerkvB ltcutil.Amount = 5 * 1000
+
+	// changeInputVirtualSize is the virtual size of the input that later spends
+	// a P2WPKH change output.
+	changeInputVirtualSize = txsizes.RedeemP2WPKHInputSize +
+		(txsizes.RedeemP2WPKHInputWitnessWeight+3)/4
 )
 
 // feeEstimateCache helps to cache the resolved fee rate until a new
@@ -60,53 +73,140 @@ type feeEstimateCache struct {
 	mu sync.RWMutex
 }
 
-// fetchAPIFeeRate queries the API fee rate.
-func (asset *Asset) fetchAPIFeeRate() ([]sharedW.FeeEstimate, error) {
-	var feerateURL string
-	net := asset.NetType()
-	switch net {
+// feeEstimators returns the fee estimators available for the wallet's
+// network sorted by the user's fallback order.
+func (asset *Asset) feeEstimators() ([]sharedW.FeeEstimator, error) {
+	estimators := make([]sharedW.FeeEstimator, 0, 3)
+	switch net := asset.NetType(); net {
 	case utils.Mainnet:
-		feerateURL = MainnetAPIFeeRateURL
+		estimators = append(estimators,
+			sharedW.NewMempoolFeeEstimator(sharedW.FeeEstimatorMempoolSpace, MainnetAPIFeeRateURL, asset.ToAmount))
 	case utils.Testnet:
-		feerateURL = TestnetAPIFeeRateURL
+		estimators = append(estimators,
+			sharedW.NewMempoolFeeEstimator(sharedW.FeeEstimatorMempoolSpace, TestnetAPIFeeRateURL, asset.ToAmount))
+	case utils.Simulation, utils.Regression:
+		// Local networks have no fee rate API, the fee rate is estimated
+		// from the recent blocks.
 	default:
 		return nil, fmt.Errorf("%v network is not supported", net)
 	}
 
-	resp := make(map[string]float64, 0)
+	if feeURL := asset.FeeEstimatorURL(); feeURL != "" {
+		estimators = append(estimators, sharedW.NewEsploraFeeEstimator(sharedW.FeeEstimatorCustom, feeURL, asset.ToAmount))
+	}
+
+	estimators = append(estimators, sharedW.NewLocalFeeEstimator(asset.recentBlockFeeRates,
+		int64(MinFeeRatePerkvB), asset.ToAmount))
 
-	req := &utils.ReqConfig{
-		Method:  http.MethodGet,
-		HTTPURL: feerateURL,
+	estimators = asset.OrderFeeEstimators(estimators)
+	if asset.electrumClient != nil {
+		// The Electrum server the wallet syncs through is tried first.
+		electrumEstimator := sharedW.NewElectrumFeeEstimator(asset.electrumClient.EstimateFee, asset.ToAmount)
+		estimators = append([]sharedW.FeeEstimator{electrumEstimator}, estimators...)
 	}
+	return estimators, nil
+}
 
-	if _, err := utils.HTTPRequest(req, &resp); err != nil {
-		return nil, fmt.Errorf("fetching API fee estimates failed: %v", err)
+// fetchAPIFeeRate queries the fee estimators in the fallback order. Remote
+// estimators are skipped if the FeeRateHTTPAPI privacy setting is on.
+func (asset *Asset) fetchAPIFeeRate() ([]sharedW.FeeEstimate, error) {
+	estimators, err := asset.feeEstimators()
+	if err != nil {
+		return nil, err
 	}
 
-	// if no data was returned, return an error.
-	if len(resp) == 0 {
-		return nil, errors.New("API fee estimates not found")
+	feerates, source, err := sharedW.EstimateFeesWithFallback(estimators,
+		asset.IsHTTPAPIAllowed(utils.FeeRateHTTPAPI))
+	if err != nil {
+		return nil, fmt.Errorf("fetching fee estimates failed: %v", err)
 	}
 
-	results := make([]sharedW.FeeEstimate, 0, len(resp))
+	log.Debugf("Fee estimates for wallet %d provided by the %s estimator", asset.ID, source)
+	return feerates, nil
+}
 
-	// Fee rate returned is in lit/vB units.
-	for blocks, feerate := range resp {
-		vals, err := strconv.ParseInt(blocks, 10, 64)
-		if err != nil {
-			// Invalid blocks confirmation found ignore it,
+// recentBlockFeeRates returns the fee rates in lit/kvB paid by the
+// transactions of the most recent blocks. The blocks are cached, only the
+// blocks mined since the last call are downloaded.
+func (asset *Asset) recentBlockFeeRates() ([]int64, error) {
+	if !asset.IsConnectedToNetwork() || asset.electrumClient != nil {
+		// Electrum servers don't serve the blocks.
+		return nil, errors.New(utils.ErrNotConnected)
+	}
+
+	cs := asset.chainClient.CS
+	best, err := cs.BestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, 0, localFeeEstimatorBlocks)
+	heights := make(map[string]int32, localFeeEstimatorBlocks)
+	for height := best.Height - localFeeEstimatorBlocks + 1; height <= best.Height; height++ {
+		if height <= 0 {
 			continue
 		}
+		hash, err := cs.GetBlockHash(int64(height))
+		if err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, hash.String())
+		heights[hash.String()] = height
+	}
 
-		results = append(results, sharedW.FeeEstimate{
-			ConfirmedBlocks: int32(vals),
-			// Fee rate conversion from lit/vB to lit/kvB is at the rate of
-			// 1000 lit/kvB == 1 lit/vB
-			Feerate: Amount(int(feerate * 1000.0)),
-		})
+	return asset.blockFeeRates.Rates(hashes, func(hashStr string) (*sharedW.FeeRateBlock, error) {
+		hash, err := chainhash.NewHashFromStr(hashStr)
+		if err != nil {
+			return nil, err
+		}
+		block, err := cs.GetBlock(*hash)
+		if err != nil {
+			return nil, err
+		}
+		return feeRateBlock(block, heights[hashStr], asset.chainParams), nil
+	})
+}
+
+// feeRateBlock reduces the block to the data needed to compute its fee rates.
+// The block's average fee rate is derived from its coinbase value.
+func feeRateBlock(block *ltcutil.Block, height int32, params *chaincfg.Params) *sharedW.FeeRateBlock {
+	txs := block.Transactions()
+	feeBlock := &sharedW.FeeRateBlock{Txs: make([]*sharedW.FeeRateTx, 0, len(txs))}
+	if len(txs) < 2 {
+		return feeBlock
+	}
+
+	for _, tx := range txs[1:] {
+		msgTx := tx.MsgTx()
+		feeTx := &sharedW.FeeRateTx{
+			Hash:    tx.Hash().String(),
+			Inputs:  make([]sharedW.FeeRateOutPoint, 0, len(msgTx.TxIn)),
+			Outputs: make([]int64, 0, len(msgTx.TxOut)),
+			VSize:   (blockchain.GetTransactionWeight(tx) + blockchain.WitnessScaleFactor - 1) / blockchain.WitnessScaleFactor,
+		}
+		for _, txIn := range msgTx.TxIn {
+			feeTx.Inputs = append(feeTx.Inputs, sharedW.FeeRateOutPoint{
+				TxHash: txIn.PreviousOutPoint.Hash.String(),
+				Index:  txIn.PreviousOutPoint.Index,
+			})
+		}
+		for _, txOut := range msgTx.TxOut {
+			feeTx.Outputs = append(feeTx.Outputs, txOut.Value)
+		}
+		feeBlock.Txs = append(feeBlock.Txs, feeTx)
+	}
+
+	var coinbaseValue int64
+	for _, txOut := range txs[0].MsgTx().TxOut {
+		coinbaseValue += txOut.Value
+	}
+
+	fees := coinbaseValue - blockchain.CalcBlockSubsidy(height, params)
+	vsize := (blockchain.GetBlockWeight(block) + blockchain.WitnessScaleFactor - 1) / blockchain.WitnessScaleFactor
+	if fees > 0 && vsize > 0 {
+		feeBlock.AverageFeeRate = fees * 1000 / vsize
 	}
-	return results, nil
+	return feeBlock
 }
 
 // GetAPIFeeEstimateRate returns the fee estimates from the API.
@@ -126,15 +226,6 @@ func (asset *Asset) GetAPIFeeEstimateRate() (feerates []sharedW.FeeEstimate, err
 		return nil, err
 	}
 
-	// Do not cache empty results.
-	if len(feerates) == 0 {
-		return nil, errors.New("API feerates not available")
-	}
-
-	sort.Slice(feerates, func(i, j int) bool {
-		return feerates[i].ConfirmedBlocks < feerates[j].ConfirmedBlocks
-	})
-
 	if len(feerates) > 5 {
 		// TODO: subject to confirmation! => persist top five fee rates only.
 		feerates = feerates[:5]
@@ -174,3 +265,23 @@ func (asset *Asset) GetUserFeeRate() sharedW.AssetAmount {
 	}
 	return asset.fees.SetFeeRatePerkvB
 }
+
+// FeeTargets returns the low, medium and high priority fee rates derived from
+// the API fee estimates. The total fee of each target is computed for the
+// transaction currently being authored, if any.
+func (asset *Asset) FeeTargets() ([]*sharedW.FeeTarget, error) {
+	estimates, err := asset.GetAPIFeeEstimateRate()
+	if err != nil {
+		return nil, err
+	}
+
+	var txSize int
+	if asset.TxAuthoredInfo != nil {
+		if feeAndSize, err := asset.EstimateFeeAndSize(); err == nil {
+			txSize = feeAndSize.EstimatedSignedSize
+		}
+	}
+
+	return sharedW.FeeTargetsFromEstimates(estimates, asset.TargetTimePerBlockMinutes(),
+		txSize, asset.ToAmount), nil
+}
@@ -0,0 +1,236 @@
+package ltc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	neutrino "github.com/dcrlabs/neutrino-ltc"
+	"github.com/dcrlabs/neutrino-ltc/banman"
+	"github.com/ltcsuite/ltcwallet/walletdb"
+)
+
+const (
+	// peerBannedByUser is the reason recorded in the neutrino ban store for
+	// the peers banned by the user.
+	peerBannedByUser banman.Reason = 100
+
+	// userBanDuration is the duration of the bans set by the user, they last
+	// until they are lifted.
+	userBanDuration = 100 * 365 * 24 * time.Hour
+)
+
+// PeerInfoRaw returns the peers the wallet is connected to. The Electrum
+// server is reported as the single peer if the wallet syncs through one.
+func (asset *Asset) PeerInfoRaw() ([]sharedW.PeerInfo, error) {
+	if !asset.IsConnectedToNetwork() {
+		return nil, errors.New(utils.ErrNotConnected)
+	}
+
+	if asset.electrumClient != nil {
+		if !asset.electrumClient.IsCurrent() {
+			return []sharedW.PeerInfo{}, nil
+		}
+		return []sharedW.PeerInfo{{Addr: asset.electrumClient.Addr(), SubVer: ElectrumBackend}}, nil
+	}
+
+	peers := asset.chainClient.CS.Peers()
+	infos := make([]sharedW.PeerInfo, 0, len(peers))
+	for _, sp := range peers {
+		stats := sp.StatsSnapshot()
+		info := sharedW.PeerInfo{
+			ID:             stats.ID,
+			Addr:           stats.Addr,
+			Services:       fmt.Sprintf("%08d", uint64(stats.Services)),
+			Version:        stats.Version,
+			SubVer:         stats.UserAgent,
+			StartingHeight: int64(stats.StartingHeight),
+			PingTime:       stats.LastPingMicros / 1000,
+			BytesSent:      stats.BytesSent,
+			BytesReceived:  stats.BytesRecv,
+			ConnTime:       stats.ConnTime.Unix(),
+		}
+		if localAddr := sp.LocalAddr(); localAddr != nil {
+			info.AddrLocal = localAddr.String()
+		}
+
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+
+	return infos, nil
+}
+
+// ConnectPeer adds addr to the persistent peers and reloads the chain service
+// so that the wallet connects to it.
+func (asset *Asset) ConnectPeer(addr string) error {
+	if asset.electrumClient != nil {
+		return errors.New(utils.ErrUnavailable)
+	}
+
+	peerAddress, err := utils.NormalizeAddress(addr, asset.chainParams.DefaultPort)
+	if err != nil {
+		return errors.New(utils.ErrInvalidAddress)
+	}
+	if asset.IsPeerBanned(peerAddress) {
+		return errors.New(utils.ErrPeerBanned)
+	}
+
+	asset.AddPersistentPeer(peerAddress)
+	go func() {
+		err := asset.reloadChainService()
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+	return nil
+}
+
+// DisconnectPeer drops the connection to the peer at addr. Neutrino may
+// connect to the peer again, ban it to prevent that.
+func (asset *Asset) DisconnectPeer(addr string) error {
+	if asset.electrumClient != nil {
+		return errors.New(utils.ErrUnavailable)
+	}
+	if !asset.IsConnectedToNetwork() {
+		return errors.New(utils.ErrNotConnected)
+	}
+
+	sp := asset.chainClient.CS.PeerByAddr(addr)
+	if sp == nil {
+		return errors.New(utils.ErrNotExist)
+	}
+	log.Infof("Disconnecting peer %s", addr)
+	sp.Disconnect()
+	return nil
+}
+
+// sharesChainService returns true if the wallet syncs through the chain
+// service shared with the other wallets of the asset. Wallets set to connect
+// to persistent peers use a private chain service.
+func (asset *Asset) sharesChainService() bool {
+	return asset.chainBackend != nil && len(asset.PersistentPeers(asset.chainParams.DefaultPort)) == 0
+}
+
+// BannedPeers returns the peers banned from the chain service the wallet syncs
+// through. The bans of the shared chain service apply to all the wallets
+// using it.
+func (asset *Asset) BannedPeers() []*sharedW.BannedPeer {
+	if !asset.sharesChainService() {
+		return asset.Wallet.BannedPeers()
+	}
+
+	peers, err := asset.chainBackend.BannedPeers()
+	if err != nil {
+		log.Errorf("Reading the banned peers failed: %v", err)
+		return nil
+	}
+	return peers
+}
+
+// IsPeerBanned returns true if the host of addr is banned from the chain
+// service the wallet syncs through.
+func (asset *Asset) IsPeerBanned(addr string) bool {
+	host := sharedW.PeerHost(addr)
+	for _, peer := range asset.BannedPeers() {
+		if peer.Host == host {
+			return true
+		}
+	}
+	return false
+}
+
+// BanPeer bans the host of addr from the chain service the wallet syncs
+// through and disconnects the peers connected from it. Neutrino rejects the
+// banned peers when connecting.
+func (asset *Asset) BanPeer(addr string) error {
+	host := sharedW.PeerHost(addr)
+	if _, err := banman.ParseIPNet(host, nil); err != nil {
+		return errors.New(utils.ErrInvalidAddress)
+	}
+	if asset.IsPeerBanned(host) {
+		return errors.New(utils.ErrExist)
+	}
+
+	if asset.sharesChainService() {
+		return asset.chainBackend.SetPeerBan(host, true)
+	}
+
+	if err := setPeerBan(asset.GetWalletDataDb().LTC, host, true); err != nil {
+		return err
+	}
+	if err := asset.SaveBannedPeer(host); err != nil {
+		return err
+	}
+	if asset.electrumClient == nil && !asset.usingSharedChain && asset.IsConnectedToNetwork() {
+		disconnectPeersFrom(asset.chainClient.CS, host)
+	}
+	return nil
+}
+
+// UnbanPeer lifts the ban of the host of addr from the chain service the
+// wallet syncs through.
+func (asset *Asset) UnbanPeer(addr string) error {
+	host := sharedW.PeerHost(addr)
+	if asset.sharesChainService() {
+		return asset.chainBackend.SetPeerBan(host, false)
+	}
+
+	if err := asset.DeleteBannedPeer(host); err != nil {
+		return err
+	}
+	return setPeerBan(asset.GetWalletDataDb().LTC, host, false)
+}
+
+// applyPeerBans records the peers banned from the wallet's private chain
+// service in the neutrino ban store of the wallet database.
+func (asset *Asset) applyPeerBans() {
+	for _, peer := range asset.Wallet.BannedPeers() {
+		if err := setPeerBan(asset.GetWalletDataDb().LTC, peer.Host, true); err != nil {
+			log.Errorf("Banning peer %s failed: %v", peer.Host, err)
+		}
+	}
+}
+
+// setPeerBan bans or unbans host in the neutrino ban store of db. Neutrino
+// bans expire, the bans set by the user last until they are lifted.
+func setPeerBan(db walletdb.DB, host string, banned bool) error {
+	ipNet, err := banman.ParseIPNet(host, nil)
+	if err != nil {
+		return errors.New(utils.ErrInvalidAddress)
+	}
+
+	store, err := banman.NewStore(db)
+	if err != nil {
+		return err
+	}
+
+	duration := userBanDuration
+	if !banned {
+		duration = 0
+	}
+	if err := store.BanIPNet(ipNet, peerBannedByUser, duration); err != nil {
+		return err
+	}
+	if !banned {
+		// Reading the status of an expired ban removes it.
+		_, err = store.Status(ipNet)
+	}
+	return err
+}
+
+// disconnectPeersFrom disconnects the peers of cs connected from host.
+func disconnectPeersFrom(cs *neutrino.ChainService, host string) {
+	for _, sp := range cs.Peers() {
+		if sharedW.PeerHost(sp.Addr()) == host {
+			log.Infof("Disconnecting banned peer %s", sp.Addr())
+			sp.Disconnect()
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package ltc
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	neutrino "github.com/dcrlabs/neutrino-ltc"
+	"github.com/ltcsuite/ltcd/wire"
+	"github.com/ltcsuite/ltcwallet/waddrmgr"
+)
+
+const (
+	// cfiltersPrefetchWorkers is the number of cfilters requested from the
+	// peers concurrently while prefetching them.
+	cfiltersPrefetchWorkers = 16
+
+	// cfiltersPrefetchReportInterval is the number of cfilters fetched
+	// between two progress reports.
+	cfiltersPrefetchReportInterval = 1000
+)
+
+// restoreStartBlock returns the block from which address discovery begins on
+// the first sync of a restored wallet. It is the block at the height estimated
+// from the birthday provided on restore if its header is synced, the highest
+// checkpoint at or below that height otherwise, or the genesis block if no
+// birthday was provided.
+func (asset *Asset) restoreStartBlock() waddrmgr.BlockStamp {
+	genesis := asset.chainParams.GenesisBlock
+	bs := waddrmgr.BlockStamp{
+		Height:    0,
+		Hash:      genesis.BlockHash(),
+		Timestamp: genesis.Header.Timestamp,
+	}
+
+	height := asset.restoreBirthdayHeight()
+	if height == 0 {
+		return bs
+	}
+
+	if asset.electrumClient == nil && asset.chainClient != nil && asset.chainClient.CS != nil {
+		cs := asset.chainClient.CS
+		if hash, err := cs.GetBlockHash(int64(height)); err == nil {
+			if header, err := cs.GetBlockHeader(hash); err == nil {
+				return waddrmgr.BlockStamp{
+					Height:    height,
+					Hash:      *hash,
+					Timestamp: header.Timestamp,
+				}
+			}
+		}
+	}
+
+	for _, checkpoint := range asset.chainParams.Checkpoints {
+		if checkpoint.Height > height {
+			break
+		}
+		// The checkpoint header may not be synced yet, the timestamp is
+		// estimated. It is only used to set the wallet birthday which is
+		// updated once the initial sync completes.
+		bs = waddrmgr.BlockStamp{
+			Height:    checkpoint.Height,
+			Hash:      *checkpoint.Hash,
+			Timestamp: genesis.Header.Timestamp.Add(time.Duration(checkpoint.Height) * asset.chainParams.TargetTimePerBlock),
+		}
+	}
+	return bs
+}
+
+// restoreBirthdayHeight returns the height estimated from the birthday
+// provided on restore, 0 if none was provided.
+func (asset *Asset) restoreBirthdayHeight() int32 {
+	birthday := asset.RestoreBirthday()
+	if birthday == nil {
+		return 0
+	}
+	genesis := asset.chainParams.GenesisBlock
+	return birthday.EstimatedHeight(genesis.Header.Timestamp, asset.chainParams.TargetTimePerBlock)
+}
+
+// waitForBirthdayHeaders starts the chain service and waits until the block
+// headers are synced up to the birthday height, so that address discovery
+// begins at the birthday block rather than at an earlier checkpoint.
+func (asset *Asset) waitForBirthdayHeaders(ctx context.Context, cs *neutrino.ChainService) {
+	height := asset.restoreBirthdayHeight()
+	if height == 0 {
+		return
+	}
+
+	if err := cs.Start(); err != nil {
+		log.Errorf("Failed to start the chain service: %v", err)
+		return
+	}
+
+	log.Infof("Waiting for the block headers up to the birthday block %d", height)
+	t := time.NewTicker(time.Second * 5)
+	defer t.Stop()
+
+	for {
+		if best, err := cs.BestBlock(); err == nil && best.Height >= height {
+			return
+		}
+		// The birthday may be past the chain tip.
+		if cs.IsCurrent() {
+			return
+		}
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// prefetchCFilters fetches the cfilters of the blocks from startHeight to the
+// chain tip concurrently once the block headers are synced. The cfilters are
+// persisted by the chain service so the address discovery that scans the
+// blocks one after the other reads them from disk instead of waiting for a
+// peer response for each block.
+func (asset *Asset) prefetchCFilters(ctx context.Context, cs *neutrino.ChainService, startHeight int32) {
+	t := time.NewTicker(time.Second * 5)
+	defer t.Stop()
+
+	for !cs.IsCurrent() {
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	best, err := cs.BestBlock()
+	if err != nil {
+		log.Errorf("Failed to get the best block to prefetch cfilters: %v", err)
+		return
+	}
+	if best.Height <= startHeight {
+		return
+	}
+
+	total := best.Height - startHeight
+	beginTime := time.Now()
+	log.Infof("Prefetching %d cfilters from block %d", total, startHeight+1)
+
+	asset.syncData.mu.Lock()
+	asset.syncData.cfiltersFetchProgress.BeginFetchCFiltersTimeStamp = beginTime.Unix()
+	asset.syncData.cfiltersFetchProgress.StartCFiltersHeight = startHeight
+	asset.syncData.cfiltersFetchProgress.TotalCFiltersToFetch = total
+	asset.syncData.cfiltersFetchProgress.TotalFetchedCFiltersCount = 0
+	asset.syncData.cfiltersFetchProgress.CFiltersFetchProgress = 0
+	asset.syncData.mu.Unlock()
+
+	heights := make(chan int32)
+	var fetched int32
+	var wg sync.WaitGroup
+	for i := 0; i < cfiltersPrefetchWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for height := range heights {
+				hash, err := cs.GetBlockHash(int64(height))
+				if err == nil {
+					_, err = cs.GetCFilter(*hash, wire.GCSFilterRegular)
+				}
+				if err != nil {
+					// The cfilter is requested again during address discovery.
+					log.Debugf("Failed to prefetch cfilter of block %d: %v", height, err)
+				}
+
+				if n := atomic.AddInt32(&fetched, 1); n%cfiltersPrefetchReportInterval == 0 || n == total {
+					asset.updateCFiltersPrefetchProgress(n, height, beginTime)
+				}
+			}
+		}()
+	}
+
+	for height := startHeight + 1; height <= best.Height; height++ {
+		select {
+		case heights <- height:
+		case <-ctx.Done():
+			close(heights)
+			wg.Wait()
+			return
+		}
+	}
+	close(heights)
+	wg.Wait()
+
+	log.Infof("Prefetched %d cfilters in %s", total, time.Since(beginTime).Round(time.Second))
+}
+
+// updateCFiltersPrefetchProgress records the progress of the cfilters
+// prefetch and republishes the sync progress it is folded into, the prefetch
+// runs alongside the scan that reports the sync progress.
+func (asset *Asset) updateCFiltersPrefetchProgress(fetched, height int32, beginTime time.Time) {
+	asset.syncData.mu.Lock()
+	defer asset.syncData.mu.Unlock()
+
+	progress := &asset.syncData.cfiltersFetchProgress
+	total := progress.TotalCFiltersToFetch
+	timeSpent := int64(time.Since(beginTime).Seconds())
+
+	progress.TotalFetchedCFiltersCount = fetched
+	progress.CurrentCFilterHeight = height
+	progress.CfiltersFetchTimeSpent = timeSpent
+	progress.CFiltersFetchProgress = int32(int64(fetched) * 100 / int64(total))
+	progress.GeneralSyncProgress.TotalTimeRemainingSeconds = timeSpent * int64(total-fetched) / int64(fetched)
+
+	headersProgress := &asset.syncData.headersFetchProgress
+	if headersProgress.GeneralSyncProgress == nil {
+		return
+	}
+	headersProgress.GeneralSyncProgress.TotalSyncProgress, headersProgress.GeneralSyncProgress.TotalTimeRemainingSeconds = asset.withCFiltersPrefetchProgress(
+		headersProgress.HeadersFetchProgress, 0)
+
+	for _, listener := range asset.syncData.syncProgressListeners {
+		listener.OnHeadersFetchProgress(headersProgress)
+	}
+}
+
+// withCFiltersPrefetchProgress folds the progress of the cfilters prefetch, if
+// one was started, into the sync progress. The prefetch and the scan weigh
+// the same. It must be called with syncData.mu held.
+func (asset *Asset) withCFiltersPrefetchProgress(syncProgress int32, timeRemaining int64) (int32, int64) {
+	progress := &asset.syncData.cfiltersFetchProgress
+	if progress.TotalCFiltersToFetch == 0 {
+		return syncProgress, timeRemaining
+	}
+
+	if prefetchRemaining := progress.GeneralSyncProgress.TotalTimeRemainingSeconds; prefetchRemaining > timeRemaining {
+		timeRemaining = prefetchRemaining
+	}
+	return (syncProgress + progress.CFiltersFetchProgress) / 2, timeRemaining
+}
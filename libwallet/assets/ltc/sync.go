@@ -2,7 +2,6 @@ package ltc
 
 import (
 	"fmt"
-	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -43,11 +42,15 @@ type SyncData struct {
 	txlistening         uint32
 	chainServiceStopped bool
 
-	syncing            bool
-	synced             bool
-	isRescan           bool
-	rescanStartTime    time.Time
-	rescanStartHeight  *int32
+	syncing       bool
+	synced        bool
+	isRescan      bool
+	rescanTracker *sharedW.RescanTracker
+	// isPartialRescan is set while a rescan limited to some accounts or
+	// addresses runs apart from the chain client.
+	isPartialRescan bool
+	// cancelRescan stops the rescan started by RescanBlocksWithOptions.
+	cancelRescan       func()
 	isSyncShuttingDown bool
 	// forcedRescanActive is set to true if forcedRescan is activated.
 	forcedRescanActive bool
@@ -146,6 +149,13 @@ func (asset *Asset) RemoveSyncProgressListener(uniqueIdentifier string) {
 // bestServerPeerBlockHeight accesses the connected peers and requests for the
 // last synced block height.
 func (asset *Asset) bestServerPeerBlockHeight() {
+	if asset.electrumClient != nil {
+		if _, height, err := asset.electrumClient.GetBestBlock(); err == nil {
+			asset.syncData.bestBlockheight = height
+		}
+		return
+	}
+
 	serverPeers := asset.chainClient.CS.Peers()
 	for _, p := range serverPeers {
 		if p.LastBlock() > asset.syncData.bestBlockheight {
@@ -196,8 +206,9 @@ func (asset *Asset) updateSyncProgress(rawBlockHeight int32) {
 
 	asset.syncData.headersFetchProgress.TotalHeadersToFetch = asset.syncData.bestBlockheight
 	asset.syncData.headersFetchProgress.HeadersFetchProgress = int32((headersFetchedSoFar * 100) / allHeadersToFetch)
-	asset.syncData.headersFetchProgress.GeneralSyncProgress.TotalSyncProgress = asset.syncData.headersFetchProgress.HeadersFetchProgress
-	asset.syncData.headersFetchProgress.GeneralSyncProgress.TotalTimeRemainingSeconds = int64((timeSpentSoFar * remainingHeaders) / headersFetchedSoFar)
+	generalProgress := asset.syncData.headersFetchProgress.GeneralSyncProgress
+	generalProgress.TotalSyncProgress, generalProgress.TotalTimeRemainingSeconds = asset.withCFiltersPrefetchProgress(
+		asset.syncData.headersFetchProgress.HeadersFetchProgress, int64((timeSpentSoFar*remainingHeaders)/headersFetchedSoFar))
 
 	// publish the sync progress results to all listeners.
 	for _, listener := range asset.syncData.syncProgressListeners {
@@ -227,7 +238,7 @@ func (asset *Asset) handleNotifications() {
 notificationsLoop:
 	for {
 		select {
-		case n, ok := <-asset.chainClient.Notifications():
+		case n, ok := <-asset.chainSource().Notifications():
 			if !ok {
 				continue notificationsLoop
 			}
@@ -274,18 +285,23 @@ notificationsLoop:
 				select {
 				case <-t.C:
 					asset.updateSyncProgress(n.Block.Height)
+					if asset.isChainRescan() {
+						asset.updateRescanProgress(n.Block.Height)
+					}
 				default:
 				}
 
 			case *chain.RescanProgress:
 				// Notifications sent at interval of 10k blocks
-				asset.updateRescanProgress(n)
+				if asset.isChainRescan() {
+					asset.updateRescanProgress(n.Height)
+				}
 
 			case *chain.RescanFinished:
-				asset.syncData.mu.Lock()
 				// Address recovery is complete.
-				asset.syncData.isRescan = false
-				asset.syncData.mu.Unlock()
+				if asset.isChainRescan() {
+					asset.endRescan(nil)
+				}
 
 				// Notification type is sent when the rescan is completed.
 				asset.updateSyncProgress(n.Height)
@@ -309,15 +325,8 @@ notificationsLoop:
 					asset.MarkWalletAsDiscoveredAccounts()
 				}
 
-				asset.syncData.mu.Lock()
-				asset.syncData.isRescan = false
-				asset.syncData.mu.Unlock()
-
-				if asset.blocksRescanProgressListener != nil {
-					asset.blocksRescanProgressListener.OnBlocksRescanEnded(asset.ID, nil)
-				}
-
 				asset.updateSyncedToBlock(n.Height)
+				go asset.resumeInterruptedRescan()
 			}
 		case <-asset.syncCtx.Done():
 			break notificationsLoop
@@ -340,6 +349,13 @@ func (asset *Asset) prepareChain() error {
 		return errors.New("wallet not found")
 	}
 
+	if cfg := asset.ElectrumServerConfig(); cfg != nil {
+		log.Debugf("Starting LTC wallet sync through electrum server %s...", cfg.Host)
+		asset.dailerCtx, asset.dailerCancel = asset.ShutdownContextWithCancel()
+		asset.electrumClient = NewElectrumClient(asset.chainParams, cfg, asset.NewNetLimiter())
+		return nil
+	}
+
 	log.Debug("Starting native LTC wallet sync...")
 	chainService, err := asset.loadChainService()
 	if err != nil {
@@ -347,29 +363,73 @@ func (asset *Asset) prepareChain() error {
 	}
 
 	asset.chainClient = labschain.NewNeutrinoClient(asset.chainParams, chainService, log)
+	asset.electrumClient = nil
 
 	return nil
 }
 
+// chainSource returns the chain client the wallet syncs through.
+func (asset *Asset) chainSource() chain.Interface {
+	if asset.electrumClient != nil {
+		return asset.electrumClient
+	}
+	return asset.chainClient
+}
+
+// electrumBestBlock returns the Electrum server's chain tip, or the block the
+// wallet is synced to while disconnected.
+func (asset *Asset) electrumBestBlock() *sharedW.BlockInfo {
+	if stamp, err := asset.electrumClient.BlockStamp(); err == nil {
+		return &sharedW.BlockInfo{Height: stamp.Height, Timestamp: stamp.Timestamp.Unix()}
+	}
+
+	if !asset.WalletOpened() {
+		return sharedW.InvalidBlock
+	}
+	stamp := asset.Internal().LTC.Manager.SyncedTo()
+	return &sharedW.BlockInfo{Height: stamp.Height, Timestamp: stamp.Timestamp.Unix()}
+}
+
+// SetElectrumServerConfig sets the Electrum server the wallet syncs through.
+// A nil config switches the wallet back to neutrino. A running sync is
+// restarted on the new chain backend.
+func (asset *Asset) SetElectrumServerConfig(cfg *sharedW.ElectrumServerConfig) error {
+	if !asset.WalletOpened() {
+		return utils.ErrLTCNotInitialized
+	}
+
+	if err := asset.SaveElectrumServerConfig(cfg); err != nil {
+		return err
+	}
+
+	isPrevConnected := asset.IsConnectedToNetwork()
+	if isPrevConnected {
+		asset.CancelSync()
+		asset.syncData.wg.Wait()
+	} else if asset.electrumClient == nil && !asset.syncData.chainServiceStopped {
+		if err := asset.stopChainService(); err != nil {
+			log.Errorf("Stopping chain service failed: %v", err)
+		}
+		asset.syncData.chainServiceStopped = true
+	}
+
+	if err := asset.prepareChain(); err != nil {
+		return err
+	}
+
+	if isPrevConnected {
+		return asset.SpvSync()
+	}
+	return nil
+}
+
 func (asset *Asset) loadChainService() (chainService *neutrino.ChainService, err error) {
 	// Read config for persistent peers, if set parse and set neutrino's ConnectedPeers
 	// persistentPeers.
-	var persistentPeers []string
+	persistentPeers := asset.PersistentPeers(asset.chainParams.DefaultPort)
 	peerAddresses := asset.ReadStringConfigValueForKey(sharedW.SpvPersistentPeerAddressesConfigKey, "")
-	if peerAddresses != "" {
-		addresses := strings.Split(peerAddresses, ";")
-		for _, address := range addresses {
-			peerAddress, err := utils.NormalizeAddress(address, asset.chainParams.DefaultPort)
-			if err != nil {
-				log.Errorf("SPV peer address(%s) is invalid: %v", peerAddress, err)
-			} else {
-				persistentPeers = append(persistentPeers, peerAddress)
-			}
-		}
-
-		if len(persistentPeers) == 0 {
-			return chainService, errors.New(utils.ErrInvalidPeers)
-		}
+	if peerAddresses != "" && len(persistentPeers) == 0 {
+		return chainService, errors.New(utils.ErrInvalidPeers)
 	}
 
 	// Add xurious DNS seed if it is TestNet4
@@ -378,25 +438,41 @@ func (asset *Asset) loadChainService() (chainService *neutrino.ChainService, err
 	}
 
 	asset.dailerCtx, asset.dailerCancel = asset.ShutdownContextWithCancel()
-	chainService, err = neutrino.NewChainService(neutrino.Config{
-		DataDir:       asset.DataDir(),
-		Database:      asset.GetWalletDataDb().LTC,
+	cfg := neutrino.Config{
 		ChainParams:   *asset.chainParams,
 		PersistToDisk: true, // keep cfilter headers on disk for efficient rescanning
 		ConnectPeers:  persistentPeers,
 		AddPeers:      asset.setSeedPeers(),
-		// Dailer function helps to better control the dailer functionality.
-		Dialer: utils.DialerFunc(asset.dailerCtx),
 		// WARNING: PublishTransaction currently uses the entire duration
 		// because if an external bug, but even if the resolved, a typical
 		// inv/getdata round trip is ~4 seconds, so we set this so neutrino does
 		// not cancel queries too readily.
 		BroadcastTimeout: 6 * time.Second,
-	})
+	}
 
-	if err != nil {
-		log.Error(err)
-		return nil, fmt.Errorf("couldn't create Neutrino ChainService: %v", err)
+	if asset.sharesChainService() {
+		chainService, err = asset.chainBackend.acquire(asset.ID, cfg, asset.SyncLimits(), asset.RecordDataUsage)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		asset.usingSharedChain = true
+
+		// The headers and cfilters of the private chain service the wallet
+		// synced with before are not needed anymore.
+		asset.RemovePrivateChainDataOnce(asset.GetWalletDataDb().LTC.Bolt)
+	} else {
+		asset.applyPeerBans()
+		cfg.DataDir = asset.DataDir()
+		cfg.Database = asset.GetWalletDataDb().LTC
+		// The limiter enforces the sync limits and meters the data usage.
+		cfg.Dialer = asset.NewNetLimiter().Dialer(asset.dailerCtx)
+		chainService, err = neutrino.NewChainService(cfg)
+		if err != nil {
+			log.Error(err)
+			return nil, fmt.Errorf("couldn't create Neutrino ChainService: %v", err)
+		}
+		asset.usingSharedChain = false
 	}
 	asset.syncData.mu.Lock()
 	asset.syncData.chainServiceStopped = false
@@ -405,6 +481,20 @@ func (asset *Asset) loadChainService() (chainService *neutrino.ChainService, err
 	return chainService, nil
 }
 
+// stopChainService stops the wallet's chain service. The shared chain service
+// is only released so that the other wallets can keep using it.
+func (asset *Asset) stopChainService() error {
+	if asset.electrumClient != nil {
+		// The Electrum client is stopped with the chain client.
+		return nil
+	}
+	if asset.usingSharedChain {
+		asset.chainBackend.Release(asset.ID)
+		return nil
+	}
+	return asset.chainClient.CS.Stop()
+}
+
 // CancelSync stops the sync process.
 func (asset *Asset) CancelSync() {
 	asset.syncData.mu.RLock()
@@ -446,7 +536,7 @@ func (asset *Asset) stopSync() {
 	}
 
 	// 2. shutdown the chain client.
-	asset.chainClient.Stop() // If active, attempt to shut it down.
+	asset.chainSource().Stop() // If active, attempt to shut it down.
 
 	if asset.WalletOpened() {
 		// Neutrino performs explicit chain service start but never explicit
@@ -454,7 +544,7 @@ func (asset *Asset) stopSync() {
 		// a wallet sync.
 		// 3. Disabling the peers connectivity allows the upstream handleChainNotification
 		// goroutine to return.
-		if err := asset.chainClient.CS.Stop(); err != nil {
+		if err := asset.stopChainService(); err != nil {
 			// ignore the error and proceed with shutdown.
 			log.Errorf("Stopping chain client failed: %v", err)
 		}
@@ -465,7 +555,7 @@ func (asset *Asset) stopSync() {
 	}
 
 	// 5. Wait for the chain client to shutdown
-	asset.chainClient.WaitForShutdown()
+	asset.chainSource().WaitForShutdown()
 
 	// Declares that the sync context is done and goroutines listening to it
 	// should exit. The shutdown protocol will eventually attempt to end this
@@ -494,33 +584,29 @@ func (asset *Asset) stopSync() {
 func (asset *Asset) startSync() error {
 	g, _ := errgroup.WithContext(asset.syncCtx)
 
-	if asset.syncData.chainServiceStopped {
-		chainService, err := asset.loadChainService()
-		if err != nil {
-			return err
-		}
-		asset.chainClient.CS = chainService
+	if err := asset.reloadStoppedChainService(); err != nil {
+		return err
 	}
 
 	// Chain client performs explicit chain service start up thus no need
 	// to re-initialize it.
-	g.Go(asset.chainClient.Start)
+	g.Go(asset.chainSource().Start)
 
 	if err := g.Wait(); err != nil {
 		asset.CancelSync()
-		log.Errorf("couldn't start Neutrino client: %v", err)
+		log.Errorf("couldn't start chain client: %v", err)
 		return err
 	}
 
 	// Subscribe to chainclient notifications.
-	if err := asset.chainClient.NotifyBlocks(); err != nil {
+	if err := asset.chainSource().NotifyBlocks(); err != nil {
 		log.Errorf("subscribing to notifications failed: %v", err)
 		return err
 	}
 
 	log.Infof("Synchronizing wallet (%s) with network...", asset.GetWalletName())
 	// Initializes the goroutines handling chain notifications, rescan progress and handlers.
-	asset.Internal().LTC.SynchronizeRPC(asset.chainClient)
+	asset.Internal().LTC.SynchronizeRPC(asset.chainSource())
 
 	select {
 	// Wait for 5 seconds so that all goroutines initialized in SynchronizeRPC()
@@ -551,16 +637,46 @@ func (asset *Asset) IsConnectedToLitecoinNetwork() bool {
 	return isSyncing || asset.syncData.isRescan
 }
 
+// reloadStoppedChainService loads a new chain service if the previous one was
+// stopped.
+func (asset *Asset) reloadStoppedChainService() error {
+	if asset.syncData.chainServiceStopped && asset.electrumClient == nil {
+		chainService, err := asset.loadChainService()
+		if err != nil {
+			return err
+		}
+		asset.chainClient.CS = chainService
+	}
+	return nil
+}
+
 // startWallet initializes the *ltcwallet.Wallet and its supporting players and
 // starts syncing.
 func (asset *Asset) startWallet() (err error) {
 	// If this is an imported wallet and address dicovery has not been performed,
-	// We want to set the assets birtday to the genesis block.
-	if asset.IsRestored && !asset.ContainsDiscoveredAccounts() {
+	// We want to set the assets birtday to the checkpoint preceding the
+	// birthday provided on restore or the genesis block.
+	restoring := asset.IsRestored && !asset.ContainsDiscoveredAccounts()
+	if restoring {
+		if asset.electrumClient == nil {
+			if err := asset.reloadStoppedChainService(); err != nil {
+				return err
+			}
+			asset.waitForBirthdayHeaders(asset.syncCtx, asset.chainClient.CS)
+		}
 		asset.forceRescan()
 	}
 	// Initiate the sync protocol and return an error incase of failure.
-	return asset.startSync()
+	if err := asset.startSync(); err != nil {
+		return err
+	}
+
+	// Address discovery requests the cfilters of the blocks one at a time,
+	// fetch them ahead of it in parallel.
+	if restoring && asset.electrumClient == nil {
+		go asset.prefetchCFilters(asset.syncCtx, asset.chainClient.CS, asset.restoreStartBlock().Height)
+	}
+	return nil
 }
 
 // waitForSyncCompletion polls if the chain considers if itself as the current
@@ -574,7 +690,7 @@ func (asset *Asset) waitForSyncCompletion() {
 	for {
 		select {
 		case <-t.C:
-			if asset.chainClient.IsCurrent() {
+			if asset.chainSource().IsCurrent() {
 				asset.syncData.mu.Lock()
 				asset.syncData.synced = true
 				asset.syncData.syncing = false
@@ -602,6 +718,11 @@ func (asset *Asset) SpvSync() (err error) {
 		return errors.New(utils.ErrSyncAlreadyInProgress)
 	}
 
+	// Respect the sync schedule and the daily data limit.
+	if err := asset.SyncAllowed(); err != nil {
+		return err
+	}
+
 	// Initialize all progress report data.
 	asset.initSyncProgressData()
 
@@ -647,7 +768,16 @@ func (asset *Asset) reloadChainService() error {
 		asset.CancelSync()
 	}
 
-	asset.chainClient.CS.Stop()
+	if asset.electrumClient != nil {
+		// The peers settings don't apply to the Electrum backend.
+		if isPrevConnected {
+			asset.syncData.wg.Wait()
+			return asset.SpvSync()
+		}
+		return nil
+	}
+
+	asset.stopChainService()
 	chainService, err := asset.loadChainService()
 	if err != nil {
 		return err
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
-	"sort"
 	"sync"
 	"time"
 
@@ -38,6 +37,10 @@ type TxAuthor struct {
 	needsConstruct bool
 
 	selectedUXTOs []*sharedW.UnspentOutput
+	coinSelection sharedW.CoinSelectionStrategy
+	// changeless is true when the selected inputs match the amount spent
+	// closely enough for the change output to be dropped.
+	changeless bool
 
 	mu sync.RWMutex
 }
@@ -61,10 +64,35 @@ func (asset *Asset) NewUnsignedTx(sourceAccountNumber int32, utxos []*sharedW.Un
 		destinations:        make(map[string]*sharedW.TransactionDestination, 0),
 		needsConstruct:      true,
 		selectedUXTOs:       utxos,
+		coinSelection:       asset.DefaultCoinSelectionStrategy(),
 	}
 	return nil
 }
 
+// SetCoinSelectionStrategy sets the coin selection strategy used to pick the
+// inputs of the current unsigned transaction.
+func (asset *Asset) SetCoinSelectionStrategy(strategy sharedW.CoinSelectionStrategy) error {
+	if !strategy.IsValid() {
+		return errors.New(utils.ErrInvalid)
+	}
+
+	asset.TxAuthoredInfo.mu.Lock()
+	defer asset.TxAuthoredInfo.mu.Unlock()
+
+	asset.TxAuthoredInfo.coinSelection = strategy
+	asset.TxAuthoredInfo.needsConstruct = true
+	return nil
+}
+
+// CoinSelectionStrategy returns the coin selection strategy used by the current
+// unsigned transaction.
+func (asset *Asset) CoinSelectionStrategy() sharedW.CoinSelectionStrategy {
+	asset.TxAuthoredInfo.mu.RLock()
+	defer asset.TxAuthoredInfo.mu.RUnlock()
+
+	return asset.TxAuthoredInfo.coinSelection
+}
+
 // GetUnsignedTx returns the unsigned transaction.
 func (asset *Asset) GetUnsignedTx() *TxAuthor {
 	return asset.TxAuthoredInfo
@@ -182,9 +210,6 @@ func (asset *Asset) TotalSendAmount() *sharedW.Amount {
 
 // EstimateFeeAndSize estimates the fee and size of the transaction.
 func (asset *Asset) EstimateFeeAndSize() (*sharedW.TxFeeAndSize, error) {
-	// compute the amount to be sent in the current tx.
-	sendAmount := ltcutil.Amount(asset.TotalSendAmount().UnitValue)
-
 	asset.TxAuthoredInfo.mu.Lock()
 	defer asset.TxAuthoredInfo.mu.Unlock()
 
@@ -193,9 +218,18 @@ func (asset *Asset) EstimateFeeAndSize() (*sharedW.TxFeeAndSize, error) {
 		return nil, utils.TranslateError(err)
 	}
 
-	// Since the fee is already calculated when computing the change source out
-	// or single destination to send max amount, no need to repeat calculations again.
-	feeToSpend := asset.TxAuthoredInfo.txSpendAmount - sendAmount
+	return asset.txFeeAndSize(unsignedTx), nil
+}
+
+// txFeeAndSize computes the fee, change and size of the provided unsigned tx.
+func (asset *Asset) txFeeAndSize(unsignedTx *txauthor.AuthoredTx) *sharedW.TxFeeAndSize {
+	// The fee is whatever the inputs pay in excess of the outputs. This also
+	// covers the remainder added to the fee when the change output is dropped.
+	var outputsTotal int64
+	for _, txOut := range unsignedTx.Tx.TxOut {
+		outputsTotal += txOut.Value
+	}
+	feeToSpend := unsignedTx.TotalInput - ltcutil.Amount(outputsTotal)
 	feeAmount := &sharedW.Amount{
 		UnitValue: int64(feeToSpend),
 		CoinValue: feeToSpend.ToBTC(),
@@ -221,7 +255,45 @@ func (asset *Asset) EstimateFeeAndSize() (*sharedW.TxFeeAndSize, error) {
 		EstimatedSignedSize: estimatedSize,
 		Fee:                 feeAmount,
 		Change:              change,
-	}, nil
+	}
+}
+
+// CoinSelectionPreview constructs the current unsigned tx with every supported
+// coin selection strategy so that their sizes and fees can be compared.
+func (asset *Asset) CoinSelectionPreview() ([]*sharedW.CoinSelectionPreview, error) {
+	if asset.TxAuthoredInfo == nil {
+		return nil, fmt.Errorf("TxAuthoredInfo is nil")
+	}
+
+	asset.TxAuthoredInfo.mu.Lock()
+	defer asset.TxAuthoredInfo.mu.Unlock()
+
+	strategy := asset.TxAuthoredInfo.coinSelection
+	defer func() {
+		// Restore the selected strategy, the tx must be reconstructed with it.
+		asset.TxAuthoredInfo.coinSelection = strategy
+		asset.TxAuthoredInfo.needsConstruct = true
+	}()
+
+	previews := make([]*sharedW.CoinSelectionPreview, 0, len(sharedW.CoinSelectionStrategies))
+	for _, s := range sharedW.CoinSelectionStrategies {
+		asset.TxAuthoredInfo.coinSelection = s
+		preview := &sharedW.CoinSelectionPreview{Strategy: s}
+
+		unsignedTx, err := asset.constructTransaction()
+		if err != nil {
+			preview.Err = utils.TranslateError(err)
+		} else {
+			feeAndSize := asset.txFeeAndSize(unsignedTx)
+			preview.Inputs = len(unsignedTx.Tx.TxIn)
+			preview.EstimatedSignedSize = feeAndSize.EstimatedSignedSize
+			preview.Fee = feeAndSize.Fee
+			preview.Change = feeAndSize.Change
+		}
+		previews = append(previews, preview)
+	}
+
+	return previews, nil
 }
 
 // EstimateMaxSendAmount estimates the maximum amount that can be sent in the transaction.
@@ -421,18 +493,43 @@ func (asset *Asset) constructTransaction() (*txauthor.AuthoredTx, error) {
 		if err != nil {
 			return nil, err
 		}
+		// Frozen outputs can only be spent by selecting them manually.
+		unspents = sharedW.FilterFrozenUTXOs(unspents)
 	}
 
-	inputSource := asset.makeInputSource(unspents, sendMax)
-	unsignedTx, err := txauthor.NewUnsignedTransaction(outputs, setFeeRate, inputSource, changeSource)
+	inputSource := asset.makeInputSource(unspents, outputs, sendMax)
+	plannedChangeless := asset.TxAuthoredInfo.changeless
+	txChangeSource := changeSource
+	if plannedChangeless {
+		// The change output is dropped, its size must not be paid for.
+		txChangeSource = &txauthor.ChangeSource{NewScript: changeSource.NewScript}
+	}
+	unsignedTx, err := txauthor.NewUnsignedTransaction(outputs, setFeeRate, inputSource, txChangeSource)
+	if err == nil && plannedChangeless && !asset.TxAuthoredInfo.changeless {
+		// The inputs had to be selected again and the new selection has
+		// change, pay for the change output.
+		unsignedTx, err = txauthor.NewUnsignedTransaction(outputs, setFeeRate, inputSource, changeSource)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("creating unsigned tx failed: %v", err)
 	}
 
+	if asset.TxAuthoredInfo.changeless && unsignedTx.ChangeIndex != -1 {
+		// The excess of a changeless selection is cheaper to add to the fee
+		// than to return as change.
+		unsignedTx.Tx.TxOut = unsignedTx.Tx.TxOut[:unsignedTx.ChangeIndex]
+		unsignedTx.ChangeIndex = -1
+	}
+
 	if unsignedTx.ChangeIndex == -1 {
-		// The change amount is zero or the Txout is likely to be considered as dust
-		// if sent to the mempool the whole tx will be rejected.
-		return nil, errors.New("adding the change txOut or sendMax tx failed")
+		if sendMax {
+			// The change amount is zero or the Txout is likely to be considered as dust
+			// if sent to the mempool the whole tx will be rejected.
+			return nil, errors.New("adding the change txOut or sendMax tx failed")
+		}
+		// The change was too small to be worth an output and was added to the
+		// fee instead.
+		return unsignedTx, nil
 	}
 
 	// Confirm that the change output is valid too.
@@ -475,11 +572,10 @@ func (asset *Asset) validateSendAmount(sendMax bool, litoshiAmount int64) error
 }
 
 // makeInputSource creates an InputSource that creates inputs for every unspent
-// output with non-zero output values. The importsource aims to create the leanest
-// transaction possible. It plans not to spend all the utxos available when servicing
-// the current transaction spending amount if possible. The sendMax shows that
+// output with non-zero output values. The inputs spent are picked using the coin
+// selection strategy set on the unsigned tx to fund txOuts. The sendMax shows that
 // all utxos must be spent without any balance(unspent utxo) left in the account.
-func (asset *Asset) makeInputSource(outputs []*sharedW.UnspentOutput, sendMax bool) txauthor.InputSource {
+func (asset *Asset) makeInputSource(outputs []*sharedW.UnspentOutput, txOuts []*wire.TxOut, sendMax bool) txauthor.InputSource {
 	var (
 		sourceErr       error
 		totalInputValue ltcutil.Amount
@@ -489,13 +585,8 @@ func (asset *Asset) makeInputSource(outputs []*sharedW.UnspentOutput, sendMax bo
 		pkScripts   = make([][]byte, 0, len(outputs))
 	)
 
-	// sorting is only necessary when send max is false.
-	if !sendMax {
-		// Sorts the outputs in the descending order (utxo with largest amount start)
-		// This descending order helps in selecting the least number of utxos needed
-		// in the servicing the transaction to be made.
-		sort.Slice(outputs, func(i, j int) bool { return outputs[i].Amount.ToCoin() > outputs[j].Amount.ToCoin() })
-	}
+	feeRate := ltcutil.Amount(asset.GetUserFeeRate().ToInt())
+	candidates := make([]*sharedW.CoinSelectionCandidate, 0, len(outputs))
 
 	// validates the utxo amounts and if an invalid amount is discovered an
 	// error is returned.
@@ -533,6 +624,11 @@ func (asset *Asset) makeInputSource(outputs []*sharedW.UnspentOutput, sendMax bo
 		}
 
 		totalInputValue += ltcutil.Amount(output.Amount.(Amount))
+		candidates = append(candidates, &sharedW.CoinSelectionCandidate{
+			UTXO:     output,
+			Value:    output.Amount.ToInt(),
+			InputFee: int64(txrules.FeeForSerializeSize(feeRate, txsizes.GetMinInputVirtualSize(script))),
+		})
 		pkScripts = append(pkScripts, script)
 		inputValues = append(inputValues, ltcutil.Amount(output.Amount.(Amount)))
 		inputs = append(inputs, wire.NewTxIn(previousOutPoint, nil, nil))
@@ -545,6 +641,33 @@ func (asset *Asset) makeInputSource(outputs []*sharedW.UnspentOutput, sendMax bo
 			asset.RequiredConfirmations())
 	}
 
+	params := sharedW.CoinSelectionParams{
+		Strategy:                asset.TxAuthoredInfo.coinSelection,
+		FeeRate:                 int64(feeRate),
+		MaxConsolidationFeeRate: int64(MaxConsolidationFeeRatePerkvB),
+		ChangeFee:               int64(txrules.FeeForSerializeSize(feeRate, txsizes.P2WPKHOutputSize)),
+		// Creating a P2WPKH change output and spending it later.
+		CostOfChange: int64(txrules.FeeForSerializeSize(feeRate, txsizes.P2WPKHOutputSize+changeInputVirtualSize)),
+	}
+
+	// The candidates' effective values already pay for their inputs, only
+	// the outputs and the fee of the transaction without inputs are targeted.
+	selectionTarget := int64(txauthor.SumOutputValues(txOuts) +
+		txrules.FeeForSerializeSize(feeRate, txsizes.EstimateVirtualSize(0, 0, 0, txOuts, 0)))
+	var (
+		selection      []int
+		availableValue int64
+	)
+	for _, c := range candidates {
+		if value := c.Value - c.InputFee; value > 0 {
+			availableValue += value
+		}
+	}
+	asset.TxAuthoredInfo.changeless = false
+	if !sendMax {
+		selection, asset.TxAuthoredInfo.changeless = sharedW.SelectCoins(candidates, selectionTarget, params)
+	}
+
 	return func(target ltcutil.Amount) (ltcutil.Amount, []*wire.TxIn, []ltcutil.Amount, [][]byte, error) {
 		// If an error was found return it first.
 		if sourceErr != nil {
@@ -562,22 +685,34 @@ func (asset *Asset) makeInputSource(outputs []*sharedW.UnspentOutput, sendMax bo
 			return totalInputValue, inputs, inputValues, pkScripts, nil
 		}
 
-		var index int
 		var totalUtxo ltcutil.Amount
-
-		for _, utxoAmount := range inputValues {
-			if totalUtxo < target {
-				// Found some utxo(s) we can spend in the current tx.
-				index++
-
-				totalUtxo += utxoAmount
-				continue
+		for {
+			totalUtxo = 0
+			for _, index := range selection {
+				totalUtxo += inputValues[index]
 			}
-			break
+			if totalUtxo >= target || selectionTarget > availableValue {
+				break
+			}
+			// The fee estimated for the whole transaction can exceed the
+			// sum of the fees of its inputs by a few units when rounded,
+			// select again for the shortfall.
+			selectionTarget += int64(target - totalUtxo)
+			selection, asset.TxAuthoredInfo.changeless = sharedW.SelectCoins(candidates, selectionTarget, params)
+		}
+
+		selectedInputs := make([]*wire.TxIn, 0, len(selection))
+		selectedValues := make([]ltcutil.Amount, 0, len(selection))
+		selectedScripts := make([][]byte, 0, len(selection))
+		for _, index := range selection {
+			selectedInputs = append(selectedInputs, inputs[index])
+			selectedValues = append(selectedValues, inputValues[index])
+			selectedScripts = append(selectedScripts, pkScripts[index])
 		}
-		asset.TxAuthoredInfo.inputs = inputs[:index]
-		asset.TxAuthoredInfo.inputValues = inputValues[:index]
-		return totalUtxo, inputs[:index], inputValues[:index], pkScripts[:index], nil
+
+		asset.TxAuthoredInfo.inputs = selectedInputs
+		asset.TxAuthoredInfo.inputValues = selectedValues
+		return totalUtxo, selectedInputs, selectedValues, selectedScripts, nil
 	}
 }
 
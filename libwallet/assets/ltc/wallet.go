@@ -40,8 +40,12 @@ var _ sharedW.Asset = (*Asset)(nil)
 type Asset struct {
 	*sharedW.Wallet
 
-	chainClient    *labschain.NeutrinoClient
-	chainParams    *ltcchaincfg.Params
+	chainClient *labschain.NeutrinoClient
+	chainParams *ltcchaincfg.Params
+
+	// electrumClient is set if the wallet syncs through an Electrum server
+	// instead of neutrino.
+	electrumClient *ElectrumClient
 	TxAuthoredInfo *TxAuthor
 
 	cancelSync context.CancelFunc
@@ -51,6 +55,12 @@ type Asset struct {
 	dailerCtx    context.Context
 	dailerCancel context.CancelFunc
 
+	// chainBackend is the chain service shared with the other wallets of the
+	// asset. usingSharedChain is set if the current chain service is the
+	// shared one.
+	chainBackend     *ChainBackend
+	usingSharedChain bool
+
 	// This field has been added to cache the expensive call to GetTransactions.
 	// If the best block height hasn't changed there is no need to make another
 	// expensive GetTransactions call.
@@ -60,6 +70,10 @@ type Asset struct {
 	// been introduced.
 	fees feeEstimateCache
 
+	// blockFeeRates caches the fee rates of the recent blocks used by the
+	// local fee estimator.
+	blockFeeRates sharedW.BlockFeeRates
+
 	// rescanStarting is set while reloading the wallet and dropping
 	// transactions from the wallet db.
 	rescanStarting uint32 // atomic
@@ -104,8 +118,9 @@ func CreateNewWallet(pass *sharedW.AuthInfo, params *sharedW.InitParams) (shared
 	}
 
 	ltcWallet := &Asset{
-		Wallet:      w,
-		chainParams: chainParams,
+		chainBackend: sharedChainBackend(params),
+		Wallet:       w,
+		chainParams:  chainParams,
 		syncData: &SyncData{
 			syncProgressListeners: make(map[string]sharedW.SyncProgressListener),
 		},
@@ -125,7 +140,8 @@ func initWalletLoader(chainParams *ltcchaincfg.Params, dbDirPath string) loader.
 	dirName := ""
 	// testnet datadir takes a special structure to differentiate "testnet4" and "testnet3"
 	// data directory.
-	if utils.ToNetworkType(chainParams.Net.String()) == utils.Testnet {
+	// The regtest network is named "TestNet", it is compared by its magic.
+	if chainParams.Net == utils.LTCtestnetParams.Net {
 		dirName = utils.NetDir(utils.LTCWalletAsset, utils.Testnet)
 	}
 
@@ -174,8 +190,9 @@ func CreateWatchOnlyWallet(walletName, extendedPublicKey string, params *sharedW
 	}
 
 	ltcWallet := &Asset{
-		Wallet:      w,
-		chainParams: chainParams,
+		chainBackend: sharedChainBackend(params),
+		Wallet:       w,
+		chainParams:  chainParams,
 		syncData: &SyncData{
 			syncProgressListeners: make(map[string]sharedW.SyncProgressListener),
 		},
@@ -211,8 +228,9 @@ func RestoreWallet(seedMnemonic string, pass *sharedW.AuthInfo, params *sharedW.
 	}
 
 	ltcWallet := &Asset{
-		Wallet:      w,
-		chainParams: chainParams,
+		chainBackend: sharedChainBackend(params),
+		Wallet:       w,
+		chainParams:  chainParams,
 		syncData: &SyncData{
 			syncProgressListeners: make(map[string]sharedW.SyncProgressListener),
 		},
@@ -246,8 +264,9 @@ func LoadExisting(w *sharedW.Wallet, params *sharedW.InitParams) (sharedW.Asset,
 	// completes.
 	ldr := initWalletLoader(chainParams, params.RootDir)
 	ltcWallet := &Asset{
-		Wallet:      w,
-		chainParams: chainParams,
+		chainBackend: sharedChainBackend(params),
+		Wallet:       w,
+		chainParams:  chainParams,
 		syncData: &SyncData{
 			syncProgressListeners: make(map[string]sharedW.SyncProgressListener),
 		},
@@ -286,6 +305,11 @@ func (asset *Asset) SafelyCancelSync() {
 
 	asset.syncData.wg.Wait()
 
+	// Let the other wallets keep using the shared chain service.
+	if asset.chainBackend != nil {
+		asset.chainBackend.Release(asset.ID)
+	}
+
 	// Stop the goroutines left active to manage the wallet functionalities that
 	// don't require activation of sync i.e. wallet rename, password update etc.
 	if asset.WalletOpened() {
@@ -336,6 +360,13 @@ func (asset *Asset) ConnectedPeers() int32 {
 	if !asset.IsConnectedToNetwork() {
 		return -1
 	}
+	if asset.electrumClient != nil {
+		// The Electrum server is the only peer.
+		if asset.electrumClient.IsCurrent() {
+			return 1
+		}
+		return 0
+	}
 	return asset.chainClient.CS.ConnectedCount()
 }
 
@@ -346,6 +377,10 @@ func (asset *Asset) IsConnectedToNetwork() bool {
 
 // GetBestBlock returns the best block.
 func (asset *Asset) GetBestBlock() *sharedW.BlockInfo {
+	if asset.electrumClient != nil {
+		return asset.electrumBestBlock()
+	}
+
 	block, err := asset.chainClient.CS.BestBlock()
 	if err != nil {
 		log.Error("GetBestBlock hash for LTC failed, Err: ", err)
@@ -370,7 +405,13 @@ func (asset *Asset) GetBestBlockTimeStamp() int64 {
 
 // GetBlockHeight returns the block height for the given block hash.
 func (asset *Asset) GetBlockHeight(hash chainhash.Hash) (int32, error) {
-	height, err := asset.chainClient.GetBlockHeight(&hash)
+	var height int32
+	var err error
+	if asset.electrumClient != nil {
+		height, err = asset.electrumClient.GetBlockHeight(&hash)
+	} else {
+		height, err = asset.chainClient.GetBlockHeight(&hash)
+	}
 	if err != nil {
 		log.Warn("GetBlockHeight for LTC failed, Err: %v", err)
 		return -1, err
@@ -380,7 +421,7 @@ func (asset *Asset) GetBlockHeight(hash chainhash.Hash) (int32, error) {
 
 // GetBlockHash returns the block hash for the given block height.
 func (asset *Asset) GetBlockHash(height int64) (*chainhash.Hash, error) {
-	blockhash, err := asset.chainClient.GetBlockHash(height)
+	blockhash, err := asset.chainSource().GetBlockHash(height)
 	if err != nil {
 		log.Warn("GetBlockHash for LTC failed, Err: %v", err)
 		return nil, err
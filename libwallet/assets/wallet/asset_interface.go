@@ -17,9 +17,19 @@ type Asset interface {
 	CancelSync()
 	IsRescanning() bool
 	RescanBlocks() error
+	RescanBlocksWithOptions(opts *RescanOptions) error
+	ResumeRescan() error
+	RescanCheckpoint() *RescanCheckpoint
+	DeleteRescanCheckpoint()
 	ConnectedPeers() int32
 	RemovePeers()
 	SetSpecificPeer(address string)
+	PeerInfoRaw() ([]PeerInfo, error)
+	ConnectPeer(addr string) error
+	DisconnectPeer(addr string) error
+	BanPeer(addr string) error
+	UnbanPeer(addr string) error
+	BannedPeers() []*BannedPeer
 	GetExtendedPubKey(account int32) (string, error)
 	IsSyncShuttingDown() bool
 
@@ -71,6 +81,23 @@ type Asset interface {
 	AccountNameRaw(accountNumber uint32) (string, error)
 	GetAccountBalance(accountNumber int32) (*Balance, error)
 	UnspentOutputs(account int32) ([]*UnspentOutput, error)
+	UTXOMetadata(txID string, vout uint32) (*UTXOMetadata, error)
+	SetUTXOFrozen(txID string, vout uint32, frozen bool) error
+	SetUTXOTag(txID string, vout uint32, tag string) error
+	SetUTXOLabel(txID string, vout uint32, label string) error
+	SetUTXOOrigin(txID string, vout uint32, origin UTXOOrigin) error
+	FrozenUTXOs() ([]*UTXOMetadata, error)
+	DefaultCoinSelectionStrategy() CoinSelectionStrategy
+	SetDefaultCoinSelectionStrategy(strategy CoinSelectionStrategy)
+	SetUserFeeRate(feeRate AssetAmount) error
+	GetUserFeeRate() AssetAmount
+	FeeTargets() ([]*FeeTarget, error)
+	FeeEstimatorOrder() []string
+	SetFeeEstimatorOrder(order []string) error
+	FeeEstimatorURL() string
+	SetFeeEstimatorURL(feeURL string) error
+	ElectrumServerConfig() *ElectrumServerConfig
+	SetElectrumServerConfig(cfg *ElectrumServerConfig) error
 
 	AddSyncProgressListener(syncProgressListener SyncProgressListener, uniqueIdentifier string) error
 	RemoveSyncProgressListener(uniqueIdentifier string)
@@ -78,6 +105,17 @@ type Asset interface {
 	RemoveTxAndBlockNotificationListener(uniqueIdentifier string)
 	SetBlocksRescanProgressListener(blocksRescanProgressListener BlocksRescanProgressListener)
 
+	SyncLimits() *SyncLimits
+	SetSyncLimits(limits *SyncLimits) error
+	SyncAllowed() error
+	TodayDataUsage() *DataUsage
+	DataUsageHistory() []DataUsage
+
+	DatabaseDriver() string
+	DBHealth() (*DBHealth, error)
+	BackupDB(dir string) (string, error)
+	MigrateDB(driver string) error
+
 	CurrentAddress(account int32) (string, error)
 	NextAddress(account int32) (string, error)
 	IsAddressValid(address string) bool
@@ -86,6 +124,9 @@ type Asset interface {
 	SignMessage(passphrase, address, message string) ([]byte, error)
 	VerifyMessage(address, message, signatureBase64 string) (bool, error)
 
+	RestoreBirthday() *WalletBirthday
+	SaveRestoreBirthday(birthday *WalletBirthday) error
+
 	SaveUserConfigValue(key string, value interface{})
 	ReadUserConfigValue(key string, valueOut interface{}) error
 
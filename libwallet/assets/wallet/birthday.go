@@ -0,0 +1,69 @@
+package wallet
+
+import (
+	"errors"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// birthdayTimeMargin is subtracted from a birthday date before it is mapped
+// to a block height. It absorbs the error of the height estimate and the
+// inaccuracy of the date remembered by the user.
+const birthdayTimeMargin = 7 * 24 * time.Hour
+
+// WalletBirthday is the approximate creation date or block height of a wallet
+// provided when it is restored. Address discovery on the first sync of the
+// restored wallet begins from the block at the birthday instead of the genesis
+// block. Height takes precedence over Time if both are set.
+type WalletBirthday struct {
+	Time   time.Time `json:"time"`
+	Height int32     `json:"height"`
+}
+
+// Validate checks that the birthday is neither in the future nor negative.
+func (b *WalletBirthday) Validate() error {
+	if b.Height < 0 || b.Time.After(time.Now()) {
+		return errors.New(utils.ErrInvalid)
+	}
+	return nil
+}
+
+// EstimatedHeight returns a block height that is at or below the wallet
+// birthday. A birthday date is converted to a height assuming blocks were
+// mined every targetTimePerBlock since genesis. Blocks have been mined faster
+// than the target on average, so the estimate is lower than the actual height.
+func (b *WalletBirthday) EstimatedHeight(genesis time.Time, targetTimePerBlock time.Duration) int32 {
+	if b.Height > 0 {
+		return b.Height
+	}
+
+	birthday := b.Time.Add(-birthdayTimeMargin)
+	if b.Time.IsZero() || !birthday.After(genesis) || targetTimePerBlock <= 0 {
+		return 0
+	}
+	return int32(birthday.Sub(genesis) / targetTimePerBlock)
+}
+
+// RestoreBirthday returns the birthday provided when the wallet was restored
+// or nil if none was provided.
+func (wallet *Wallet) RestoreBirthday() *WalletBirthday {
+	birthday := new(WalletBirthday)
+	if err := wallet.ReadUserConfigValue(RestoreBirthdayConfigKey, birthday); err != nil {
+		return nil
+	}
+	if birthday.Height <= 0 && birthday.Time.IsZero() {
+		return nil
+	}
+	return birthday
+}
+
+// SaveRestoreBirthday stores the birthday provided when the wallet was
+// restored.
+func (wallet *Wallet) SaveRestoreBirthday(birthday *WalletBirthday) error {
+	if err := birthday.Validate(); err != nil {
+		return err
+	}
+	wallet.SaveUserConfigValue(RestoreBirthdayConfigKey, birthday)
+	return nil
+}
@@ -0,0 +1,35 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWalletBirthdayEstimatedHeight(t *testing.T) {
+	genesis := time.Date(2009, 1, 3, 18, 15, 5, 0, time.UTC)
+	target := 10 * time.Minute
+	date := genesis.Add(birthdayTimeMargin + 1000*target)
+
+	tests := []struct {
+		name     string
+		birthday WalletBirthday
+		target   time.Duration
+		height   int32
+	}{
+		{"height", WalletBirthday{Height: 500}, target, 500},
+		{"height over time", WalletBirthday{Height: 500, Time: date}, target, 500},
+		{"time", WalletBirthday{Time: date}, target, 1000},
+		{"time within the margin", WalletBirthday{Time: date.Add(target / 2)}, target, 1000},
+		{"zero time", WalletBirthday{}, target, 0},
+		{"before genesis", WalletBirthday{Time: genesis.Add(-target)}, target, 0},
+		{"genesis within the margin", WalletBirthday{Time: genesis.Add(birthdayTimeMargin)}, target, 0},
+		{"no target time per block", WalletBirthday{Time: date}, 0, 0},
+	}
+
+	for _, test := range tests {
+		height := test.birthday.EstimatedHeight(genesis, test.target)
+		if height != test.height {
+			t.Errorf("%s: expected height %d, got %d", test.name, test.height, height)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package wallet
+
+import (
+	"sync"
+)
+
+// FeeRateOutPoint identifies a transaction output.
+type FeeRateOutPoint struct {
+	TxHash string
+	Index  uint32
+}
+
+// FeeRateTx is a block transaction reduced to the data needed to compute its
+// fee rate.
+type FeeRateTx struct {
+	Hash string
+	// Inputs are the outputs spent by the transaction.
+	Inputs []FeeRateOutPoint
+	// Outputs are the values of the transaction's outputs.
+	Outputs []int64
+	VSize   int64
+}
+
+// FeeRateBlock is a block reduced to the data needed to compute the fee rates
+// of its transactions. The coinbase is left out of Txs.
+type FeeRateBlock struct {
+	Txs []*FeeRateTx
+	// AverageFeeRate is the average fee rate paid in the block in smallest
+	// unit per kvB. It is used if the rate of none of the transactions can be
+	// computed.
+	AverageFeeRate int64
+}
+
+// cachedBlockFeeRates holds the fee rates computed for a block and the values
+// of its outputs, which are needed to compute the fees of later blocks.
+type cachedBlockFeeRates struct {
+	rates   []int64
+	outputs map[FeeRateOutPoint]int64
+}
+
+// BlockFeeRates caches the fee rates paid by the transactions of the recent
+// blocks so that each block is only downloaded once. Light clients don't know
+// the values of the outputs spent by a transaction, the fee rate of a
+// transaction is only computed if all the outputs it spends were created in
+// the cached blocks. The zero value is ready to use.
+type BlockFeeRates struct {
+	mu     sync.Mutex
+	blocks map[string]*cachedBlockFeeRates
+}
+
+// Rates returns the fee rates in smallest unit per kvB paid in the blocks
+// identified by hashes, ordered from the oldest block to the newest. The
+// blocks missing from the cache are fetched with fetchBlock, the blocks that
+// are not in hashes are dropped from the cache.
+func (c *BlockFeeRates) Rates(hashes []string, fetchBlock func(hash string) (*FeeRateBlock, error)) ([]int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.blocks == nil {
+		c.blocks = make(map[string]*cachedBlockFeeRates)
+	}
+
+	window := make(map[string]struct{}, len(hashes))
+	for _, hash := range hashes {
+		window[hash] = struct{}{}
+	}
+	for hash := range c.blocks {
+		if _, ok := window[hash]; !ok {
+			delete(c.blocks, hash)
+		}
+	}
+
+	var rates []int64
+	for _, hash := range hashes {
+		cached, ok := c.blocks[hash]
+		if !ok {
+			block, err := fetchBlock(hash)
+			if err != nil {
+				return nil, err
+			}
+			cached = c.computeRates(block)
+			c.blocks[hash] = cached
+		}
+		rates = append(rates, cached.rates...)
+	}
+	return rates, nil
+}
+
+// computeRates computes the fee rates of the block's transactions that only
+// spend outputs of the cached blocks or of earlier transactions of the block.
+func (c *BlockFeeRates) computeRates(block *FeeRateBlock) *cachedBlockFeeRates {
+	cached := &cachedBlockFeeRates{
+		outputs: make(map[FeeRateOutPoint]int64),
+	}
+
+	outputValue := func(outPoint FeeRateOutPoint) (int64, bool) {
+		if value, ok := cached.outputs[outPoint]; ok {
+			return value, true
+		}
+		for _, b := range c.blocks {
+			if value, ok := b.outputs[outPoint]; ok {
+				return value, true
+			}
+		}
+		return 0, false
+	}
+
+	for _, tx := range block.Txs {
+		var inputsValue, outputsValue int64
+		known := len(tx.Inputs) > 0
+		for _, input := range tx.Inputs {
+			value, ok := outputValue(input)
+			if !ok {
+				known = false
+				break
+			}
+			inputsValue += value
+		}
+
+		for i, value := range tx.Outputs {
+			outputsValue += value
+			cached.outputs[FeeRateOutPoint{TxHash: tx.Hash, Index: uint32(i)}] = value
+		}
+
+		if fee := inputsValue - outputsValue; known && fee >= 0 && tx.VSize > 0 {
+			cached.rates = append(cached.rates, fee*1000/tx.VSize)
+		}
+	}
+
+	if len(cached.rates) == 0 && block.AverageFeeRate > 0 {
+		cached.rates = append(cached.rates, block.AverageFeeRate)
+	}
+	return cached
+}
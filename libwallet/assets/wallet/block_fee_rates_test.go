@@ -0,0 +1,66 @@
+package wallet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBlockFeeRates(t *testing.T) {
+	blocks := map[string]*FeeRateBlock{
+		"a": {
+			Txs: []*FeeRateTx{
+				// Spends an output of a block that isn't cached.
+				{Hash: "a1", Inputs: []FeeRateOutPoint{{"old", 0}}, Outputs: []int64{10000, 5000}, VSize: 200},
+			},
+			AverageFeeRate: 3000,
+		},
+		"b": {
+			Txs: []*FeeRateTx{
+				// Spends both outputs of a1, fee 1000 for 250 vB.
+				{Hash: "b1", Inputs: []FeeRateOutPoint{{"a1", 0}, {"a1", 1}}, Outputs: []int64{14000}, VSize: 250},
+				// Spends an output of the earlier transaction of the block,
+				// fee 2000 for 100 vB.
+				{Hash: "b2", Inputs: []FeeRateOutPoint{{"b1", 0}}, Outputs: []int64{12000}, VSize: 100},
+				{Hash: "b3", Inputs: []FeeRateOutPoint{{"old", 1}}, Outputs: []int64{1000}, VSize: 100},
+			},
+			AverageFeeRate: 5000,
+		},
+	}
+
+	var fetched []string
+	fetch := func(hash string) (*FeeRateBlock, error) {
+		fetched = append(fetched, hash)
+		return blocks[hash], nil
+	}
+
+	var cache BlockFeeRates
+	rates, err := cache.Rates([]string{"a", "b"}, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// No rate of block a can be computed, its average is used.
+	if want := []int64{3000, 4000, 20000}; !reflect.DeepEqual(rates, want) {
+		t.Errorf("got rates %v, want %v", rates, want)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(fetched, want) {
+		t.Errorf("fetched %v, want %v", fetched, want)
+	}
+
+	// Cached blocks are not fetched again and blocks that left the window
+	// are dropped.
+	fetched = nil
+	blocks["c"] = &FeeRateBlock{AverageFeeRate: 1000}
+	rates, err = cache.Rates([]string{"b", "c"}, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int64{4000, 20000, 1000}; !reflect.DeepEqual(rates, want) {
+		t.Errorf("got rates %v, want %v", rates, want)
+	}
+	if want := []string{"c"}; !reflect.DeepEqual(fetched, want) {
+		t.Errorf("fetched %v, want %v", fetched, want)
+	}
+	if _, ok := cache.blocks["a"]; ok {
+		t.Errorf("block a is still cached")
+	}
+}
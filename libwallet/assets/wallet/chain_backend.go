@@ -0,0 +1,52 @@
+package wallet
+
+import (
+	"os"
+	"path/filepath"
+
+	"go.etcd.io/bbolt"
+)
+
+// privateChainFiles are the flat files in which a wallet's private neutrino
+// chain service stores the block headers and the cfilter headers.
+var privateChainFiles = []string{"block_headers.bin", "reg_filter_headers.bin"}
+
+// privateChainBuckets are the buckets in which a wallet's private neutrino
+// chain service indexes the headers and stores the cfilters.
+var privateChainBuckets = [][]byte{[]byte("header-index"), []byte("filter-store")}
+
+// RemovePrivateChainDataOnce deletes the wallet's private chain data the first
+// time the wallet syncs through the shared chain service. The data of a
+// private chain service used afterwards, e.g. while persistent peers are set,
+// is kept so that switching back and forth does not download it again.
+func (wallet *Wallet) RemovePrivateChainDataOnce(db *bbolt.DB) {
+	if wallet.ReadBoolConfigValueForKey(PrivateChainDataRemovedConfigKey, false) {
+		return
+	}
+
+	if err := RemovePrivateChainData(wallet.DataDir(), db); err != nil {
+		log.Errorf("Removing the private chain data of wallet %d failed: %v", wallet.ID, err)
+		return
+	}
+	wallet.SetBoolConfigValueForKey(PrivateChainDataRemovedConfigKey, true)
+}
+
+// RemovePrivateChainData deletes the headers and cfilters stored in the wallet
+// directory and database by a private neutrino chain service. They are unused
+// once the wallet syncs through the shared chain service.
+func RemovePrivateChainData(dataDir string, db *bbolt.DB) error {
+	for _, name := range privateChainFiles {
+		if err := os.Remove(filepath.Join(dataDir, name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return db.Update(func(tx *bbolt.Tx) error {
+		for _, bucket := range privateChainBuckets {
+			if err := tx.DeleteBucket(bucket); err != nil && err != bbolt.ErrBucketNotFound {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,303 @@
+package wallet
+
+import (
+	"sort"
+)
+
+// CoinSelectionStrategy defines how the inputs of a transaction are picked
+// from the available unspent outputs.
+type CoinSelectionStrategy uint8
+
+const (
+	// CoinSelectionLargestFirst spends the largest outputs first, producing
+	// the fewest inputs possible.
+	CoinSelectionLargestFirst CoinSelectionStrategy = iota
+	// CoinSelectionBranchAndBound searches for a set of outputs that matches
+	// the target closely enough for the change output to be skipped. It falls
+	// back to largest first if no such set exists.
+	CoinSelectionBranchAndBound
+	// CoinSelectionOldestFirst spends the oldest outputs first.
+	CoinSelectionOldestFirst
+	// CoinSelectionPrivacy spends all the outputs paid to an address together
+	// so that later transactions cannot be linked to this one through the
+	// reused address.
+	CoinSelectionPrivacy
+	// CoinSelectionConsolidation spends every available output when the fee
+	// rate is low enough, otherwise it behaves as largest first.
+	CoinSelectionConsolidation
+)
+
+// bnbMaxTries limits the number of branches explored by the branch and bound
+// search.
+const bnbMaxTries = 100000
+
+// CoinSelectionStrategies lists all the supported coin selection strategies.
+var CoinSelectionStrategies = []CoinSelectionStrategy{
+	CoinSelectionLargestFirst,
+	CoinSelectionBranchAndBound,
+	CoinSelectionOldestFirst,
+	CoinSelectionPrivacy,
+	CoinSelectionConsolidation,
+}
+
+// String returns the name of the coin selection strategy.
+func (strategy CoinSelectionStrategy) String() string {
+	switch strategy {
+	case CoinSelectionLargestFirst:
+		return "largest_first"
+	case CoinSelectionBranchAndBound:
+		return "branch_and_bound"
+	case CoinSelectionOldestFirst:
+		return "oldest_first"
+	case CoinSelectionPrivacy:
+		return "privacy"
+	case CoinSelectionConsolidation:
+		return "consolidation"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseCoinSelectionStrategy returns the strategy whose String() value matches
+// the provided name.
+func ParseCoinSelectionStrategy(name string) (CoinSelectionStrategy, bool) {
+	for _, strategy := range CoinSelectionStrategies {
+		if strategy.String() == name {
+			return strategy, true
+		}
+	}
+	return CoinSelectionLargestFirst, false
+}
+
+// IsValid returns true if the strategy is one of the supported strategies.
+func (strategy CoinSelectionStrategy) IsValid() bool {
+	return strategy <= CoinSelectionConsolidation
+}
+
+// CoinSelectionCandidate is an unspent output that can be used as an input.
+// Value and InputFee are expressed in the asset's smallest unit.
+type CoinSelectionCandidate struct {
+	UTXO     *UnspentOutput
+	Value    int64
+	InputFee int64 // The fee paid for spending the output at the current fee rate.
+}
+
+// effectiveValue returns the value the candidate contributes to the target
+// once the cost of spending it has been paid.
+func (c *CoinSelectionCandidate) effectiveValue() int64 {
+	return c.Value - c.InputFee
+}
+
+// CoinSelectionParams holds the fee information used by the strategies.
+type CoinSelectionParams struct {
+	Strategy CoinSelectionStrategy
+	// FeeRate and MaxConsolidationFeeRate are in the smallest unit per kvB.
+	FeeRate                 int64
+	MaxConsolidationFeeRate int64
+	// ChangeFee is the fee for adding the change output to the transaction.
+	// Selections that produce change must fund it on top of the target.
+	ChangeFee int64
+	// CostOfChange is the cost of creating and later spending a change
+	// output. Branch and bound accepts a selection that overshoots the target
+	// by up to this amount, the excess is added to the fee instead of being
+	// returned as change.
+	CostOfChange int64
+}
+
+// CoinSelectionPreview summarizes the transaction produced by a strategy.
+type CoinSelectionPreview struct {
+	Strategy            CoinSelectionStrategy
+	Inputs              int
+	EstimatedSignedSize int
+	Fee                 *Amount
+	Change              *Amount
+	Err                 error
+}
+
+// SelectCoins returns the indexes of the candidates selected by the strategy in
+// params to fund the target amount. The target is the total of the outputs and
+// the fee of the transaction without inputs and without change, the fees of
+// the inputs are paid from the effective values of the candidates. Changeless
+// is true if the transaction must be built without a change output, the
+// excess of the selection is then added to the fee. All the candidates are
+// returned if the target cannot be reached.
+func SelectCoins(candidates []*CoinSelectionCandidate, target int64, params CoinSelectionParams) (selection []int, changeless bool) {
+	if params.Strategy == CoinSelectionBranchAndBound {
+		if selection := branchAndBound(candidates, target, params.CostOfChange); selection != nil {
+			return selection, true
+		}
+	}
+
+	// The remaining selections produce change.
+	target += params.ChangeFee
+
+	switch params.Strategy {
+	case CoinSelectionOldestFirst:
+		return accumulate(candidates, target, func(a, b *CoinSelectionCandidate) bool {
+			return a.UTXO.ReceiveTime.Before(b.UTXO.ReceiveTime)
+		}), false
+	case CoinSelectionPrivacy:
+		return selectByAddress(candidates, target), false
+	case CoinSelectionConsolidation:
+		if params.FeeRate <= params.MaxConsolidationFeeRate {
+			selection := make([]int, 0, len(candidates))
+			for i, c := range candidates {
+				if c.effectiveValue() > 0 {
+					selection = append(selection, i)
+				}
+			}
+			return selection, false
+		}
+	}
+
+	return accumulate(candidates, target, func(a, b *CoinSelectionCandidate) bool {
+		return a.Value > b.Value
+	}), false
+}
+
+// accumulate sorts the candidates using less and selects them in that order
+// until the target is reached.
+func accumulate(candidates []*CoinSelectionCandidate, target int64, less func(a, b *CoinSelectionCandidate) bool) []int {
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return less(candidates[order[i]], candidates[order[j]])
+	})
+
+	var total int64
+	selection := make([]int, 0, len(candidates))
+	for _, i := range order {
+		if total >= target {
+			break
+		}
+		if candidates[i].effectiveValue() <= 0 {
+			continue
+		}
+		total += candidates[i].effectiveValue()
+		selection = append(selection, i)
+	}
+	return selection
+}
+
+// branchAndBound performs a depth first search for the set of candidates whose
+// effective value lies within [target, target+costOfChange] with the least
+// excess. Nil is returned if no such set is found.
+func branchAndBound(candidates []*CoinSelectionCandidate, target, costOfChange int64) []int {
+	order := make([]int, 0, len(candidates))
+	var available int64
+	for i, c := range candidates {
+		if c.effectiveValue() > 0 {
+			order = append(order, i)
+			available += c.effectiveValue()
+		}
+	}
+	if available < target {
+		return nil
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return candidates[order[i]].effectiveValue() > candidates[order[j]].effectiveValue()
+	})
+
+	var (
+		best       []int
+		bestExcess int64 = -1
+		tries      int
+		current    = make([]int, 0, len(order))
+	)
+
+	var search func(depth int, total, remaining int64)
+	search = func(depth int, total, remaining int64) {
+		tries++
+		if tries > bnbMaxTries || total > target+costOfChange || total+remaining < target {
+			return
+		}
+
+		if total >= target {
+			if excess := total - target; bestExcess < 0 || excess < bestExcess {
+				bestExcess = excess
+				best = append(best[:0], current...)
+			}
+			return
+		}
+
+		if depth == len(order) {
+			return
+		}
+
+		value := candidates[order[depth]].effectiveValue()
+
+		// Explore the inclusion branch first.
+		current = append(current, order[depth])
+		search(depth+1, total+value, remaining-value)
+		current = current[:len(current)-1]
+
+		search(depth+1, total, remaining-value)
+	}
+	search(0, 0, available)
+
+	return best
+}
+
+// selectByAddress groups the candidates by address and spends whole groups.
+// The smallest group that funds the target on its own is preferred, otherwise
+// the largest groups are combined.
+func selectByAddress(candidates []*CoinSelectionCandidate, target int64) []int {
+	type addressGroup struct {
+		indexes []int
+		total   int64
+	}
+
+	groupsByAddress := make(map[string]*addressGroup)
+	groups := make([]*addressGroup, 0)
+	for i, c := range candidates {
+		if c.effectiveValue() <= 0 {
+			continue
+		}
+		group, ok := groupsByAddress[c.UTXO.Address]
+		if !ok {
+			group = &addressGroup{}
+			groupsByAddress[c.UTXO.Address] = group
+			groups = append(groups, group)
+		}
+		group.indexes = append(group.indexes, i)
+		group.total += c.effectiveValue()
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].total > groups[j].total })
+
+	for i := len(groups) - 1; i >= 0; i-- {
+		if groups[i].total >= target {
+			return groups[i].indexes
+		}
+	}
+
+	var total int64
+	selection := make([]int, 0, len(candidates))
+	for _, group := range groups {
+		if total >= target {
+			break
+		}
+		total += group.total
+		selection = append(selection, group.indexes...)
+	}
+	return selection
+}
+
+// DefaultCoinSelectionStrategy returns the coin selection strategy applied to
+// new transactions unless overridden for a specific send.
+func (wallet *Wallet) DefaultCoinSelectionStrategy() CoinSelectionStrategy {
+	strategy := CoinSelectionStrategy(wallet.ReadIntConfigValueForKey(CoinSelectionStrategyConfigKey, int(CoinSelectionLargestFirst)))
+	if !strategy.IsValid() {
+		return CoinSelectionLargestFirst
+	}
+	return strategy
+}
+
+// SetDefaultCoinSelectionStrategy saves the coin selection strategy applied to
+// new transactions.
+func (wallet *Wallet) SetDefaultCoinSelectionStrategy(strategy CoinSelectionStrategy) {
+	wallet.SetIntConfigValueForKey(CoinSelectionStrategyConfigKey, int(strategy))
+}
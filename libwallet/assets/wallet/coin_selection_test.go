@@ -0,0 +1,122 @@
+package wallet
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func coinSelectionCandidates(values ...int64) []*CoinSelectionCandidate {
+	candidates := make([]*CoinSelectionCandidate, 0, len(values))
+	for _, value := range values {
+		candidates = append(candidates, &CoinSelectionCandidate{
+			UTXO:     &UnspentOutput{},
+			Value:    value,
+			InputFee: 10,
+		})
+	}
+	return candidates
+}
+
+func TestBranchAndBound(t *testing.T) {
+	tests := []struct {
+		name         string
+		values       []int64
+		target       int64
+		costOfChange int64
+		want         []int
+	}{
+		{
+			name:   "exact match",
+			values: []int64{1010, 2010, 3010, 5010},
+			target: 6000,
+			want:   []int{0, 3},
+		},
+		{
+			name:         "least excess within cost of change",
+			values:       []int64{4010, 2060, 2030},
+			target:       6000,
+			costOfChange: 100,
+			want:         []int{0, 2},
+		},
+		{
+			name:         "excess above cost of change",
+			values:       []int64{4010, 4010},
+			target:       6000,
+			costOfChange: 100,
+			want:         nil,
+		},
+		{
+			name:   "insufficient funds",
+			values: []int64{1010, 2010},
+			target: 6000,
+			want:   nil,
+		},
+		{
+			name:   "input fees are paid",
+			values: []int64{3000, 3000},
+			target: 6000,
+			want:   nil,
+		},
+	}
+
+	for _, test := range tests {
+		got := branchAndBound(coinSelectionCandidates(test.values...), test.target, test.costOfChange)
+		sort.Ints(got)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestSelectCoins(t *testing.T) {
+	tests := []struct {
+		name           string
+		values         []int64
+		target         int64
+		params         CoinSelectionParams
+		want           []int
+		wantChangeless bool
+	}{
+		{
+			name:           "branch and bound exact match",
+			values:         []int64{5010, 1010, 3010},
+			target:         4000,
+			params:         CoinSelectionParams{Strategy: CoinSelectionBranchAndBound, ChangeFee: 30, CostOfChange: 100},
+			want:           []int{1, 2},
+			wantChangeless: true,
+		},
+		{
+			name:   "branch and bound falls back to largest first",
+			values: []int64{5010, 1510, 3510},
+			target: 4000,
+			params: CoinSelectionParams{Strategy: CoinSelectionBranchAndBound, ChangeFee: 30, CostOfChange: 100},
+			want:   []int{0},
+		},
+		{
+			name:   "largest first funds the change output",
+			values: []int64{4010, 1010, 3010},
+			target: 4000,
+			params: CoinSelectionParams{Strategy: CoinSelectionLargestFirst, ChangeFee: 30},
+			want:   []int{0, 2},
+		},
+		{
+			name:   "insufficient funds",
+			values: []int64{1010, 2010, -5},
+			target: 4000,
+			params: CoinSelectionParams{Strategy: CoinSelectionBranchAndBound, ChangeFee: 30, CostOfChange: 100},
+			want:   []int{0, 1},
+		},
+	}
+
+	for _, test := range tests {
+		got, changeless := SelectCoins(coinSelectionCandidates(test.values...), test.target, test.params)
+		sort.Ints(got)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+		if changeless != test.wantChangeless {
+			t.Errorf("%s: got changeless %v, want %v", test.name, changeless, test.wantChangeless)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package wallet
+
+import (
+	"crypto/x509"
+	"errors"
+	"net"
+	"strconv"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// ElectrumServerConfig describes the Electrum server a BTC or LTC wallet
+// syncs through in place of neutrino.
+type ElectrumServerConfig struct {
+	// Host is the host:port of the server.
+	Host string `json:"host"`
+	// TLS enables TLS for the connection to the server.
+	TLS bool `json:"tls"`
+	// CertPEM, if set, is the only certificate trusted for the TLS
+	// connection. Electrum servers commonly use self-signed certificates.
+	CertPEM string `json:"cert_pem"`
+	// Proxy is the address of a SOCKS5 proxy, such as Tor, the connection is
+	// made through.
+	Proxy string `json:"proxy"`
+}
+
+// Validate returns an error if the server or proxy address is invalid or the
+// certificate can't be parsed.
+func (cfg *ElectrumServerConfig) Validate() error {
+	if !isHostPort(cfg.Host) {
+		return errors.New(utils.ErrInvalidAddress)
+	}
+	if cfg.Proxy != "" && !isHostPort(cfg.Proxy) {
+		return errors.New(utils.ErrInvalidAddress)
+	}
+	if cfg.CertPEM != "" {
+		if !x509.NewCertPool().AppendCertsFromPEM([]byte(cfg.CertPEM)) {
+			return errors.New(utils.ErrInvalid)
+		}
+	}
+	return nil
+}
+
+func isHostPort(addr string) bool {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || host == "" {
+		return false
+	}
+	p, err := strconv.ParseUint(port, 10, 16)
+	return err == nil && p > 0
+}
+
+// ElectrumServerConfig returns the Electrum server the wallet syncs through.
+// Nil is returned if the wallet syncs through neutrino.
+func (wallet *Wallet) ElectrumServerConfig() *ElectrumServerConfig {
+	cfg := new(ElectrumServerConfig)
+	err := wallet.ReadUserConfigValue(ElectrumServerConfigKey, cfg)
+	if err != nil || cfg.Host == "" {
+		return nil
+	}
+	return cfg
+}
+
+// SaveElectrumServerConfig persists the Electrum server the wallet syncs
+// through. A nil config switches the wallet back to neutrino. The change
+// takes effect the next time the wallet syncs.
+func (wallet *Wallet) SaveElectrumServerConfig(cfg *ElectrumServerConfig) error {
+	if cfg == nil {
+		wallet.DeleteUserConfigValueForKey(ElectrumServerConfigKey)
+		return nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	wallet.SaveUserConfigValue(ElectrumServerConfigKey, cfg)
+	return nil
+}
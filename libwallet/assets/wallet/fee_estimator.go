@@ -0,0 +1,356 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// FeeEstimatorBlockstream queries the blockstream.info esplora API.
+	FeeEstimatorBlockstream = "blockstream"
+	// FeeEstimatorMempoolSpace queries the mempool.space API or one of its
+	// forks.
+	FeeEstimatorMempoolSpace = "mempool.space"
+	// FeeEstimatorCustom queries the esplora compatible endpoint set by the
+	// user.
+	FeeEstimatorCustom = "custom"
+	// FeeEstimatorLocal computes the fee rates from the recent blocks seen by
+	// the chain service.
+	FeeEstimatorLocal = "local"
+	// FeeEstimatorElectrum queries the Electrum server the wallet syncs
+	// through.
+	FeeEstimatorElectrum = "electrum"
+)
+
+// DefaultFeeEstimatorOrder is the order in which the fee estimators are tried
+// unless the user has set a different one.
+var DefaultFeeEstimatorOrder = []string{
+	FeeEstimatorCustom,
+	FeeEstimatorBlockstream,
+	FeeEstimatorMempoolSpace,
+	FeeEstimatorLocal,
+}
+
+// FeeEstimator is a source of fee rate estimates.
+type FeeEstimator interface {
+	// Name identifies the estimator in the fallback ordering.
+	Name() string
+	// IsRemote returns true if the estimator queries a third party. Remote
+	// estimators are disabled by the FeeRateHTTPAPI privacy setting.
+	IsRemote() bool
+	// EstimateFees returns the fee rates in the asset's smallest unit per kvB
+	// sorted by ConfirmedBlocks in ascending order.
+	EstimateFees() ([]FeeEstimate, error)
+}
+
+// EsploraFeeEstimator queries an esplora style API. The API returns a map of
+// confirmation targets to fee rates in smallest unit per vB.
+type EsploraFeeEstimator struct {
+	name     string
+	url      string
+	toAmount func(int64) AssetAmount
+}
+
+// NewEsploraFeeEstimator creates an estimator that queries the esplora style
+// fee-estimates endpoint at url.
+func NewEsploraFeeEstimator(name, url string, toAmount func(int64) AssetAmount) *EsploraFeeEstimator {
+	return &EsploraFeeEstimator{name: name, url: url, toAmount: toAmount}
+}
+
+// Name implements FeeEstimator.
+func (e *EsploraFeeEstimator) Name() string { return e.name }
+
+// IsRemote implements FeeEstimator.
+func (e *EsploraFeeEstimator) IsRemote() bool { return true }
+
+// EstimateFees implements FeeEstimator.
+func (e *EsploraFeeEstimator) EstimateFees() ([]FeeEstimate, error) {
+	resp := make(map[string]float64)
+	req := &utils.ReqConfig{
+		Method:  http.MethodGet,
+		HTTPURL: e.url,
+	}
+
+	if _, err := utils.HTTPRequest(req, &resp); err != nil {
+		return nil, fmt.Errorf("fetching %s fee estimates failed: %v", e.name, err)
+	}
+
+	results := make([]FeeEstimate, 0, len(resp))
+	for blocks, feerate := range resp {
+		confirmations, err := strconv.ParseInt(blocks, 10, 32)
+		if err != nil {
+			// Invalid blocks confirmation found ignore it.
+			continue
+		}
+		results = append(results, FeeEstimate{
+			ConfirmedBlocks: int32(confirmations),
+			// 1 unit/vB == 1000 units/kvB
+			Feerate: e.toAmount(int64(feerate * 1000.0)),
+		})
+	}
+	return sortFeeEstimates(results)
+}
+
+// MempoolFeeEstimator queries the recommended fees endpoint of a mempool.space
+// style API.
+type MempoolFeeEstimator struct {
+	name     string
+	url      string
+	toAmount func(int64) AssetAmount
+}
+
+// NewMempoolFeeEstimator creates an estimator that queries the mempool.space
+// style recommended fees endpoint at url.
+func NewMempoolFeeEstimator(name, url string, toAmount func(int64) AssetAmount) *MempoolFeeEstimator {
+	return &MempoolFeeEstimator{name: name, url: url, toAmount: toAmount}
+}
+
+// Name implements FeeEstimator.
+func (e *MempoolFeeEstimator) Name() string { return e.name }
+
+// IsRemote implements FeeEstimator.
+func (e *MempoolFeeEstimator) IsRemote() bool { return true }
+
+// EstimateFees implements FeeEstimator.
+func (e *MempoolFeeEstimator) EstimateFees() ([]FeeEstimate, error) {
+	var resp struct {
+		FastestFee  float64 `json:"fastestFee"`
+		HalfHourFee float64 `json:"halfHourFee"`
+		HourFee     float64 `json:"hourFee"`
+		EconomyFee  float64 `json:"economyFee"`
+	}
+	req := &utils.ReqConfig{
+		Method:  http.MethodGet,
+		HTTPURL: e.url,
+	}
+
+	if _, err := utils.HTTPRequest(req, &resp); err != nil {
+		return nil, fmt.Errorf("fetching %s fee estimates failed: %v", e.name, err)
+	}
+
+	// The recommended fees are mapped onto the equivalent number of blocks.
+	results := make([]FeeEstimate, 0, 4)
+	for blocks, feerate := range map[int32]float64{
+		1:   resp.FastestFee,
+		3:   resp.HalfHourFee,
+		6:   resp.HourFee,
+		144: resp.EconomyFee,
+	} {
+		if feerate <= 0 {
+			continue
+		}
+		results = append(results, FeeEstimate{
+			ConfirmedBlocks: blocks,
+			Feerate:         e.toAmount(int64(feerate * 1000.0)),
+		})
+	}
+	return sortFeeEstimates(results)
+}
+
+// LocalFeeEstimator derives the fee rates from the fee rates paid in the most
+// recent blocks. No third party is queried.
+type LocalFeeEstimator struct {
+	// blockFeeRates returns the fee rates paid in the recent blocks in
+	// smallest unit per kvB.
+	blockFeeRates func() ([]int64, error)
+	minFeeRate    int64
+	toAmount      func(int64) AssetAmount
+}
+
+// NewLocalFeeEstimator creates an estimator that derives the fee rates from
+// the block fee rates returned by blockFeeRates. No estimate is lower than
+// minFeeRate.
+func NewLocalFeeEstimator(blockFeeRates func() ([]int64, error), minFeeRate int64,
+	toAmount func(int64) AssetAmount,
+) *LocalFeeEstimator {
+	return &LocalFeeEstimator{
+		blockFeeRates: blockFeeRates,
+		minFeeRate:    minFeeRate,
+		toAmount:      toAmount,
+	}
+}
+
+// Name implements FeeEstimator.
+func (e *LocalFeeEstimator) Name() string { return FeeEstimatorLocal }
+
+// IsRemote implements FeeEstimator.
+func (e *LocalFeeEstimator) IsRemote() bool { return false }
+
+// EstimateFees implements FeeEstimator. Faster confirmation targets use the
+// higher percentiles of the recent block fee rates.
+func (e *LocalFeeEstimator) EstimateFees() ([]FeeEstimate, error) {
+	rates, err := e.blockFeeRates()
+	if err != nil {
+		return nil, err
+	}
+	if len(rates) == 0 {
+		return nil, errors.New("no recent block fee rates available")
+	}
+
+	sort.Slice(rates, func(i, j int) bool { return rates[i] < rates[j] })
+	percentile := func(p int) int64 {
+		rate := feeRatePercentile(rates, p)
+		if rate < e.minFeeRate {
+			return e.minFeeRate
+		}
+		return rate
+	}
+
+	return []FeeEstimate{
+		{ConfirmedBlocks: FeeTargetHighBlocks, Feerate: e.toAmount(percentile(90))},
+		{ConfirmedBlocks: FeeTargetMediumBlocks, Feerate: e.toAmount(percentile(50))},
+		{ConfirmedBlocks: FeeTargetLowBlocks, Feerate: e.toAmount(percentile(10))},
+	}, nil
+}
+
+// feeRatePercentile returns the nearest rank p-th percentile of the rates,
+// which must be sorted in ascending order.
+func feeRatePercentile(rates []int64, p int) int64 {
+	rank := (len(rates)*p + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return rates[rank-1]
+}
+
+// ElectrumFeeEstimator queries the fee estimates of the Electrum server the
+// wallet syncs through. The server is chosen by the user and already sees the
+// wallet's addresses, it is therefore not considered a third party.
+type ElectrumFeeEstimator struct {
+	// estimateFee returns the fee rate in smallest unit per kvB needed for a
+	// confirmation within blocks.
+	estimateFee func(blocks int32) (int64, error)
+	toAmount    func(int64) AssetAmount
+}
+
+// NewElectrumFeeEstimator creates an estimator that queries estimateFee for
+// each fee priority.
+func NewElectrumFeeEstimator(estimateFee func(blocks int32) (int64, error),
+	toAmount func(int64) AssetAmount,
+) *ElectrumFeeEstimator {
+	return &ElectrumFeeEstimator{estimateFee: estimateFee, toAmount: toAmount}
+}
+
+// Name implements FeeEstimator.
+func (e *ElectrumFeeEstimator) Name() string { return FeeEstimatorElectrum }
+
+// IsRemote implements FeeEstimator.
+func (e *ElectrumFeeEstimator) IsRemote() bool { return false }
+
+// EstimateFees implements FeeEstimator.
+func (e *ElectrumFeeEstimator) EstimateFees() ([]FeeEstimate, error) {
+	results := make([]FeeEstimate, 0, len(FeePriorities))
+	for _, blocks := range []int32{FeeTargetHighBlocks, FeeTargetMediumBlocks, FeeTargetLowBlocks} {
+		feeRate, err := e.estimateFee(blocks)
+		if err != nil {
+			return nil, fmt.Errorf("fetching electrum fee estimates failed: %v", err)
+		}
+		results = append(results, FeeEstimate{
+			ConfirmedBlocks: blocks,
+			Feerate:         e.toAmount(feeRate),
+		})
+	}
+	return results, nil
+}
+
+// sortFeeEstimates sorts the estimates by ConfirmedBlocks in ascending order.
+// An error is returned if there are no estimates.
+func sortFeeEstimates(estimates []FeeEstimate) ([]FeeEstimate, error) {
+	if len(estimates) == 0 {
+		return nil, errors.New("fee estimates not found")
+	}
+	sort.Slice(estimates, func(i, j int) bool {
+		return estimates[i].ConfirmedBlocks < estimates[j].ConfirmedBlocks
+	})
+	return estimates, nil
+}
+
+// EstimateFeesWithFallback queries the estimators in order and returns the
+// first successful result together with the name of the estimator used.
+// Remote estimators are skipped if allowRemote is false.
+func EstimateFeesWithFallback(estimators []FeeEstimator, allowRemote bool) ([]FeeEstimate, string, error) {
+	var lastErr error
+	for _, estimator := range estimators {
+		if estimator.IsRemote() && !allowRemote {
+			continue
+		}
+
+		estimates, err := estimator.EstimateFees()
+		if err != nil {
+			log.Debugf("%s fee estimator failed: %v", estimator.Name(), err)
+			lastErr = err
+			continue
+		}
+		return estimates, estimator.Name(), nil
+	}
+
+	if lastErr == nil {
+		lastErr = errors.New("no fee estimator available")
+	}
+	return nil, "", lastErr
+}
+
+// OrderFeeEstimators returns the estimators sorted by the wallet's fee
+// estimator order. Estimators missing from the order are dropped.
+func (wallet *Wallet) OrderFeeEstimators(estimators []FeeEstimator) []FeeEstimator {
+	byName := make(map[string]FeeEstimator, len(estimators))
+	for _, estimator := range estimators {
+		byName[estimator.Name()] = estimator
+	}
+
+	ordered := make([]FeeEstimator, 0, len(estimators))
+	for _, name := range wallet.FeeEstimatorOrder() {
+		if estimator, ok := byName[name]; ok {
+			ordered = append(ordered, estimator)
+		}
+	}
+	return ordered
+}
+
+// FeeEstimatorOrder returns the order in which the fee estimators are tried.
+func (wallet *Wallet) FeeEstimatorOrder() []string {
+	var order []string
+	if err := wallet.ReadUserConfigValue(FeeEstimatorOrderConfigKey, &order); err != nil || len(order) == 0 {
+		return DefaultFeeEstimatorOrder
+	}
+	return order
+}
+
+// SetFeeEstimatorOrder sets the order in which the fee estimators are tried.
+// Estimators left out of the order are disabled.
+func (wallet *Wallet) SetFeeEstimatorOrder(order []string) error {
+	for _, name := range order {
+		switch name {
+		case FeeEstimatorBlockstream, FeeEstimatorMempoolSpace, FeeEstimatorCustom, FeeEstimatorLocal:
+		default:
+			return fmt.Errorf("unknown fee estimator %q", name)
+		}
+	}
+	wallet.SaveUserConfigValue(FeeEstimatorOrderConfigKey, order)
+	return nil
+}
+
+// FeeEstimatorURL returns the self-hosted esplora compatible fee estimates
+// endpoint. It is empty if none was set.
+func (wallet *Wallet) FeeEstimatorURL() string {
+	return wallet.ReadStringConfigValueForKey(FeeEstimatorURLConfigKey, "")
+}
+
+// SetFeeEstimatorURL sets the self-hosted esplora compatible fee estimates
+// endpoint. An empty URL disables the custom estimator.
+func (wallet *Wallet) SetFeeEstimatorURL(feeURL string) error {
+	if feeURL != "" {
+		u, err := url.ParseRequestURI(feeURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return errors.New(utils.ErrInvalidAddress)
+		}
+	}
+	wallet.SetStringConfigValueForKey(FeeEstimatorURLConfigKey, feeURL)
+	return nil
+}
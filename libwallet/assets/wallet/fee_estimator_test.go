@@ -0,0 +1,61 @@
+package wallet
+
+import (
+	"testing"
+)
+
+func TestFeeRatePercentile(t *testing.T) {
+	rates := []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tests := []struct {
+		p    int
+		want int64
+	}{
+		{0, 1},
+		{10, 1},
+		{50, 5},
+		{90, 9},
+		{100, 10},
+	}
+
+	for _, test := range tests {
+		if got := feeRatePercentile(rates, test.p); got != test.want {
+			t.Errorf("p%d: got %d, want %d", test.p, got, test.want)
+		}
+	}
+
+	if got := feeRatePercentile([]int64{7}, 90); got != 7 {
+		t.Errorf("single rate: got %d, want 7", got)
+	}
+}
+
+func TestLocalFeeEstimator(t *testing.T) {
+	rates := []int64{9000, 1000, 500, 4000, 2000, 3000, 8000, 6000, 5000, 7000}
+	estimator := NewLocalFeeEstimator(func() ([]int64, error) {
+		return append([]int64(nil), rates...), nil
+	}, 1000, toTestAmount)
+
+	estimates, err := estimator.EstimateFees()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[int32]int64{
+		FeeTargetHighBlocks:   8000,
+		FeeTargetMediumBlocks: 4000,
+		// The 10th percentile is below the minimum fee rate.
+		FeeTargetLowBlocks: 1000,
+	}
+	if len(estimates) != len(want) {
+		t.Fatalf("got %d estimates, want %d", len(estimates), len(want))
+	}
+	for _, estimate := range estimates {
+		if got := estimate.Feerate.ToInt(); got != want[estimate.ConfirmedBlocks] {
+			t.Errorf("%d blocks: got %d, want %d", estimate.ConfirmedBlocks, got, want[estimate.ConfirmedBlocks])
+		}
+	}
+
+	empty := NewLocalFeeEstimator(func() ([]int64, error) { return nil, nil }, 1000, toTestAmount)
+	if _, err := empty.EstimateFees(); err == nil {
+		t.Errorf("expected an error without block fee rates")
+	}
+}
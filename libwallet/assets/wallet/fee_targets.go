@@ -0,0 +1,115 @@
+package wallet
+
+import (
+	"time"
+)
+
+// FeePriority defines how fast a transaction should be confirmed.
+type FeePriority uint8
+
+const (
+	// FeePriorityLow targets a confirmation within FeeTargetLowBlocks.
+	FeePriorityLow FeePriority = iota
+	// FeePriorityMedium targets a confirmation within FeeTargetMediumBlocks.
+	FeePriorityMedium
+	// FeePriorityHigh targets a confirmation within FeeTargetHighBlocks.
+	FeePriorityHigh
+)
+
+const (
+	// FeeTargetHighBlocks is the number of blocks a high priority tx is
+	// expected to be confirmed in.
+	FeeTargetHighBlocks int32 = 1
+	// FeeTargetMediumBlocks is the number of blocks a medium priority tx is
+	// expected to be confirmed in.
+	FeeTargetMediumBlocks int32 = 3
+	// FeeTargetLowBlocks is the number of blocks a low priority tx is expected
+	// to be confirmed in.
+	FeeTargetLowBlocks int32 = 6
+)
+
+// FeePriorities lists the fee priorities from the cheapest to the fastest.
+var FeePriorities = []FeePriority{FeePriorityLow, FeePriorityMedium, FeePriorityHigh}
+
+// String returns the name of the fee priority.
+func (priority FeePriority) String() string {
+	switch priority {
+	case FeePriorityLow:
+		return "low"
+	case FeePriorityMedium:
+		return "medium"
+	case FeePriorityHigh:
+		return "high"
+	default:
+		return "unknown"
+	}
+}
+
+// TargetBlocks returns the number of blocks the priority targets.
+func (priority FeePriority) TargetBlocks() int32 {
+	switch priority {
+	case FeePriorityHigh:
+		return FeeTargetHighBlocks
+	case FeePriorityMedium:
+		return FeeTargetMediumBlocks
+	default:
+		return FeeTargetLowBlocks
+	}
+}
+
+// FeeTarget is a fee rate suggestion for a given priority.
+type FeeTarget struct {
+	Priority FeePriority
+	// FeeRate is in Sat/kvB, Lit/kvB or Atoms/kB.
+	FeeRate AssetAmount
+	// ConfirmationBlocks is the number of blocks the tx is expected to be
+	// confirmed in when paying FeeRate.
+	ConfirmationBlocks        int32
+	EstimatedConfirmationTime time.Duration
+	// TotalFee is the fee paid by the transaction currently being authored.
+	// It is nil if no transaction is being authored.
+	TotalFee AssetAmount
+}
+
+// NewFeeTarget creates a fee target. The total fee is only computed if txSize
+// is greater than zero.
+func NewFeeTarget(priority FeePriority, feeRate int64, blocks int32, blockTimeMinutes float64,
+	txSize int, toAmount func(int64) AssetAmount,
+) *FeeTarget {
+	target := &FeeTarget{
+		Priority:                  priority,
+		FeeRate:                   toAmount(feeRate),
+		ConfirmationBlocks:        blocks,
+		EstimatedConfirmationTime: time.Duration(float64(blocks) * blockTimeMinutes * float64(time.Minute)),
+	}
+	if txSize > 0 {
+		target.TotalFee = toAmount(feeRate * int64(txSize) / 1000)
+	}
+	return target
+}
+
+// FeeTargetsFromEstimates maps the API fee estimates onto the fee priorities.
+// Each priority uses the estimate with the largest number of confirmation
+// blocks that doesn't exceed its target, or the fastest estimate if none does.
+// The estimates must be sorted by ConfirmedBlocks in ascending order.
+func FeeTargetsFromEstimates(estimates []FeeEstimate, blockTimeMinutes float64, txSize int,
+	toAmount func(int64) AssetAmount,
+) []*FeeTarget {
+	if len(estimates) == 0 {
+		return nil
+	}
+
+	targets := make([]*FeeTarget, 0, len(FeePriorities))
+	for _, priority := range FeePriorities {
+		estimate := estimates[0]
+		for _, e := range estimates {
+			if e.ConfirmedBlocks > priority.TargetBlocks() {
+				break
+			}
+			estimate = e
+		}
+		targets = append(targets, NewFeeTarget(priority, estimate.Feerate.ToInt(),
+			estimate.ConfirmedBlocks, blockTimeMinutes, txSize, toAmount))
+	}
+	return targets
+}
@@ -0,0 +1,75 @@
+package wallet
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// testAmount is an AssetAmount counted in the smallest unit.
+type testAmount int64
+
+func (a testAmount) ToCoin() float64              { return float64(a) / 1e8 }
+func (a testAmount) String() string               { return strconv.FormatInt(int64(a), 10) }
+func (a testAmount) MulF64(f float64) AssetAmount { return testAmount(float64(a) * f) }
+func (a testAmount) ToInt() int64                 { return int64(a) }
+
+func toTestAmount(v int64) AssetAmount { return testAmount(v) }
+
+func TestFeeTargetsFromEstimates(t *testing.T) {
+	estimates := []FeeEstimate{
+		{ConfirmedBlocks: 2, Feerate: testAmount(20000)},
+		{ConfirmedBlocks: 3, Feerate: testAmount(15000)},
+		{ConfirmedBlocks: 5, Feerate: testAmount(8000)},
+		{ConfirmedBlocks: 144, Feerate: testAmount(1000)},
+	}
+
+	tests := []struct {
+		priority FeePriority
+		blocks   int32
+		feeRate  int64
+	}{
+		// No estimate confirms within one block, the fastest is used.
+		{FeePriorityHigh, 2, 20000},
+		{FeePriorityMedium, 3, 15000},
+		// The slowest estimate that confirms within six blocks.
+		{FeePriorityLow, 5, 8000},
+	}
+
+	targets := FeeTargetsFromEstimates(estimates, 10, 250, toTestAmount)
+	if len(targets) != len(FeePriorities) {
+		t.Fatalf("got %d targets, want %d", len(targets), len(FeePriorities))
+	}
+
+	for _, test := range tests {
+		var target *FeeTarget
+		for _, tg := range targets {
+			if tg.Priority == test.priority {
+				target = tg
+			}
+		}
+		if target == nil {
+			t.Errorf("%s: target missing", test.priority)
+			continue
+		}
+		if target.ConfirmationBlocks != test.blocks {
+			t.Errorf("%s: got %d blocks, want %d", test.priority, target.ConfirmationBlocks, test.blocks)
+		}
+		if target.FeeRate.ToInt() != test.feeRate {
+			t.Errorf("%s: got fee rate %d, want %d", test.priority, target.FeeRate.ToInt(), test.feeRate)
+		}
+		if want := time.Duration(test.blocks) * 10 * time.Minute; target.EstimatedConfirmationTime != want {
+			t.Errorf("%s: got confirmation time %v, want %v", test.priority, target.EstimatedConfirmationTime, want)
+		}
+		if want := test.feeRate * 250 / 1000; target.TotalFee == nil || target.TotalFee.ToInt() != want {
+			t.Errorf("%s: got total fee %v, want %d", test.priority, target.TotalFee, want)
+		}
+	}
+
+	if targets := FeeTargetsFromEstimates(nil, 10, 250, toTestAmount); targets != nil {
+		t.Errorf("got %d targets without estimates", len(targets))
+	}
+	if targets := FeeTargetsFromEstimates(estimates, 10, 0, toTestAmount); targets[0].TotalFee != nil {
+		t.Errorf("got a total fee without a transaction")
+	}
+}
@@ -0,0 +1,127 @@
+package wallet
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// localNodeHost is the host of the node the wallets connect to on the local
+// networks.
+const localNodeHost = "127.0.0.1"
+
+// BannedPeer is a peer the user banned. Peers are banned by host so that all
+// the ports of the host are rejected.
+type BannedPeer struct {
+	Host     string `json:"host"`
+	BannedAt int64  `json:"banned_at"`
+}
+
+// PeerHost returns the host part of a peer address. The address is returned
+// as is if it has no port.
+func PeerHost(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return strings.Trim(addr, "[]")
+	}
+	return host
+}
+
+// BannedPeers returns the peers banned by the user.
+func (wallet *Wallet) BannedPeers() []*BannedPeer {
+	var peers []*BannedPeer
+	if err := wallet.ReadUserConfigValue(BannedPeersConfigKey, &peers); err != nil {
+		return nil
+	}
+	return peers
+}
+
+// IsPeerBanned returns true if the host of addr was banned by the user.
+func (wallet *Wallet) IsPeerBanned(addr string) bool {
+	host := PeerHost(addr)
+	for _, peer := range wallet.BannedPeers() {
+		if peer.Host == host {
+			return true
+		}
+	}
+	return false
+}
+
+// SaveBannedPeer adds the host of addr to the banned peers. The assets
+// disconnect the banned peers and don't connect to them again.
+func (wallet *Wallet) SaveBannedPeer(addr string) error {
+	host := PeerHost(addr)
+	if host == "" {
+		return errors.New(utils.ErrInvalidAddress)
+	}
+	if wallet.IsPeerBanned(host) {
+		return errors.New(utils.ErrExist)
+	}
+
+	peers := append(wallet.BannedPeers(), &BannedPeer{Host: host, BannedAt: time.Now().Unix()})
+	wallet.SaveUserConfigValue(BannedPeersConfigKey, peers)
+	return nil
+}
+
+// DeleteBannedPeer removes the host of addr from the banned peers.
+func (wallet *Wallet) DeleteBannedPeer(addr string) error {
+	host := PeerHost(addr)
+	peers := wallet.BannedPeers()
+	for i, peer := range peers {
+		if peer.Host == host {
+			peers = append(peers[:i], peers[i+1:]...)
+			wallet.SaveUserConfigValue(BannedPeersConfigKey, peers)
+			return nil
+		}
+	}
+	return errors.New(utils.ErrNotExist)
+}
+
+// PersistentPeers returns the peer addresses the wallet is set to connect to,
+// with their default port if none was provided. Banned and invalid addresses
+// are skipped. Simnet and regnet have no seeders, wallets on these networks
+// connect to a node running on the local host unless peers are set.
+func (wallet *Wallet) PersistentPeers(defaultPort string) []string {
+	peerAddresses := wallet.ReadStringConfigValueForKey(SpvPersistentPeerAddressesConfigKey, "")
+	if peerAddresses == "" {
+		if wallet.netType == utils.Simulation || wallet.netType == utils.Regression {
+			return []string{net.JoinHostPort(localNodeHost, defaultPort)}
+		}
+		return nil
+	}
+
+	var peers []string
+	for _, address := range strings.Split(peerAddresses, ";") {
+		if address == "" {
+			continue
+		}
+		peerAddress, err := utils.NormalizeAddress(address, defaultPort)
+		if err != nil {
+			log.Errorf("SPV peer address(%s) is invalid: %v", address, err)
+			continue
+		}
+		if wallet.IsPeerBanned(peerAddress) {
+			log.Warnf("Skipping banned SPV peer %s", peerAddress)
+			continue
+		}
+		peers = append(peers, peerAddress)
+	}
+	return peers
+}
+
+// AddPersistentPeer adds addr to the peers the wallet is set to connect to.
+func (wallet *Wallet) AddPersistentPeer(addr string) {
+	knownAddr := wallet.ReadStringConfigValueForKey(SpvPersistentPeerAddressesConfigKey, "")
+	for _, known := range strings.Split(knownAddr, ";") {
+		if known == addr {
+			return
+		}
+	}
+	if knownAddr != "" {
+		knownAddr += ";"
+	}
+	wallet.SaveUserConfigValue(SpvPersistentPeerAddressesConfigKey, knownAddr+addr)
+}
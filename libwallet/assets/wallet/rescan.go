@@ -0,0 +1,174 @@
+package wallet
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// RescanOptions defines the blocks and the addresses to rescan.
+type RescanOptions struct {
+	// StartHeight is the first block rescanned.
+	StartHeight int32 `json:"start_height"`
+	// EndHeight is the last block rescanned. The rescan runs up to the chain
+	// tip if it is not set.
+	EndHeight int32 `json:"end_height"`
+	// Accounts limits the rescan to the addresses of the accounts.
+	Accounts []int32 `json:"accounts"`
+	// Addresses limits the rescan to the addresses.
+	Addresses []string `json:"addresses"`
+}
+
+// Validate checks that the range of blocks to rescan is within the chain.
+func (opts *RescanOptions) Validate(bestHeight int32) error {
+	if opts.StartHeight < 0 || opts.StartHeight > bestHeight || opts.EndHeight > bestHeight {
+		return errors.New(utils.ErrInvalid)
+	}
+	if opts.EndHeight > 0 && opts.EndHeight < opts.StartHeight {
+		return errors.New(utils.ErrInvalid)
+	}
+	return nil
+}
+
+// IsPartial returns true if the rescan is limited to some of the wallet
+// addresses.
+func (opts *RescanOptions) IsPartial() bool {
+	return len(opts.Accounts) > 0 || len(opts.Addresses) > 0
+}
+
+// rescanCheckpointInterval is the minimum time between two writes of the
+// rescan checkpoint to the wallet DB.
+const rescanCheckpointInterval = 30 * time.Second
+
+// RescanCheckpoint is the progress of an unfinished rescan saved so that it
+// can resume after a restart or a cancellation.
+type RescanCheckpoint struct {
+	RescanOptions
+	ScannedThrough int32 `json:"scanned_through"`
+}
+
+// ResumeOptions returns the options to rescan the blocks not scanned yet.
+func (cp *RescanCheckpoint) ResumeOptions() *RescanOptions {
+	opts := cp.RescanOptions
+	if cp.ScannedThrough > opts.StartHeight {
+		opts.StartHeight = cp.ScannedThrough
+	}
+	return &opts
+}
+
+// RescanCheckpoint returns the progress of the unfinished rescan or nil if
+// there is none.
+func (wallet *Wallet) RescanCheckpoint() *RescanCheckpoint {
+	cp := new(RescanCheckpoint)
+	if err := wallet.ReadUserConfigValue(RescanCheckpointConfigKey, cp); err != nil || cp.ScannedThrough <= 0 {
+		return nil
+	}
+	return cp
+}
+
+// DeleteRescanCheckpoint discards the progress of the unfinished rescan so
+// that it is not resumed.
+func (wallet *Wallet) DeleteRescanCheckpoint() {
+	wallet.DeleteUserConfigValueForKey(RescanCheckpointConfigKey)
+}
+
+// RescanTracker computes the progress reports of a rescan and optionally
+// saves the progress as the wallet rescan checkpoint.
+type RescanTracker struct {
+	wallet          *Wallet
+	opts            RescanOptions
+	endHeight       int32
+	startTime       time.Time
+	saveCheckpoints bool
+
+	scannedThrough int32
+	savedThrough   int32
+	lastSave       time.Time
+}
+
+// NewRescanTracker returns a tracker of a rescan of the blocks defined by
+// opts. bestHeight is the end of the rescan if opts has no end height.
+func (wallet *Wallet) NewRescanTracker(opts *RescanOptions, bestHeight int32, saveCheckpoints bool) *RescanTracker {
+	endHeight := opts.EndHeight
+	if endHeight <= 0 {
+		endHeight = bestHeight
+	}
+	return &RescanTracker{
+		wallet:          wallet,
+		opts:            *opts,
+		endHeight:       endHeight,
+		startTime:       time.Now(),
+		saveCheckpoints: saveCheckpoints,
+	}
+}
+
+// Progress returns the progress report of the rescan once the blocks up to
+// scannedThrough are scanned. The checkpoint is saved at most once every
+// rescanCheckpointInterval.
+func (t *RescanTracker) Progress(scannedThrough int32) *HeadersRescanProgressReport {
+	t.scannedThrough = scannedThrough
+	if time.Since(t.lastSave) >= rescanCheckpointInterval {
+		t.SaveCheckpoint()
+	}
+
+	if scannedThrough > t.endHeight {
+		// The chain grew since the rescan started.
+		t.endHeight = scannedThrough
+	}
+
+	report := &HeadersRescanProgressReport{
+		WalletID:            t.wallet.ID,
+		StartRescanHeight:   t.opts.StartHeight,
+		CurrentRescanHeight: scannedThrough,
+		TotalHeadersToScan:  t.endHeight,
+	}
+
+	scanned := float64(scannedThrough - t.opts.StartHeight)
+	total := float64(t.endHeight - t.opts.StartHeight)
+	if total <= 0 {
+		report.RescanProgress = 100
+	} else {
+		report.RescanProgress = int32(math.Round(math.Max(scanned, 0) * 100 / total))
+	}
+
+	elapsed := time.Since(t.startTime).Seconds()
+	if scanned > 0 && elapsed > 0 {
+		report.BlocksPerSecond = scanned / elapsed
+		report.RescanTimeRemaining = int64(math.Round(float64(t.endHeight-scannedThrough) / report.BlocksPerSecond))
+	}
+
+	report.GeneralSyncProgress = &GeneralSyncProgress{
+		TotalSyncProgress:         report.RescanProgress,
+		TotalTimeRemainingSeconds: report.RescanTimeRemaining,
+	}
+	return report
+}
+
+// SaveCheckpoint saves the progress of the rescan as the wallet rescan
+// checkpoint if it advanced since the last save.
+func (t *RescanTracker) SaveCheckpoint() {
+	if !t.saveCheckpoints || t.scannedThrough <= t.savedThrough {
+		return
+	}
+	t.wallet.SaveUserConfigValue(RescanCheckpointConfigKey, &RescanCheckpoint{
+		RescanOptions:  t.opts,
+		ScannedThrough: t.scannedThrough,
+	})
+	t.savedThrough = t.scannedThrough
+	t.lastSave = time.Now()
+}
+
+// Done returns true if the blocks up to the end of the range are scanned.
+// Rescans without an end height are done when the chain client reports it.
+func (t *RescanTracker) Done(scannedThrough int32) bool {
+	return t.opts.EndHeight > 0 && scannedThrough >= t.opts.EndHeight
+}
+
+// Finish deletes the rescan checkpoint once the rescan completes.
+func (t *RescanTracker) Finish() {
+	if t.saveCheckpoints {
+		t.wallet.DeleteRescanCheckpoint()
+	}
+}
@@ -0,0 +1,136 @@
+package wallet
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/asdine/storm"
+)
+
+func TestRescanOptionsValidate(t *testing.T) {
+	const bestHeight = 1000
+
+	tests := []struct {
+		name  string
+		opts  RescanOptions
+		valid bool
+	}{
+		{"full", RescanOptions{}, true},
+		{"from height", RescanOptions{StartHeight: 500}, true},
+		{"range", RescanOptions{StartHeight: 500, EndHeight: 600}, true},
+		{"single block", RescanOptions{StartHeight: 500, EndHeight: 500}, true},
+		{"up to the tip", RescanOptions{StartHeight: 500, EndHeight: bestHeight}, true},
+		{"negative start", RescanOptions{StartHeight: -1}, false},
+		{"start past the tip", RescanOptions{StartHeight: bestHeight + 1}, false},
+		{"end past the tip", RescanOptions{EndHeight: bestHeight + 1}, false},
+		{"end before start", RescanOptions{StartHeight: 500, EndHeight: 499}, false},
+	}
+
+	for _, test := range tests {
+		err := test.opts.Validate(bestHeight)
+		if valid := err == nil; valid != test.valid {
+			t.Errorf("%s: expected valid %v, got error %v", test.name, test.valid, err)
+		}
+	}
+}
+
+func TestRescanTrackerProgress(t *testing.T) {
+	wallet := &Wallet{ID: 1}
+	tracker := wallet.NewRescanTracker(&RescanOptions{StartHeight: 100, EndHeight: 300}, 1000, false)
+
+	tests := []struct {
+		scannedThrough int32
+		progress       int32
+		total          int32
+		done           bool
+	}{
+		{100, 0, 300, false},
+		{150, 25, 300, false},
+		{200, 50, 300, false},
+		{300, 100, 300, true},
+		// Blocks past the end are reported by chain clients that scan to
+		// the tip.
+		{400, 100, 400, true},
+	}
+
+	for _, test := range tests {
+		report := tracker.Progress(test.scannedThrough)
+		if report.WalletID != wallet.ID || report.StartRescanHeight != 100 {
+			t.Errorf("%d: unexpected report %+v", test.scannedThrough, report)
+		}
+		if report.RescanProgress != test.progress || report.TotalSyncProgress != test.progress {
+			t.Errorf("%d: expected progress %d, got %d", test.scannedThrough, test.progress, report.RescanProgress)
+		}
+		if report.TotalHeadersToScan != test.total {
+			t.Errorf("%d: expected %d headers to scan, got %d", test.scannedThrough, test.total, report.TotalHeadersToScan)
+		}
+		if done := tracker.Done(test.scannedThrough); done != test.done {
+			t.Errorf("%d: expected done %v, got %v", test.scannedThrough, test.done, done)
+		}
+	}
+
+	// A rescan without an end height runs up to the best height and is
+	// only done when the chain client reports it.
+	tracker = wallet.NewRescanTracker(&RescanOptions{}, 1000, false)
+	if report := tracker.Progress(250); report.RescanProgress != 25 || report.TotalHeadersToScan != 1000 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if tracker.Done(1000) {
+		t.Error("a rescan without an end height is done")
+	}
+}
+
+func TestRescanTrackerCheckpoint(t *testing.T) {
+	db, err := storm.Open(filepath.Join(t.TempDir(), "wallet.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	wallet := &Wallet{ID: 1, db: db}
+	opts := &RescanOptions{StartHeight: 100, EndHeight: 300}
+	tracker := wallet.NewRescanTracker(opts, 1000, true)
+
+	scannedThrough := func() int32 {
+		cp := wallet.RescanCheckpoint()
+		if cp == nil {
+			return 0
+		}
+		return cp.ScannedThrough
+	}
+
+	// The first progress report is saved, the following ones are
+	// throttled.
+	tracker.Progress(150)
+	if got := scannedThrough(); got != 150 {
+		t.Fatalf("expected checkpoint at 150, got %d", got)
+	}
+	tracker.Progress(200)
+	if got := scannedThrough(); got != 150 {
+		t.Fatalf("expected throttled checkpoint at 150, got %d", got)
+	}
+
+	// The latest progress is saved on demand.
+	tracker.SaveCheckpoint()
+	cp := wallet.RescanCheckpoint()
+	if cp == nil || cp.ScannedThrough != 200 {
+		t.Fatalf("expected checkpoint at 200, got %+v", cp)
+	}
+	resume := cp.ResumeOptions()
+	if resume.StartHeight != 200 || resume.EndHeight != opts.EndHeight {
+		t.Errorf("unexpected resume options %+v", resume)
+	}
+
+	// Once the interval elapsed, the progress is saved again.
+	tracker.lastSave = time.Now().Add(-rescanCheckpointInterval)
+	tracker.Progress(250)
+	if got := scannedThrough(); got != 250 {
+		t.Fatalf("expected checkpoint at 250, got %d", got)
+	}
+
+	tracker.Finish()
+	if cp := wallet.RescanCheckpoint(); cp != nil {
+		t.Errorf("expected no checkpoint once finished, got %+v", cp)
+	}
+}
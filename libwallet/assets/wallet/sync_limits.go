@@ -0,0 +1,219 @@
+package wallet
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// dataUsageDateLayout is the layout of the dates the data usage is
+	// recorded for.
+	dataUsageDateLayout = "2006-01-02"
+
+	// dataUsageRetentionDays is the number of days the data usage is kept.
+	dataUsageRetentionDays = 30
+
+	// dataUsageSaveInterval is the minimum time between two writes of the
+	// data usage to the database.
+	dataUsageSaveInterval = time.Minute
+)
+
+// SyncLimits restricts the network resources used to sync a wallet. A limit
+// that is zero is not enforced.
+type SyncLimits struct {
+	// MaxPeers is the maximum number of peers connected to. It only lowers
+	// the number of peers the SPV syncers target.
+	MaxPeers int32 `json:"max_peers"`
+	// BandwidthKBps is the maximum bandwidth shared by all the peers in
+	// kilobytes per second.
+	BandwidthKBps int32 `json:"bandwidth_kbps"`
+	// DailyDataLimitMB is the maximum data sent and received in a day in
+	// megabytes. The sync is stopped once the limit is reached.
+	DailyDataLimitMB int32 `json:"daily_data_limit_mb"`
+	// ScheduleStartHour and ScheduleEndHour are the local hours between which
+	// the wallet may sync. The schedule is not enforced if they are equal.
+	// The schedule ends on the next day if the end hour is before the start
+	// hour.
+	ScheduleStartHour int32 `json:"schedule_start_hour"`
+	ScheduleEndHour   int32 `json:"schedule_end_hour"`
+}
+
+// Validate checks that the limits are not negative and the schedule hours are
+// hours of the day.
+func (l *SyncLimits) Validate() error {
+	if l.MaxPeers < 0 || l.BandwidthKBps < 0 || l.DailyDataLimitMB < 0 {
+		return errors.New(utils.ErrInvalid)
+	}
+	if l.ScheduleStartHour < 0 || l.ScheduleStartHour > 23 || l.ScheduleEndHour < 0 || l.ScheduleEndHour > 23 {
+		return errors.New(utils.ErrInvalid)
+	}
+	return nil
+}
+
+// HasSchedule returns true if the wallet only syncs at some hours of the day.
+func (l *SyncLimits) HasSchedule() bool {
+	return l.ScheduleStartHour != l.ScheduleEndHour
+}
+
+// InSchedule returns true if the wallet may sync at t.
+func (l *SyncLimits) InSchedule(t time.Time) bool {
+	if !l.HasSchedule() {
+		return true
+	}
+	hour := int32(t.Hour())
+	if l.ScheduleStartHour < l.ScheduleEndHour {
+		return hour >= l.ScheduleStartHour && hour < l.ScheduleEndHour
+	}
+	return hour >= l.ScheduleStartHour || hour < l.ScheduleEndHour
+}
+
+// DataUsage is the data sent and received by a wallet on a day.
+type DataUsage struct {
+	Date     string `json:"date"`
+	Sent     int64  `json:"sent"`
+	Received int64  `json:"received"`
+}
+
+// Total returns the data sent and received.
+func (u *DataUsage) Total() int64 {
+	return u.Sent + u.Received
+}
+
+// SyncLimits returns the limits on the network resources used to sync the
+// wallet.
+func (wallet *Wallet) SyncLimits() *SyncLimits {
+	limits := new(SyncLimits)
+	if err := wallet.ReadUserConfigValue(SyncLimitsConfigKey, limits); err != nil {
+		return new(SyncLimits)
+	}
+	return limits
+}
+
+// SetSyncLimits stores the limits on the network resources used to sync the
+// wallet. The peers and bandwidth limits apply from the next sync.
+func (wallet *Wallet) SetSyncLimits(limits *SyncLimits) error {
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+	wallet.SaveUserConfigValue(SyncLimitsConfigKey, limits)
+	return nil
+}
+
+// SyncAllowed returns an error if the wallet may not sync now because it is
+// outside of the sync schedule or the daily data limit is reached.
+func (wallet *Wallet) SyncAllowed() error {
+	limits := wallet.SyncLimits()
+	if !limits.InSchedule(time.Now()) {
+		return errors.New(utils.ErrSyncNotAllowed)
+	}
+	if limits.DailyDataLimitMB > 0 && wallet.TodayDataUsage().Total() >= int64(limits.DailyDataLimitMB)*1e6 {
+		return errors.New(utils.ErrSyncNotAllowed)
+	}
+	return nil
+}
+
+// NewNetLimiter returns the limiter the sync connections are dialed through,
+// whether to peers, an Electrum server or a dcrd node. It enforces the peers
+// and bandwidth limits and records the data usage.
+func (wallet *Wallet) NewNetLimiter() *utils.NetLimiter {
+	limits := wallet.SyncLimits()
+	return utils.NewNetLimiter(int(limits.MaxPeers), int64(limits.BandwidthKBps)*1000, wallet.RecordDataUsage)
+}
+
+// RecordDataUsage adds the bytes sent and received to the data usage of the
+// day. The usage is saved at most once every dataUsageSaveInterval.
+func (wallet *Wallet) RecordDataUsage(sent, received int64) {
+	wallet.dataUsageMu.Lock()
+	defer wallet.dataUsageMu.Unlock()
+
+	usage := wallet.loadDataUsage()
+	date := time.Now().Format(dataUsageDateLayout)
+	day, ok := usage[date]
+	if !ok {
+		day = &DataUsage{Date: date}
+		usage[date] = day
+	}
+	day.Sent += sent
+	day.Received += received
+
+	if time.Since(wallet.dataUsageSavedAt) >= dataUsageSaveInterval {
+		wallet.saveDataUsage()
+	}
+}
+
+// TodayDataUsage returns the data sent and received by the wallet today.
+func (wallet *Wallet) TodayDataUsage() *DataUsage {
+	wallet.dataUsageMu.Lock()
+	defer wallet.dataUsageMu.Unlock()
+
+	date := time.Now().Format(dataUsageDateLayout)
+	if day, ok := wallet.loadDataUsage()[date]; ok {
+		usage := *day
+		return &usage
+	}
+	return &DataUsage{Date: date}
+}
+
+// DataUsageHistory returns the data sent and received by the wallet on each
+// of the last 30 days it synced, the most recent day first.
+func (wallet *Wallet) DataUsageHistory() []DataUsage {
+	wallet.dataUsageMu.Lock()
+	defer wallet.dataUsageMu.Unlock()
+
+	usage := wallet.loadDataUsage()
+	history := make([]DataUsage, 0, len(usage))
+	for _, day := range usage {
+		history = append(history, *day)
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Date > history[j].Date
+	})
+	return history
+}
+
+// FlushDataUsage saves the data usage not saved yet.
+func (wallet *Wallet) FlushDataUsage() {
+	wallet.dataUsageMu.Lock()
+	defer wallet.dataUsageMu.Unlock()
+
+	if wallet.dataUsage != nil {
+		wallet.saveDataUsage()
+	}
+}
+
+// loadDataUsage reads the data usage from the database on first use. It must
+// be called with dataUsageMu held.
+func (wallet *Wallet) loadDataUsage() map[string]*DataUsage {
+	if wallet.dataUsage != nil {
+		return wallet.dataUsage
+	}
+
+	var history []*DataUsage
+	if err := wallet.ReadUserConfigValue(DataUsageConfigKey, &history); err != nil {
+		log.Debugf("No data usage recorded for wallet %d: %v", wallet.ID, err)
+	}
+	wallet.dataUsage = make(map[string]*DataUsage, len(history))
+	for _, day := range history {
+		wallet.dataUsage[day.Date] = day
+	}
+	return wallet.dataUsage
+}
+
+// saveDataUsage drops the usage older than dataUsageRetentionDays and saves
+// the rest. It must be called with dataUsageMu held.
+func (wallet *Wallet) saveDataUsage() {
+	oldest := time.Now().AddDate(0, 0, -dataUsageRetentionDays).Format(dataUsageDateLayout)
+	history := make([]*DataUsage, 0, len(wallet.dataUsage))
+	for date, day := range wallet.dataUsage {
+		if date < oldest {
+			delete(wallet.dataUsage, date)
+			continue
+		}
+		history = append(history, day)
+	}
+	wallet.SaveUserConfigValue(DataUsageConfigKey, history)
+	wallet.dataUsageSavedAt = time.Now()
+}
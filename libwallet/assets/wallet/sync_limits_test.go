@@ -0,0 +1,37 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSyncLimitsInSchedule(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2024, 3, 1, hour, 30, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name       string
+		start, end int32
+		hour       int
+		inSchedule bool
+	}{
+		{"no schedule", 0, 0, 12, true},
+		{"no schedule at another hour", 8, 8, 3, true},
+		{"before the schedule", 8, 17, 7, false},
+		{"schedule start", 8, 17, 8, true},
+		{"within the schedule", 8, 17, 12, true},
+		{"schedule end", 8, 17, 17, false},
+		{"overnight before midnight", 22, 6, 23, true},
+		{"overnight after midnight", 22, 6, 5, true},
+		{"overnight schedule end", 22, 6, 6, false},
+		{"outside the overnight schedule", 22, 6, 12, false},
+	}
+
+	for _, test := range tests {
+		limits := &SyncLimits{ScheduleStartHour: test.start, ScheduleEndHour: test.end}
+		if got := limits.InSchedule(at(test.hour)); got != test.inSchedule {
+			t.Errorf("%s: expected in schedule %v, got %v", test.name, test.inSchedule, got)
+		}
+	}
+}
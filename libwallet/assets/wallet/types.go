@@ -43,6 +43,24 @@ type InitParams struct {
 	DB       *storm.DB
 	DbDriver string
 	LogDir   string
+	// HTTPAPIAllowed reports whether the given HTTP API type may be queried
+	// under the current privacy settings.
+	HTTPAPIAllowed func(apiType utils.HTTPAPIType) bool
+	// Endpoints returns the hosts of the remote service in the order they
+	// are tried, the default hosts unless the user configured others.
+	Endpoints func(endpointType utils.EndpointType) []utils.Endpoint
+	// ChainBackends holds the chain data sources shared by all the wallets
+	// of an asset.
+	ChainBackends map[utils.AssetType]ChainBackend
+}
+
+// ChainBackend is a chain data source shared by all the wallets of an asset.
+type ChainBackend interface {
+	// Release removes the wallet from the backend's users.
+	Release(walletID int)
+	// Close shuts the backend down. It must only be called once all the
+	// wallets have shut down.
+	Close() error
 }
 
 // AuthInfo defines the complete information required to either create a
@@ -218,6 +236,12 @@ type PeerInfo struct {
 	SubVer         string `json:"sub_ver"`
 	StartingHeight int64  `json:"starting_height"`
 	BanScore       int32  `json:"ban_score"`
+	// PingTime is the latency of the last ping in milliseconds.
+	PingTime      int64  `json:"ping_time"`
+	BytesSent     uint64 `json:"bytes_sent"`
+	BytesReceived uint64 `json:"bytes_received"`
+	// ConnTime is the unix time the connection was established.
+	ConnTime int64 `json:"conn_time"`
 }
 
 /** begin sync-related types */
@@ -269,10 +293,13 @@ type AddressDiscoveryProgressReport struct {
 
 type HeadersRescanProgressReport struct {
 	*GeneralSyncProgress
-	TotalHeadersToScan  int32 `json:"totalHeadersToScan"`
-	CurrentRescanHeight int32 `json:"currentRescanHeight"`
-	RescanProgress      int32 `json:"rescanProgress"`
-	RescanTimeRemaining int64 `json:"rescanTimeRemaining"`
+	WalletID            int     `json:"walletID"`
+	StartRescanHeight   int32   `json:"startRescanHeight"`
+	TotalHeadersToScan  int32   `json:"totalHeadersToScan"`
+	CurrentRescanHeight int32   `json:"currentRescanHeight"`
+	RescanProgress      int32   `json:"rescanProgress"`
+	RescanTimeRemaining int64   `json:"rescanTimeRemaining"`
+	BlocksPerSecond     float64 `json:"blocksPerSecond"`
 }
 
 type DebugInfo struct {
@@ -453,4 +480,73 @@ type UnspentOutput struct {
 	Spendable     bool
 	ReceiveTime   time.Time
 	Tree          int8
+
+	// Frozen, Tag, Label and Origin are read from the locally persisted
+	// UTXOMetadata of the output.
+	Frozen bool
+	Tag    string
+	Label  string
+	Origin UTXOOrigin
+}
+
+// UTXOOrigin describes where the funds held by an unspent output came from.
+type UTXOOrigin string
+
+const (
+	UTXOOriginUnknown  UTXOOrigin = ""
+	UTXOOriginMixed    UTXOOrigin = "mixed"
+	UTXOOriginExchange UTXOOrigin = "kyc_exchange"
+	UTXOOriginChange   UTXOOrigin = "change"
+)
+
+// UTXOMetadata holds user assigned information about an unspent output that
+// is persisted in the wallet data db. Frozen outputs are never picked by
+// automatic coin selection.
+type UTXOMetadata struct {
+	OutPoint  string `storm:"id"`
+	Frozen    bool   `storm:"index"`
+	Tag       string `storm:"index"`
+	Label     string
+	Origin    UTXOOrigin
+	UpdatedAt int64
+}
+
+// Fields of an indexed transaction that are compared with the wallet
+// transaction store when the transaction index is verified.
+const (
+	TxIndexFieldAmount        = "amount"
+	TxIndexFieldHeight        = "height"
+	TxIndexFieldType          = "type"
+	TxIndexFieldTicketSpender = "ticket_spender"
+)
+
+// TxIndexMismatch is an indexed transaction whose details differ from the
+// wallet transaction store.
+type TxIndexMismatch struct {
+	Hash string
+	// Fields are the TxIndexField values that differ.
+	Fields []string
+}
+
+// TxIndexReport is the outcome of a comparison of the transaction index with
+// the wallet transaction store.
+type TxIndexReport struct {
+	// WalletTxs is the number of transactions in the wallet transaction store.
+	WalletTxs int
+	// IndexedTxs is the number of transactions in the index.
+	IndexedTxs int
+	// Missing are the hashes of the wallet transactions that are not indexed.
+	Missing []string
+	// Orphaned are the hashes of the indexed transactions the wallet does not
+	// know of.
+	Orphaned   []string
+	Mismatched []TxIndexMismatch
+	// Repaired reports whether the index was updated to match the wallet
+	// transaction store.
+	Repaired bool
+}
+
+// IsConsistent returns true if the index matched the wallet transaction store.
+func (r *TxIndexReport) IsConsistent() bool {
+	return len(r.Missing) == 0 && len(r.Orphaned) == 0 && len(r.Mismatched) == 0
 }
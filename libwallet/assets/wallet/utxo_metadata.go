@@ -0,0 +1,181 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// maxUTXOTagLength limits the length of the tags and labels assigned to
+// unspent outputs.
+const maxUTXOTagLength = 64
+
+func utxoOutPoint(txID string, vout uint32) string {
+	return fmt.Sprintf("%s:%d", txID, vout)
+}
+
+// UTXOMetadata returns the metadata saved for the unspent output identified by
+// the provided tx hash and output index. An empty metadata record is returned
+// if nothing has been saved for the output.
+func (wallet *Wallet) UTXOMetadata(txID string, vout uint32) (*UTXOMetadata, error) {
+	db := wallet.GetWalletDataDb()
+	if db == nil {
+		return nil, errors.New(utils.ErrWalletNotLoaded)
+	}
+
+	metadata := &UTXOMetadata{}
+	err := db.FindOne("OutPoint", utxoOutPoint(txID, vout), metadata)
+	if err != nil {
+		if err != storm.ErrNotFound {
+			return nil, err
+		}
+		metadata.OutPoint = utxoOutPoint(txID, vout)
+	}
+	return metadata, nil
+}
+
+// updateUTXOMetadata applies update to the metadata of the provided output and
+// persists the result.
+func (wallet *Wallet) updateUTXOMetadata(txID string, vout uint32, update func(*UTXOMetadata)) error {
+	if txID == "" {
+		return errors.New(utils.ErrInvalid)
+	}
+
+	metadata, err := wallet.UTXOMetadata(txID, vout)
+	if err != nil {
+		return err
+	}
+
+	update(metadata)
+	metadata.UpdatedAt = time.Now().Unix()
+	return wallet.GetWalletDataDb().SaveRecord(metadata)
+}
+
+// SetUTXOFrozen freezes or unfreezes the provided output. Frozen outputs are
+// skipped by automatic coin selection but can still be spent by selecting them
+// manually.
+func (wallet *Wallet) SetUTXOFrozen(txID string, vout uint32, frozen bool) error {
+	return wallet.updateUTXOMetadata(txID, vout, func(metadata *UTXOMetadata) {
+		metadata.Frozen = frozen
+	})
+}
+
+// SetUTXOTag assigns a tag, e.g. the source of the funds, to the provided
+// output. An empty tag clears the existing one.
+func (wallet *Wallet) SetUTXOTag(txID string, vout uint32, tag string) error {
+	tag = strings.TrimSpace(tag)
+	if len(tag) > maxUTXOTagLength {
+		return errors.New(utils.ErrInvalid)
+	}
+	return wallet.updateUTXOMetadata(txID, vout, func(metadata *UTXOMetadata) {
+		metadata.Tag = tag
+	})
+}
+
+// SetUTXOLabel assigns a free form label to the provided output.
+func (wallet *Wallet) SetUTXOLabel(txID string, vout uint32, label string) error {
+	label = strings.TrimSpace(label)
+	if len(label) > maxUTXOTagLength {
+		return errors.New(utils.ErrInvalid)
+	}
+	return wallet.updateUTXOMetadata(txID, vout, func(metadata *UTXOMetadata) {
+		metadata.Label = label
+	})
+}
+
+// SetUTXOOrigin records where the funds held by the provided output came from.
+func (wallet *Wallet) SetUTXOOrigin(txID string, vout uint32, origin UTXOOrigin) error {
+	switch origin {
+	case UTXOOriginUnknown, UTXOOriginMixed, UTXOOriginExchange, UTXOOriginChange:
+	default:
+		return errors.New(utils.ErrInvalid)
+	}
+	return wallet.updateUTXOMetadata(txID, vout, func(metadata *UTXOMetadata) {
+		metadata.Origin = origin
+	})
+}
+
+// FrozenUTXOs returns the metadata of all the outputs that are currently frozen.
+func (wallet *Wallet) FrozenUTXOs() ([]*UTXOMetadata, error) {
+	db := wallet.GetWalletDataDb()
+	if db == nil {
+		return nil, errors.New(utils.ErrWalletNotLoaded)
+	}
+
+	var frozen []*UTXOMetadata
+	if err := db.Find(q.Eq("Frozen", true), &frozen); err != nil {
+		return nil, err
+	}
+	return frozen, nil
+}
+
+// ApplyUTXOMetadata sets the Frozen, Tag, Label and Origin fields of the
+// provided outputs from their saved metadata.
+func (wallet *Wallet) ApplyUTXOMetadata(utxos []*UnspentOutput) error {
+	db := wallet.GetWalletDataDb()
+	if db == nil {
+		return errors.New(utils.ErrWalletNotLoaded)
+	}
+
+	var records []*UTXOMetadata
+	if err := db.All(&records); err != nil {
+		return err
+	}
+
+	metadata := make(map[string]*UTXOMetadata, len(records))
+	for _, record := range records {
+		metadata[record.OutPoint] = record
+	}
+
+	for _, utxo := range utxos {
+		record, ok := metadata[utxoOutPoint(utxo.TxID, utxo.Vout)]
+		if !ok {
+			continue
+		}
+		utxo.Frozen = record.Frozen
+		utxo.Tag = record.Tag
+		utxo.Label = record.Label
+		if record.Origin != UTXOOriginUnknown {
+			utxo.Origin = record.Origin
+		}
+	}
+	return nil
+}
+
+// FilterFrozenUTXOs returns the provided outputs less the frozen ones.
+func FilterFrozenUTXOs(utxos []*UnspentOutput) []*UnspentOutput {
+	filtered := make([]*UnspentOutput, 0, len(utxos))
+	for _, utxo := range utxos {
+		if !utxo.Frozen {
+			filtered = append(filtered, utxo)
+		}
+	}
+	return filtered
+}
+
+// UTXOSelectionTags returns the distinct tags, or origins for untagged outputs,
+// found in the provided selection. Spending outputs that return more than one
+// value links funds the user chose to keep apart. Change outputs inherit the
+// history of the funds they were created from and are therefore ignored
+// unless tagged.
+func UTXOSelectionTags(utxos []*UnspentOutput) []string {
+	seen := make(map[string]struct{})
+	var tags []string
+	for _, utxo := range utxos {
+		tag := utxo.Tag
+		if tag == "" && utxo.Origin != UTXOOriginChange {
+			tag = string(utxo.Origin)
+		}
+		if _, ok := seen[tag]; ok || tag == "" {
+			continue
+		}
+		seen[tag] = struct{}{}
+		tags = append(tags, tag)
+	}
+	return tags
+}
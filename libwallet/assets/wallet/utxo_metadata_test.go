@@ -0,0 +1,45 @@
+package wallet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilterFrozenUTXOs(t *testing.T) {
+	utxos := []*UnspentOutput{
+		{TxID: "a", Vout: 0},
+		{TxID: "a", Vout: 1, Frozen: true},
+		{TxID: "b", Vout: 0},
+		{TxID: "c", Vout: 2, Frozen: true},
+	}
+
+	filtered := FilterFrozenUTXOs(utxos)
+	want := []*UnspentOutput{utxos[0], utxos[2]}
+	if !reflect.DeepEqual(filtered, want) {
+		t.Errorf("got %v, want %v", filtered, want)
+	}
+	if len(utxos) != 4 {
+		t.Errorf("the provided outputs were modified")
+	}
+
+	if filtered := FilterFrozenUTXOs(nil); len(filtered) != 0 {
+		t.Errorf("got %d outputs from none", len(filtered))
+	}
+}
+
+func TestUTXOSelectionTags(t *testing.T) {
+	utxos := []*UnspentOutput{
+		{Tag: "salary"},
+		{Origin: UTXOOriginExchange},
+		{Tag: "salary", Origin: UTXOOriginMixed},
+		// Untagged change doesn't link funds.
+		{Origin: UTXOOriginChange},
+		{},
+	}
+
+	got := UTXOSelectionTags(utxos)
+	want := []string{"salary", string(UTXOOriginExchange)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -11,6 +11,9 @@ const (
 	AccountMixerMixedAccount   = "account_mixer_mixed_account"
 	AccountMixerUnmixedAccount = "account_mixer_unmixed_account"
 	AccountMixerMixTxChange    = "account_mixer_mix_tx_change"
+	AccountMixerCSPPServer     = "account_mixer_cspp_server"
+	AccountMixerSchedule       = "account_mixer_schedule"
+	DcrdRPCConfigKey           = "dcrd_rpc_config"
 
 	userConfigBucketName      = "user_config" // Asset level bucket.
 	walletsMetadataBucketName = "metadata"    // Wallet level bucket.
@@ -30,7 +33,13 @@ const (
 	SyncOnCellularConfigKey             = "always_sync"
 	NetworkModeConfigKey                = "network_mode"
 	SpvPersistentPeerAddressesConfigKey = "spv_peer_addresses"
+	BannedPeersConfigKey                = "banned_peers"
+	RestoreBirthdayConfigKey            = "restore_birthday"
+	RescanCheckpointConfigKey           = "rescan_checkpoint"
+	SyncLimitsConfigKey                 = "sync_limits"
+	DataUsageConfigKey                  = "data_usage"
 	UserAgentConfigKey                  = "user_agent"
+	PrivateChainDataRemovedConfigKey    = "private_chain_data_removed"
 
 	PoliteiaNotificationConfigKey = "politeia_notification"
 
@@ -43,6 +52,8 @@ const (
 	TicketBuyerAccountConfigKey = "tb_account_number"
 	TicketBuyerATMConfigKey     = "tb_amount_to_maintain"
 
+	AutoVoteConfigKey = "politeia_auto_vote"
+
 	ExchangeSourceDstnTypeConfigKey = "exchange_source_destination_key"
 
 	HideBalanceConfigKey             = "hide_balance"
@@ -55,6 +66,13 @@ const (
 	KnownDexServersConfigKey         = "known_dex_servers"
 	LanguagePreferenceKey            = "app_language"
 	DarkModeConfigKey                = "dark_mode"
+	CoinSelectionStrategyConfigKey   = "coin_selection_strategy"
+	FeeEstimatorOrderConfigKey       = "fee_estimator_order"
+	FeeEstimatorURLConfigKey         = "fee_estimator_url"
+	ElectrumServerConfigKey          = "electrum_server"
+	VoteReminderIntervalsConfigKey   = "vote_reminder_intervals"
+	VoteRemindersSentConfigKey       = "vote_reminders_sent"
+	EndpointsConfigKey               = "endpoints"
 
 	PassphraseTypePin  int32 = 0
 	PassphraseTypePass int32 = 1
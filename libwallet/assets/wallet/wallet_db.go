@@ -0,0 +1,196 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/internal/loader"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// DBDriverBdb and DBDriverBadger are the drivers a wallet database can be
+	// opened with. BTC and LTC wallets only support DBDriverBdb.
+	DBDriverBdb    = "bdb"
+	DBDriverBadger = "badgerdb"
+
+	// compactionMinReclaimable is the space on disk a compaction must free
+	// for it to be recommended.
+	compactionMinReclaimable = 10 << 20 // 10 MiB
+)
+
+// DBBucketStats describes the content of a top level bucket of the wallet
+// database and of all its nested buckets.
+type DBBucketStats struct {
+	Name          string
+	Keys          int
+	NestedBuckets int
+	DataSize      int64
+}
+
+// DBHealth describes the state of the wallet database.
+type DBHealth struct {
+	Driver string
+	Path   string
+	// Size is the space used by the database on disk in bytes.
+	Size int64
+	// DataSize is the size of all the keys and values stored in bytes.
+	DataSize int64
+	Buckets  []DBBucketStats
+	// Details holds the stats collected by the database driver, if any.
+	Details string
+	// WalletDataSize is the space used by the transactions index database on
+	// disk in bytes.
+	WalletDataSize int64
+}
+
+// Reclaimable returns an estimate of the space on disk a compaction of the
+// database would free.
+func (h *DBHealth) Reclaimable() int64 {
+	if h.Size <= h.DataSize {
+		return 0
+	}
+	return h.Size - h.DataSize
+}
+
+// CompactionRecommended reports whether the database uses at least twice the
+// space its data needs and a compaction would free a significant space.
+func (h *DBHealth) CompactionRecommended() bool {
+	return h.Size >= 2*h.DataSize && h.Reclaimable() >= compactionMinReclaimable
+}
+
+// DatabaseDriver returns the driver the wallet database is opened with.
+func (wallet *Wallet) DatabaseDriver() string {
+	return wallet.loader.GetDatabaseDriver()
+}
+
+// DBHealth returns the state of the wallet database. The wallet must be open.
+func (wallet *Wallet) DBHealth() (*DBHealth, error) {
+	stats, err := wallet.loader.DBStats(strconv.Itoa(wallet.ID))
+	if err != nil {
+		return nil, utils.TranslateError(err)
+	}
+
+	health := &DBHealth{
+		Driver:   stats.Driver,
+		Path:     stats.Path,
+		Size:     stats.Size,
+		DataSize: stats.DataSize,
+		Details:  stats.Details,
+	}
+	for _, bucket := range stats.Buckets {
+		health.Buckets = append(health.Buckets, DBBucketStats{
+			Name:          bucket.Name,
+			Keys:          bucket.Keys,
+			NestedBuckets: bucket.NestedBuckets,
+			DataSize:      bucket.DataSize,
+		})
+	}
+
+	if walletDataDB := wallet.GetWalletDataDb(); walletDataDB != nil {
+		if health.WalletDataSize, err = loader.PathSize(walletDataDB.Path); err != nil {
+			log.Warnf("reading wallet data database size failed: %v", err)
+		}
+	}
+	return health, nil
+}
+
+// BackupDB writes a consistent copy of the wallet database to a new file in
+// dir while the wallet is in use, and returns the path of the file. The file
+// name ends with the database driver the copy is read with.
+func (wallet *Wallet) BackupDB(dir string) (string, error) {
+	if err := os.MkdirAll(dir, utils.UserFilePerm); err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("%s-%d-%s.%s", wallet.Type.ToStringLower(), wallet.ID,
+		time.Now().Format("20060102-150405"), wallet.DatabaseDriver())
+	backupPath := filepath.Join(dir, name)
+
+	// The copy is written to a temporary file so that an interrupted backup
+	// is never mistaken for a complete one.
+	tmpPath := backupPath + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	err = wallet.loader.BackupDB(file)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, backupPath)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return "", utils.TranslateError(err)
+	}
+
+	log.Infof("(%s) wallet database backed up to %s", wallet.Name, backupPath)
+	return backupPath, nil
+}
+
+// MigrateDB converts the wallet database to the driver. Migrating to the
+// current driver compacts the database. The wallet is closed while the
+// database is copied and opened again afterwards, it must not be syncing.
+func (wallet *Wallet) MigrateDB(driver string) error {
+	if driver != DBDriverBdb && driver != DBDriverBadger {
+		return errors.New(utils.ErrDBDriverNotSupported)
+	}
+
+	wasOpened := wallet.WalletOpened()
+	if wasOpened {
+		if err := wallet.unloadWallet(); err != nil {
+			return err
+		}
+	}
+
+	ctx, _ := wallet.ShutdownContextWithCancel()
+	err := wallet.loader.MigrateDB(ctx, strconv.Itoa(wallet.ID), driver)
+	if err == nil {
+		wallet.mu.Lock()
+		wallet.DbDriver = driver
+		err = wallet.db.Save(wallet)
+		wallet.mu.Unlock()
+	}
+
+	if wasOpened {
+		if openErr := wallet.OpenWallet(); err == nil {
+			err = openErr
+		}
+	}
+	return utils.TranslateError(err)
+}
+
+// unloadWallet stops the upstream wallet and closes its database.
+func (wallet *Wallet) unloadWallet() error {
+	loadedWallet, ok := wallet.loader.GetLoadedWallet()
+	if !ok {
+		return nil
+	}
+
+	// The dcr loader closes the database when the wallet is unloaded.
+	switch wallet.Type {
+	case utils.BTCWalletAsset:
+		loadedWallet.BTC.Stop()
+		loadedWallet.BTC.WaitForShutdown()
+		if err := loadedWallet.BTC.Database().Close(); err != nil {
+			return err
+		}
+	case utils.LTCWalletAsset:
+		loadedWallet.LTC.Stop()
+		loadedWallet.LTC.WaitForShutdown()
+		if err := loadedWallet.LTC.Database().Close(); err != nil {
+			return err
+		}
+	}
+	return wallet.loader.UnloadWallet()
+}
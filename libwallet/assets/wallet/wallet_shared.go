@@ -22,10 +22,13 @@ type Wallet struct {
 	Name      string    `storm:"unique"`
 	CreatedAt time.Time `storm:"index"`
 	Type      utils.AssetType
-	dbDriver  string
-	rootDir   string
-	db        *storm.DB
-	logDir    string
+	// DbDriver is the driver the wallet database is opened with. It is empty
+	// for the wallets created before it was recorded, which use the driver
+	// of the assets manager.
+	DbDriver string
+	rootDir  string
+	db       *storm.DB
+	logDir   string
 
 	EncryptedSeed         []byte
 	IsRestored            bool
@@ -34,6 +37,11 @@ type Wallet struct {
 
 	netType      utils.NetworkType
 	chainsParams *utils.ChainsParams
+	// httpAPIAllowed reports whether the given HTTP API type may be queried
+	// under the current privacy settings.
+	httpAPIAllowed func(apiType utils.HTTPAPIType) bool
+	// endpoints returns the user configured hosts of a remote service.
+	endpoints    func(endpointType utils.EndpointType) []utils.Endpoint
 	loader       loader.AssetLoader
 	walletDataDB *walletdata.DB
 
@@ -52,6 +60,12 @@ type Wallet struct {
 	shuttingDown chan bool
 	cancelFuncs  []context.CancelFunc
 
+	// dataUsage holds the data sent and received on each day, indexed by
+	// date. It is loaded from the database on first use.
+	dataUsageMu      sync.Mutex
+	dataUsage        map[string]*DataUsage
+	dataUsageSavedAt time.Time
+
 	mu sync.RWMutex
 }
 
@@ -65,6 +79,8 @@ func (wallet *Wallet) Prepare(loader loader.AssetLoader, params *InitParams) (er
 	wallet.db = params.DB
 	wallet.loader = loader
 	wallet.netType = params.NetType
+	wallet.httpAPIAllowed = params.HTTPAPIAllowed
+	wallet.endpoints = params.Endpoints
 	wallet.rootDir = params.RootDir
 	wallet.logDir = params.LogDir
 	return wallet.prepare()
@@ -156,6 +172,8 @@ func (wallet *Wallet) Shutdown() {
 		}
 	}
 
+	wallet.FlushDataUsage()
+
 	// close db connection as the last shutdown protocol.
 	if wallet.walletDataDB != nil {
 		err := wallet.walletDataDB.Close()
@@ -167,6 +185,22 @@ func (wallet *Wallet) Shutdown() {
 	log.Infof("(%s) full network shutdown protocols completed.", wallet.Name)
 }
 
+// IsHTTPAPIAllowed returns true if the given HTTP API type may be queried
+// under the current privacy settings.
+func (wallet *Wallet) IsHTTPAPIAllowed(apiType utils.HTTPAPIType) bool {
+	return wallet.httpAPIAllowed != nil && wallet.httpAPIAllowed(apiType)
+}
+
+// Endpoints returns the hosts of the remote service in the order they are
+// tried, the default hosts unless the user configured others. nil is returned
+// if no hosts are known for the service.
+func (wallet *Wallet) Endpoints(endpointType utils.EndpointType) []utils.Endpoint {
+	if wallet.endpoints == nil {
+		return nil
+	}
+	return wallet.endpoints(endpointType)
+}
+
 func (wallet *Wallet) TargetTimePerBlockMinutes() float64 {
 	if wallet.Type == utils.BTCWalletAsset {
 		return wallet.chainsParams.BTC.TargetTimePerBlock.Minutes()
@@ -329,7 +363,7 @@ func CreateNewWallet(pass *AuthInfo, loader loader.AssetLoader,
 	wallet := &Wallet{
 		Name:          pass.Name,
 		db:            params.DB,
-		dbDriver:      params.DbDriver,
+		DbDriver:      params.DbDriver,
 		rootDir:       params.RootDir,
 		logDir:        params.LogDir,
 		CreatedAt:     time.Now(),
@@ -340,6 +374,8 @@ func CreateNewWallet(pass *AuthInfo, loader loader.AssetLoader,
 		Type:                  assetType,
 		loader:                loader,
 		netType:               params.NetType,
+		httpAPIAllowed:        params.HTTPAPIAllowed,
+		endpoints:             params.Endpoints,
 	}
 
 	return wallet.saveNewWallet(func() error {
@@ -387,7 +423,7 @@ func CreateWatchOnlyWallet(walletName, extendedPublicKey string, loader loader.A
 	wallet := &Wallet{
 		Name:     walletName,
 		db:       params.DB,
-		dbDriver: params.DbDriver,
+		DbDriver: params.DbDriver,
 		rootDir:  params.RootDir,
 		logDir:   params.LogDir,
 
@@ -398,6 +434,8 @@ func CreateWatchOnlyWallet(walletName, extendedPublicKey string, loader loader.A
 		Type:                  assetType,
 		loader:                loader,
 		netType:               params.NetType,
+		httpAPIAllowed:        params.HTTPAPIAllowed,
+		endpoints:             params.Endpoints,
 	}
 
 	return wallet.saveNewWallet(func() error {
@@ -434,7 +472,7 @@ func RestoreWallet(seedMnemonic string, pass *AuthInfo, loader loader.AssetLoade
 		Name:                  pass.Name,
 		PrivatePassphraseType: pass.PrivatePassType,
 		db:                    params.DB,
-		dbDriver:              params.DbDriver,
+		DbDriver:              params.DbDriver,
 		rootDir:               params.RootDir,
 		logDir:                params.LogDir,
 
@@ -443,6 +481,8 @@ func RestoreWallet(seedMnemonic string, pass *AuthInfo, loader loader.AssetLoade
 		Type:                  assetType,
 		loader:                loader,
 		netType:               params.NetType,
+		httpAPIAllowed:        params.HTTPAPIAllowed,
+		endpoints:             params.Endpoints,
 	}
 
 	return wallet.saveNewWallet(func() error {
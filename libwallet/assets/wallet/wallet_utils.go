@@ -172,6 +172,17 @@ func decryptWalletSeed(pass []byte, encryptedSeed []byte) (string, error) {
 	return string(decryptedSeed), nil
 }
 
+// EncryptWithPassphrase encrypts secret with the wallet's private passphrase
+// so that it can be persisted with the wallet's config.
+func EncryptWithPassphrase(privatePass, secret string) ([]byte, error) {
+	return encryptWalletSeed([]byte(privatePass), secret)
+}
+
+// DecryptWithPassphrase decrypts a secret encrypted by EncryptWithPassphrase.
+func DecryptWithPassphrase(privatePass string, encrypted []byte) (string, error) {
+	return decryptWalletSeed([]byte(privatePass), encrypted)
+}
+
 // For use with gomobile bind,
 // doesn't support the alternative `GenerateSeed` function because it returns more than 2 types.
 func generateSeed(assetType utils.AssetType) (v string, err error) {
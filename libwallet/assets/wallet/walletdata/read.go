@@ -83,3 +83,12 @@ func (db *DB) FindLast(fieldName string, value interface{}, txObj interface{}) e
 func (db *DB) FindAll(fieldName string, value interface{}, txObj interface{}) error {
 	return db.walletDataDB.Find(fieldName, value, txObj)
 }
+
+// All reads every record of the type pointed to by records.
+func (db *DB) All(records interface{}) error {
+	err := db.walletDataDB.All(records)
+	if err != nil && err != storm.ErrNotFound {
+		return err
+	}
+	return nil
+}
@@ -86,3 +86,14 @@ func (db *DB) ClearSavedTransactions(emptyTxPointer interface{}) error {
 
 	return db.SaveLastIndexPoint(0)
 }
+
+// SaveRecord saves the provided record overwriting any existing record with
+// the same id.
+func (db *DB) SaveRecord(record interface{}) error {
+	return db.walletDataDB.Save(record)
+}
+
+// DeleteRecord deletes the provided record.
+func (db *DB) DeleteRecord(record interface{}) error {
+	return db.walletDataDB.DeleteStruct(record)
+}
@@ -252,6 +252,27 @@ func (mgr *AssetsManager) ClearExchangeConfig() {
 	mgr.db.DeleteWalletConfigValue(sharedW.ExchangeSourceDstnTypeConfigKey)
 }
 
+// GetVoteReminderIntervals returns the number of hours before the end of a
+// governance vote at which a reminder is raised if some of the wallets'
+// tickets have not voted yet. An empty list disables the reminders.
+func (mgr *AssetsManager) GetVoteReminderIntervals() []int {
+	var hours []int
+	err := mgr.db.ReadWalletConfigValue(sharedW.VoteReminderIntervalsConfigKey, &hours)
+	if err != nil {
+		return defaultVoteReminderIntervals
+	}
+	return hours
+}
+
+// SetVoteReminderIntervals sets the number of hours before the end of a
+// governance vote at which a reminder is raised.
+func (mgr *AssetsManager) SetVoteReminderIntervals(hours []int) {
+	if hours == nil {
+		hours = []int{}
+	}
+	mgr.db.SaveWalletConfigValue(sharedW.VoteReminderIntervalsConfigKey, hours)
+}
+
 func genKey(prefix, identifier interface{}) string {
 	return fmt.Sprintf("%v-%v", prefix, identifier)
 }
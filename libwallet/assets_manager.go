@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 
 	"decred.org/dcrwallet/v3/errors"
 	"github.com/asdine/storm"
@@ -49,6 +50,12 @@ type AssetsManager struct {
 	shuttingDown chan bool
 	cancelFuncs  []context.CancelFunc
 	chainsParams utils.ChainsParams
+	// politeiaHost is the default politeia host for the network.
+	politeiaHost string
+	autoVoter    *autoVoter
+
+	voteReminderListenersMu sync.RWMutex
+	voteReminderListeners   map[string]VoteReminderListener
 
 	Politeia        *politeia.Politeia
 	InstantSwap     *instantswap.InstantSwap
@@ -86,6 +93,19 @@ func initializeAssetsFields(rootDir, dbDriver, logDir string, netType utils.Netw
 	mgr := &AssetsManager{
 		params: params,
 		Assets: new(Assets),
+
+		voteReminderListeners: make(map[string]VoteReminderListener),
+	}
+	params.HTTPAPIAllowed = mgr.IsHTTPAPIPrivacyModeOff
+	params.Endpoints = mgr.GetEndpoints
+
+	// BTC and LTC wallets share a single chain service per asset so that the
+	// block headers, cfilters and peers are not duplicated per wallet. DCR
+	// wallets share the peers.
+	params.ChainBackends = map[utils.AssetType]sharedW.ChainBackend{
+		utils.BTCWalletAsset: btc.NewChainBackend(chainBackendDir(params, utils.BTCWalletAsset)),
+		utils.DCRWalletAsset: dcr.NewChainBackend(chainBackendDir(params, utils.DCRWalletAsset)),
+		utils.LTCWalletAsset: ltc.NewChainBackend(chainBackendDir(params, utils.LTCWalletAsset)),
 	}
 
 	mgr.Assets.BTC.Wallets = make(map[int]sharedW.Asset)
@@ -139,6 +159,14 @@ func NewAssetsManager(rootDir, dbDriver, politeiaHost, logDir string, netType ut
 		return nil, err
 	}
 
+	// init database for caching the treasury history
+	for _, data := range []interface{}{&TreasuryDay{}, &TreasurySpend{}} {
+		if err = mwDB.Init(data); err != nil {
+			log.Errorf("Error initializing treasury history database: %s", err.Error())
+			return nil, err
+		}
+	}
+
 	politeia, err := politeia.New(politeiaHost, mwDB)
 	if err != nil {
 		return nil, err
@@ -150,7 +178,12 @@ func NewAssetsManager(rootDir, dbDriver, politeiaHost, logDir string, netType ut
 	}
 
 	mgr.params.DB = mwDB
+	mgr.politeiaHost = politeiaHost
 	mgr.Politeia = politeia
+	mgr.autoVoter = newAutoVoter(mgr)
+	if err = politeia.AddNotificationListener(mgr.autoVoter, autoVoteListenerID); err != nil {
+		return nil, err
+	}
 	mgr.InstantSwap = instantSwap
 
 	// initialize the ExternalService. ExternalService provides assetsManager with
@@ -167,6 +200,12 @@ func NewAssetsManager(rootDir, dbDriver, politeiaHost, logDir string, netType ut
 
 	log.Infof("Loaded %d wallets", mgr.LoadedWalletsCount())
 
+	// Query the user configured hosts of the remote services, the saved
+	// config can only be read once the wallets are loaded.
+	for _, endpointType := range utils.EndpointTypes {
+		mgr.applyEndpoints(endpointType)
+	}
+
 	// Attempt to set the log levels if a valid db interface was found.
 	if mgr.IsAssetManagerDB() {
 		mgr.GetLogLevels()
@@ -174,6 +213,18 @@ func NewAssetsManager(rootDir, dbDriver, politeiaHost, logDir string, netType ut
 
 	mgr.listenForShutdown()
 
+	syncLimitsCtx, cancel := context.WithCancel(context.Background())
+	mgr.cancelFuncs = append(mgr.cancelFuncs, cancel)
+	go mgr.enforceSyncLimits(syncLimitsCtx)
+
+	autoVoteCtx, cancel := context.WithCancel(context.Background())
+	mgr.cancelFuncs = append(mgr.cancelFuncs, cancel)
+	go mgr.autoVoter.run(autoVoteCtx)
+
+	voteRemindersCtx, cancel := context.WithCancel(context.Background())
+	mgr.cancelFuncs = append(mgr.cancelFuncs, cancel)
+	go mgr.remindVoteDeadlines(voteRemindersCtx)
+
 	return mgr, nil
 }
 
@@ -293,6 +344,12 @@ func (mgr *AssetsManager) Shutdown() {
 		wallet.CancelRescan()
 	}
 
+	for assetType, backend := range mgr.params.ChainBackends {
+		if err := backend.Close(); err != nil {
+			log.Errorf("closing %v chain backend failed: %v", assetType, err)
+		}
+	}
+
 	// Disable all active network connections
 	utils.ShutdownHTTPClients()
 
@@ -311,6 +368,30 @@ func (mgr *AssetsManager) Shutdown() {
 	}
 }
 
+// chainBackendDir returns the directory holding the chain data shared by all
+// the wallets of the asset type. It is kept out of the asset's wallets
+// directory, whose unknown subdirectories are removed as deleted wallets.
+func chainBackendDir(params *sharedW.InitParams, assetType utils.AssetType) string {
+	dirName := ""
+	if params.NetType == utils.Testnet {
+		dirName = utils.NetDir(assetType, params.NetType)
+	}
+	dataDir := filepath.Join(params.RootDir, dirName, "chain", assetType.ToStringLower())
+
+	// Move the chain data out of the wallets directory where it used to be.
+	legacyDir := filepath.Join(params.RootDir, dirName, assetType.ToStringLower(), "chain")
+	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
+		if _, err := os.Stat(legacyDir); err == nil {
+			if err := os.MkdirAll(filepath.Dir(dataDir), utils.UserFilePerm); err != nil {
+				log.Errorf("creating the %v chain directory failed: %v", assetType, err)
+			} else if err := os.Rename(legacyDir, dataDir); err != nil {
+				log.Errorf("moving the %v chain directory failed: %v", assetType, err)
+			}
+		}
+	}
+	return dataDir
+}
+
 // NetType returns the network type of the assets manager.
 // It is either mainnet or testnet.
 func (mgr *AssetsManager) NetType() utils.NetworkType {
@@ -547,18 +628,39 @@ func (mgr *AssetsManager) WalletWithSeed(walletType utils.AssetType, seedMnemoni
 	}
 }
 
-// RestoreWallet restores a wallet from the given seed.
-func (mgr *AssetsManager) RestoreWallet(walletType utils.AssetType, walletName, seedMnemonic, privatePassphrase string, privatePassphraseType int32) (sharedW.Asset, error) {
+// RestoreWallet restores a wallet from the given seed. If birthday is not nil,
+// the first sync of a restored BTC or LTC wallet skips the blocks mined before
+// it. The first sync of a DCR wallet always begins at the genesis block, the
+// birthday is where its later usage discoveries and their rescans begin.
+func (mgr *AssetsManager) RestoreWallet(walletType utils.AssetType, walletName, seedMnemonic, privatePassphrase string, privatePassphraseType int32, birthday *sharedW.WalletBirthday) (sharedW.Asset, error) {
+	if birthday != nil {
+		if err := birthday.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	var wallet sharedW.Asset
+	var err error
 	switch walletType {
 	case utils.BTCWalletAsset:
-		return mgr.RestoreBTCWallet(walletName, seedMnemonic, privatePassphrase, privatePassphraseType)
+		wallet, err = mgr.RestoreBTCWallet(walletName, seedMnemonic, privatePassphrase, privatePassphraseType)
 	case utils.DCRWalletAsset:
-		return mgr.RestoreDCRWallet(walletName, seedMnemonic, privatePassphrase, privatePassphraseType)
+		wallet, err = mgr.RestoreDCRWallet(walletName, seedMnemonic, privatePassphrase, privatePassphraseType)
 	case utils.LTCWalletAsset:
-		return mgr.RestoreLTCWallet(walletName, seedMnemonic, privatePassphrase, privatePassphraseType)
+		wallet, err = mgr.RestoreLTCWallet(walletName, seedMnemonic, privatePassphrase, privatePassphraseType)
 	default:
 		return nil, utils.ErrAssetUnknown
 	}
+	if err != nil || birthday == nil {
+		return wallet, err
+	}
+
+	// The birthday is read when syncing the restored wallet to skip the
+	// blocks mined before the wallet was created.
+	if err = wallet.SaveRestoreBirthday(birthday); err != nil {
+		log.Errorf("Failed to save the birthday of wallet %s: %v", walletName, err)
+	}
+	return wallet, nil
 }
 
 // WalletWithXPub returns the ID of the wallet with the given xpub. If a wallet
@@ -7,8 +7,12 @@ package badgerdb
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 
 	"decred.org/dcrwallet/v3/errors"
 	"decred.org/dcrwallet/v3/wallet/walletdb"
@@ -577,9 +581,29 @@ func (c *Cursor) Close() {
 // transactions which are obtained through the specific Namespace.
 type db struct {
 	*badger.DB
+	path   string
 	closed bool
 }
 
+// openDBs are the open databases indexed by path. The wallet only exposes
+// the walletdb.DB interface of its database.
+var (
+	openDBsMu sync.Mutex
+	openDBs   = make(map[string]*db)
+)
+
+// Stats returns the stats of the open database at the provided path pretty
+// printed into a string, or an empty string if no database is open at path.
+func Stats(dbPath string) string {
+	openDBsMu.Lock()
+	d := openDBs[filepath.Clean(dbPath)]
+	openDBsMu.Unlock()
+	if d == nil {
+		return ""
+	}
+	return d.PrintStats()
+}
+
 // Enforce db implements the walletdb.DB interface.
 var _ walletdb.DB = (*db)(nil)
 
@@ -601,12 +625,43 @@ func (db *db) BeginReadWriteTx() (walletdb.ReadWriteTx, error) {
 	return db.beginTx(true)
 }
 
-// Copy writes a copy of the database to the provided writer.  This call will
-// start a read-only transaction to perform all operations.
+// Copy writes a copy of the database to the provided writer.  The copy is a
+// badger backup of all the keys visible at the start of the call, it can be
+// loaded into a new database with Restore.
 //
 // This function is part of the walletdb.DB interface implementation.
-func (db *db) Copy(_ io.Writer) error {
-	return errors.E(errors.Invalid, "method not implemented")
+func (db *db) Copy(w io.Writer) error {
+	if db.closed {
+		return errors.E(errors.Invalid)
+	}
+
+	_, err := db.DB.Backup(w, 0)
+	return convertErr(err)
+}
+
+// PrintStats returns the size of the LSM tree and value log of the database
+// and the number of tables on each level pretty printed into a string.
+func (db *db) PrintStats() string {
+	if db.closed {
+		return ""
+	}
+
+	lsm, vlog := db.DB.Size()
+	levels := make(map[int]int)
+	maxLevel := 0
+	for _, table := range db.DB.Tables(false) {
+		levels[table.Level]++
+		if table.Level > maxLevel {
+			maxLevel = table.Level
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "lsm: %d bytes, vlog: %d bytes", lsm, vlog)
+	for level := 0; level <= maxLevel; level++ {
+		fmt.Fprintf(&b, ", L%d: %d tables", level, levels[level])
+	}
+	return b.String()
 }
 
 // Close cleanly shuts down the database and syncs all data.
@@ -619,6 +674,12 @@ func (db *db) Close() error {
 
 	db.closed = true // setting this to true to pause all operations that will happen while db is closing
 
+	openDBsMu.Lock()
+	if openDBs[db.path] == db {
+		delete(openDBs, db.path)
+	}
+	openDBsMu.Unlock()
+
 	err := db.DB.Close()
 	if err != nil {
 		return convertErr(err)
@@ -656,12 +717,36 @@ func openDB(dbPath string, create bool) (walletdb.DB, error) {
 		WithNumLevelZeroTablesStall(2)
 
 	d := &db{
+		path:   filepath.Clean(dbPath),
 		closed: false,
 	}
 	badgerDB, err := badger.Open(opts)
 	if err == nil {
 		d.DB = badgerDB
+		openDBsMu.Lock()
+		openDBs[d.path] = d
+		openDBsMu.Unlock()
 	}
 
 	return d, convertErr(err)
 }
+
+// Restore creates a database at the provided path and loads the copy of a
+// database written by Copy into it.
+func Restore(dbPath string, r io.Reader) error {
+	if fileExists(dbPath) {
+		return errors.E(errors.Exist, "database already exists")
+	}
+
+	d, err := openDB(dbPath, true)
+	if err != nil {
+		return err
+	}
+	bdb := d.(*db)
+
+	if err := bdb.DB.Load(r, 256); err != nil {
+		bdb.Close()
+		return convertErr(err)
+	}
+	return bdb.Close()
+}
@@ -14,6 +14,9 @@ import (
 
 const (
 	dbType = "badgerdb"
+
+	// DriverName is the name the driver is registered with.
+	DriverName = dbType
 )
 
 // parseArgs parses the arguments from the walletdb Open/Create methods.
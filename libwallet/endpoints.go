@@ -0,0 +1,118 @@
+package libwallet
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/ext"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// endpointHealthPaths are the paths requested to check whether an endpoint
+// of the service is up.
+var endpointHealthPaths = map[utils.EndpointType]string{
+	utils.PoliteiaEndpoint:  "v1/version",
+	utils.DcrdataEndpoint:   "api/status",
+	utils.BlockbookEndpoint: "api",
+	utils.VSPListEndpoint:   "",
+}
+
+// DefaultEndpoints returns the hosts of the service used if none are
+// configured. The politeia host is provided to NewAssetsManager, the
+// dcrdata and blockbook hosts are those of the ext package.
+func (mgr *AssetsManager) DefaultEndpoints(endpointType utils.EndpointType) []utils.Endpoint {
+	switch endpointType {
+	case utils.PoliteiaEndpoint:
+		if mgr.politeiaHost != "" {
+			return []utils.Endpoint{{URL: mgr.politeiaHost}}
+		}
+	case utils.DcrdataEndpoint, utils.BlockbookEndpoint:
+		params, err := utils.DCRChainParams(mgr.NetType())
+		if err != nil {
+			return nil
+		}
+		backend := ext.DcrData
+		if endpointType == utils.BlockbookEndpoint {
+			backend = ext.BlockBook
+		}
+		return ext.DefaultEndpoints(backend, params.Name)
+	case utils.VSPListEndpoint:
+		return []utils.Endpoint{{URL: dcr.DefaultVSPListURL}}
+	}
+	return nil
+}
+
+// GetEndpoints returns the hosts of the service in the order they are tried.
+func (mgr *AssetsManager) GetEndpoints(endpointType utils.EndpointType) []utils.Endpoint {
+	if mgr.IsAssetManagerDB() {
+		var endpoints []utils.Endpoint
+		err := mgr.db.ReadWalletConfigValue(genKey(sharedW.EndpointsConfigKey, endpointType), &endpoints)
+		if err == nil && len(endpoints) > 0 {
+			return endpoints
+		}
+	}
+	return mgr.DefaultEndpoints(endpointType)
+}
+
+// SetEndpoints saves the hosts of the service in the order they are tried,
+// the first enabled host that responds is used. An empty list restores the
+// default hosts.
+func (mgr *AssetsManager) SetEndpoints(endpointType utils.EndpointType, endpoints []utils.Endpoint) error {
+	if !mgr.IsAssetManagerDB() {
+		return errors.New(utils.ErrWalletNotLoaded)
+	}
+
+	key := genKey(sharedW.EndpointsConfigKey, endpointType)
+	if len(endpoints) == 0 {
+		mgr.db.DeleteWalletConfigValue(key)
+		mgr.applyEndpoints(endpointType)
+		return nil
+	}
+
+	urls := make(map[string]bool, len(endpoints))
+	for i := range endpoints {
+		if err := endpoints[i].Validate(); err != nil {
+			return err
+		}
+		if urls[endpoints[i].URL] {
+			return errors.New(utils.ErrExist)
+		}
+		urls[endpoints[i].URL] = true
+	}
+
+	mgr.db.SaveWalletConfigValue(key, endpoints)
+	mgr.applyEndpoints(endpointType)
+	return nil
+}
+
+// CheckEndpoints checks whether each host of the service is up. The hosts
+// are checked concurrently, the results are in the order of the hosts.
+func (mgr *AssetsManager) CheckEndpoints(endpointType utils.EndpointType, endpoints []utils.Endpoint) []*utils.EndpointHealth {
+	results := make([]*utils.EndpointHealth, len(endpoints))
+	var wg sync.WaitGroup
+	for i := range endpoints {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = utils.CheckEndpoint(endpoints[i], endpointHealthPaths[endpointType])
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// applyEndpoints makes the services query the configured hosts. The VSP list
+// hosts are read by the DCR wallets when the list is reloaded.
+func (mgr *AssetsManager) applyEndpoints(endpointType utils.EndpointType) {
+	endpoints := mgr.GetEndpoints(endpointType)
+	switch endpointType {
+	case utils.PoliteiaEndpoint:
+		mgr.Politeia.SetEndpoints(endpoints)
+	case utils.DcrdataEndpoint:
+		mgr.ExternalService.SetEndpoints(ext.DcrData, endpoints)
+	case utils.BlockbookEndpoint:
+		mgr.ExternalService.SetEndpoints(ext.BlockBook, endpoints)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -458,6 +459,50 @@ func TestGetTreasuryBalance(t *testing.T) {
 	}
 }
 
+func TestGetTreasuryIO(t *testing.T) {
+	tests := []struct {
+		name             string
+		server           *httptest.Server
+		expectedResponse *TreasuryIO
+		expectedErr      error
+	}{
+		{
+			name: "treasury io by day",
+			server: httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/treasury/io/day" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"time":[1621382400,1621468800,1621555200],"received":[179.85926612,180.05924178,179.61238204],
+						"sent":[0,0,1000],"net":[179.85926612,180.05924178,-820.38761796]}`))
+			})),
+			expectedResponse: &TreasuryIO{
+				Time:     []int64{1621382400, 1621468800, 1621555200},
+				Received: []float64{179.85926612, 180.05924178, 179.61238204},
+				Sent:     []float64{0, 0, 1000},
+				Net:      []float64{179.85926612, 180.05924178, -820.38761796},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer tc.server.Close()
+			backendURL["mainnet"][DcrData] = tc.server.URL + "/"
+			backendURL["testnet3"][DcrData] = tc.server.URL + "/"
+			resp, err := service.GetTreasuryIO(TreasuryDayGrouping)
+			if !reflect.DeepEqual(resp, tc.expectedResponse) {
+				t.Errorf("(%v), expected (%v), got (%v)", tc.name, tc.expectedResponse, resp)
+			}
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("(%v), expected (%v), got (%v)", tc.name, tc.expectedErr, err)
+			}
+		})
+	}
+}
+
 func TestGetExchangeRate(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -608,3 +653,50 @@ func TestGetAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestEndpointFailover(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	up := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`681536`))
+	}))
+	defer up.Close()
+	pin := utils.CertPin(up.Certificate())
+
+	tests := []struct {
+		name             string
+		endpoints        []utils.Endpoint
+		expectedResponse int32
+	}{
+		{
+			name:             "failover to pinned host",
+			endpoints:        []utils.Endpoint{{URL: down.URL}, {URL: up.URL, CertPin: pin}},
+			expectedResponse: 681536,
+		},
+		{
+			name:             "disabled host",
+			endpoints:        []utils.Endpoint{{URL: up.URL, CertPin: pin, Disabled: true}},
+			expectedResponse: -1,
+		},
+		{
+			name:             "pin mismatch",
+			endpoints:        []utils.Endpoint{{URL: up.URL, CertPin: strings.Repeat("0", 64)}},
+			expectedResponse: -1,
+		},
+	}
+
+	s := mainnetService()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s.SetEndpoints(DcrData, tc.endpoints)
+			resp := s.GetBestBlock()
+			if resp != tc.expectedResponse {
+				t.Errorf("(%v), expected (%v), got (%v)", tc.name, tc.expectedResponse, resp)
+			}
+		})
+	}
+}
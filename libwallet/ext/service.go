@@ -1,11 +1,13 @@
 package ext
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/decred/dcrd/chaincfg/v3"
@@ -18,6 +20,11 @@ type (
 	// 3rd party services or external resources.
 	Service struct {
 		chainParams *chaincfg.Params
+
+		endpointsMu sync.RWMutex
+		// endpoints holds the user configured hosts of the backends. The
+		// default backend URL is used for backends without endpoints.
+		endpoints map[string][]utils.Endpoint
 	}
 )
 
@@ -35,6 +42,11 @@ const (
 	mainnetAddressIdentifier = "D"
 	mainnetXpubIdentifier    = "d"
 	testnetXpubIdentifier    = "t"
+
+	// TreasuryDayGrouping and TreasuryMonthGrouping are the time intervals the
+	// treasury inflows and outflows can be binned by.
+	TreasuryDayGrouping   = "day"
+	TreasuryMonthGrouping = "month"
 )
 
 var (
@@ -57,8 +69,29 @@ var (
 		chaincfg.MainNetParams().Name:  mainnetURL,
 		chaincfg.TestNet3Params().Name: testnetURL,
 	}
+	// failoverURLs maps backends to the hosts failed over to, in order, when
+	// their url on the network can't be reached.
+	failoverURLs = map[string]map[string][]string{
+		chaincfg.MainNetParams().Name: {
+			DcrData: {"https://dcrdata.decred.org/"},
+		},
+	}
 )
 
+// DefaultEndpoints returns the hosts of the backend on the network, net being
+// the chain params name, in the order they are tried if none are set with
+// SetEndpoints.
+func DefaultEndpoints(backend, net string) []utils.Endpoint {
+	var endpoints []utils.Endpoint
+	if authority, ok := backendURL[net][backend]; ok {
+		endpoints = append(endpoints, utils.Endpoint{URL: authority})
+	}
+	for _, authority := range failoverURLs[net][backend] {
+		endpoints = append(endpoints, utils.Endpoint{URL: authority})
+	}
+	return endpoints
+}
+
 // NewService configures and return a news instance of the service type.
 func NewService(chainParams *chaincfg.Params) *Service {
 	return &Service{
@@ -66,6 +99,33 @@ func NewService(chainParams *chaincfg.Params) *Service {
 	}
 }
 
+// SetEndpoints sets the hosts the backend is queried from, in the order they
+// are tried. The default backend URL is used if no endpoints are set.
+func (s *Service) SetEndpoints(backend string, endpoints []utils.Endpoint) {
+	s.endpointsMu.Lock()
+	defer s.endpointsMu.Unlock()
+	if s.endpoints == nil {
+		s.endpoints = make(map[string][]utils.Endpoint)
+	}
+	s.endpoints[backend] = endpoints
+}
+
+// request sends the request to the configured endpoints of the backend,
+// failing over between them, or to the default backend URL if the backend
+// has no endpoints. reqConf.HTTPURL holds the path of the request.
+func (s *Service) request(backend string, reqConf *utils.ReqConfig, respObj interface{}) (*http.Response, error) {
+	s.endpointsMu.RLock()
+	endpoints := s.endpoints[backend]
+	s.endpointsMu.RUnlock()
+
+	if len(endpoints) > 0 {
+		return utils.EndpointRequest(endpoints, reqConf, respObj)
+	}
+
+	reqConf.HTTPURL = setBackend(backend, s.chainParams.Name, reqConf.HTTPURL)
+	return utils.HTTPRequest(reqConf, respObj)
+}
+
 // Setbackend sets the appropriate URL scheme and authority for the backend resource.
 func setBackend(backend, net, rawURL string) string {
 	// Check if URL scheme and authority is already set.
@@ -84,12 +144,12 @@ func setBackend(backend, net, rawURL string) string {
 func (s *Service) GetBestBlock() int32 {
 	reqConf := &utils.ReqConfig{
 		Method:    http.MethodGet,
-		HTTPURL:   setBackend(DcrData, s.chainParams.Name, "api/block/best/height"),
+		HTTPURL:   "api/block/best/height",
 		IsRetByte: true,
 	}
 
 	var resp []byte
-	_, err := utils.HTTPRequest(reqConf, &resp)
+	_, err := s.request(DcrData, reqConf, &resp)
 	if err != nil {
 		log.Error(err)
 		return -1
@@ -108,11 +168,11 @@ func (s *Service) GetBestBlock() int32 {
 func (s *Service) GetBestBlockTimeStamp() int64 {
 	reqConf := &utils.ReqConfig{
 		Method:  http.MethodGet,
-		HTTPURL: setBackend(DcrData, s.chainParams.Name, "api/block/best?txtotals=false"),
+		HTTPURL: "api/block/best?txtotals=false",
 	}
 
 	resp := &BlockDataBasic{}
-	_, err := utils.HTTPRequest(reqConf, resp)
+	_, err := s.request(DcrData, reqConf, resp)
 	if err != nil {
 		log.Error(err)
 		return -1
@@ -124,10 +184,10 @@ func (s *Service) GetBestBlockTimeStamp() int64 {
 func (s *Service) GetCurrentAgendaStatus() (agenda *chainjson.GetVoteInfoResult, err error) {
 	reqConf := &utils.ReqConfig{
 		Method:  http.MethodGet,
-		HTTPURL: setBackend(DcrData, s.chainParams.Name, "api/stake/vote/info"),
+		HTTPURL: "api/stake/vote/info",
 	}
 	agenda = &chainjson.GetVoteInfoResult{}
-	_, err = utils.HTTPRequest(reqConf, agenda)
+	_, err = s.request(DcrData, reqConf, agenda)
 	return agenda, err
 }
 
@@ -135,10 +195,10 @@ func (s *Service) GetCurrentAgendaStatus() (agenda *chainjson.GetVoteInfoResult,
 func (s *Service) GetAgendas() (agendas *[]apiTypes.AgendasInfo, err error) {
 	reqConf := &utils.ReqConfig{
 		Method:  http.MethodGet,
-		HTTPURL: setBackend(DcrData, s.chainParams.Name, "api/agendas"),
+		HTTPURL: "api/agendas",
 	}
 	agendas = &[]apiTypes.AgendasInfo{}
-	_, err = utils.HTTPRequest(reqConf, agendas)
+	_, err = s.request(DcrData, reqConf, agendas)
 	return agendas, err
 }
 
@@ -146,10 +206,10 @@ func (s *Service) GetAgendas() (agendas *[]apiTypes.AgendasInfo, err error) {
 func (s *Service) GetAgendaDetails(agendaID string) (agendaDetails *AgendaAPIResponse, err error) {
 	reqConf := &utils.ReqConfig{
 		Method:  http.MethodGet,
-		HTTPURL: setBackend(DcrData, s.chainParams.Name, "api/agenda/"+agendaID),
+		HTTPURL: "api/agenda/" + agendaID,
 	}
 	agendaDetails = &AgendaAPIResponse{}
-	_, err = utils.HTTPRequest(reqConf, agendaDetails)
+	_, err = s.request(DcrData, reqConf, agendaDetails)
 	return agendaDetails, err
 }
 
@@ -167,13 +227,80 @@ func (s *Service) GetTreasuryBalance() (bal int64, err error) {
 func (s *Service) GetTreasuryDetails() (treasuryDetails *TreasuryDetails, err error) {
 	reqConf := &utils.ReqConfig{
 		Method:  http.MethodGet,
-		HTTPURL: setBackend(DcrData, s.chainParams.Name, "api/treasury/balance"),
+		HTTPURL: "api/treasury/balance",
 	}
 	treasuryDetails = &TreasuryDetails{}
-	_, err = utils.HTTPRequest(reqConf, treasuryDetails)
+	_, err = s.request(DcrData, reqConf, treasuryDetails)
 	return treasuryDetails, err
 }
 
+// GetTreasuryIO returns the treasury inflows and outflows since the treasury
+// activation, binned by the grouping time interval.
+func (s *Service) GetTreasuryIO(grouping string) (treasuryIO *TreasuryIO, err error) {
+	reqConf := &utils.ReqConfig{
+		Method:  http.MethodGet,
+		HTTPURL: "api/treasury/io/" + grouping,
+	}
+	treasuryIO = &TreasuryIO{}
+	_, err = s.request(DcrData, reqConf, treasuryIO)
+	return treasuryIO, err
+}
+
+// GetBlockRange returns the blocks from height from to height to, both
+// included, taking every step blocks.
+func (s *Service) GetBlockRange(from, to, step uint32) (blocks []*BlockDataBasic, err error) {
+	reqConf := &utils.ReqConfig{
+		Method:  http.MethodGet,
+		HTTPURL: fmt.Sprintf("api/block/range/%d/%d/%d", from, to, step),
+	}
+	_, err = s.request(DcrData, reqConf, &blocks)
+	return blocks, err
+}
+
+// GetBlockTransactions returns the hashes of the regular and the stake
+// transactions of the block at the height.
+func (s *Service) GetBlockTransactions(height uint32) (blockTxs *apiTypes.BlockTransactions, err error) {
+	reqConf := &utils.ReqConfig{
+		Method:  http.MethodGet,
+		HTTPURL: fmt.Sprintf("api/block/%d/tx", height),
+	}
+	blockTxs = &apiTypes.BlockTransactions{}
+	_, err = s.request(DcrData, reqConf, blockTxs)
+	return blockTxs, err
+}
+
+// GetTransactions returns the decoded transactions with the hashes.
+func (s *Service) GetTransactions(txHashes []string) (txs []*apiTypes.Tx, err error) {
+	payload, err := json.Marshal(&apiTypes.Txns{Transactions: txHashes})
+	if err != nil {
+		return nil, err
+	}
+
+	reqConf := &utils.ReqConfig{
+		Method:  http.MethodPost,
+		HTTPURL: "api/txs",
+		Payload: payload,
+	}
+	_, err = s.request(DcrData, reqConf, &txs)
+	return txs, err
+}
+
+// GetRawTransaction returns the hex encoded transaction with the hash.
+func (s *Service) GetRawTransaction(txHash string) (string, error) {
+	reqConf := &utils.ReqConfig{
+		Method:    http.MethodGet,
+		HTTPURL:   "api/tx/hex/" + txHash,
+		IsRetByte: true,
+	}
+
+	var resp []byte
+	if _, err := s.request(DcrData, reqConf, &resp); err != nil {
+		return "", err
+	}
+	// The hex may be returned as a JSON string.
+	return strings.Trim(strings.TrimSpace(string(resp)), `"`), nil
+}
+
 // GetExchangeRate fetches exchange rate data summary
 func (s *Service) GetExchangeRate() (rates *ExchangeRates, err error) {
 	reqConf := &utils.ReqConfig{
@@ -205,10 +332,10 @@ func (s *Service) GetExchanges() (state *ExchangeState, err error) {
 func (s *Service) GetTicketFeeRateSummary() (ticketInfo *apiTypes.MempoolTicketFeeInfo, err error) {
 	reqConf := &utils.ReqConfig{
 		Method:  http.MethodGet,
-		HTTPURL: setBackend(DcrData, s.chainParams.Name, "api/mempool/sstx"),
+		HTTPURL: "api/mempool/sstx",
 	}
 	ticketInfo = &apiTypes.MempoolTicketFeeInfo{}
-	_, err = utils.HTTPRequest(reqConf, ticketInfo)
+	_, err = s.request(DcrData, reqConf, ticketInfo)
 	return ticketInfo, err
 }
 
@@ -217,10 +344,10 @@ func (s *Service) GetTicketFeeRateSummary() (ticketInfo *apiTypes.MempoolTicketF
 func (s *Service) GetTicketFeeRate() (ticketFeeRate *apiTypes.MempoolTicketFees, err error) {
 	reqConf := &utils.ReqConfig{
 		Method:  http.MethodGet,
-		HTTPURL: setBackend(DcrData, s.chainParams.Name, "api/mempool/sstx/fees"),
+		HTTPURL: "api/mempool/sstx/fees",
 	}
 	ticketFeeRate = &apiTypes.MempoolTicketFees{}
-	_, err = utils.HTTPRequest(reqConf, ticketFeeRate)
+	_, err = s.request(DcrData, reqConf, ticketFeeRate)
 	return ticketFeeRate, err
 }
 
@@ -229,10 +356,10 @@ func (s *Service) GetTicketFeeRate() (ticketFeeRate *apiTypes.MempoolTicketFees,
 func (s *Service) GetNHighestTicketFeeRate(nHighest int) (ticketFeeRate *apiTypes.MempoolTicketFees, err error) {
 	reqConf := &utils.ReqConfig{
 		Method:  http.MethodGet,
-		HTTPURL: setBackend(DcrData, s.chainParams.Name, "api/mempool/sstx/fees/"+strconv.Itoa(nHighest)),
+		HTTPURL: "api/mempool/sstx/fees/" + strconv.Itoa(nHighest),
 	}
 	ticketFeeRate = &apiTypes.MempoolTicketFees{}
-	_, err = utils.HTTPRequest(reqConf, ticketFeeRate)
+	_, err = s.request(DcrData, reqConf, ticketFeeRate)
 	return ticketFeeRate, err
 }
 
@@ -241,10 +368,10 @@ func (s *Service) GetNHighestTicketFeeRate(nHighest int) (ticketFeeRate *apiType
 func (s *Service) GetTicketDetails() (ticketDetails *apiTypes.MempoolTicketDetails, err error) {
 	reqConf := &utils.ReqConfig{
 		Method:  http.MethodGet,
-		HTTPURL: setBackend(DcrData, s.chainParams.Name, "api/mempool/sstx/details"),
+		HTTPURL: "api/mempool/sstx/details",
 	}
 	ticketDetails = &apiTypes.MempoolTicketDetails{}
-	_, err = utils.HTTPRequest(reqConf, ticketDetails)
+	_, err = s.request(DcrData, reqConf, ticketDetails)
 	return ticketDetails, err
 }
 
@@ -252,10 +379,10 @@ func (s *Service) GetTicketDetails() (ticketDetails *apiTypes.MempoolTicketDetai
 func (s *Service) GetNHighestTicketDetails(nHighest int) (ticketDetails *apiTypes.MempoolTicketDetails, err error) {
 	reqConf := &utils.ReqConfig{
 		Method:  http.MethodGet,
-		HTTPURL: setBackend(DcrData, s.chainParams.Name, "api/mempool/sstx/details/"+strconv.Itoa(nHighest)),
+		HTTPURL: "api/mempool/sstx/details/" + strconv.Itoa(nHighest),
 	}
 	ticketDetails = &apiTypes.MempoolTicketDetails{}
-	_, err = utils.HTTPRequest(reqConf, ticketDetails)
+	_, err = s.request(DcrData, reqConf, ticketDetails)
 	return ticketDetails, err
 }
 
@@ -279,10 +406,10 @@ func (s *Service) GetAddress(address string) (addressState *AddressState, err er
 
 	reqConf := &utils.ReqConfig{
 		Method:  http.MethodGet,
-		HTTPURL: setBackend(BlockBook, s.chainParams.Name, "api/v2/address/"+address),
+		HTTPURL: "api/v2/address/" + address,
 	}
 	addressState = &AddressState{}
-	_, err = utils.HTTPRequest(reqConf, addressState)
+	_, err = s.request(BlockBook, reqConf, addressState)
 	return addressState, err
 }
 
@@ -304,10 +431,10 @@ func (s *Service) GetXpub(xPub string) (xPubBalAndTxs *XpubBalAndTxs, err error)
 
 	reqConf := &utils.ReqConfig{
 		Method:  http.MethodGet,
-		HTTPURL: setBackend(BlockBook, s.chainParams.Name, "api/v2/xpub/"+xPub),
+		HTTPURL: "api/v2/xpub/" + xPub,
 	}
 	xPubBalAndTxs = &XpubBalAndTxs{}
-	_, err = utils.HTTPRequest(reqConf, xPubBalAndTxs)
+	_, err = s.request(BlockBook, reqConf, xPubBalAndTxs)
 	return xPubBalAndTxs, err
 }
 
@@ -39,6 +39,17 @@ type (
 		Immature       int64 `json:"immature"`
 	}
 
+	// TreasuryIO holds the treasury inflows and outflows binned by time
+	// interval, the amounts are in DCR. Received holds the treasury adds and
+	// treasurybases, Sent the treasury spends and Net their difference. Time
+	// holds the unix timestamps of the start of the intervals.
+	TreasuryIO struct {
+		Time     []int64   `json:"time"`
+		Received []float64 `json:"received"`
+		Sent     []float64 `json:"sent"`
+		Net      []float64 `json:"net"`
+	}
+
 	// BaseState are the non-iterable fields of the ExchangeState, which embeds
 	// BaseState.
 	BaseState struct {
@@ -0,0 +1,378 @@
+// Package harness runs an AssetsManager against full nodes on a local simnet
+// or regnet chain so that the wallet flows can be tested end to end.
+//
+// The dcrd, btcd and ltcd binaries must be in the PATH for the assets tested.
+// Each node mines the blocks it generates to a miner wallet of the harness,
+// the other wallets are funded by the miner wallet.
+//
+// DCR blocks can't be generated past the stake validation height since no
+// wallet of the harness votes.
+package harness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/crypto-power/cryptopower/libwallet"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/ltcsuite/ltcd/ltcutil"
+)
+
+const (
+	// Passphrase is the private passphrase of the wallets of the harness.
+	Passphrase = "harness"
+
+	// DefaultAccount is the account the wallets are funded to.
+	DefaultAccount int32 = 0
+
+	// pollInterval is the time between two checks of a wallet state.
+	pollInterval = 250 * time.Millisecond
+
+	// minerWalletSuffix ends the name of the wallets the nodes mine to.
+	// Wallet names are unique across the assets.
+	minerWalletSuffix = "-miner"
+)
+
+// Harness is an AssetsManager on a local network with the full nodes its
+// wallets sync from.
+type Harness struct {
+	NetType utils.NetworkType
+	Manager *libwallet.AssetsManager
+
+	dir    string
+	nodes  map[utils.AssetType]*Node
+	miners map[utils.AssetType]sharedW.Asset
+}
+
+// New creates a harness that stores its nodes and wallets data in dir. The
+// network must be simnet or regnet.
+func New(dir string, netType utils.NetworkType) (*Harness, error) {
+	if netType != utils.Simulation && netType != utils.Regression {
+		return nil, fmt.Errorf("%v: (%v)", utils.ErrInvalidNet, netType)
+	}
+
+	walletsDir := filepath.Join(dir, "wallets")
+	if err := os.MkdirAll(walletsDir, utils.UserFilePerm); err != nil {
+		return nil, err
+	}
+
+	mgr, err := libwallet.NewAssetsManager(walletsDir, "bdb", "", filepath.Join(dir, "logs"), netType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Harness{
+		NetType: netType,
+		Manager: mgr,
+		dir:     dir,
+		nodes:   make(map[utils.AssetType]*Node),
+		miners:  make(map[utils.AssetType]sharedW.Asset),
+	}, nil
+}
+
+// Close shuts the wallets and the nodes down.
+func (h *Harness) Close() {
+	h.Manager.Shutdown()
+	for _, node := range h.nodes {
+		node.Stop()
+	}
+}
+
+// Node returns the full node of the asset or nil if it is not running.
+func (h *Harness) Node(assetType utils.AssetType) *Node {
+	return h.nodes[assetType]
+}
+
+// Miner returns the wallet the node of the asset mines to or nil if the node
+// is not running.
+func (h *Harness) Miner(assetType utils.AssetType) sharedW.Asset {
+	return h.miners[assetType]
+}
+
+// StartNode starts the full node of the asset and a miner wallet that
+// receives the block rewards. It mines enough blocks for the miner wallet to
+// have spendable funds.
+func (h *Harness) StartNode(ctx context.Context, assetType utils.AssetType) (*Node, error) {
+	if node, ok := h.nodes[assetType]; ok {
+		return node, nil
+	}
+
+	miner, err := h.CreateWallet(assetType, assetType.ToStringLower()+minerWalletSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeDir := filepath.Join(h.dir, "nodes", assetType.ToStringLower())
+	node, err := newNode(assetType, h.NetType, nodeDir)
+	if err != nil {
+		return nil, err
+	}
+	h.nodes[assetType] = node
+	h.miners[assetType] = miner
+
+	if assetType == utils.DCRWalletAsset {
+		miningAddr, err := miner.CurrentAddress(DefaultAccount)
+		if err != nil {
+			return nil, err
+		}
+		if err := node.start(ctx, miningAddr); err != nil {
+			return nil, err
+		}
+	} else {
+		// BTC and LTC wallets only derive addresses once they are connected
+		// to the network. The node mines a first block to a placeholder
+		// address for the miner wallet to sync, then it is restarted to mine
+		// to the miner wallet.
+		placeholder, err := placeholderAddress(assetType, h.NetType)
+		if err != nil {
+			return nil, err
+		}
+		if err := node.start(ctx, placeholder); err != nil {
+			return nil, err
+		}
+		if err := node.Generate(ctx, 1); err != nil {
+			return nil, err
+		}
+		if err := h.Sync(ctx, miner); err != nil {
+			return nil, err
+		}
+		miningAddr, err := miner.CurrentAddress(DefaultAccount)
+		if err != nil {
+			return nil, err
+		}
+		if err := node.Restart(ctx, miningAddr); err != nil {
+			return nil, err
+		}
+	}
+
+	maturity, err := h.coinbaseMaturity(assetType)
+	if err != nil {
+		return nil, err
+	}
+	if err := node.Generate(ctx, uint32(maturity)+10); err != nil {
+		return nil, err
+	}
+	if err := h.Sync(ctx, miner); err != nil {
+		return nil, err
+	}
+	if err := h.WaitForBalance(ctx, miner, DefaultAccount, 1); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// CreateWallet creates a wallet of the asset protected by Passphrase.
+func (h *Harness) CreateWallet(assetType utils.AssetType, name string) (sharedW.Asset, error) {
+	passType := sharedW.PassphraseTypePass
+	switch assetType {
+	case utils.DCRWalletAsset:
+		return h.Manager.CreateNewDCRWallet(name, Passphrase, passType)
+	case utils.BTCWalletAsset:
+		return h.Manager.CreateNewBTCWallet(name, Passphrase, passType)
+	case utils.LTCWalletAsset:
+		return h.Manager.CreateNewLTCWallet(name, Passphrase, passType)
+	default:
+		return nil, utils.ErrAssetUnknown
+	}
+}
+
+// RestoreWallet restores a wallet of the asset from the seed. The wallet is
+// protected by Passphrase.
+func (h *Harness) RestoreWallet(assetType utils.AssetType, name, seed string) (sharedW.Asset, error) {
+	return h.Manager.RestoreWallet(assetType, name, seed, Passphrase, sharedW.PassphraseTypePass, nil)
+}
+
+// Sync connects the wallet to the node of its asset and waits for it to be
+// synced.
+func (h *Harness) Sync(ctx context.Context, wallet sharedW.Asset) error {
+	node, ok := h.nodes[wallet.GetAssetType()]
+	if !ok {
+		return fmt.Errorf("%s node is not running", wallet.GetAssetType())
+	}
+
+	wallet.SaveUserConfigValue(sharedW.SpvPersistentPeerAddressesConfigKey, node.P2PAddr)
+	if !wallet.IsSyncing() && !wallet.IsSynced() {
+		if err := wallet.SpvSync(); err != nil {
+			return err
+		}
+	}
+	return h.WaitForHeight(ctx, wallet, -1)
+}
+
+// WaitForHeight waits for the wallet to be synced up to height, or up to the
+// node best block if height is negative.
+func (h *Harness) WaitForHeight(ctx context.Context, wallet sharedW.Asset, height int32) error {
+	return poll(ctx, func() (bool, error) {
+		if !wallet.IsSynced() {
+			return false, nil
+		}
+		target := height
+		if target < 0 {
+			count, err := h.nodes[wallet.GetAssetType()].BlockCount(ctx)
+			if err != nil {
+				return false, err
+			}
+			target = count
+		}
+		return wallet.GetBestBlockHeight() >= target, nil
+	})
+}
+
+// Mine generates numBlocks blocks and waits for the synced wallets of the
+// asset to process them.
+func (h *Harness) Mine(ctx context.Context, assetType utils.AssetType, numBlocks uint32) error {
+	node, ok := h.nodes[assetType]
+	if !ok {
+		return fmt.Errorf("%s node is not running", assetType)
+	}
+
+	height, err := node.BlockCount(ctx)
+	if err != nil {
+		return err
+	}
+	target := height + int32(numBlocks)
+
+	if assetType == utils.DCRWalletAsset {
+		params, err := utils.DCRChainParams(h.NetType)
+		if err != nil {
+			return err
+		}
+		if int64(target) >= params.StakeValidationHeight {
+			return fmt.Errorf("dcr blocks can't be mined past the stake validation height (%d)", params.StakeValidationHeight)
+		}
+	}
+
+	if err := node.Generate(ctx, numBlocks); err != nil {
+		return err
+	}
+
+	for _, wallet := range h.Manager.AllWallets() {
+		if wallet.GetAssetType() != assetType || !wallet.IsSynced() {
+			continue
+		}
+		if err := h.WaitForHeight(ctx, wallet, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Send sends amount from the account of the wallet to the address.
+func (h *Harness) Send(wallet sharedW.Asset, account int32, address string, amount int64) error {
+	if err := wallet.NewUnsignedTx(account, nil); err != nil {
+		return err
+	}
+	if err := wallet.AddSendDestination(address, amount, false); err != nil {
+		return err
+	}
+	_, err := wallet.Broadcast(Passphrase, "")
+	return err
+}
+
+// Fund sends amount from the miner wallet to the default account of the
+// wallet, mines the transaction and waits for the wallet to see it. The
+// wallet must be synced.
+func (h *Harness) Fund(ctx context.Context, wallet sharedW.Asset, amount int64) error {
+	miner, ok := h.miners[wallet.GetAssetType()]
+	if !ok {
+		return fmt.Errorf("%s node is not running", wallet.GetAssetType())
+	}
+
+	balance, err := wallet.GetAccountBalance(DefaultAccount)
+	if err != nil {
+		return err
+	}
+	address, err := wallet.CurrentAddress(DefaultAccount)
+	if err != nil {
+		return err
+	}
+	if err := h.Send(miner, DefaultAccount, address, amount); err != nil {
+		return err
+	}
+	if err := h.Mine(ctx, wallet.GetAssetType(), 1); err != nil {
+		return err
+	}
+	return h.WaitForBalance(ctx, wallet, DefaultAccount, balance.Spendable.ToInt()+amount)
+}
+
+// WaitForBalance waits for the spendable balance of the account to be at
+// least minSpendable.
+func (h *Harness) WaitForBalance(ctx context.Context, wallet sharedW.Asset, account int32, minSpendable int64) error {
+	return poll(ctx, func() (bool, error) {
+		balance, err := wallet.GetAccountBalance(account)
+		if err != nil {
+			return false, err
+		}
+		return balance.Spendable.ToInt() >= minSpendable, nil
+	})
+}
+
+// coinbaseMaturity returns the number of blocks before a block reward can be
+// spent.
+func (h *Harness) coinbaseMaturity(assetType utils.AssetType) (uint16, error) {
+	params, err := utils.GetChainParams(assetType, h.NetType)
+	if err != nil {
+		return 0, err
+	}
+	switch assetType {
+	case utils.DCRWalletAsset:
+		return params.DCR.CoinbaseMaturity, nil
+	case utils.BTCWalletAsset:
+		return params.BTC.CoinbaseMaturity, nil
+	case utils.LTCWalletAsset:
+		return params.LTC.CoinbaseMaturity, nil
+	default:
+		return 0, utils.ErrAssetUnknown
+	}
+}
+
+// placeholderAddress returns an address of the network that no wallet owns.
+func placeholderAddress(assetType utils.AssetType, netType utils.NetworkType) (string, error) {
+	params, err := utils.GetChainParams(assetType, netType)
+	if err != nil {
+		return "", err
+	}
+
+	var hash160 [20]byte
+	switch assetType {
+	case utils.BTCWalletAsset:
+		addr, err := btcutil.NewAddressPubKeyHash(hash160[:], params.BTC)
+		if err != nil {
+			return "", err
+		}
+		return addr.EncodeAddress(), nil
+	case utils.LTCWalletAsset:
+		addr, err := ltcutil.NewAddressPubKeyHash(hash160[:], params.LTC)
+		if err != nil {
+			return "", err
+		}
+		return addr.EncodeAddress(), nil
+	default:
+		return "", utils.ErrAssetUnknown
+	}
+}
+
+// poll calls done until it returns true or an error, or the context is
+// done.
+func poll(ctx context.Context, done func() (bool, error)) error {
+	t := time.NewTicker(pollInterval)
+	defer t.Stop()
+
+	for {
+		ok, err := done()
+		if err != nil || ok {
+			return err
+		}
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return errors.New(utils.ErrContextCanceled)
+		}
+	}
+}
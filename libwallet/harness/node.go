@@ -0,0 +1,238 @@
+package harness
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	rpcUser = "harness"
+	rpcPass = "harness"
+
+	// nodeStartTimeout is the time a node has to start its RPC server.
+	nodeStartTimeout = 30 * time.Second
+
+	// nodeStopTimeout is the time a node has to shut down before it is
+	// killed.
+	nodeStopTimeout = 10 * time.Second
+)
+
+// nodeBinaries are the full nodes run for each asset. They are looked up in
+// the PATH.
+var nodeBinaries = map[utils.AssetType]string{
+	utils.DCRWalletAsset: "dcrd",
+	utils.BTCWalletAsset: "btcd",
+	utils.LTCWalletAsset: "ltcd",
+}
+
+// NodeBinary returns the path of the full node binary of the asset or an
+// error if it is not installed.
+func NodeBinary(assetType utils.AssetType) (string, error) {
+	binary, ok := nodeBinaries[assetType]
+	if !ok {
+		return "", utils.ErrAssetUnknown
+	}
+	return exec.LookPath(binary)
+}
+
+// Node is a full node of an asset running on a local network in its own
+// process. It mines the blocks it is asked to generate to a single address.
+type Node struct {
+	AssetType utils.AssetType
+	// P2PAddr is the address the wallets connect to.
+	P2PAddr string
+
+	binary string
+	args   []string
+	dir    string
+	cmd    *exec.Cmd
+	exited chan struct{}
+	rpc    *rpcClient
+}
+
+// netFlag returns the node option that selects the network.
+func netFlag(assetType utils.AssetType, netType utils.NetworkType) (string, error) {
+	switch netType {
+	case utils.Simulation:
+		return "--simnet", nil
+	case utils.Regression:
+		if assetType == utils.DCRWalletAsset {
+			return "--regnet", nil
+		}
+		return "--regtest", nil
+	default:
+		return "", fmt.Errorf("%v: (%v)", utils.ErrInvalidNet, netType)
+	}
+}
+
+// freeAddress returns a local address that is not in use.
+func freeAddress() (string, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer l.Close()
+	return l.Addr().String(), nil
+}
+
+// newNode configures the full node of the asset to store its data in dir.
+func newNode(assetType utils.AssetType, netType utils.NetworkType, dir string) (*Node, error) {
+	binary, err := NodeBinary(assetType)
+	if err != nil {
+		return nil, err
+	}
+	network, err := netFlag(assetType, netType)
+	if err != nil {
+		return nil, err
+	}
+
+	p2pAddr, err := freeAddress()
+	if err != nil {
+		return nil, err
+	}
+	rpcAddr, err := freeAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, utils.UserFilePerm); err != nil {
+		return nil, err
+	}
+	// An empty config file stops the node from reading or creating the
+	// config file of the user.
+	configFile := filepath.Join(dir, "node.conf")
+	if err := os.WriteFile(configFile, nil, 0600); err != nil {
+		return nil, err
+	}
+
+	args := []string{
+		network,
+		"--configfile=" + configFile,
+		"--listen=" + p2pAddr,
+		"--rpclisten=" + rpcAddr,
+		"--rpcuser=" + rpcUser,
+		"--rpcpass=" + rpcPass,
+		"--notls",
+		"--debuglevel=info",
+	}
+	if assetType == utils.DCRWalletAsset {
+		args = append(args, "--appdata="+dir)
+	} else {
+		args = append(args, "--datadir="+filepath.Join(dir, "data"), "--logdir="+filepath.Join(dir, "logs"))
+	}
+
+	return &Node{
+		AssetType: assetType,
+		P2PAddr:   p2pAddr,
+		binary:    binary,
+		args:      args,
+		dir:       dir,
+		rpc: &rpcClient{
+			url:  "http://" + rpcAddr,
+			user: rpcUser,
+			pass: rpcPass,
+		},
+	}, nil
+}
+
+// start runs the node process. The blocks generated are mined to miningAddr.
+func (n *Node) start(ctx context.Context, miningAddr string) error {
+	logFile, err := os.OpenFile(filepath.Join(n.dir, "output.log"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	args := append([]string{"--miningaddr=" + miningAddr}, n.args...)
+	cmd := exec.Command(n.binary, args...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err := cmd.Start(); err != nil {
+		logFile.Close()
+		return err
+	}
+
+	exited := make(chan struct{})
+	n.cmd = cmd
+	n.exited = exited
+
+	// The log file is written by the node process, it is closed once the
+	// process exits.
+	go func() {
+		_ = cmd.Wait()
+		logFile.Close()
+		close(exited)
+	}()
+
+	if err := n.waitForRPC(ctx); err != nil {
+		n.Stop()
+		return fmt.Errorf("%s did not start, see %s: %v", n.binary, logFile.Name(), err)
+	}
+	return nil
+}
+
+// Restart stops the node and starts it again mining to miningAddr. The
+// wallets connected to the node reconnect on their own.
+func (n *Node) Restart(ctx context.Context, miningAddr string) error {
+	n.Stop()
+	return n.start(ctx, miningAddr)
+}
+
+// waitForRPC waits for the node RPC server to answer.
+func (n *Node) waitForRPC(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, nodeStartTimeout)
+	defer cancel()
+
+	t := time.NewTicker(500 * time.Millisecond)
+	defer t.Stop()
+
+	for {
+		if _, err := n.BlockCount(ctx); err == nil {
+			return nil
+		}
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// BlockCount returns the height of the node best block.
+func (n *Node) BlockCount(ctx context.Context) (int32, error) {
+	var count int32
+	err := n.rpc.call(ctx, &count, "getblockcount")
+	return count, err
+}
+
+// Generate mines numBlocks blocks to the mining address of the node.
+func (n *Node) Generate(ctx context.Context, numBlocks uint32) error {
+	return n.rpc.call(ctx, nil, "generate", numBlocks)
+}
+
+// Stop shuts the node down, killing it if it does not stop in time.
+func (n *Node) Stop() {
+	if n.cmd == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), nodeStopTimeout)
+	defer cancel()
+
+	if err := n.rpc.call(ctx, nil, "stop"); err == nil {
+		select {
+		case <-n.exited:
+			return
+		case <-ctx.Done():
+		}
+	}
+
+	_ = n.cmd.Process.Kill()
+	<-n.exited
+}
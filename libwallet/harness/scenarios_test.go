@@ -0,0 +1,242 @@
+package harness_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	"github.com/crypto-power/cryptopower/libwallet/harness"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// The scenarios run on simnet unless HARNESS_NETWORK is set to regnet. The
+// ticket and mixer scenarios need a VSP and a CoinShuffle++ server running
+// on the same network, they are set with the environment variables below.
+const (
+	envNetwork   = "HARNESS_NETWORK"
+	envVSPHost   = "HARNESS_VSP_HOST"
+	envVSPPubKey = "HARNESS_VSP_PUBKEY" // base64 encoded
+	envCSPP      = "HARNESS_CSPP_SERVER"
+	envCSPPCert  = "HARNESS_CSPP_CERT" // path of the server certificate
+
+	scenarioTimeout = 10 * time.Minute
+
+	// oneCoin is one DCR, BTC or LTC in atoms.
+	oneCoin = 1e8
+)
+
+var allAssets = []utils.AssetType{utils.DCRWalletAsset, utils.BTCWalletAsset, utils.LTCWalletAsset}
+
+// newHarness starts a harness with the nodes of the asset. The test is
+// skipped if the node binary is not installed.
+func newHarness(t *testing.T, assetType utils.AssetType) (*harness.Harness, context.Context) {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("skipping end to end scenario in short mode")
+	}
+	if _, err := harness.NodeBinary(assetType); err != nil {
+		t.Skipf("skipping %s scenario: %v", assetType, err)
+	}
+
+	netType := utils.Simulation
+	if net := os.Getenv(envNetwork); net != "" {
+		netType = utils.ToNetworkType(net)
+	}
+
+	h, err := harness.New(t.TempDir(), netType)
+	if err != nil {
+		t.Fatalf("creating harness failed: %v", err)
+	}
+	t.Cleanup(h.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), scenarioTimeout)
+	t.Cleanup(cancel)
+
+	if _, err := h.StartNode(ctx, assetType); err != nil {
+		t.Fatalf("starting %s node failed: %v", assetType, err)
+	}
+	return h, ctx
+}
+
+func TestCreateSyncSend(t *testing.T) {
+	for _, assetType := range allAssets {
+		assetType := assetType
+		t.Run(assetType.ToStringLower(), func(t *testing.T) {
+			h, ctx := newHarness(t, assetType)
+
+			wallet, err := h.CreateWallet(assetType, "alice")
+			if err != nil {
+				t.Fatalf("creating wallet failed: %v", err)
+			}
+			if err := h.Sync(ctx, wallet); err != nil {
+				t.Fatalf("syncing wallet failed: %v", err)
+			}
+			if err := h.Fund(ctx, wallet, oneCoin); err != nil {
+				t.Fatalf("funding wallet failed: %v", err)
+			}
+
+			minerAddr, err := h.Miner(assetType).CurrentAddress(harness.DefaultAccount)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := h.Send(wallet, harness.DefaultAccount, minerAddr, oneCoin/2); err != nil {
+				t.Fatalf("sending failed: %v", err)
+			}
+			if err := h.Mine(ctx, assetType, 1); err != nil {
+				t.Fatal(err)
+			}
+
+			balance, err := wallet.GetAccountBalance(harness.DefaultAccount)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if spendable := balance.Spendable.ToInt(); spendable <= 0 || spendable >= oneCoin/2 {
+				t.Fatalf("expected the change of the payment to be spendable, got %d", spendable)
+			}
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	for _, assetType := range allAssets {
+		assetType := assetType
+		t.Run(assetType.ToStringLower(), func(t *testing.T) {
+			h, ctx := newHarness(t, assetType)
+
+			wallet, err := h.CreateWallet(assetType, "alice")
+			if err != nil {
+				t.Fatalf("creating wallet failed: %v", err)
+			}
+			if err := h.Sync(ctx, wallet); err != nil {
+				t.Fatalf("syncing wallet failed: %v", err)
+			}
+			if err := h.Fund(ctx, wallet, oneCoin); err != nil {
+				t.Fatalf("funding wallet failed: %v", err)
+			}
+
+			seed, err := wallet.DecryptSeed(harness.Passphrase)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := h.Manager.DeleteWallet(wallet.GetWalletID(), harness.Passphrase); err != nil {
+				t.Fatalf("deleting wallet failed: %v", err)
+			}
+
+			restored, err := h.RestoreWallet(assetType, "alice-restored", seed)
+			if err != nil {
+				t.Fatalf("restoring wallet failed: %v", err)
+			}
+			if err := h.Sync(ctx, restored); err != nil {
+				t.Fatalf("syncing restored wallet failed: %v", err)
+			}
+			if err := h.WaitForBalance(ctx, restored, harness.DefaultAccount, oneCoin); err != nil {
+				t.Fatalf("restored wallet balance not found: %v", err)
+			}
+		})
+	}
+}
+
+func TestTicketPurchase(t *testing.T) {
+	vspHost, vspPubKey := os.Getenv(envVSPHost), os.Getenv(envVSPPubKey)
+	if vspHost == "" || vspPubKey == "" {
+		t.Skipf("skipping ticket scenario: %s and %s are not set", envVSPHost, envVSPPubKey)
+	}
+	pubKey, err := base64.StdEncoding.DecodeString(vspPubKey)
+	if err != nil {
+		t.Fatalf("invalid %s: %v", envVSPPubKey, err)
+	}
+
+	h, ctx := newHarness(t, utils.DCRWalletAsset)
+
+	wallet, err := h.CreateWallet(utils.DCRWalletAsset, "alice")
+	if err != nil {
+		t.Fatalf("creating wallet failed: %v", err)
+	}
+	if err := h.Sync(ctx, wallet); err != nil {
+		t.Fatalf("syncing wallet failed: %v", err)
+	}
+	if err := h.Fund(ctx, wallet, 100*oneCoin); err != nil {
+		t.Fatalf("funding wallet failed: %v", err)
+	}
+
+	// Tickets can only be bought from the stake enabled height.
+	params, _ := utils.DCRChainParams(h.NetType)
+	height, err := h.Node(utils.DCRWalletAsset).BlockCount(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blocks := params.StakeEnabledHeight - int64(height); blocks > 0 {
+		if err := h.Mine(ctx, utils.DCRWalletAsset, uint32(blocks)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tickets, err := wallet.(*dcr.Asset).PurchaseTickets(harness.DefaultAccount, 1, vspHost, harness.Passphrase, pubKey)
+	if err != nil {
+		t.Fatalf("purchasing ticket failed: %v", err)
+	}
+	if len(tickets) != 1 {
+		t.Fatalf("expected 1 ticket, got %d", len(tickets))
+	}
+}
+
+func TestAccountMixer(t *testing.T) {
+	server := os.Getenv(envCSPP)
+	if server == "" {
+		t.Skipf("skipping mixer scenario: %s is not set", envCSPP)
+	}
+	host, port, err := net.SplitHostPort(server)
+	if err != nil {
+		t.Fatalf("invalid %s: %v", envCSPP, err)
+	}
+	var certPEM []byte
+	if certFile := os.Getenv(envCSPPCert); certFile != "" {
+		if certPEM, err = os.ReadFile(certFile); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	h, ctx := newHarness(t, utils.DCRWalletAsset)
+
+	wallet, err := h.CreateWallet(utils.DCRWalletAsset, "alice")
+	if err != nil {
+		t.Fatalf("creating wallet failed: %v", err)
+	}
+	asset := wallet.(*dcr.Asset)
+	if err := h.Sync(ctx, wallet); err != nil {
+		t.Fatalf("syncing wallet failed: %v", err)
+	}
+
+	if err := asset.CreateMixerAccounts("mixed", "unmixed", harness.Passphrase); err != nil {
+		t.Fatalf("creating mixer accounts failed: %v", err)
+	}
+	err = asset.SetCSPPServerConfig(&dcr.CSPPServerConfig{Host: host, Port: port, CertPEM: string(certPEM)})
+	if err != nil {
+		t.Fatalf("setting cspp server failed: %v", err)
+	}
+
+	unmixedAddr, err := wallet.CurrentAddress(asset.UnmixedAccountNumber())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Send(h.Miner(utils.DCRWalletAsset), harness.DefaultAccount, unmixedAddr, 10*oneCoin); err != nil {
+		t.Fatalf("funding unmixed account failed: %v", err)
+	}
+	if err := h.Mine(ctx, utils.DCRWalletAsset, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := asset.StartAccountMixer(harness.Passphrase); err != nil {
+		t.Fatalf("starting mixer failed: %v", err)
+	}
+	defer func() { _ = asset.StopAccountMixer() }()
+
+	if err := h.WaitForBalance(ctx, wallet, asset.MixedAccountNumber(), 1); err != nil {
+		t.Fatalf("no mixed output received: %v", err)
+	}
+}
@@ -0,0 +1,870 @@
+package electrum
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	chainRequestTimeout = 30 * time.Second
+	chainPingInterval   = time.Minute
+	chainReconnectDelay = 10 * time.Second
+
+	// headersBatch is the number of headers requested at once. It is the
+	// maximum number of headers served per request by most servers.
+	headersBatch = 2016
+	// maxCachedHeaders caps the number of headers kept in memory. The headers
+	// close to the tip are kept to detect reorgs.
+	maxCachedHeaders = 4 * headersBatch
+	reorgDepth       = 100
+)
+
+// ErrNotConnected is returned by the ChainClient requests made while it is
+// not connected to the server.
+var ErrNotConnected = errors.New("not connected to the electrum server")
+
+// BlockHeader is a serialized block header and the fields of it the chain
+// client needs. Hash and PrevBlock are in internal byte order, the order of
+// chainhash.Hash.
+type BlockHeader struct {
+	Raw       []byte
+	Hash      [32]byte
+	PrevBlock [32]byte
+	Timestamp time.Time
+}
+
+// ParseBlockHeader parses a serialized bitcoin style block header. The block
+// hash is the double sha256 hash of the header, which holds for LTC as well.
+func ParseBlockHeader(raw []byte) (*BlockHeader, error) {
+	if len(raw) != HeaderSize {
+		return nil, fmt.Errorf("invalid header size %d", len(raw))
+	}
+
+	first := sha256.Sum256(raw)
+	header := &BlockHeader{
+		Raw:       raw,
+		Hash:      sha256.Sum256(first[:]),
+		Timestamp: time.Unix(int64(binary.LittleEndian.Uint32(raw[68:72])), 0),
+	}
+	copy(header.PrevBlock[:], raw[4:36])
+	return header, nil
+}
+
+// ChainNotifications builds the notifications a ChainClient delivers from
+// Notifications. The chain backends built on top of it use them to produce
+// the notifications of their wallet.
+type ChainNotifications struct {
+	ClientConnected   func() interface{}
+	BlockConnected    func(height int32, header *BlockHeader) interface{}
+	BlockDisconnected func(height int32, header *BlockHeader) interface{}
+	// RelevantTx is called for the transactions of the watched scripts.
+	// header is nil for mempool transactions.
+	RelevantTx     func(rawTx []byte, height int32, header *BlockHeader) (interface{}, error)
+	RescanFinished func(height int32, header *BlockHeader) interface{}
+}
+
+// ChainClient holds the chain logic shared by the BTC and LTC chain backends
+// built on an Electrum server. It tracks the server's chain tip and reorgs,
+// watches output scripts through script hash subscriptions and reports the
+// transactions paying to or spending from them. No block or cfilter is
+// downloaded.
+type ChainClient struct {
+	cfg   *Config
+	ntfns *ChainNotifications
+
+	mu           sync.RWMutex
+	conn         *Client
+	tipHeight    int32
+	tip          *BlockHeader
+	headers      map[int32]*BlockHeader
+	heights      map[[32]byte]int32
+	watched      map[string]struct{}
+	histories    map[string][]*History
+	reported     map[string]int32
+	notifyBlocks bool
+	started      bool
+
+	enqueue chan interface{}
+	dequeue chan interface{}
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewChainClient creates a chain client that syncs through the server
+// described by cfg.
+func NewChainClient(cfg *Config, ntfns *ChainNotifications) *ChainClient {
+	return &ChainClient{
+		cfg:       cfg,
+		ntfns:     ntfns,
+		headers:   make(map[int32]*BlockHeader),
+		heights:   make(map[[32]byte]int32),
+		watched:   make(map[string]struct{}),
+		histories: make(map[string][]*History),
+		reported:  make(map[string]int32),
+	}
+}
+
+// Addr returns the address of the server.
+func (c *ChainClient) Addr() string {
+	return c.cfg.Addr
+}
+
+// Start connects to the server and starts delivering notifications.
+func (c *ChainClient) Start() error {
+	c.mu.Lock()
+	if c.started {
+		c.mu.Unlock()
+		return nil
+	}
+	c.started = true
+	c.notifyBlocks = false
+	c.enqueue = make(chan interface{})
+	c.dequeue = make(chan interface{})
+	c.quit = make(chan struct{})
+	c.mu.Unlock()
+
+	if err := c.connect(); err != nil {
+		c.mu.Lock()
+		c.started = false
+		c.mu.Unlock()
+		return err
+	}
+
+	c.mu.RLock()
+	enqueue, dequeue, quit := c.enqueue, c.dequeue, c.quit
+	c.mu.RUnlock()
+
+	c.wg.Add(2)
+	go c.notificationQueue(enqueue, dequeue, quit)
+	go c.handler(quit)
+
+	c.notify(c.ntfns.ClientConnected())
+	return nil
+}
+
+// Stop disconnects from the server.
+func (c *ChainClient) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.started {
+		return
+	}
+	c.started = false
+	close(c.quit)
+	if c.conn != nil {
+		c.conn.Close()
+	}
+}
+
+// WaitForShutdown blocks until the client goroutines have stopped.
+func (c *ChainClient) WaitForShutdown() {
+	c.wg.Wait()
+}
+
+// Notifications returns the channel the notifications are delivered on.
+func (c *ChainClient) Notifications() <-chan interface{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.dequeue
+}
+
+// connect dials the server, subscribes to the new headers and renews the
+// script hash subscriptions.
+func (c *ChainClient) connect() error {
+	ctx, cancel := context.WithTimeout(context.Background(), chainRequestTimeout)
+	defer cancel()
+
+	conn, err := Dial(ctx, c.cfg)
+	if err != nil {
+		return fmt.Errorf("connecting to electrum server %s failed: %v", c.cfg.Addr, err)
+	}
+
+	tip, err := conn.SubscribeHeaders(ctx)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	header, err := parseHeaderHex(tip.Hex)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	c.mu.Lock()
+	c.conn = conn
+	c.cacheHeader(tip.Height, header)
+	c.setTip(tip.Height, header)
+	scriptHashes := make([]string, 0, len(c.watched))
+	for scriptHash := range c.watched {
+		scriptHashes = append(scriptHashes, scriptHash)
+	}
+	c.mu.Unlock()
+
+	for _, scriptHash := range scriptHashes {
+		if _, err := conn.SubscribeScriptHash(ctx, scriptHash); err != nil {
+			conn.Close()
+			return err
+		}
+	}
+
+	log.Infof("Connected to electrum server %s at height %d", c.cfg.Addr, tip.Height)
+	return nil
+}
+
+// handler processes the server notifications and keeps the connection alive,
+// reconnecting if it drops.
+func (c *ChainClient) handler(quit chan struct{}) {
+	defer c.wg.Done()
+
+	ping := time.NewTicker(chainPingInterval)
+	defer ping.Stop()
+
+	for {
+		conn, err := c.client()
+		if err != nil {
+			return
+		}
+
+		select {
+		case <-conn.HeaderUpdates():
+			if err := c.handleNewTip(conn.LatestHeader()); err != nil {
+				log.Errorf("Processing electrum header failed: %v", err)
+			}
+
+		case <-conn.ScriptHashUpdates():
+			for scriptHash := range conn.TakeScriptHashStatuses() {
+				if err := c.processScriptHash(scriptHash); err != nil {
+					log.Errorf("Processing electrum script hash update failed: %v", err)
+				}
+			}
+
+		case <-ping.C:
+			ctx, cancel := context.WithTimeout(context.Background(), chainRequestTimeout)
+			if err := conn.Ping(ctx); err != nil {
+				log.Debugf("Electrum ping failed: %v", err)
+			}
+			cancel()
+
+		case <-conn.Done():
+			log.Warnf("Electrum server %s disconnected: %v", c.cfg.Addr, conn.Err())
+			if !c.reconnect(quit) {
+				return
+			}
+			// Let the wallet catch up with the blocks missed while
+			// disconnected.
+			c.notify(c.ntfns.ClientConnected())
+
+		case <-quit:
+			return
+		}
+	}
+}
+
+// reconnect retries connecting to the server until it succeeds or the client
+// is stopped. It returns false if the client was stopped.
+func (c *ChainClient) reconnect(quit chan struct{}) bool {
+	for {
+		select {
+		case <-time.After(chainReconnectDelay):
+		case <-quit:
+			return false
+		}
+
+		if err := c.connect(); err != nil {
+			log.Debug(err)
+			continue
+		}
+		return true
+	}
+}
+
+// notificationQueue forwards the notifications to the wallet without blocking
+// the handler on a slow reader.
+func (c *ChainClient) notificationQueue(enqueue, dequeue chan interface{}, quit chan struct{}) {
+	defer c.wg.Done()
+	defer close(dequeue)
+
+	var queue []interface{}
+	for {
+		var out chan interface{}
+		var next interface{}
+		if len(queue) > 0 {
+			out = dequeue
+			next = queue[0]
+		}
+
+		select {
+		case n := <-enqueue:
+			queue = append(queue, n)
+		case out <- next:
+			queue[0] = nil
+			queue = queue[1:]
+		case <-quit:
+			return
+		}
+	}
+}
+
+func (c *ChainClient) notify(n interface{}) {
+	c.mu.RLock()
+	enqueue, quit := c.enqueue, c.quit
+	c.mu.RUnlock()
+
+	select {
+	case enqueue <- n:
+	case <-quit:
+	}
+}
+
+func (c *ChainClient) client() (*Client, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.started || c.conn == nil {
+		return nil, ErrNotConnected
+	}
+	return c.conn, nil
+}
+
+// setTip must be called with the mutex held.
+func (c *ChainClient) setTip(height int32, header *BlockHeader) {
+	c.tipHeight = height
+	c.tip = header
+}
+
+// cacheHeader must be called with the mutex held. The cache is pruned down to
+// the headers close to the tip once it grows too large.
+func (c *ChainClient) cacheHeader(height int32, header *BlockHeader) {
+	if len(c.headers) >= maxCachedHeaders {
+		for h, cached := range c.headers {
+			if h < c.tipHeight-reorgDepth {
+				delete(c.heights, cached.Hash)
+				delete(c.headers, h)
+			}
+		}
+	}
+
+	if old, ok := c.headers[height]; ok {
+		delete(c.heights, old.Hash)
+	}
+	c.headers[height] = header
+	c.heights[header.Hash] = height
+}
+
+// HeaderAt returns the header of the block at height, fetching a batch of
+// headers from the server if it is not cached.
+func (c *ChainClient) HeaderAt(height int32) (*BlockHeader, error) {
+	c.mu.RLock()
+	header, ok := c.headers[height]
+	tipHeight := c.tipHeight
+	c.mu.RUnlock()
+	if ok {
+		return header, nil
+	}
+
+	conn, err := c.client()
+	if err != nil {
+		return nil, err
+	}
+
+	count := headersBatch
+	if remaining := int(tipHeight-height) + 1; remaining > 0 && remaining < count {
+		count = remaining
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), chainRequestTimeout)
+	defer cancel()
+	rawHeaders, err := conn.BlockHeaders(ctx, height, count)
+	if err != nil {
+		return nil, err
+	}
+	if len(rawHeaders) == 0 {
+		return nil, fmt.Errorf("no header at height %d", height)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, raw := range rawHeaders {
+		h, err := ParseBlockHeader(raw)
+		if err != nil {
+			return nil, err
+		}
+		c.cacheHeader(height+int32(i), h)
+		if i == 0 {
+			header = h
+		}
+	}
+	return header, nil
+}
+
+// fetchHeader returns the header of the block at height as currently seen by
+// the server, bypassing the cache.
+func (c *ChainClient) fetchHeader(height int32) (*BlockHeader, error) {
+	conn, err := c.client()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), chainRequestTimeout)
+	defer cancel()
+	raw, err := conn.BlockHeader(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBlockHeader(raw)
+}
+
+// handleNewTip reports the blocks connected and disconnected since the last
+// tip.
+func (c *ChainClient) handleNewTip(tip *Header) error {
+	if tip == nil {
+		return nil
+	}
+	header, err := parseHeaderHex(tip.Hex)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	oldTipHeight, oldTip := c.tipHeight, c.tip
+	notifyBlocks := c.notifyBlocks
+	extends := tip.Height == oldTipHeight+1 && header.PrevBlock == oldTip.Hash
+	if !notifyBlocks || extends {
+		c.cacheHeader(tip.Height, header)
+		c.setTip(tip.Height, header)
+	}
+	c.mu.Unlock()
+
+	if !notifyBlocks {
+		return nil
+	}
+	if extends {
+		c.notify(c.ntfns.BlockConnected(tip.Height, header))
+		return nil
+	}
+
+	// Find the last block shared by the old and the new chain.
+	fork := oldTipHeight
+	if tip.Height <= fork {
+		fork = tip.Height - 1
+	}
+	for ; fork > 0; fork-- {
+		c.mu.RLock()
+		ours, ok := c.headers[fork]
+		c.mu.RUnlock()
+		if !ok {
+			break
+		}
+
+		theirs, err := c.fetchHeader(fork)
+		if err != nil {
+			return err
+		}
+		if theirs.Hash == ours.Hash {
+			break
+		}
+	}
+
+	for height := oldTipHeight; height > fork; height-- {
+		c.mu.Lock()
+		stale, ok := c.headers[height]
+		if ok {
+			delete(c.headers, height)
+			delete(c.heights, stale.Hash)
+		}
+		c.mu.Unlock()
+		if ok {
+			c.notify(c.ntfns.BlockDisconnected(height, stale))
+		}
+	}
+
+	c.mu.Lock()
+	c.cacheHeader(tip.Height, header)
+	c.setTip(tip.Height, header)
+	c.mu.Unlock()
+
+	for height := fork + 1; height <= tip.Height; height++ {
+		connected, err := c.HeaderAt(height)
+		if err != nil {
+			return err
+		}
+		c.notify(c.ntfns.BlockConnected(height, connected))
+	}
+	return nil
+}
+
+// Tip returns the height and the header of the server's chain tip.
+func (c *ChainClient) Tip() (int32, *BlockHeader, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.tip == nil || c.tipHeight == 0 {
+		return 0, nil, ErrNotConnected
+	}
+	return c.tipHeight, c.tip, nil
+}
+
+// BlockHeight returns the height of the block. Only the blocks recently
+// looked up by height are known.
+func (c *ChainClient) BlockHeight(hash [32]byte) (int32, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	height, ok := c.heights[hash]
+	if !ok {
+		return 0, fmt.Errorf("unknown block %x", hash)
+	}
+	return height, nil
+}
+
+// IsCurrent returns true once connected, the server's chain tip is
+// authoritative.
+func (c *ChainClient) IsCurrent() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.started && c.conn != nil && c.tipHeight > 0
+}
+
+// Broadcast publishes the serialized transaction and returns its hash.
+func (c *ChainClient) Broadcast(rawTx []byte) (string, error) {
+	conn, err := c.client()
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), chainRequestTimeout)
+	defer cancel()
+	return conn.Broadcast(ctx, rawTx)
+}
+
+// NotifyBlocks starts reporting the connected and disconnected blocks.
+func (c *ChainClient) NotifyBlocks() {
+	c.mu.Lock()
+	c.notifyBlocks = true
+	c.mu.Unlock()
+}
+
+// Watch starts watching the output scripts for transactions. The
+// transactions of the scripts that were not watched yet are reported.
+func (c *ChainClient) Watch(pkScripts [][]byte) error {
+	for _, pkScript := range pkScripts {
+		scriptHash, isNew, err := c.watch(pkScript)
+		if err != nil {
+			return err
+		}
+		if isNew {
+			if err := c.processScriptHash(scriptHash); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// watch subscribes to the script hash of pkScript. isNew is false if the
+// script was already watched.
+func (c *ChainClient) watch(pkScript []byte) (scriptHash string, isNew bool, err error) {
+	scriptHash = ScriptHash(pkScript)
+
+	c.mu.RLock()
+	_, watched := c.watched[scriptHash]
+	c.mu.RUnlock()
+	if watched {
+		return scriptHash, false, nil
+	}
+
+	conn, err := c.client()
+	if err != nil {
+		return "", false, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), chainRequestTimeout)
+	defer cancel()
+	if _, err := conn.SubscribeScriptHash(ctx, scriptHash); err != nil {
+		return "", false, err
+	}
+
+	c.mu.Lock()
+	c.watched[scriptHash] = struct{}{}
+	c.mu.Unlock()
+	return scriptHash, true, nil
+}
+
+// history returns the history of the script hash. Histories are cached until
+// the server notifies a status change.
+func (c *ChainClient) history(scriptHash string) ([]*History, error) {
+	c.mu.RLock()
+	history, ok := c.histories[scriptHash]
+	c.mu.RUnlock()
+	if ok {
+		return history, nil
+	}
+
+	conn, err := c.client()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), chainRequestTimeout)
+	defer cancel()
+	history, err = conn.ScriptHashHistory(ctx, scriptHash)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.histories[scriptHash] = history
+	c.mu.Unlock()
+	return history, nil
+}
+
+// processScriptHash reports the transactions of the script hash that are new
+// or whose confirmation height changed.
+func (c *ChainClient) processScriptHash(scriptHash string) error {
+	c.mu.Lock()
+	delete(c.histories, scriptHash)
+	c.mu.Unlock()
+
+	history, err := c.history(scriptHash)
+	if err != nil {
+		return err
+	}
+	for _, entry := range history {
+		if err := c.reportTx(entry.TxHash, entry.Height); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Transaction fetches the serialized transaction from the server.
+func (c *ChainClient) Transaction(txHash string) ([]byte, error) {
+	conn, err := c.client()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), chainRequestTimeout)
+	defer cancel()
+	return conn.Transaction(ctx, txHash)
+}
+
+// reportTx sends the transaction to the wallet unless it was already reported
+// at the same height. Mempool transactions have a height of 0 or less.
+func (c *ChainClient) reportTx(txHash string, height int32) error {
+	if height <= 0 {
+		height = -1
+	}
+
+	c.mu.RLock()
+	reportedHeight, reported := c.reported[txHash]
+	c.mu.RUnlock()
+	if reported && reportedHeight == height {
+		return nil
+	}
+
+	rawTx, err := c.Transaction(txHash)
+	if err != nil {
+		return err
+	}
+
+	var header *BlockHeader
+	if height > 0 {
+		if header, err = c.HeaderAt(height); err != nil {
+			return err
+		}
+	}
+
+	n, err := c.ntfns.RelevantTx(rawTx, height, header)
+	if err != nil {
+		return err
+	}
+	c.notify(n)
+
+	c.mu.Lock()
+	c.reported[txHash] = height
+	c.mu.Unlock()
+	return nil
+}
+
+// Rescan reports the transactions of the output scripts confirmed since the
+// start block in chain order, then watches the scripts for new transactions.
+// The whole history is scanned if the start block is not known. The rescan
+// runs in the background and ends with a RescanFinished notification.
+func (c *ChainClient) Rescan(startHash [32]byte, pkScripts [][]byte) {
+	startHeight, err := c.BlockHeight(startHash)
+	if err != nil {
+		startHeight = 0
+	}
+
+	c.mu.Lock()
+	c.histories = make(map[string][]*History)
+	c.mu.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		if err := c.rescan(startHeight, pkScripts); err != nil {
+			// The wallet rescans again once reconnected.
+			log.Errorf("Electrum rescan failed: %v", err)
+		}
+	}()
+}
+
+func (c *ChainClient) rescan(startHeight int32, pkScripts [][]byte) error {
+	txHeights := make(map[string]int32)
+	for _, pkScript := range pkScripts {
+		scriptHash, _, err := c.watch(pkScript)
+		if err != nil {
+			return err
+		}
+		history, err := c.history(scriptHash)
+		if err != nil {
+			return err
+		}
+		for _, entry := range history {
+			if entry.Height <= 0 || entry.Height >= startHeight {
+				txHeights[entry.TxHash] = entry.Height
+			}
+		}
+	}
+
+	txHashes := make([]string, 0, len(txHeights))
+	for txHash := range txHeights {
+		txHashes = append(txHashes, txHash)
+	}
+	// Report the mined transactions in chain order, mempool ones last.
+	sort.Slice(txHashes, func(i, j int) bool {
+		hi, hj := txHeights[txHashes[i]], txHeights[txHashes[j]]
+		if hi <= 0 || hj <= 0 {
+			return hi > 0 && hj <= 0
+		}
+		return hi < hj
+	})
+
+	for _, txHash := range txHashes {
+		if err := c.reportTx(txHash, txHeights[txHash]); err != nil {
+			return err
+		}
+	}
+
+	tipHeight, tip, err := c.Tip()
+	if err != nil {
+		return err
+	}
+	c.notify(c.ntfns.RescanFinished(tipHeight, tip))
+	return nil
+}
+
+// FilterBlocks returns the index in heights of the first block with a
+// transaction involving the output scripts and the serialized transactions
+// of that block that involve them. The index is -1 if no block has any.
+func (c *ChainClient) FilterBlocks(heights []int32, pkScripts [][]byte) (int, [][]byte, error) {
+	blockIndexes := make(map[int32]int, len(heights))
+	for i, height := range heights {
+		blockIndexes[height] = i
+	}
+
+	batchIndex := -1
+	var txHashes map[string]struct{}
+	for _, pkScript := range pkScripts {
+		history, err := c.history(ScriptHash(pkScript))
+		if err != nil {
+			return -1, nil, err
+		}
+
+		for _, entry := range history {
+			index, ok := blockIndexes[entry.Height]
+			if !ok || (batchIndex != -1 && index > batchIndex) {
+				continue
+			}
+			if index != batchIndex {
+				batchIndex = index
+				txHashes = make(map[string]struct{})
+			}
+			txHashes[entry.TxHash] = struct{}{}
+		}
+	}
+
+	if batchIndex == -1 {
+		return -1, nil, nil
+	}
+
+	rawTxs, err := c.transactions(txHashes)
+	if err != nil {
+		return -1, nil, err
+	}
+	return batchIndex, rawTxs, nil
+}
+
+// BlockTransactions returns the serialized transactions of the watched
+// scripts confirmed in the block at height. Electrum servers don't serve
+// full blocks, the other transactions of the block are unknown.
+func (c *ChainClient) BlockTransactions(height int32) ([][]byte, error) {
+	c.mu.RLock()
+	scriptHashes := make([]string, 0, len(c.watched))
+	for scriptHash := range c.watched {
+		scriptHashes = append(scriptHashes, scriptHash)
+	}
+	c.mu.RUnlock()
+
+	txHashes := make(map[string]struct{})
+	for _, scriptHash := range scriptHashes {
+		history, err := c.history(scriptHash)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range history {
+			if entry.Height == height {
+				txHashes[entry.TxHash] = struct{}{}
+			}
+		}
+	}
+	return c.transactions(txHashes)
+}
+
+// transactions fetches the transactions, ordered by hash.
+func (c *ChainClient) transactions(txHashes map[string]struct{}) ([][]byte, error) {
+	sorted := make([]string, 0, len(txHashes))
+	for txHash := range txHashes {
+		sorted = append(sorted, txHash)
+	}
+	sort.Strings(sorted)
+
+	rawTxs := make([][]byte, 0, len(sorted))
+	for _, txHash := range sorted {
+		rawTx, err := c.Transaction(txHash)
+		if err != nil {
+			return nil, err
+		}
+		rawTxs = append(rawTxs, rawTx)
+	}
+	return rawTxs, nil
+}
+
+// EstimateFee returns the fee rate in coins per kB the server estimates is
+// needed for a confirmation within blocks.
+func (c *ChainClient) EstimateFee(blocks int32) (float64, error) {
+	conn, err := c.client()
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), chainRequestTimeout)
+	defer cancel()
+	return conn.EstimateFee(ctx, blocks)
+}
+
+func parseHeaderHex(headerHex string) (*BlockHeader, error) {
+	raw, err := hex.DecodeString(headerHex)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBlockHeader(raw)
+}
@@ -0,0 +1,533 @@
+// Package electrum implements a client for the Electrum server protocol. BTC
+// and LTC wallets use it as an alternative chain backend to neutrino.
+package electrum
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/go-socks/socks"
+)
+
+const (
+	// ClientName is the name the client identifies itself with.
+	ClientName = "cryptopower"
+	// ProtocolVersion is the Electrum protocol version the client speaks.
+	ProtocolVersion = "1.4"
+
+	// HeaderSize is the size of a serialized block header.
+	HeaderSize = 80
+
+	dialTimeout = 30 * time.Second
+	// maxMessageSize caps the size of a single server message. Large
+	// histories and header batches can take a few megabytes.
+	maxMessageSize = 32 << 20
+)
+
+// ErrClosed is returned by the requests made after the connection closed.
+var ErrClosed = errors.New("electrum connection closed")
+
+// Config describes how to connect to an Electrum server.
+type Config struct {
+	// Addr is the host:port of the server.
+	Addr string
+	// TLS enables TLS for the connection.
+	TLS bool
+	// CertPEM, if set, is the only certificate trusted for the TLS
+	// connection. Electrum servers commonly use self-signed certificates.
+	CertPEM []byte
+	// Proxy is the address of a SOCKS5 proxy, such as Tor, the connection is
+	// made through. The connection is made directly if it is empty.
+	Proxy string
+	// Limiter, if set, throttles and meters the connection.
+	Limiter *utils.NetLimiter
+}
+
+// RPCError is an error returned by the server.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("electrum error %d: %s", e.Code, e.Message)
+}
+
+type request struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+// message is either a response to a request or a subscription notification.
+type message struct {
+	ID     *uint64         `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+// Header is a block header reported by the headers subscription.
+type Header struct {
+	Height int32  `json:"height"`
+	Hex    string `json:"hex"`
+}
+
+// History is a transaction in the history of a script hash. Height is 0 for
+// mempool transactions and -1 for mempool transactions with unconfirmed
+// inputs.
+type History struct {
+	Height int32  `json:"height"`
+	TxHash string `json:"tx_hash"`
+	Fee    int64  `json:"fee,omitempty"`
+}
+
+// Unspent is an unspent output paying to a script hash.
+type Unspent struct {
+	Height int32  `json:"height"`
+	TxHash string `json:"tx_hash"`
+	TxPos  uint32 `json:"tx_pos"`
+	Value  int64  `json:"value"`
+}
+
+// Client is a connection to an Electrum server. Requests can be made
+// concurrently.
+type Client struct {
+	conn net.Conn
+
+	writeMu sync.Mutex
+
+	mu       sync.Mutex
+	nextID   uint64
+	pending  map[uint64]chan *message
+	header   *Header
+	statuses map[string]string
+	err      error
+
+	headerSignal chan struct{}
+	statusSignal chan struct{}
+	done         chan struct{}
+}
+
+// Dial connects to the Electrum server and negotiates the protocol version.
+func Dial(ctx context.Context, cfg *Config) (*Client, error) {
+	conn, err := dial(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		conn:         conn,
+		pending:      make(map[uint64]chan *message),
+		statuses:     make(map[string]string),
+		headerSignal: make(chan struct{}, 1),
+		statusSignal: make(chan struct{}, 1),
+		done:         make(chan struct{}),
+	}
+	go c.readLoop()
+
+	if _, _, err := c.ServerVersion(ctx); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func dial(ctx context.Context, cfg *Config) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(cfg.Addr)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+
+	conn, err := cfg.Limiter.DialWith(ctx, func(ctx context.Context) (net.Conn, error) {
+		if cfg.Proxy != "" {
+			proxy := &socks.Proxy{Addr: cfg.Proxy, TorIsolation: true}
+			return proxy.DialContext(ctx, "tcp", cfg.Addr)
+		}
+		return new(net.Dialer).DialContext(ctx, "tcp", cfg.Addr)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !cfg.TLS {
+		return conn, nil
+	}
+
+	tlsCfg := &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+	if len(cfg.CertPEM) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(cfg.CertPEM) {
+			conn.Close()
+			return nil, errors.New("invalid electrum server certificate")
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	tlsConn := tls.Client(conn, tlsCfg)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
+
+// Close closes the connection. Pending and future requests fail.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Done is closed once the connection closes.
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns the reason the connection closed.
+func (c *Client) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+func (c *Client) readLoop() {
+	reader := bufio.NewReaderSize(c.conn, 64<<10)
+	var err error
+	for {
+		var line []byte
+		line, err = readLine(reader)
+		if err != nil {
+			break
+		}
+
+		msg := new(message)
+		if err = json.Unmarshal(line, msg); err != nil {
+			break
+		}
+
+		if msg.ID == nil {
+			c.handleNotification(msg)
+			continue
+		}
+
+		c.mu.Lock()
+		respC, ok := c.pending[*msg.ID]
+		delete(c.pending, *msg.ID)
+		c.mu.Unlock()
+		if ok {
+			respC <- msg
+		}
+	}
+
+	c.conn.Close()
+	c.mu.Lock()
+	c.err = err
+	for id, respC := range c.pending {
+		close(respC)
+		delete(c.pending, id)
+	}
+	c.mu.Unlock()
+	close(c.done)
+}
+
+// readLine reads a newline delimited message, failing if it is larger than
+// maxMessageSize.
+func readLine(reader *bufio.Reader) ([]byte, error) {
+	var line []byte
+	for {
+		chunk, isPrefix, err := reader.ReadLine()
+		if err != nil {
+			return nil, err
+		}
+		line = append(line, chunk...)
+		if len(line) > maxMessageSize {
+			return nil, errors.New("electrum message too large")
+		}
+		if !isPrefix {
+			return line, nil
+		}
+	}
+}
+
+// handleNotification records the subscription notification. Only the latest
+// header and script hash statuses are kept so that reading the connection
+// never blocks on a slow consumer.
+func (c *Client) handleNotification(msg *message) {
+	switch msg.Method {
+	case "blockchain.headers.subscribe":
+		var headers []*Header
+		if err := json.Unmarshal(msg.Params, &headers); err != nil || len(headers) == 0 {
+			return
+		}
+		c.mu.Lock()
+		c.header = headers[len(headers)-1]
+		c.mu.Unlock()
+		signal(c.headerSignal)
+
+	case "blockchain.scripthash.subscribe":
+		var params []*string
+		if err := json.Unmarshal(msg.Params, &params); err != nil || len(params) != 2 || params[0] == nil {
+			return
+		}
+		var status string
+		if params[1] != nil {
+			status = *params[1]
+		}
+		c.mu.Lock()
+		c.statuses[*params[0]] = status
+		c.mu.Unlock()
+		signal(c.statusSignal)
+	}
+}
+
+func signal(c chan struct{}) {
+	select {
+	case c <- struct{}{}:
+	default:
+	}
+}
+
+// call makes a request and decodes its result into result.
+func (c *Client) call(ctx context.Context, method string, result interface{}, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	respC := make(chan *message, 1)
+	c.mu.Lock()
+	if c.err != nil || isClosed(c.done) {
+		c.mu.Unlock()
+		return ErrClosed
+	}
+	c.nextID++
+	id := c.nextID
+	c.pending[id] = respC
+	c.mu.Unlock()
+
+	req, err := json.Marshal(&request{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+
+	c.writeMu.Lock()
+	_, err = c.conn.Write(append(req, '\n'))
+	c.writeMu.Unlock()
+	if err != nil {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+		return err
+	}
+
+	select {
+	case resp, ok := <-respC:
+		if !ok {
+			return ErrClosed
+		}
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if result == nil {
+			return nil
+		}
+		return json.Unmarshal(resp.Result, result)
+	case <-ctx.Done():
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+func isClosed(c chan struct{}) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
+
+// ServerVersion negotiates the protocol version. It returns the server
+// software name and the negotiated protocol version.
+func (c *Client) ServerVersion(ctx context.Context) (software, protocol string, err error) {
+	var resp []string
+	if err := c.call(ctx, "server.version", &resp, ClientName, ProtocolVersion); err != nil {
+		return "", "", err
+	}
+	if len(resp) != 2 {
+		return "", "", errors.New("invalid server.version response")
+	}
+	return resp[0], resp[1], nil
+}
+
+// Ping keeps the connection alive.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.call(ctx, "server.ping", nil)
+}
+
+// SubscribeHeaders subscribes to new block headers and returns the current
+// chain tip. HeaderUpdates signals the new tips.
+func (c *Client) SubscribeHeaders(ctx context.Context) (*Header, error) {
+	header := new(Header)
+	if err := c.call(ctx, "blockchain.headers.subscribe", header); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// HeaderUpdates signals that the server reported a new chain tip. The tip is
+// returned by LatestHeader.
+func (c *Client) HeaderUpdates() <-chan struct{} {
+	return c.headerSignal
+}
+
+// LatestHeader returns the last chain tip notified by the server, nil if none
+// was notified yet.
+func (c *Client) LatestHeader() *Header {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.header
+}
+
+// BlockHeader returns the serialized header of the block at height.
+func (c *Client) BlockHeader(ctx context.Context, height int32) ([]byte, error) {
+	var headerHex string
+	if err := c.call(ctx, "blockchain.block.header", &headerHex, height); err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(headerHex)
+}
+
+// BlockHeaders returns up to count consecutive serialized headers starting at
+// startHeight. Servers cap the number of headers returned per request.
+func (c *Client) BlockHeaders(ctx context.Context, startHeight int32, count int) ([][]byte, error) {
+	var resp struct {
+		Count int    `json:"count"`
+		Hex   string `json:"hex"`
+	}
+	if err := c.call(ctx, "blockchain.block.headers", &resp, startHeight, count); err != nil {
+		return nil, err
+	}
+
+	raw, err := hex.DecodeString(resp.Hex)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) != resp.Count*HeaderSize {
+		return nil, fmt.Errorf("invalid headers response: %d bytes for %d headers", len(raw), resp.Count)
+	}
+
+	headers := make([][]byte, resp.Count)
+	for i := range headers {
+		headers[i] = raw[i*HeaderSize : (i+1)*HeaderSize]
+	}
+	return headers, nil
+}
+
+// ScriptHashHistory returns the confirmed and mempool transactions involving
+// the script hash.
+func (c *Client) ScriptHashHistory(ctx context.Context, scriptHash string) ([]*History, error) {
+	var history []*History
+	err := c.call(ctx, "blockchain.scripthash.get_history", &history, scriptHash)
+	return history, err
+}
+
+// ScriptHashUnspent returns the unspent outputs paying to the script hash.
+func (c *Client) ScriptHashUnspent(ctx context.Context, scriptHash string) ([]*Unspent, error) {
+	var unspent []*Unspent
+	err := c.call(ctx, "blockchain.scripthash.listunspent", &unspent, scriptHash)
+	return unspent, err
+}
+
+// SubscribeScriptHash subscribes to the status changes of the script hash and
+// returns its current status. The status is empty if the script hash has no
+// history. ScriptHashUpdates signals the status changes.
+func (c *Client) SubscribeScriptHash(ctx context.Context, scriptHash string) (string, error) {
+	var status *string
+	if err := c.call(ctx, "blockchain.scripthash.subscribe", &status, scriptHash); err != nil {
+		return "", err
+	}
+	if status == nil {
+		return "", nil
+	}
+	return *status, nil
+}
+
+// ScriptHashUpdates signals that the status of subscribed script hashes
+// changed. The changes are returned by TakeScriptHashStatuses.
+func (c *Client) ScriptHashUpdates() <-chan struct{} {
+	return c.statusSignal
+}
+
+// TakeScriptHashStatuses returns the status changes notified since the last
+// call, keyed by script hash.
+func (c *Client) TakeScriptHashStatuses() map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	statuses := c.statuses
+	c.statuses = make(map[string]string)
+	return statuses
+}
+
+// Broadcast publishes the serialized transaction and returns its hash.
+func (c *Client) Broadcast(ctx context.Context, rawTx []byte) (string, error) {
+	var txHash string
+	err := c.call(ctx, "blockchain.transaction.broadcast", &txHash, hex.EncodeToString(rawTx))
+	return txHash, err
+}
+
+// Transaction returns the serialized transaction.
+func (c *Client) Transaction(ctx context.Context, txHash string) ([]byte, error) {
+	var txHex string
+	if err := c.call(ctx, "blockchain.transaction.get", &txHex, txHash, false); err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(txHex)
+}
+
+// EstimateFee returns the fee rate in coins per kB needed for a transaction
+// to be confirmed within blocks.
+func (c *Client) EstimateFee(ctx context.Context, blocks int32) (float64, error) {
+	var feeRate float64
+	if err := c.call(ctx, "blockchain.estimatefee", &feeRate, blocks); err != nil {
+		return 0, err
+	}
+	if feeRate <= 0 {
+		return 0, fmt.Errorf("no fee estimate for %d blocks", blocks)
+	}
+	return feeRate, nil
+}
+
+// RelayFee returns the minimum fee rate in coins per kB accepted by the
+// server's mempool.
+func (c *Client) RelayFee(ctx context.Context) (float64, error) {
+	var feeRate float64
+	err := c.call(ctx, "blockchain.relayfee", &feeRate)
+	return feeRate, err
+}
+
+// ScriptHash returns the Electrum script hash of the output script: the
+// reversed sha256 hash of the script, hex encoded.
+func ScriptHash(pkScript []byte) string {
+	hash := sha256.Sum256(pkScript)
+	for i, j := 0, len(hash)-1; i < j; i, j = i+1, j-1 {
+		hash[i], hash[j] = hash[j], hash[i]
+	}
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,248 @@
+package electrum
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/internal/electrum/electrumtest"
+)
+
+func dialMock(t *testing.T, s *electrumtest.Server) *Client {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	c, err := Dial(ctx, &Config{Addr: s.Addr()})
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestScriptHash(t *testing.T) {
+	// P2PKH script of 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa, the example used by
+	// the Electrum protocol documentation.
+	script, _ := hex.DecodeString("76a91462e907b15cbf27d5425399ebf6f0fb50ebb88f1888ac")
+	const want = "8b01df4e368ea28f8dc0423bcf7a4923e3a12d307c875e47a0cfbf90b5c39161"
+	if got := ScriptHash(script); got != want {
+		t.Fatalf("ScriptHash = %s, want %s", got, want)
+	}
+}
+
+func TestServerVersionAndPing(t *testing.T) {
+	s := electrumtest.NewServer(t)
+	c := dialMock(t, s)
+
+	software, protocol, err := c.ServerVersion(testContext(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if software != "MockServer 1.0" || protocol != ProtocolVersion {
+		t.Fatalf("unexpected version %q %q", software, protocol)
+	}
+
+	if err := c.Ping(testContext(t)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	s := electrumtest.NewServer(t)
+	header := strings.Repeat("ab", HeaderSize)
+	s.Handle("blockchain.headers.subscribe", func([]json.RawMessage) (interface{}, *electrumtest.Error) {
+		return &Header{Height: 100, Hex: header}, nil
+	})
+	s.Handle("blockchain.block.header", func(params []json.RawMessage) (interface{}, *electrumtest.Error) {
+		return header, nil
+	})
+	s.Handle("blockchain.block.headers", func(params []json.RawMessage) (interface{}, *electrumtest.Error) {
+		var count int
+		json.Unmarshal(params[1], &count)
+		return map[string]interface{}{"count": count, "hex": strings.Repeat(header, count), "max": 2016}, nil
+	})
+	c := dialMock(t, s)
+
+	tip, err := c.SubscribeHeaders(testContext(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tip.Height != 100 || tip.Hex != header {
+		t.Fatalf("unexpected tip %+v", tip)
+	}
+
+	raw, err := c.BlockHeader(testContext(t), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(raw) != HeaderSize {
+		t.Fatalf("header size %d, want %d", len(raw), HeaderSize)
+	}
+
+	headers, err := c.BlockHeaders(testContext(t), 90, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(headers) != 3 {
+		t.Fatalf("got %d headers, want 3", len(headers))
+	}
+
+	s.Notify("blockchain.headers.subscribe", &Header{Height: 101, Hex: header})
+	select {
+	case <-c.HeaderUpdates():
+	case <-time.After(5 * time.Second):
+		t.Fatal("header notification not received")
+	}
+	if latest := c.LatestHeader(); latest == nil || latest.Height != 101 {
+		t.Fatalf("unexpected latest header %+v", latest)
+	}
+}
+
+func TestScriptHashRequests(t *testing.T) {
+	const scriptHash = "8b01df4e368ea28f8dc0423bcf7a4923e3a12d307c875e47a0cfbf90b5c39161"
+	const txHash = "f4184fc596403b9d638783cf57adfe4c75c605f6356fbc91338530e9831e9e16"
+
+	s := electrumtest.NewServer(t)
+	s.Handle("blockchain.scripthash.subscribe", func([]json.RawMessage) (interface{}, *electrumtest.Error) {
+		return nil, nil
+	})
+	s.Handle("blockchain.scripthash.get_history", func([]json.RawMessage) (interface{}, *electrumtest.Error) {
+		return []*History{{Height: 170, TxHash: txHash}, {Height: 0, TxHash: txHash, Fee: 250}}, nil
+	})
+	s.Handle("blockchain.scripthash.listunspent", func([]json.RawMessage) (interface{}, *electrumtest.Error) {
+		return []*Unspent{{Height: 170, TxHash: txHash, TxPos: 1, Value: 1000}}, nil
+	})
+	c := dialMock(t, s)
+
+	status, err := c.SubscribeScriptHash(testContext(t), scriptHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != "" {
+		t.Fatalf("status of unused script hash = %q, want empty", status)
+	}
+
+	history, err := c.ScriptHashHistory(testContext(t), scriptHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 || history[0].Height != 170 || history[1].Fee != 250 {
+		t.Fatalf("unexpected history %+v", history)
+	}
+
+	unspent, err := c.ScriptHashUnspent(testContext(t), scriptHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unspent) != 1 || unspent[0].TxPos != 1 || unspent[0].Value != 1000 {
+		t.Fatalf("unexpected unspent outputs %+v", unspent)
+	}
+
+	s.Notify("blockchain.scripthash.subscribe", scriptHash, "deadbeef")
+	select {
+	case <-c.ScriptHashUpdates():
+	case <-time.After(5 * time.Second):
+		t.Fatal("script hash notification not received")
+	}
+	statuses := c.TakeScriptHashStatuses()
+	if statuses[scriptHash] != "deadbeef" {
+		t.Fatalf("unexpected statuses %v", statuses)
+	}
+	if len(c.TakeScriptHashStatuses()) != 0 {
+		t.Fatal("statuses not cleared")
+	}
+}
+
+func TestTransactionsAndFees(t *testing.T) {
+	const txHash = "f4184fc596403b9d638783cf57adfe4c75c605f6356fbc91338530e9831e9e16"
+
+	s := electrumtest.NewServer(t)
+	s.Handle("blockchain.transaction.broadcast", func(params []json.RawMessage) (interface{}, *electrumtest.Error) {
+		var rawTx string
+		json.Unmarshal(params[0], &rawTx)
+		if rawTx != "0100" {
+			return nil, &electrumtest.Error{Code: 1, Message: "bad-txns"}
+		}
+		return txHash, nil
+	})
+	s.Handle("blockchain.transaction.get", func([]json.RawMessage) (interface{}, *electrumtest.Error) {
+		return "0100", nil
+	})
+	s.Handle("blockchain.estimatefee", func(params []json.RawMessage) (interface{}, *electrumtest.Error) {
+		var blocks int32
+		json.Unmarshal(params[0], &blocks)
+		if blocks > 25 {
+			return -1, nil
+		}
+		return 0.0002, nil
+	})
+	s.Handle("blockchain.relayfee", func([]json.RawMessage) (interface{}, *electrumtest.Error) {
+		return 0.00001, nil
+	})
+	c := dialMock(t, s)
+
+	hash, err := c.Broadcast(testContext(t), []byte{0x01, 0x00})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != txHash {
+		t.Fatalf("broadcast returned %s, want %s", hash, txHash)
+	}
+
+	_, err = c.Broadcast(testContext(t), []byte{0x02})
+	if rpcErr, ok := err.(*RPCError); !ok || rpcErr.Message != "bad-txns" {
+		t.Fatalf("expected server error, got %v", err)
+	}
+
+	rawTx, err := c.Transaction(testContext(t), txHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(rawTx) != "0100" {
+		t.Fatalf("unexpected tx %x", rawTx)
+	}
+
+	feeRate, err := c.EstimateFee(testContext(t), 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if feeRate != 0.0002 {
+		t.Fatalf("fee rate %v, want 0.0002", feeRate)
+	}
+	if _, err := c.EstimateFee(testContext(t), 100); err == nil {
+		t.Fatal("expected an error when the server has no estimate")
+	}
+
+	relayFee, err := c.RelayFee(testContext(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if relayFee != 0.00001 {
+		t.Fatalf("relay fee %v, want 0.00001", relayFee)
+	}
+}
+
+func TestConnectionClosed(t *testing.T) {
+	s := electrumtest.NewServer(t)
+	c := dialMock(t, s)
+
+	s.Close()
+	select {
+	case <-c.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("client not notified of the closed connection")
+	}
+
+	if err := c.Ping(testContext(t)); err != ErrClosed {
+		t.Fatalf("Ping after close returned %v, want %v", err, ErrClosed)
+	}
+}
@@ -0,0 +1,142 @@
+// Package electrumtest provides a fake Electrum server for tests of the
+// Electrum client and of the chain backends built on top of it.
+package electrumtest
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"sync"
+	"testing"
+)
+
+// Error is an error returned by the server.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Handler answers a request with its result or an error.
+type Handler func(params []json.RawMessage) (interface{}, *Error)
+
+// Server is a minimal Electrum server answering requests with the results of
+// the registered handlers. It is closed once the test ends.
+type Server struct {
+	t        *testing.T
+	listener net.Listener
+
+	mu       sync.Mutex
+	conns    []net.Conn
+	handlers map[string]Handler
+}
+
+// NewServer starts a server that answers the version negotiation and pings.
+func NewServer(t *testing.T) *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{
+		t:        t,
+		listener: listener,
+		handlers: map[string]Handler{
+			"server.version": func(params []json.RawMessage) (interface{}, *Error) {
+				var protocol string
+				if len(params) > 1 {
+					json.Unmarshal(params[1], &protocol)
+				}
+				return []string{"MockServer 1.0", protocol}, nil
+			},
+			"server.ping": func([]json.RawMessage) (interface{}, *Error) {
+				return nil, nil
+			},
+		},
+	}
+	go s.serve()
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Addr returns the host:port the server listens on.
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Handle registers the handler of the method, replacing any previous one.
+func (s *Server) Handle(method string, handler Handler) {
+	s.mu.Lock()
+	s.handlers[method] = handler
+	s.mu.Unlock()
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns = append(s.conns, conn)
+		s.mu.Unlock()
+		go s.serveConn(conn)
+	}
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var req struct {
+			ID     uint64            `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			s.t.Errorf("invalid request: %v", err)
+			return
+		}
+
+		s.mu.Lock()
+		handler, ok := s.handlers[req.Method]
+		s.mu.Unlock()
+
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if !ok {
+			resp["error"] = &Error{Code: -32601, Message: "unknown method " + req.Method}
+		} else if result, rpcErr := handler(req.Params); rpcErr != nil {
+			resp["error"] = rpcErr
+		} else {
+			resp["result"] = result
+		}
+		s.write(conn, resp)
+	}
+}
+
+// Notify sends the notification to all the connected clients.
+func (s *Server) Notify(method string, params ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		s.write(conn, map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params})
+	}
+}
+
+func (s *Server) write(conn net.Conn, msg interface{}) {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		s.t.Errorf("marshal message: %v", err)
+		return
+	}
+	conn.Write(append(b, '\n'))
+}
+
+// Close stops the server and closes the client connections.
+func (s *Server) Close() {
+	s.listener.Close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+}
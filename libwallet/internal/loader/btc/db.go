@@ -0,0 +1,133 @@
+package btc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/btcsuite/btcwallet/wallet"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/crypto-power/cryptopower/libwallet/internal/loader"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"go.etcd.io/bbolt"
+)
+
+// compactTxMaxSize is the size of the data written in each transaction of a
+// database compaction.
+const compactTxMaxSize = 64 << 20
+
+// BackupDB writes a consistent copy of the database of the loaded wallet to
+// w.
+func (l *btcLoader) BackupDB(w io.Writer) error {
+	defer l.mu.RUnlock()
+	l.mu.RLock()
+
+	if l.wallet == nil {
+		return errors.New("wallet is unopened")
+	}
+	return l.wallet.Database().Copy(w)
+}
+
+// DBStats returns the stats of the database of the loaded wallet.
+func (l *btcLoader) DBStats(walletID string) (*loader.DBStats, error) {
+	defer l.mu.RUnlock()
+	l.mu.RLock()
+
+	if l.wallet == nil {
+		return nil, errors.New("wallet is unopened")
+	}
+	db := l.wallet.Database()
+
+	var buckets []*loader.BucketStats
+	err := walletdb.View(db, func(tx walletdb.ReadTx) error {
+		return tx.ForEachBucket(func(key []byte) error {
+			stats := &loader.BucketStats{Name: string(key)}
+			if err := bucketStats(tx.ReadBucket(key), stats); err != nil {
+				return err
+			}
+			buckets = append(buckets, stats)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dbPath, _, err := l.FileExists(walletID, wallet.WalletDBName, utils.BTCWalletAsset)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := loader.NewDBStats(l.DbDriver, dbPath, buckets)
+	if err != nil {
+		return nil, err
+	}
+	stats.Details = db.PrintStats()
+	return stats, nil
+}
+
+// bucketStats adds the keys of the bucket and of its nested buckets to stats.
+func bucketStats(bucket walletdb.ReadBucket, stats *loader.BucketStats) error {
+	return bucket.ForEach(func(k, v []byte) error {
+		if v == nil {
+			if nested := bucket.NestedReadBucket(k); nested != nil {
+				stats.Add(k, nil, true)
+				return bucketStats(nested, stats)
+			}
+		}
+		stats.Add(k, v, false)
+		return nil
+	})
+}
+
+// MigrateDB compacts the database of the unloaded wallet, btc wallets only
+// have a bdb database driver. The previous database is kept next to the
+// compacted one with a ".<driver>.bak" suffix.
+func (l *btcLoader) MigrateDB(_ context.Context, walletID, driver string) error {
+	defer l.mu.Unlock()
+	l.mu.Lock()
+
+	if l.wallet != nil {
+		return errors.New("wallet is opened")
+	}
+	if driver != l.DbDriver {
+		return errors.New(utils.ErrDBDriverNotSupported)
+	}
+
+	srcPath, exists, err := l.FileExists(walletID, wallet.WalletDBName, utils.BTCWalletAsset)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("missing db at path %v", srcPath)
+	}
+
+	// Remove the leftovers of an interrupted compaction.
+	dstPath := srcPath + ".migrating"
+	if err := os.RemoveAll(dstPath); err != nil {
+		return err
+	}
+
+	src, err := bbolt.Open(srcPath, 0600, &bbolt.Options{Timeout: l.dbTimeout, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	dst, err := bbolt.Open(dstPath, 0600, &bbolt.Options{Timeout: l.dbTimeout})
+	if err != nil {
+		src.Close()
+		return err
+	}
+
+	err = bbolt.Compact(dst, src, compactTxMaxSize)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	src.Close()
+	if err != nil {
+		os.RemoveAll(dstPath)
+		return err
+	}
+
+	return loader.ReplaceDB(srcPath, dstPath, srcPath+"."+l.DbDriver+".bak")
+}
@@ -2,6 +2,7 @@ package loader
 
 import (
 	"context"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -52,6 +53,7 @@ type CreateWalletParams struct {
 type AssetLoader interface {
 	GetDbDirPath() string
 	SetDatabaseDriver(driver string)
+	GetDatabaseDriver() string
 
 	OpenExistingWallet(ctx context.Context, WalletID string, pubPassphrase []byte) (*LoadedWallets, error)
 	CreateNewWallet(ctx context.Context, params *CreateWalletParams) (*LoadedWallets, error)
@@ -60,6 +62,14 @@ type AssetLoader interface {
 	GetLoadedWallet() (*LoadedWallets, bool)
 	UnloadWallet() error
 	WalletExists(WalletID string) (bool, error)
+
+	// BackupDB writes a consistent copy of the database of the loaded wallet
+	// to w while the wallet is in use.
+	BackupDB(w io.Writer) error
+	// DBStats returns the stats of the database of the loaded wallet.
+	DBStats(walletID string) (*DBStats, error)
+	// MigrateDB converts the database of the unloaded wallet to the driver.
+	MigrateDB(ctx context.Context, walletID, driver string) error
 }
 
 func NewLoader(dbDirPath string) *Loader {
@@ -74,6 +84,11 @@ func (l *Loader) SetDatabaseDriver(driver string) {
 	l.DbDriver = driver
 }
 
+// GetDatabaseDriver returns the database driver used by walletdb.
+func (l *Loader) GetDatabaseDriver() string {
+	return l.DbDriver
+}
+
 // CreateDirPath checks that fully qualified path to the wallet bucket exists.
 // If it doesn't exist it's created. It also checks if the actual db file
 // required exists, if it exists an error is returned otherwise it's created.
@@ -110,8 +125,9 @@ func (l *Loader) CreateDirPath(WalletID, walletDbName string, assetType utils.As
 	}
 
 	// No error was returned. Else used here to maintain the scope of
-	// file variable just with the if-else statement.
-	if !file.IsDir() {
+	// file variable just with the if-else statement. file is nil if the
+	// directory was just created.
+	if file != nil && !file.IsDir() {
 		return "", errors.Errorf("%q is not a directory", folderPath)
 	}
 
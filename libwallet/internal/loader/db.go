@@ -0,0 +1,106 @@
+package loader
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// DBStats describes the content of a wallet database.
+type DBStats struct {
+	// Driver is the database driver the wallet database is opened with.
+	Driver string
+	// Path is the location of the database file or directory.
+	Path string
+	// Size is the space used by the database on disk in bytes.
+	Size int64
+	// DataSize is the size of all the keys and values stored in bytes.
+	DataSize int64
+	// Buckets holds the stats of each top level bucket.
+	Buckets []*BucketStats
+	// Details holds the stats collected by the driver, if any.
+	Details string
+}
+
+// BucketStats describes the content of a top level bucket and of all its
+// nested buckets.
+type BucketStats struct {
+	Name          string
+	Keys          int
+	NestedBuckets int
+	DataSize      int64
+}
+
+// Add records a key/value pair of the bucket or of one of its nested
+// buckets. isBucket reports whether the key is a nested bucket.
+func (s *BucketStats) Add(k, v []byte, isBucket bool) {
+	if isBucket {
+		s.NestedBuckets++
+	} else {
+		s.Keys++
+	}
+	s.DataSize += int64(len(k) + len(v))
+}
+
+// NewDBStats returns the stats of the database at path with the stats of its
+// top level buckets. The size on disk is read from the file system.
+func NewDBStats(driver, path string, buckets []*BucketStats) (*DBStats, error) {
+	size, err := PathSize(path)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &DBStats{
+		Driver:  driver,
+		Path:    path,
+		Size:    size,
+		Buckets: buckets,
+	}
+	for _, bucket := range buckets {
+		stats.DataSize += bucket.DataSize
+	}
+	return stats, nil
+}
+
+// PathSize returns the size of the file at path or the size of all the files
+// in the directory at path.
+func PathSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	if !info.IsDir() {
+		return info.Size(), nil
+	}
+
+	var size int64
+	err = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
+
+// ReplaceDB replaces the database at path with the database at newPath. The
+// replaced database is moved to backupPath.
+func ReplaceDB(path, newPath, backupPath string) error {
+	if err := os.RemoveAll(backupPath); err != nil {
+		return err
+	}
+	if err := os.Rename(path, backupPath); err != nil {
+		return err
+	}
+	if err := os.Rename(newPath, path); err != nil {
+		// Put the replaced database back in place.
+		_ = os.Rename(backupPath, path)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package dcr
+
+import (
+	"context"
+
+	"decred.org/dcrwallet/v3/errors"
+	"decred.org/dcrwallet/v3/wallet/walletdb"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+)
+
+// ticketsAgendaPrefsBucketKey is the top level bucket holding a nested bucket
+// of agenda choices for each ticket with choices of its own.
+var ticketsAgendaPrefsBucketKey = []byte("ticketsagendaprefs")
+
+// TicketHasAgendaChoices returns true if agenda choices were saved for the
+// ticket. The wallet returns the default agenda choices for the tickets
+// without choices of their own.
+func (l *dcrLoader) TicketHasAgendaChoices(ctx context.Context, ticketHash *chainhash.Hash) (bool, error) {
+	const op errors.Op = "loader.TicketHasAgendaChoices"
+
+	defer l.mu.RUnlock()
+	l.mu.RLock()
+
+	db, err := l.loadedDB()
+	if err != nil {
+		return false, errors.E(op, err)
+	}
+
+	var exists bool
+	err = walletdb.View(ctx, db, func(tx walletdb.ReadTx) error {
+		exists = tx.ReadBucket(ticketsAgendaPrefsBucketKey).NestedReadBucket(ticketHash[:]) != nil
+		return nil
+	})
+	if err != nil {
+		return false, errors.E(op, err)
+	}
+	return exists, nil
+}
+
+// DeleteTicketAgendaChoices deletes the agenda choices saved for the ticket,
+// which then votes with the default agenda choices.
+func (l *dcrLoader) DeleteTicketAgendaChoices(ctx context.Context, ticketHash *chainhash.Hash) error {
+	const op errors.Op = "loader.DeleteTicketAgendaChoices"
+
+	defer l.mu.RUnlock()
+	l.mu.RLock()
+
+	db, err := l.loadedDB()
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	err = walletdb.Update(ctx, db, func(tx walletdb.ReadWriteTx) error {
+		bucket := tx.ReadWriteBucket(ticketsAgendaPrefsBucketKey)
+		if bucket.NestedReadBucket(ticketHash[:]) == nil {
+			return nil
+		}
+		return bucket.DeleteNestedBucket(ticketHash[:])
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
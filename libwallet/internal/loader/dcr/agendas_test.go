@@ -0,0 +1,77 @@
+package dcr
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"decred.org/dcrwallet/v3/wallet"
+	"decred.org/dcrwallet/v3/wallet/udb"
+	"decred.org/dcrwallet/v3/wallet/walletdb"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+)
+
+func TestTicketAgendaChoices(t *testing.T) {
+	ctx := context.Background()
+
+	walletDB, err := wallet.CreateDB("bdb", filepath.Join(t.TempDir(), "wallet.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer walletDB.Close()
+	db := walletDB.(walletdb.DB)
+
+	err = walletdb.Update(ctx, db, func(tx walletdb.ReadWriteTx) error {
+		_, err := tx.CreateTopLevelBucket(ticketsAgendaPrefsBucketKey)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := &dcrLoader{wallet: &wallet.Wallet{}, db: walletDB}
+	ticket, other := &chainhash.Hash{0x01}, &chainhash.Hash{0x02}
+
+	hasChoices := func(hash *chainhash.Hash) bool {
+		t.Helper()
+		has, err := l.TicketHasAgendaChoices(ctx, hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return has
+	}
+
+	if hasChoices(ticket) {
+		t.Fatal("ticket without saved choices has choices")
+	}
+
+	err = walletdb.Update(ctx, db, func(tx walletdb.ReadWriteTx) error {
+		for _, hash := range []*chainhash.Hash{ticket, other} {
+			if err := udb.SetTicketAgendaPreference(tx, hash, 10, "agenda", "yes"); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasChoices(ticket) {
+		t.Fatal("ticket with saved choices has no choices")
+	}
+
+	if err := l.DeleteTicketAgendaChoices(ctx, ticket); err != nil {
+		t.Fatal(err)
+	}
+	if hasChoices(ticket) {
+		t.Fatal("ticket choices not deleted")
+	}
+	if !hasChoices(other) {
+		t.Fatal("choices of another ticket deleted")
+	}
+
+	// Deleting the choices of a ticket without choices is a no-op.
+	if err := l.DeleteTicketAgendaChoices(ctx, ticket); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,297 @@
+package dcr
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"decred.org/dcrwallet/v3/errors"
+	"decred.org/dcrwallet/v3/wallet/walletdb"
+	"github.com/crypto-power/cryptopower/libwallet/badgerdb"
+	"github.com/crypto-power/cryptopower/libwallet/internal/loader"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// migrationBatchSize is the number of keys written in each transaction of a
+// database migration. Badger limits the size of a transaction.
+const migrationBatchSize = 10000
+
+// topLevelBuckets are the top level buckets of a wallet database. walletdb
+// has no way to list the top level buckets of a database.
+var topLevelBuckets = []string{
+	"meta",
+	"waddrmgr",
+	"wtxmgr",
+	"wstakemgr",
+	"agendaprefs",
+	"ticketsagendaprefs",
+	"tspendpolicy",
+	"treasurypolicy",
+	"vsptspendpolicy",
+	"vsptreasurypolicy",
+	"vsp",
+	"vsphost",
+	"vsppubkey",
+}
+
+// loadedDB returns the database of the loaded wallet. Requires mutex to be
+// locked.
+func (l *dcrLoader) loadedDB() (walletdb.DB, error) {
+	if l.wallet == nil {
+		return nil, errors.E(errors.Invalid, "wallet is unopened")
+	}
+	db, ok := l.db.(walletdb.DB)
+	if !ok {
+		return nil, errors.E(errors.Invalid, "unknown wallet database")
+	}
+	return db, nil
+}
+
+// BackupDB writes a consistent copy of the database of the loaded wallet to
+// w. A bdb copy is a database file, a badgerdb copy is loaded with
+// badgerdb.Restore.
+func (l *dcrLoader) BackupDB(w io.Writer) error {
+	const op errors.Op = "loader.BackupDB"
+
+	defer l.mu.RUnlock()
+	l.mu.RLock()
+
+	db, err := l.loadedDB()
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if err := db.Copy(w); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// DBStats returns the stats of the database of the loaded wallet.
+func (l *dcrLoader) DBStats(walletID string) (*loader.DBStats, error) {
+	const op errors.Op = "loader.DBStats"
+
+	defer l.mu.RUnlock()
+	l.mu.RLock()
+
+	db, err := l.loadedDB()
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	var buckets []*loader.BucketStats
+	err = walletdb.View(context.Background(), db, func(tx walletdb.ReadTx) error {
+		for _, name := range topLevelBuckets {
+			bucket := tx.ReadBucket([]byte(name))
+			if bucket == nil {
+				continue
+			}
+			stats := &loader.BucketStats{Name: name}
+			if err := bucketStats(bucket, stats); err != nil {
+				return err
+			}
+			buckets = append(buckets, stats)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	dbPath, _, err := l.FileExists(walletID, walletDbName, utils.DCRWalletAsset)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	stats, err := loader.NewDBStats(l.DbDriver, dbPath, buckets)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if l.DbDriver == badgerdb.DriverName {
+		stats.Details = badgerdb.Stats(dbPath)
+	}
+	return stats, nil
+}
+
+// bucketStats adds the keys of the bucket and of its nested buckets to stats.
+func bucketStats(bucket walletdb.ReadBucket, stats *loader.BucketStats) error {
+	return bucket.ForEach(func(k, v []byte) error {
+		if v == nil {
+			if nested := bucket.NestedReadBucket(k); nested != nil {
+				stats.Add(k, nil, true)
+				return bucketStats(nested, stats)
+			}
+		}
+		stats.Add(k, v, false)
+		return nil
+	})
+}
+
+// MigrateDB copies the database of the unloaded wallet to a new database
+// opened with the driver, which replaces it once the copy is complete. A copy
+// to the same driver compacts the database. The previous database is kept
+// next to the new one with a ".<driver>.bak" suffix.
+func (l *dcrLoader) MigrateDB(ctx context.Context, walletID, driver string) error {
+	const op errors.Op = "loader.MigrateDB"
+
+	defer l.mu.Unlock()
+	l.mu.Lock()
+
+	if l.wallet != nil {
+		return errors.E(op, errors.Invalid, "wallet is opened")
+	}
+
+	srcPath, exists, err := l.FileExists(walletID, walletDbName, utils.DCRWalletAsset)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if !exists {
+		return errors.E(op, errors.NotExist, "missing wallet database")
+	}
+
+	// Remove the leftovers of an interrupted migration.
+	dstPath := srcPath + ".migrating"
+	if err := os.RemoveAll(dstPath); err != nil {
+		return errors.E(op, err)
+	}
+
+	src, err := walletdb.Open(l.DbDriver, srcPath)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	dst, err := walletdb.Create(driver, dstPath)
+	if err != nil {
+		src.Close()
+		return errors.E(op, err)
+	}
+
+	err = copyDB(ctx, src, dst)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	src.Close()
+	if err != nil {
+		os.RemoveAll(dstPath)
+		return errors.E(op, err)
+	}
+
+	backupPath := srcPath + "." + l.DbDriver + ".bak"
+	if err := loader.ReplaceDB(srcPath, dstPath, backupPath); err != nil {
+		return errors.E(op, err)
+	}
+
+	log.Infof("Migrated wallet %s database from %s to %s", walletID, l.DbDriver, driver)
+	l.DbDriver = driver
+	return nil
+}
+
+// dbCopier writes the keys of a database to another database, committing the
+// write transaction every migrationBatchSize keys.
+type dbCopier struct {
+	ctx  context.Context
+	dst  walletdb.DB
+	tx   walletdb.ReadWriteTx
+	keys int
+}
+
+// copyDB copies all the top level buckets of src to dst from a single read
+// transaction.
+func copyDB(ctx context.Context, src, dst walletdb.DB) error {
+	c := &dbCopier{ctx: ctx, dst: dst}
+	err := walletdb.View(ctx, src, func(tx walletdb.ReadTx) error {
+		for _, name := range topLevelBuckets {
+			bucket := tx.ReadBucket([]byte(name))
+			if bucket == nil {
+				continue
+			}
+			if err := c.copyBucket(bucket, [][]byte{[]byte(name)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if c.tx != nil {
+			_ = c.tx.Rollback()
+		}
+		return err
+	}
+	return c.commit()
+}
+
+// bucket returns the destination bucket at path, creating it if needed.
+func (c *dbCopier) bucket(path [][]byte) (walletdb.ReadWriteBucket, error) {
+	if c.tx == nil {
+		tx, err := c.dst.BeginReadWriteTx()
+		if err != nil {
+			return nil, err
+		}
+		c.tx = tx
+	}
+
+	bucket := c.tx.ReadWriteBucket(path[0])
+	if bucket == nil {
+		var err error
+		if bucket, err = c.tx.CreateTopLevelBucket(path[0]); err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range path[1:] {
+		var err error
+		if bucket, err = bucket.CreateBucketIfNotExists(key); err != nil {
+			return nil, err
+		}
+	}
+	return bucket, nil
+}
+
+// commit commits the pending write transaction, if any.
+func (c *dbCopier) commit() error {
+	if c.tx == nil {
+		return nil
+	}
+	err := c.tx.Commit()
+	c.tx = nil
+	c.keys = 0
+	return err
+}
+
+// copyBucket copies the keys of the bucket and its nested buckets to the
+// destination bucket at path.
+func (c *dbCopier) copyBucket(src walletdb.ReadBucket, path [][]byte) error {
+	dst, err := c.bucket(path)
+	if err != nil {
+		return err
+	}
+	dstTx := c.tx
+
+	return src.ForEach(func(k, v []byte) error {
+		// The keys and values are only valid during the iteration.
+		k = append([]byte(nil), k...)
+
+		if v == nil {
+			if nested := src.NestedReadBucket(k); nested != nil {
+				nestedPath := append(path[:len(path):len(path)], k)
+				return c.copyBucket(nested, nestedPath)
+			}
+		}
+
+		if c.keys >= migrationBatchSize {
+			if err := c.ctx.Err(); err != nil {
+				return err
+			}
+			if err := c.commit(); err != nil {
+				return err
+			}
+		}
+		// The destination bucket belongs to a committed transaction after a
+		// batch is committed.
+		if c.tx == nil || c.tx != dstTx {
+			if dst, err = c.bucket(path); err != nil {
+				return err
+			}
+			dstTx = c.tx
+		}
+
+		c.keys++
+		return dst.Put(k, append([]byte{}, v...))
+	})
+}
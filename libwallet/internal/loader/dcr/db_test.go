@@ -0,0 +1,156 @@
+package dcr
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"decred.org/dcrwallet/v3/wallet/walletdb"
+	"github.com/crypto-power/cryptopower/libwallet/badgerdb"
+)
+
+// dumpDB returns the keys and values of the top level buckets of db keyed by
+// their path. Nested buckets are reported with a nil value.
+func dumpDB(t *testing.T, db walletdb.DB) map[string][]byte {
+	t.Helper()
+
+	dump := make(map[string][]byte)
+	var dumpBucket func(bucket walletdb.ReadBucket, path string) error
+	dumpBucket = func(bucket walletdb.ReadBucket, path string) error {
+		return bucket.ForEach(func(k, v []byte) error {
+			keyPath := path + "/" + string(k)
+			if v == nil {
+				if nested := bucket.NestedReadBucket(k); nested != nil {
+					dump[keyPath] = nil
+					return dumpBucket(nested, keyPath)
+				}
+			}
+			dump[keyPath] = append([]byte{}, v...)
+			return nil
+		})
+	}
+
+	err := walletdb.View(context.Background(), db, func(tx walletdb.ReadTx) error {
+		for _, name := range topLevelBuckets {
+			if bucket := tx.ReadBucket([]byte(name)); bucket != nil {
+				if err := dumpBucket(bucket, name); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dump
+}
+
+func TestCopyDB(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	src, err := walletdb.Create("bdb", filepath.Join(dir, "src.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+
+	// Fill every top level bucket, with nested buckets and enough keys in
+	// one of them to be copied over several batches.
+	err = walletdb.Update(ctx, src, func(tx walletdb.ReadWriteTx) error {
+		for i, name := range topLevelBuckets {
+			bucket, err := tx.CreateTopLevelBucket([]byte(name))
+			if err != nil {
+				return err
+			}
+			keys := i + 1
+			if name == "wtxmgr" {
+				keys = migrationBatchSize + 10
+			}
+			for k := 0; k < keys; k++ {
+				if err := bucket.Put([]byte(fmt.Sprintf("key%d", k)), []byte(fmt.Sprintf("%s%d", name, k))); err != nil {
+					return err
+				}
+			}
+
+			nested, err := bucket.CreateBucket([]byte("nested"))
+			if err != nil {
+				return err
+			}
+			if err := nested.Put([]byte("key"), []byte(name)); err != nil {
+				return err
+			}
+			deepest, err := nested.CreateBucket([]byte("deepest"))
+			if err != nil {
+				return err
+			}
+			if err := deepest.Put([]byte("key"), []byte(name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := dumpDB(t, src)
+
+	// Round trip through badger and back to bdb.
+	drivers := []string{badgerdb.DriverName, "bdb"}
+	for i, driver := range drivers {
+		dst, err := walletdb.Create(driver, filepath.Join(dir, fmt.Sprintf("dst%d.db", i)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer dst.Close()
+
+		if err := copyDB(ctx, src, dst); err != nil {
+			t.Fatalf("copy to %s: %v", driver, err)
+		}
+		if got := dumpDB(t, dst); !reflect.DeepEqual(got, want) {
+			t.Fatalf("copy to %s: got %d keys, want %d", driver, len(got), len(want))
+		}
+		src = dst
+	}
+}
+
+func TestCopyDBCanceled(t *testing.T) {
+	dir := t.TempDir()
+
+	src, err := walletdb.Create("bdb", filepath.Join(dir, "src.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+
+	err = walletdb.Update(context.Background(), src, func(tx walletdb.ReadWriteTx) error {
+		bucket, err := tx.CreateTopLevelBucket([]byte(topLevelBuckets[0]))
+		if err != nil {
+			return err
+		}
+		for k := 0; k <= migrationBatchSize; k++ {
+			if err := bucket.Put([]byte(fmt.Sprintf("key%d", k)), nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst, err := walletdb.Create(badgerdb.DriverName, filepath.Join(dir, "dst.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := copyDB(ctx, src, dst); err == nil {
+		t.Fatal("expected the canceled copy to fail")
+	}
+}
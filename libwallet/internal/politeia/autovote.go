@@ -0,0 +1,358 @@
+package politeia
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"decred.org/dcrwallet/v3/errors"
+	"decred.org/dcrwallet/v3/wallet"
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+)
+
+const (
+	AutoVotePolicyConfigKey = "politeia_auto_vote_policy"
+
+	// AutoVoteRuleFollowVoters casts the option the majority of the trusted
+	// voting addresses voted for on the proposal.
+	AutoVoteRuleFollowVoters = "follow_voters"
+	// AutoVoteRuleBudget casts the configured option for proposals whose
+	// requested budget does not exceed the configured amount.
+	AutoVoteRuleBudget = "budget"
+	// AutoVoteRuleAbstain skips voting on the proposal.
+	AutoVoteRuleAbstain = "abstain"
+)
+
+// AutoVoteRule is a single rule of the auto-vote policy. Only the fields
+// relevant to the rule's Type are used.
+type AutoVoteRule struct {
+	Type string `json:"type"`
+	// Addresses are the ticket voting addresses of the trusted voters
+	// followed by AutoVoteRuleFollowVoters.
+	Addresses []string `json:"addresses,omitempty"`
+	// MaxAmount is the budget limit in cents of AutoVoteRuleBudget.
+	MaxAmount uint64 `json:"maxamount,omitempty"`
+	// VoteOption is the option (VoteBitYes or VoteBitNo) cast by
+	// AutoVoteRuleBudget.
+	VoteOption string `json:"voteoption,omitempty"`
+}
+
+// AutoVotePolicy holds the rules used to auto-cast votes on proposals. The
+// rules are evaluated in order and the first rule that reaches a decision
+// is applied.
+type AutoVotePolicy struct {
+	Rules []AutoVoteRule `json:"rules"`
+}
+
+// AutoVoteDecision is the outcome of evaluating the auto-vote policy for a
+// proposal. An empty VoteOption means no vote is cast.
+type AutoVoteDecision struct {
+	Token        string
+	ProposalName string
+	VoteOption   string
+	Rule         string
+	Reason       string
+	NumTickets   int
+}
+
+// AutoVoteLog is the audit record of an auto-vote decision and of the vote
+// cast for it, if any.
+type AutoVoteLog struct {
+	ID           int    `storm:"id,increment"`
+	WalletID     int    `json:"walletid" storm:"index"`
+	Token        string `json:"token"`
+	ProposalName string `json:"name"`
+	VoteOption   string `json:"voteoption"`
+	Rule         string `json:"rule"`
+	Reason       string `json:"reason"`
+	NumTickets   int    `json:"numtickets"`
+	Error        string `json:"error"`
+	Timestamp    int64  `json:"timestamp" storm:"index"`
+}
+
+// SaveAutoVotePolicy validates and saves the auto-vote policy.
+func (p *Politeia) SaveAutoVotePolicy(policy *AutoVotePolicy) error {
+	for _, rule := range policy.Rules {
+		switch rule.Type {
+		case AutoVoteRuleFollowVoters:
+			if len(rule.Addresses) == 0 {
+				return errors.New(ErrInvalid)
+			}
+		case AutoVoteRuleBudget:
+			if rule.VoteOption != VoteBitYes && rule.VoteOption != VoteBitNo {
+				return errors.New(ErrInvalid)
+			}
+		case AutoVoteRuleAbstain:
+		default:
+			return errors.New(ErrInvalid)
+		}
+	}
+
+	b, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+
+	return p.db.Set(configDBBkt, AutoVotePolicyConfigKey, b)
+}
+
+// AutoVotePolicy returns the saved auto-vote policy. A policy without rules
+// is returned if none was saved.
+func (p *Politeia) AutoVotePolicy() (*AutoVotePolicy, error) {
+	var b []byte
+	err := p.db.Get(configDBBkt, AutoVotePolicyConfigKey, &b)
+	if err == storm.ErrNotFound {
+		return &AutoVotePolicy{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	policy := new(AutoVotePolicy)
+	if err = json.Unmarshal(b, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// AutoVoteLogs returns the audit logs of the auto-votes decided for the
+// wallet, newest first.
+func (p *Politeia) AutoVoteLogs(walletID int) ([]AutoVoteLog, error) {
+	var logs []AutoVoteLog
+	err := p.db.Select(q.Eq("WalletID", walletID)).OrderBy("Timestamp").Reverse().Find(&logs)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, fmt.Errorf("error fetching auto-vote logs: %s", err.Error())
+	}
+
+	return logs, nil
+}
+
+// SaveAutoVoteLog records the decision in the wallet's auto-vote logs, along
+// with the error that kept its vote from being cast if any.
+func (p *Politeia) SaveAutoVoteLog(walletID int, decision *AutoVoteDecision, voteErr error) error {
+	auditLog := &AutoVoteLog{
+		WalletID:     walletID,
+		Token:        decision.Token,
+		ProposalName: decision.ProposalName,
+		VoteOption:   decision.VoteOption,
+		Rule:         decision.Rule,
+		Reason:       decision.Reason,
+		NumTickets:   decision.NumTickets,
+		Timestamp:    time.Now().Unix(),
+	}
+	if voteErr != nil {
+		auditLog.Error = voteErr.Error()
+	}
+	return p.db.Save(auditLog)
+}
+
+// HasAutoVoteLog returns true if a decision on the proposal was recorded in
+// the wallet's auto-vote logs.
+func (p *Politeia) HasAutoVoteLog(walletID int, token string) (bool, error) {
+	count, err := p.db.Select(q.Eq("WalletID", walletID), q.Eq("Token", token)).Count(&AutoVoteLog{})
+	if err != nil && err != storm.ErrNotFound {
+		return false, fmt.Errorf("error fetching auto-vote logs: %s", err.Error())
+	}
+	return count > 0, nil
+}
+
+// PreviewAutoVote evaluates the auto-vote policy for the proposal without
+// casting any vote.
+func (p *Politeia) PreviewAutoVote(ctx context.Context, wallet *wallet.Wallet, token string) (*AutoVoteDecision, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	client, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.autoVoteDecision(ctx, client, wallet, token)
+}
+
+// CastAutoVote casts the decided vote on the proposal with the wallet's
+// eligible tickets and records the attempt in the wallet's auto-vote logs.
+// No vote is cast if the wallet is locked, ErrWalletLocked is returned
+// instead so that the caller can ask for the wallet to be unlocked.
+func (p *Politeia) CastAutoVote(ctx context.Context, wallet *wallet.Wallet, walletID int, decision *AutoVoteDecision) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	client, err := p.getClient()
+	if err != nil {
+		return err
+	}
+
+	if decision.VoteOption != "" && decision.NumTickets > 0 {
+		if wallet.Locked() {
+			err = errors.New(ErrWalletLocked)
+		} else {
+			err = p.castAutoVotes(ctx, client, wallet, walletID, decision)
+		}
+	}
+
+	if saveErr := p.SaveAutoVoteLog(walletID, decision, err); saveErr != nil {
+		log.Errorf("error saving auto-vote log: %v", saveErr)
+	}
+
+	return err
+}
+
+func (p *Politeia) castAutoVotes(ctx context.Context, client *politeiaClient, wallet *wallet.Wallet, walletID int, decision *AutoVoteDecision) error {
+	voteDetails, err := p.proposalVoteDetails(ctx, client, wallet, decision.Token)
+	if err != nil {
+		return err
+	}
+
+	votes := make([]*ProposalVote, 0, len(voteDetails.EligibleTickets))
+	for _, ticket := range voteDetails.EligibleTickets {
+		votes = append(votes, &ProposalVote{Ticket: ticket, Bit: decision.VoteOption})
+	}
+
+	return p.castVotes(ctx, client, wallet, walletID, votes, decision.Token)
+}
+
+func (p *Politeia) autoVoteDecision(ctx context.Context, client *politeiaClient, wallet *wallet.Wallet, token string) (*AutoVoteDecision, error) {
+	proposal, err := p.GetProposalRaw(token)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	policy, err := p.AutoVotePolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	voteDetails, err := p.proposalVoteDetails(ctx, client, wallet, token)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := &AutoVoteDecision{
+		Token:        token,
+		ProposalName: proposal.Name,
+		NumTickets:   len(voteDetails.EligibleTickets),
+		Reason:       "no rule matched",
+	}
+
+	src := &serverAutoVoteSource{p: p, client: client}
+	for _, rule := range policy.Rules {
+		option, reason, err := evaluateAutoVoteRule(src, rule, proposal)
+		if err != nil {
+			return nil, err
+		}
+		if reason == "" {
+			continue
+		}
+
+		decision.Rule = rule.Type
+		decision.VoteOption = option
+		decision.Reason = reason
+		break
+	}
+
+	return decision, nil
+}
+
+// autoVoteSource provides the data the auto-vote rules are evaluated
+// against.
+type autoVoteSource interface {
+	trustedVotes(token string, addresses []string) (yes, no int, err error)
+	proposalAmount(proposal *Proposal) (uint64, error)
+}
+
+// evaluateAutoVoteRule returns the option to vote according to the rule and
+// why. An empty reason means the rule does not apply to the proposal yet,
+// e.g. while none of the trusted voters voted on it.
+func evaluateAutoVoteRule(src autoVoteSource, rule AutoVoteRule, proposal *Proposal) (string, string, error) {
+	switch rule.Type {
+	case AutoVoteRuleFollowVoters:
+		yes, no, err := src.trustedVotes(proposal.Token, rule.Addresses)
+		if err != nil {
+			return "", "", err
+		}
+
+		switch {
+		case yes > no:
+			return VoteBitYes, fmt.Sprintf("trusted voters voted %d yes, %d no", yes, no), nil
+		case no > yes:
+			return VoteBitNo, fmt.Sprintf("trusted voters voted %d yes, %d no", yes, no), nil
+		}
+		return "", "", nil
+
+	case AutoVoteRuleBudget:
+		amount, err := src.proposalAmount(proposal)
+		if err != nil {
+			return "", "", err
+		}
+
+		if amount > rule.MaxAmount {
+			return "", "", nil
+		}
+		return rule.VoteOption, fmt.Sprintf("budget $%.2f is within $%.2f",
+			float64(amount)/100, float64(rule.MaxAmount)/100), nil
+
+	case AutoVoteRuleAbstain:
+		return "", "abstain", nil
+	}
+
+	return "", "", nil
+}
+
+// serverAutoVoteSource evaluates the auto-vote rules against the politeia
+// server the client is connected to.
+type serverAutoVoteSource struct {
+	p      *Politeia
+	client *politeiaClient
+}
+
+// proposalAmount returns the budget in cents requested by the proposal.
+func (s *serverAutoVoteSource) proposalAmount(proposal *Proposal) (uint64, error) {
+	proposalVersion, err := s.p.fetchProposalVersion(s.client, proposal.Token, proposal.Version)
+	if err != nil {
+		return 0, err
+	}
+	return proposalVersion.Amount, nil
+}
+
+// trustedVotes counts the yes and no votes cast on the proposal by tickets
+// whose voting address is one of the trusted addresses.
+func (s *serverAutoVoteSource) trustedVotes(token string, addresses []string) (yes, no int, err error) {
+	detailsReply, err := s.client.voteDetails(token)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	resultsReply, err := s.client.voteResults(token)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	optionByBit := make(map[string]string)
+	for _, option := range detailsReply.Vote.Params.Options {
+		optionByBit[strconv.FormatUint(option.Bit, 16)] = option.ID
+	}
+
+	trusted := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		trusted[address] = true
+	}
+
+	for _, vote := range resultsReply.Votes {
+		if !trusted[vote.Address] {
+			continue
+		}
+		switch optionByBit[vote.VoteBit] {
+		case VoteBitYes:
+			yes++
+		case VoteBitNo:
+			no++
+		}
+	}
+
+	return yes, no, nil
+}
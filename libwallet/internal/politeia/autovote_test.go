@@ -0,0 +1,130 @@
+package politeia
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/asdine/storm"
+)
+
+// testAutoVoteSource returns fixed trusted votes and proposal amount.
+type testAutoVoteSource struct {
+	yes, no int
+	amount  uint64
+	err     error
+}
+
+func (s *testAutoVoteSource) trustedVotes(_ string, _ []string) (int, int, error) {
+	return s.yes, s.no, s.err
+}
+
+func (s *testAutoVoteSource) proposalAmount(_ *Proposal) (uint64, error) {
+	return s.amount, s.err
+}
+
+func TestEvaluateAutoVoteRule(t *testing.T) {
+	followVoters := AutoVoteRule{Type: AutoVoteRuleFollowVoters, Addresses: []string{"addr"}}
+	budgetYes := AutoVoteRule{Type: AutoVoteRuleBudget, MaxAmount: 1000, VoteOption: VoteBitYes}
+	budgetNo := AutoVoteRule{Type: AutoVoteRuleBudget, MaxAmount: 1000, VoteOption: VoteBitNo}
+
+	tests := []struct {
+		name    string
+		rule    AutoVoteRule
+		src     testAutoVoteSource
+		option  string
+		applies bool
+		wantErr bool
+	}{
+		// The trusted voters may not have voted when voting starts, the
+		// rule applies once they have.
+		{"no trusted votes yet", followVoters, testAutoVoteSource{}, "", false, false},
+		{"trusted voters tied", followVoters, testAutoVoteSource{yes: 2, no: 2}, "", false, false},
+		{"trusted voters approve", followVoters, testAutoVoteSource{yes: 3, no: 1}, VoteBitYes, true, false},
+		{"trusted voters reject", followVoters, testAutoVoteSource{yes: 1, no: 3}, VoteBitNo, true, false},
+		{"trusted votes error", followVoters, testAutoVoteSource{err: errors.New("offline")}, "", false, true},
+		{"under budget", budgetYes, testAutoVoteSource{amount: 999}, VoteBitYes, true, false},
+		{"at budget", budgetNo, testAutoVoteSource{amount: 1000}, VoteBitNo, true, false},
+		{"over budget", budgetYes, testAutoVoteSource{amount: 1001}, "", false, false},
+		{"budget error", budgetYes, testAutoVoteSource{err: errors.New("offline")}, "", false, true},
+		{"abstain", AutoVoteRule{Type: AutoVoteRuleAbstain}, testAutoVoteSource{}, "", true, false},
+		{"unknown rule", AutoVoteRule{Type: "unknown"}, testAutoVoteSource{}, "", false, false},
+	}
+
+	for _, test := range tests {
+		src := test.src
+		option, reason, err := evaluateAutoVoteRule(&src, test.rule, &Proposal{Token: "token"})
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+			continue
+		}
+		if option != test.option {
+			t.Errorf("%s: expected option %q, got %q", test.name, test.option, option)
+		}
+		if applies := reason != ""; applies != test.applies {
+			t.Errorf("%s: expected the rule to apply %v, got reason %q", test.name, test.applies, reason)
+		}
+	}
+}
+
+func TestAutoVoteLogs(t *testing.T) {
+	db, err := storm.Open(filepath.Join(t.TempDir(), "politeia.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	p, err := New("", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Decisions to not vote are logged along with the votes cast.
+	abstain := &AutoVoteDecision{Token: "a", Rule: AutoVoteRuleAbstain, Reason: "abstain", NumTickets: 2}
+	locked := &AutoVoteDecision{Token: "b", VoteOption: VoteBitYes, Reason: "budget", NumTickets: 2}
+	if err = p.SaveAutoVoteLog(1, abstain, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err = p.SaveAutoVoteLog(1, locked, errors.New(ErrWalletLocked)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		walletID int
+		token    string
+		logged   bool
+	}{
+		{1, "a", true},
+		{1, "b", true},
+		{1, "c", false},
+		{2, "a", false},
+	} {
+		logged, err := p.HasAutoVoteLog(test.walletID, test.token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if logged != test.logged {
+			t.Errorf("wallet %d, proposal %s: expected logged %v, got %v", test.walletID, test.token, test.logged, logged)
+		}
+	}
+
+	logs, err := p.AutoVoteLogs(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 2 {
+		t.Fatalf("expected 2 logs, got %d", len(logs))
+	}
+	for _, l := range logs {
+		switch l.Token {
+		case "a":
+			if l.VoteOption != "" || l.Rule != AutoVoteRuleAbstain || l.Error != "" {
+				t.Errorf("unexpected abstain log %+v", l)
+			}
+		case "b":
+			if l.VoteOption != VoteBitYes || l.Error != ErrWalletLocked {
+				t.Errorf("unexpected locked wallet log %+v", l)
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package politeia
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want []DiffLine
+	}{{
+		name: "identical",
+		from: "a\nb\n",
+		to:   "a\nb",
+		want: []DiffLine{{DiffEqual, "a"}, {DiffEqual, "b"}},
+	}, {
+		name: "from empty",
+		from: "",
+		to:   "a",
+		want: []DiffLine{{DiffInsert, "a"}},
+	}, {
+		name: "to empty",
+		from: "a\r\nb",
+		to:   "",
+		want: []DiffLine{{DiffDelete, "a"}, {DiffDelete, "b"}},
+	}, {
+		name: "edited line",
+		from: "# Title\nbudget 10\nend",
+		to:   "# Title\nbudget 20\nend\nextra",
+		want: []DiffLine{
+			{DiffEqual, "# Title"},
+			{DiffDelete, "budget 10"},
+			{DiffInsert, "budget 20"},
+			{DiffEqual, "end"},
+			{DiffInsert, "extra"},
+		},
+	}}
+
+	for _, test := range tests {
+		got := diffLines(test.from, test.to)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
@@ -14,6 +14,12 @@ const (
 	ErrInvalidAddress        = "invalid_address"
 	ErrInvalidPassphrase     = "invalid_passphrase"
 	ErrNoPeers               = "no_peers"
+	ErrWalletLocked          = "wallet_locked"
+	ErrVoteNotCounted        = "vote_not_counted"
+	ErrVoteMismatch          = "vote_mismatch"
+	ErrServerKeyMismatch     = "server_key_mismatch"
+	ErrServerKeyUnknown      = "server_key_unknown"
+	ErrServerKeyRotated      = "server_key_rotated"
 )
 
 func translateError(err error) error {
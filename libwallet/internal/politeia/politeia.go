@@ -4,11 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"sync"
 
 	"decred.org/dcrwallet/v3/errors"
 	"github.com/asdine/storm"
 	"github.com/asdine/storm/q"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
 )
 
 const (
@@ -17,17 +20,31 @@ const (
 
 	configDBBkt                  = "politeia_config"
 	LastSyncedTimestampConfigKey = "politeia_last_synced_timestamp"
+	// ServerPubKeyConfigKey holds the public key of the politeia server
+	// identity pinned the first time the server was reached, or re-pinned by
+	// the user since. The saved vote receipts signed with another key are
+	// flagged.
+	ServerPubKeyConfigKey = "politeia_server_pubkey"
+	// PreviousServerPubKeysConfigKey holds the server keys pinned before the
+	// current one, the receipts they signed are still trusted.
+	PreviousServerPubKeysConfigKey = "politeia_previous_server_pubkeys"
 )
 
 type Politeia struct {
-	host string
-	db   *storm.DB
+	db *storm.DB
 
 	// TODO: Check usages of mu, seems not to always be unlocked.
 	mu         *sync.RWMutex // Pointer required to avoid copying literal values.
 	ctx        context.Context
 	cancelSync context.CancelFunc
-	client     *politeiaClient
+
+	// clientMu guards the endpoints and the client connected to one of
+	// them. The client is replaced when its server fails, requests use the
+	// client returned by getClient rather than this field.
+	clientMu *sync.Mutex // Pointer required to avoid copying literal values.
+	// endpoints are the politeia servers in the order they are tried.
+	endpoints []utils.Endpoint
+	client    *politeiaClient
 
 	notificationListenersMu *sync.RWMutex // Pointer required to avoid copying literal values.
 	notificationListeners   map[string]ProposalNotificationListener
@@ -48,15 +65,62 @@ func New(host string, db *storm.DB) (*Politeia, error) {
 		return nil, err
 	}
 
-	return &Politeia{
-		host: host,
-		db:   db,
+	if err := db.Init(&Comment{}); err != nil {
+		log.Errorf("Error initializing politeia comments database: %s", err.Error())
+		return nil, err
+	}
+
+	if err := db.Init(&ProposalVersion{}); err != nil {
+		log.Errorf("Error initializing politeia proposal versions database: %s", err.Error())
+		return nil, err
+	}
+
+	if err := db.Init(&AutoVoteLog{}); err != nil {
+		log.Errorf("Error initializing politeia auto-vote logs database: %s", err.Error())
+		return nil, err
+	}
+
+	if err := db.Init(&ProposalSearchTerm{}); err != nil {
+		log.Errorf("Error initializing politeia search index database: %s", err.Error())
+		return nil, err
+	}
+
+	if err := db.Init(&VotedProposal{}); err != nil {
+		log.Errorf("Error initializing politeia voted proposals database: %s", err.Error())
+		return nil, err
+	}
+
+	if err := db.Init(&VoteReceipt{}); err != nil {
+		log.Errorf("Error initializing politeia vote receipts database: %s", err.Error())
+		return nil, err
+	}
+
+	p := &Politeia{
+		endpoints: []utils.Endpoint{{URL: host}},
+		db:        db,
 
 		mu:                      &sync.RWMutex{},
+		clientMu:                &sync.Mutex{},
 		notificationListenersMu: &sync.RWMutex{},
 
 		notificationListeners: make(map[string]ProposalNotificationListener),
-	}, nil
+	}
+
+	if err := p.upgradeSearchIndex(); err != nil {
+		log.Errorf("Error upgrading politeia search index: %s", err.Error())
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// SetEndpoints sets the politeia servers in the order they are tried. The
+// next request connects to the first enabled server that responds.
+func (p *Politeia) SetEndpoints(endpoints []utils.Endpoint) {
+	p.clientMu.Lock()
+	defer p.clientMu.Unlock()
+	p.endpoints = endpoints
+	p.client = nil
 }
 
 func (p *Politeia) saveLastSyncedTimestamp(lastSyncedTimestamp int64) {
@@ -82,6 +146,13 @@ func (p *Politeia) saveOrOverwiteProposal(proposal *Proposal) error {
 	}
 
 	if oldProposal.Token != "" {
+		// keep the local state of the proposal
+		proposal.Voted = oldProposal.Voted
+		proposal.Bookmarked = oldProposal.Bookmarked
+		if proposal.Amount == 0 {
+			proposal.Amount = oldProposal.Amount
+		}
+
 		// delete old record before saving new (if it exists)
 		p.db.DeleteStruct(oldProposal)
 	}
@@ -248,9 +319,230 @@ func (p *Politeia) ClearSavedProposals() error {
 		return translateError(err)
 	}
 
+	err = p.db.Drop(&Comment{})
+	if err != nil && err != storm.ErrNotFound {
+		return translateError(err)
+	}
+
+	if err = p.db.Init(&Comment{}); err != nil {
+		return err
+	}
+
+	err = p.db.Drop(&ProposalVersion{})
+	if err != nil && err != storm.ErrNotFound {
+		return translateError(err)
+	}
+
+	if err = p.db.Init(&ProposalVersion{}); err != nil {
+		return err
+	}
+
+	err = p.db.Drop(&ProposalSearchTerm{})
+	if err != nil && err != storm.ErrNotFound {
+		return translateError(err)
+	}
+
+	if err = p.db.Init(&ProposalSearchTerm{}); err != nil {
+		return err
+	}
+
 	return p.db.Init(&Proposal{})
 }
 
+// saveProposalComments merges the comments fetched from the server into
+// the cached comments of the proposal. Only new or modified comments are
+// written and cached comments no longer returned by the server are removed.
+func (p *Politeia) saveProposalComments(token string, comments []Comment) error {
+	var cached []Comment
+	err := p.db.Find("ProposalToken", token, &cached)
+	if err != nil && err != storm.ErrNotFound {
+		return fmt.Errorf("error fetching cached comments: %s", err.Error())
+	}
+
+	cachedByID := make(map[uint32]Comment, len(cached))
+	for _, comment := range cached {
+		cachedByID[comment.CommentID] = comment
+	}
+
+	for i := range comments {
+		comment := comments[i]
+		oldComment, ok := cachedByID[comment.CommentID]
+		delete(cachedByID, comment.CommentID)
+		if ok {
+			comment.ID = oldComment.ID
+			if comment == oldComment {
+				continue
+			}
+		}
+
+		if err = p.db.Save(&comment); err != nil {
+			return fmt.Errorf("error saving comment: %s", err.Error())
+		}
+	}
+
+	for _, comment := range cachedByID {
+		comment := comment
+		if err = p.db.DeleteStruct(&comment); err != nil {
+			return fmt.Errorf("error deleting comment: %s", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// GetProposalCommentsRaw returns the cached comments of the proposal
+// specified by it's censorship record token, oldest first.
+func (p *Politeia) GetProposalCommentsRaw(censorshipToken string) ([]Comment, error) {
+	var comments []Comment
+	err := p.db.Select(q.Eq("ProposalToken", censorshipToken)).OrderBy("CommentID").Find(&comments)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, fmt.Errorf("error fetching comments: %s", err.Error())
+	}
+
+	return comments, nil
+}
+
+// GetProposalComments returns the result of GetProposalCommentsRaw as a JSON string
+func (p *Politeia) GetProposalComments(censorshipToken string) (string, error) {
+	return p.marshalResult(p.GetProposalCommentsRaw(censorshipToken))
+}
+
+// GetProposalCommentThreads returns the cached comments of the proposal
+// arranged as discussion threads. Top level comments and replies are
+// ordered oldest first.
+func (p *Politeia) GetProposalCommentThreads(censorshipToken string) ([]*ProposalCommentThread, error) {
+	comments, err := p.GetProposalCommentsRaw(censorshipToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return commentThreads(comments), nil
+}
+
+func commentThreads(comments []Comment) []*ProposalCommentThread {
+	threadByID := make(map[uint32]*ProposalCommentThread, len(comments))
+	for i := range comments {
+		threadByID[comments[i].CommentID] = &ProposalCommentThread{Comment: &comments[i]}
+	}
+
+	threads := make([]*ProposalCommentThread, 0)
+	for i := range comments {
+		thread := threadByID[comments[i].CommentID]
+		parent, ok := threadByID[comments[i].ParentID]
+		if comments[i].ParentID == 0 || !ok {
+			// Replies whose parent is missing are shown at the top level.
+			threads = append(threads, thread)
+			continue
+		}
+		parent.Replies = append(parent.Replies, thread)
+	}
+
+	return threads
+}
+
+// getProposalVersion returns the saved snapshot of the proposal at the
+// specified version or nil if the version was never saved.
+func (p *Politeia) getProposalVersion(token, version string) (*ProposalVersion, error) {
+	var proposalVersion ProposalVersion
+	err := p.db.Select(q.Eq("ProposalToken", token), q.Eq("Version", version)).First(&proposalVersion)
+	if err == storm.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching proposal version: %s", err.Error())
+	}
+
+	return &proposalVersion, nil
+}
+
+// saveProposalVersion saves the snapshot of a proposal version, replacing
+// the existing snapshot of the same version if any.
+func (p *Politeia) saveProposalVersion(proposalVersion *ProposalVersion) error {
+	oldVersion, err := p.getProposalVersion(proposalVersion.ProposalToken, proposalVersion.Version)
+	if err != nil {
+		return err
+	}
+
+	if oldVersion != nil {
+		proposalVersion.ID = oldVersion.ID
+		return p.db.Update(proposalVersion)
+	}
+
+	return p.db.Save(proposalVersion)
+}
+
+// GetProposalVersionsRaw returns the saved versions of the proposal specified
+// by it's censorship record token, oldest first.
+func (p *Politeia) GetProposalVersionsRaw(censorshipToken string) ([]ProposalVersion, error) {
+	var versions []ProposalVersion
+	err := p.db.Find("ProposalToken", censorshipToken, &versions)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, fmt.Errorf("error fetching proposal versions: %s", err.Error())
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versionNumber(versions[i].Version) < versionNumber(versions[j].Version)
+	})
+
+	return versions, nil
+}
+
+// GetProposalVersions returns the result of GetProposalVersionsRaw as a JSON string
+func (p *Politeia) GetProposalVersions(censorshipToken string) (string, error) {
+	return p.marshalResult(p.GetProposalVersionsRaw(censorshipToken))
+}
+
+// markProposalVoted records that tickets of the wallet voted on the
+// proposal.
+func (p *Politeia) markProposalVoted(token string, walletID int) {
+	var voted []VotedProposal
+	err := p.db.Find("ProposalToken", token, &voted)
+	if err != nil && err != storm.ErrNotFound {
+		log.Errorf("error reading voted proposal %s: %v", token, err)
+		return
+	}
+	for _, v := range voted {
+		if v.WalletID == walletID {
+			return
+		}
+	}
+
+	err = p.db.Save(&VotedProposal{ProposalToken: token, WalletID: walletID})
+	if err == nil {
+		var proposal *Proposal
+		if proposal, err = p.GetProposalRaw(token); err == nil {
+			err = p.db.UpdateField(&Proposal{ID: proposal.ID}, "Voted", true)
+		}
+	}
+	if err != nil {
+		log.Errorf("error saving voted proposal %s: %v", token, err)
+	}
+}
+
+// SetProposalBookmarked adds or removes the proposal specified by it's
+// censorship record token from the bookmarked proposals.
+func (p *Politeia) SetProposalBookmarked(censorshipToken string, bookmarked bool) error {
+	proposal, err := p.GetProposalRaw(censorshipToken)
+	if err != nil {
+		return err
+	}
+
+	// UpdateField is used since Update skips zero values.
+	return p.db.UpdateField(&Proposal{ID: proposal.ID}, "Bookmarked", bookmarked)
+}
+
+// isProposalFollowed returns true if updates to the proposal should be
+// notified to the user.
+func (p *Politeia) isProposalFollowed(token string) bool {
+	proposal, err := p.GetProposalRaw(token)
+	return err == nil && (proposal.Voted || proposal.Bookmarked)
+}
+
+func versionNumber(version string) uint32 {
+	n, _ := strconv.ParseUint(version, 10, 32)
+	return uint32(n)
+}
+
 func (p *Politeia) marshalResult(result interface{}, err error) (string, error) {
 	if err != nil {
 		return "", translateError(err)
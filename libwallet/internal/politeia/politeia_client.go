@@ -2,54 +2,76 @@ package politeia
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/crypto-power/cryptopower/libwallet/utils"
+	cmv1 "github.com/decred/politeia/politeiawww/api/comments/v1"
+	rcv1 "github.com/decred/politeia/politeiawww/api/records/v1"
 	tkv1 "github.com/decred/politeia/politeiawww/api/ticketvote/v1"
 	www "github.com/decred/politeia/politeiawww/api/www/v1"
 	"github.com/decred/politeia/politeiawww/client"
 )
 
 type politeiaClient struct {
-	host    string
+	host    utils.Endpoint
 	version *www.VersionReply
 	policy  *www.PolicyReply
 	cookies []*http.Cookie
 }
 
 const (
-	ticketVoteAPI       = tkv1.APIRoute
-	proposalDetailsPath = "/proposals/"
+	ticketVoteAPI = tkv1.APIRoute
+	commentsAPI   = cmv1.APIRoute
+	recordsAPI    = rcv1.APIRoute
 )
 
 var apiPath = www.PoliteiaWWWAPIRoute
 
-func (p *Politeia) getClient() error {
-	if p.client == nil {
-		p.client = &politeiaClient{host: p.host}
-		if err := p.client.serverVersion(); err != nil {
-			return err
-		}
+// getClient returns the client of the first enabled politeia server that
+// returns its version and policy. The client is kept until the endpoints are
+// changed or a request to its server fails. Callers must use the returned
+// client for the whole operation, p.client may be replaced meanwhile.
+func (p *Politeia) getClient() (*politeiaClient, error) {
+	p.clientMu.Lock()
+	defer p.clientMu.Unlock()
 
-		if err := p.client.serverPolicy(); err != nil {
-			return err
+	if p.client != nil && !utils.EndpointFailed(p.client.host.URL) {
+		return p.client, nil
+	}
+	p.client = nil
+
+	err := errors.New(utils.ErrUnavailable)
+	for _, endpoint := range utils.OrderEndpoints(p.endpoints) {
+		client := &politeiaClient{host: endpoint}
+		if err = client.serverVersion(); err != nil {
+			log.Errorf("Error connecting to politeia server %s: %v", endpoint.URL, err)
+			continue
+		}
+		if err = client.serverPolicy(); err != nil {
+			log.Errorf("Error connecting to politeia server %s: %v", endpoint.URL, err)
+			continue
 		}
+
+		p.client = client
+		p.pinServerPubKey(client.version.PubKey)
+		return client, nil
 	}
-	return nil
+	return nil, err
 }
 
 func (c *politeiaClient) makeRequest(method, apiRoute, path string, body interface{}, dest interface{}) error {
 	req := &utils.ReqConfig{
 		Payload:   body,
 		Method:    method,
-		HTTPURL:   c.host + apiRoute + path,
+		HTTPURL:   apiRoute + path,
 		IsRetByte: true,
 		Cookies:   c.cookies,
 	}
 
 	respBytes := []byte{}
-	_, err := utils.HTTPRequest(req, &respBytes)
+	_, err := utils.EndpointRequest([]utils.Endpoint{c.host}, req, &respBytes)
 	if err != nil {
 		return err
 	}
@@ -117,16 +139,26 @@ func (c *politeiaClient) batchProposals(tokens []string) ([]Proposal, error) {
 	return proposals, nil
 }
 
-func (c *politeiaClient) proposalDetails(token string) (*www.ProposalDetailsReply, error) {
-	route := proposalDetailsPath + token
+// recordDetails returns the record of the proposal at the specified version.
+// The latest version is returned if version is 0.
+func (c *politeiaClient) recordDetails(token string, version uint32) (*rcv1.Record, error) {
+	requestBody, err := json.Marshal(&rcv1.Details{Token: token, Version: version})
+	if err != nil {
+		return nil, err
+	}
+
+	var detailsReply rcv1.DetailsReply
+	err = c.makeRequest(http.MethodPost, recordsAPI, rcv1.RouteDetails, requestBody, &detailsReply)
+	if err != nil {
+		return nil, err
+	}
 
-	var proposalDetailsReply www.ProposalDetailsReply
-	err := c.makeRequest(http.MethodGet, apiPath, route, nil, &proposalDetailsReply)
+	err = client.RecordVerify(detailsReply.Record, c.version.PubKey)
 	if err != nil {
 		return nil, err
 	}
 
-	return &proposalDetailsReply, nil
+	return &detailsReply.Record, nil
 }
 
 func (c *politeiaClient) tokenInventory() (*www.TokenInventoryReply, error) {
@@ -184,6 +216,34 @@ func (c *politeiaClient) voteResults(token string) (*tkv1.ResultsReply, error) {
 	return &resultReply, nil
 }
 
+func (c *politeiaClient) comments(token string) ([]cmv1.Comment, error) {
+	requestBody, err := json.Marshal(&cmv1.Comments{Token: token})
+	if err != nil {
+		return nil, err
+	}
+
+	var commentsReply cmv1.CommentsReply
+	err = c.makeRequest(http.MethodPost, commentsAPI, cmv1.RouteComments, requestBody, &commentsReply)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the comments signatures and server receipts. Edited comments
+	// are signed over the comment ID in addition to the original fields.
+	for _, comment := range commentsReply.Comments {
+		if comment.Version > 1 && !comment.Deleted {
+			err = client.CommentEditVerify(comment, c.version.PubKey)
+		} else {
+			err = client.CommentVerify(comment, c.version.PubKey)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return commentsReply.Comments, nil
+}
+
 func (c *politeiaClient) batchVoteSummary(tokens []string) (map[string]www.VoteSummary, error) {
 	b, err := json.Marshal(&www.BatchVoteSummary{Tokens: tokens})
 	if err != nil {
@@ -200,22 +260,19 @@ func (c *politeiaClient) batchVoteSummary(tokens []string) (map[string]www.VoteS
 	return batchVoteSummaryReply.Summaries, nil
 }
 
-func (c *politeiaClient) sendVotes(votes []tkv1.CastVote) error {
+// sendVotes submits the ballot and returns the server receipt of each vote.
+// Receipts of votes rejected by the server carry an error context.
+func (c *politeiaClient) sendVotes(votes []tkv1.CastVote) ([]tkv1.CastVoteReply, error) {
 	b, err := json.Marshal(&tkv1.CastBallot{Votes: votes})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var reply tkv1.CastBallotReply
 	err = c.makeRequest(http.MethodPost, ticketVoteAPI, tkv1.RouteCastBallot, b, &reply)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	for _, receipt := range reply.Receipts {
-		if receipt.ErrorContext != "" {
-			return fmt.Errorf(receipt.ErrorContext)
-		}
-	}
-	return nil
+	return reply.Receipts, nil
 }
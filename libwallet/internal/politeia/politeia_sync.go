@@ -14,8 +14,11 @@ import (
 	"decred.org/dcrwallet/v3/wallet"
 	"decred.org/dcrwallet/v3/wallet/udb"
 	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
 	"github.com/decred/dcrd/chaincfg/chainhash"
 	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	piv1 "github.com/decred/politeia/politeiawww/api/pi/v1"
+	rcv1 "github.com/decred/politeia/politeiawww/api/records/v1"
 	tkv1 "github.com/decred/politeia/politeiawww/api/ticketvote/v1"
 	www "github.com/decred/politeia/politeiawww/api/www/v1"
 )
@@ -50,7 +53,7 @@ func (p *Politeia) Sync(ctx context.Context) error {
 			return p.ctx.Err()
 		}
 
-		err := p.getClient()
+		client, err := p.getClient()
 		if err != nil {
 			log.Errorf("Error fetching for politeia server policy: %v", err)
 			time.Sleep(retryInterval * time.Second)
@@ -59,7 +62,9 @@ func (p *Politeia) Sync(ctx context.Context) error {
 
 		log.Info("Politeia sync: checking for updates")
 
-		err = p.checkForUpdates()
+		// A failed request marks its server as failed, the next attempt
+		// reconnects and fails over to the next server if it is down.
+		err = p.checkForUpdates(client)
 		if err != nil {
 			log.Errorf("Error checking for politeia updates: %v", err)
 			time.Sleep(retryInterval * time.Second)
@@ -69,6 +74,12 @@ func (p *Politeia) Sync(ctx context.Context) error {
 		log.Info("Politeia sync: update complete")
 		p.saveLastSyncedTimestamp(time.Now().Unix())
 		p.publishSynced()
+
+		// The proposals are listed before their descriptions are indexed for
+		// search, which takes longer.
+		if err = p.indexProposals(client); err != nil {
+			log.Errorf("Error indexing politeia proposals: %v", err)
+		}
 		return nil
 	}
 }
@@ -93,19 +104,14 @@ func (p *Politeia) StopSync() {
 	log.Info("Politeia sync: stopped")
 }
 
-func (p *Politeia) checkForUpdates() error {
+func (p *Politeia) checkForUpdates(client *politeiaClient) error {
 	// if server's policy is not set at this point the politeia server is not accessible
-	p.mu.RLock()
-	clientPolicy := p.client.policy
-	p.mu.RUnlock()
-	if clientPolicy == nil {
+	if client.policy == nil {
 		return errors.New("politeia server policy not set")
 	}
 
 	offset := 0
-	p.mu.RLock()
-	limit := int32(p.client.policy.ProposalListPageSize)
-	p.mu.RUnlock()
+	limit := int32(client.policy.ProposalListPageSize)
 
 	for {
 		// Check if politeia has been shutdown and exit if true.
@@ -124,7 +130,7 @@ func (p *Politeia) checkForUpdates() error {
 
 		offset += len(proposals)
 
-		err = p.handleProposalsUpdate(proposals)
+		err = p.handleProposalsUpdate(client, proposals)
 		if err != nil {
 			return err
 		}
@@ -136,7 +142,7 @@ func (p *Politeia) checkForUpdates() error {
 		return err
 	}
 
-	err = p.handleNewProposals(allProposals)
+	err = p.handleNewProposals(client, allProposals)
 	if err != nil {
 		return err
 	}
@@ -144,23 +150,21 @@ func (p *Politeia) checkForUpdates() error {
 	return nil
 }
 
-func (p *Politeia) handleNewProposals(proposals []Proposal) error {
+func (p *Politeia) handleNewProposals(client *politeiaClient, proposals []Proposal) error {
 	loadedTokens := make([]string, len(proposals))
 	for i := range proposals {
 		loadedTokens[i] = proposals[i].Token
 	}
 
-	p.mu.RLock()
-	tokenInventory, err := p.client.tokenInventory()
-	p.mu.RUnlock()
+	tokenInventory, err := client.tokenInventory()
 	if err != nil {
 		return err
 	}
 
-	return p.fetchAllUnfetchedProposals(tokenInventory, loadedTokens)
+	return p.fetchAllUnfetchedProposals(client, tokenInventory, loadedTokens)
 }
 
-func (p *Politeia) handleProposalsUpdate(proposals []Proposal) error {
+func (p *Politeia) handleProposalsUpdate(client *politeiaClient, proposals []Proposal) error {
 	tokens := make([]string, len(proposals))
 	for i := range proposals {
 		tokens[i] = proposals[i].Token
@@ -169,12 +173,12 @@ func (p *Politeia) handleProposalsUpdate(proposals []Proposal) error {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
-	batchProposals, err := p.client.batchProposals(tokens)
+	batchProposals, err := client.batchProposals(tokens)
 	if err != nil {
 		return err
 	}
 
-	batchVotesSummaries, err := p.client.batchVoteSummary(tokens)
+	batchVotesSummaries, err := client.batchVoteSummary(tokens)
 	if err != nil {
 		return err
 	}
@@ -186,6 +190,7 @@ func (p *Politeia) handleProposalsUpdate(proposals []Proposal) error {
 			batchProposals[i].PassPercentage = int32(voteSummary.PassPercentage)
 			batchProposals[i].EligibleTickets = int32(voteSummary.EligibleTickets)
 			batchProposals[i].QuorumPercentage = int32(voteSummary.QuorumPercentage)
+			batchProposals[i].EndBlockHeight = int32(voteSummary.EndHeight)
 			batchProposals[i].YesVotes, batchProposals[i].NoVotes = getVotesCount(voteSummary.Results)
 		}
 
@@ -194,7 +199,7 @@ func (p *Politeia) handleProposalsUpdate(proposals []Proposal) error {
 
 				// proposal category
 				batchProposals[i].Category = proposals[k].Category
-				err := p.updateProposalDetails(proposals[k], batchProposals[i])
+				err := p.updateProposalDetails(client, proposals[k], batchProposals[i])
 				if err != nil {
 					return err
 				}
@@ -205,8 +210,11 @@ func (p *Politeia) handleProposalsUpdate(proposals []Proposal) error {
 	return nil
 }
 
-func (p *Politeia) updateProposalDetails(oldProposal, updatedProposal Proposal) error {
+func (p *Politeia) updateProposalDetails(client *politeiaClient, oldProposal, updatedProposal Proposal) error {
 	updatedProposal.ID = oldProposal.ID
+	updatedProposal.Voted = oldProposal.Voted
+	updatedProposal.Bookmarked = oldProposal.Bookmarked
+	updatedProposal.Amount = oldProposal.Amount
 
 	if reflect.DeepEqual(oldProposal, updatedProposal) {
 		return nil
@@ -238,6 +246,18 @@ func (p *Politeia) updateProposalDetails(oldProposal, updatedProposal Proposal)
 		return fmt.Errorf("error saving updated proposal: %s", err.Error())
 	}
 
+	if oldProposal.Version != updatedProposal.Version {
+		p.handleNewProposalVersion(client, oldProposal, updatedProposal)
+	}
+
+	// Refresh the cached discussion only for proposals whose comments were
+	// already fetched, the rest are fetched when the proposal is opened.
+	if oldProposal.NumComments != updatedProposal.NumComments && p.hasCachedComments(updatedProposal.Token) {
+		if err = p.fetchProposalComments(client, updatedProposal.Token); err != nil {
+			log.Errorf("error refreshing comments of proposal %s: %v", updatedProposal.Token, err)
+		}
+	}
+
 	if callback != nil {
 		callback(&updatedProposal)
 	}
@@ -245,7 +265,38 @@ func (p *Politeia) updateProposalDetails(oldProposal, updatedProposal Proposal)
 	return nil
 }
 
-func (p *Politeia) fetchAllUnfetchedProposals(tokenInventory *www.TokenInventoryReply, savedTokens []string) error {
+// handleNewProposalVersion keeps the metadata of the new version of the
+// proposal. The previous and new versions are fetched in full for proposals
+// the user follows so they can be compared offline, and the user is notified.
+func (p *Politeia) handleNewProposalVersion(client *politeiaClient, oldProposal, updatedProposal Proposal) {
+	newVersion, err := p.getProposalVersion(updatedProposal.Token, updatedProposal.Version)
+	if err == nil && newVersion == nil {
+		err = p.saveProposalVersion(&ProposalVersion{
+			ProposalToken: updatedProposal.Token,
+			Version:       updatedProposal.Version,
+			Name:          updatedProposal.Name,
+			Username:      updatedProposal.Username,
+			Timestamp:     updatedProposal.Timestamp,
+		})
+	}
+	if err != nil {
+		log.Errorf("error saving proposal version: %v", err)
+	}
+
+	if oldProposal.Version == "" || !p.isProposalFollowed(updatedProposal.Token) {
+		return
+	}
+
+	for _, version := range []string{oldProposal.Version, updatedProposal.Version} {
+		if _, err := p.fetchProposalVersion(client, updatedProposal.Token, version); err != nil {
+			log.Errorf("error fetching version %s of proposal %s: %v", version, updatedProposal.Token, err)
+		}
+	}
+
+	p.publishVersionUpdated(&updatedProposal)
+}
+
+func (p *Politeia) fetchAllUnfetchedProposals(client *politeiaClient, tokenInventory *www.TokenInventoryReply, savedTokens []string) error {
 	broadcastNotification := len(savedTokens) > 0
 
 	approvedTokens, savedTokens := getUniqueTokens(tokenInventory.Approved, savedTokens)
@@ -280,7 +331,7 @@ func (p *Politeia) fetchAllUnfetchedProposals(tokenInventory *www.TokenInventory
 	}
 
 	for category, tokens := range inventoryMap {
-		err := p.fetchBatchProposals(category, tokens, broadcastNotification)
+		err := p.fetchBatchProposals(client, category, tokens, broadcastNotification)
 		if err != nil {
 			return err
 		}
@@ -289,30 +340,26 @@ func (p *Politeia) fetchAllUnfetchedProposals(tokenInventory *www.TokenInventory
 	return nil
 }
 
-func (p *Politeia) fetchBatchProposals(category int32, tokens []string, broadcastNotification bool) error {
+func (p *Politeia) fetchBatchProposals(client *politeiaClient, category int32, tokens []string, broadcastNotification bool) error {
 	for {
 		if len(tokens) == 0 {
 			break
 		}
 
-		p.mu.RLock()
-
 		// Check if politeia has been shutdown and exit if true.
 		if p.ctx.Err() != nil {
 			return p.ctx.Err()
 		}
 
-		limit := int(p.client.policy.ProposalListPageSize)
+		limit := int(client.policy.ProposalListPageSize)
 		if len(tokens) <= limit {
 			limit = len(tokens)
 		}
 
-		p.mu.RUnlock()
-
 		var tokenBatch []string
 		tokenBatch, tokens = tokens[:limit], tokens[limit:]
 
-		proposals, err := p.client.batchProposals(tokenBatch)
+		proposals, err := client.batchProposals(tokenBatch)
 		if err != nil {
 			return err
 		}
@@ -322,7 +369,7 @@ func (p *Politeia) fetchBatchProposals(category int32, tokens []string, broadcas
 			return p.ctx.Err()
 		}
 
-		votesSummaries, err := p.client.batchVoteSummary(tokenBatch)
+		votesSummaries, err := client.batchVoteSummary(tokenBatch)
 		if err != nil {
 			return err
 		}
@@ -340,6 +387,7 @@ func (p *Politeia) fetchBatchProposals(category int32, tokens []string, broadcas
 				proposals[i].PassPercentage = int32(voteSummary.PassPercentage)
 				proposals[i].EligibleTickets = int32(voteSummary.EligibleTickets)
 				proposals[i].QuorumPercentage = int32(voteSummary.QuorumPercentage)
+				proposals[i].EndBlockHeight = int32(voteSummary.EndHeight)
 				proposals[i].YesVotes, proposals[i].NoVotes = getVotesCount(voteSummary.Results)
 			}
 
@@ -375,41 +423,233 @@ func (p *Politeia) FetchProposalDescription(token string) (string, error) {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
-	err = p.getClient()
+	client, err := p.getClient()
 	if err != nil {
 		return "", err
 	}
 
-	proposalDetailsReply, err := p.client.proposalDetails(token)
+	proposalVersion, err := p.fetchProposalVersion(client, token, proposal.Version)
 	if err != nil {
 		return "", err
 	}
 
-	for _, file := range proposalDetailsReply.Proposal.Files {
-		if file.Name == "index.md" {
+	if proposalVersion.IndexFile == "" {
+		return "", errors.New(ErrNotExist)
+	}
+
+	err = p.saveProposalDescription(proposal, proposalVersion)
+	if err != nil {
+		log.Errorf("error saving new proposal: %s", err.Error())
+	}
+
+	return proposal.IndexFile, nil
+}
+
+// FetchProposalVersion returns the snapshot of the proposal at the specified
+// version. The version's record is fetched from the server and saved if it
+// was not fetched before.
+func (p *Politeia) FetchProposalVersion(token, version string) (*ProposalVersion, error) {
+	// Check if politeia has been shutdown and exit if true.
+	if p.ctx != nil && p.ctx.Err() != nil {
+		return nil, p.ctx.Err()
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	client, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.fetchProposalVersion(client, token, version)
+}
+
+// DiffProposalVersions returns the changes made to the proposal's metadata
+// and description between the from and to versions.
+func (p *Politeia) DiffProposalVersions(token, fromVersion, toVersion string) (*ProposalVersionDiff, error) {
+	from, err := p.FetchProposalVersion(token, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := p.FetchProposalVersion(token, toVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProposalVersionDiff{
+		From:        from,
+		To:          to,
+		Fields:      proposalFieldChanges(from, to),
+		Description: diffLines(from.IndexFile, to.IndexFile),
+	}, nil
+}
+
+func (p *Politeia) fetchProposalVersion(client *politeiaClient, token, version string) (*ProposalVersion, error) {
+	proposalVersion, err := p.getProposalVersion(token, version)
+	if err != nil {
+		return nil, err
+	}
+
+	// Snapshots without the index file only hold the metadata returned
+	// during sync, fetch the complete record.
+	if proposalVersion != nil && proposalVersion.IndexFile != "" {
+		return proposalVersion, nil
+	}
+
+	versionNum, err := strconv.ParseUint(version, 10, 32)
+	if err != nil {
+		return nil, errors.New(ErrInvalid)
+	}
+
+	record, err := client.recordDetails(token, uint32(versionNum))
+	if err != nil {
+		return nil, err
+	}
+
+	proposalVersion, err = proposalVersionFromRecord(token, record)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.saveProposalVersion(proposalVersion)
+	if err != nil {
+		log.Errorf("error saving proposal version: %s", err.Error())
+	}
+
+	return proposalVersion, nil
+}
+
+func proposalVersionFromRecord(token string, record *rcv1.Record) (*ProposalVersion, error) {
+	proposalVersion := &ProposalVersion{
+		ProposalToken: token,
+		Version:       strconv.FormatUint(uint64(record.Version), 10),
+		Username:      record.Username,
+		Timestamp:     record.Timestamp,
+	}
+
+	for _, file := range record.Files {
+		switch file.Name {
+		case piv1.FileNameIndexFile:
 			b, err := base64.StdEncoding.DecodeString(file.Payload)
 			if err != nil {
-				return "", err
+				return nil, err
 			}
+			proposalVersion.IndexFile = string(b)
 
-			// save file to db
-			proposal.IndexFile = string(b)
-			// index file version will be used to determine if the
-			// saved file is out of date when compared to version.
-			proposal.IndexFileVersion = proposal.Version
-			err = p.saveOrOverwiteProposal(proposal)
+		case piv1.FileNameProposalMetadata:
+			b, err := base64.StdEncoding.DecodeString(file.Payload)
 			if err != nil {
-				log.Errorf("error saving new proposal: %s", err.Error())
+				return nil, err
 			}
 
-			return proposal.IndexFile, nil
+			var metadata piv1.ProposalMetadata
+			if err = json.Unmarshal(b, &metadata); err != nil {
+				return nil, err
+			}
+
+			proposalVersion.Name = metadata.Name
+			proposalVersion.Amount = metadata.Amount
+			proposalVersion.StartDate = metadata.StartDate
+			proposalVersion.EndDate = metadata.EndDate
+			proposalVersion.Domain = metadata.Domain
+		}
+	}
+
+	return proposalVersion, nil
+}
+
+func proposalFieldChanges(from, to *ProposalVersion) []ProposalFieldChange {
+	formatAmount := func(cents uint64) string {
+		return fmt.Sprintf("$%.2f", float64(cents)/100)
+	}
+	formatDate := func(timestamp int64) string {
+		if timestamp == 0 {
+			return ""
+		}
+		return time.Unix(timestamp, 0).UTC().Format("2006-01-02")
+	}
+
+	fields := []ProposalFieldChange{
+		{Field: ProposalFieldName, From: from.Name, To: to.Name},
+		{Field: ProposalFieldAmount, From: formatAmount(from.Amount), To: formatAmount(to.Amount)},
+		{Field: ProposalFieldStartDate, From: formatDate(from.StartDate), To: formatDate(to.StartDate)},
+		{Field: ProposalFieldEndDate, From: formatDate(from.EndDate), To: formatDate(to.EndDate)},
+		{Field: ProposalFieldDomain, From: from.Domain, To: to.Domain},
+	}
+
+	changes := make([]ProposalFieldChange, 0, len(fields))
+	for _, field := range fields {
+		if field.From != field.To {
+			changes = append(changes, field)
+		}
+	}
+
+	return changes
+}
+
+// FetchProposalComments fetches the discussion of the proposal specified by
+// it's censorship record token from the server, updates the local cache and
+// returns the comments arranged as threads.
+func (p *Politeia) FetchProposalComments(token string) ([]*ProposalCommentThread, error) {
+	// Check if politeia has been shutdown and exit if true.
+	if p.ctx != nil && p.ctx.Err() != nil {
+		return nil, p.ctx.Err()
+	}
+
+	p.mu.RLock()
+	client, err := p.getClient()
+	if err == nil {
+		err = p.fetchProposalComments(client, token)
+	}
+	p.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.GetProposalCommentThreads(token)
+}
+
+func (p *Politeia) fetchProposalComments(client *politeiaClient, token string) error {
+	serverComments, err := client.comments(token)
+	if err != nil {
+		return err
+	}
+
+	comments := make([]Comment, len(serverComments))
+	for i, c := range serverComments {
+		comments[i] = Comment{
+			ProposalToken: token,
+			CommentID:     c.CommentID,
+			ParentID:      c.ParentID,
+			UserID:        c.UserID,
+			Username:      c.Username,
+			Comment:       c.Comment,
+			Version:       c.Version,
+			CreatedAt:     c.CreatedAt,
+			Timestamp:     c.Timestamp,
+			Upvotes:       c.Upvotes,
+			Downvotes:     c.Downvotes,
+			Deleted:       c.Deleted,
+			Reason:        c.Reason,
 		}
 	}
 
-	return "", errors.New(ErrNotExist)
+	return p.saveProposalComments(token, comments)
 }
 
-func (p *Politeia) ProposalVoteDetailsRaw(ctx context.Context, wallet *wallet.Wallet, token string) (*ProposalVoteDetails, error) {
+// hasCachedComments returns true if the discussion of the proposal has been
+// fetched before.
+func (p *Politeia) hasCachedComments(token string) bool {
+	count, err := p.db.Select(q.Eq("ProposalToken", token)).Count(&Comment{})
+	return err == nil && count > 0
+}
+
+// ProposalVoteDetailsRaw returns the wallet's tickets eligible to vote on the
+// proposal and their votes. The proposal is recorded as voted on by the
+// wallet if any of its tickets voted, from this app or any other.
+func (p *Politeia) ProposalVoteDetailsRaw(ctx context.Context, wallet *wallet.Wallet, walletID int, token string) (*ProposalVoteDetails, error) {
 	// Check if politeia has been shutdown and exit if true.
 	if p.ctx.Err() != nil {
 		return nil, p.ctx.Err()
@@ -418,17 +658,30 @@ func (p *Politeia) ProposalVoteDetailsRaw(ctx context.Context, wallet *wallet.Wa
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
-	err := p.getClient()
+	client, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	voteDetails, err := p.proposalVoteDetails(ctx, client, wallet, token)
 	if err != nil {
 		return nil, err
 	}
 
-	detailsReply, err := p.client.voteDetails(token)
+	if len(voteDetails.Votes) > 0 {
+		p.markProposalVoted(token, walletID)
+	}
+
+	return voteDetails, nil
+}
+
+func (p *Politeia) proposalVoteDetails(ctx context.Context, client *politeiaClient, wallet *wallet.Wallet, token string) (*ProposalVoteDetails, error) {
+	detailsReply, err := client.voteDetails(token)
 	if err != nil {
 		return nil, err
 	}
 
-	votesResults, err := p.client.voteResults(token)
+	votesResults, err := client.voteResults(token)
 	if err != nil {
 		return nil, err
 	}
@@ -507,8 +760,8 @@ func (p *Politeia) ProposalVoteDetailsRaw(ctx context.Context, wallet *wallet.Wa
 	}, nil
 }
 
-func (p *Politeia) ProposalVoteDetails(ctx context.Context, wallet *wallet.Wallet, token string) (string, error) {
-	voteDetails, err := p.ProposalVoteDetailsRaw(ctx, wallet, token)
+func (p *Politeia) ProposalVoteDetails(ctx context.Context, wallet *wallet.Wallet, walletID int, token string) (string, error) {
+	voteDetails, err := p.ProposalVoteDetailsRaw(ctx, wallet, walletID, token)
 	if err != nil {
 		return "", err
 	}
@@ -517,16 +770,11 @@ func (p *Politeia) ProposalVoteDetails(ctx context.Context, wallet *wallet.Walle
 	return string(result), nil
 }
 
-func (p *Politeia) CastVotes(ctx context.Context, wallet *wallet.Wallet, eligibleTickets []*ProposalVote, token, passphrase string) error {
+func (p *Politeia) CastVotes(ctx context.Context, wallet *wallet.Wallet, walletID int, eligibleTickets []*ProposalVote, token, passphrase string) error {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
-	err := p.getClient()
-	if err != nil {
-		return err
-	}
-
-	detailsReply, err := p.client.voteDetails(token)
+	client, err := p.getClient()
 	if err != nil {
 		return err
 	}
@@ -537,6 +785,17 @@ func (p *Politeia) CastVotes(ctx context.Context, wallet *wallet.Wallet, eligibl
 	}
 	defer wallet.Lock()
 
+	return p.castVotes(ctx, client, wallet, walletID, eligibleTickets, token)
+}
+
+// castVotes signs and submits the votes using the tickets of the wallet. The
+// wallet must be unlocked.
+func (p *Politeia) castVotes(ctx context.Context, client *politeiaClient, wallet *wallet.Wallet, walletID int, eligibleTickets []*ProposalVote, token string) error {
+	detailsReply, err := client.voteDetails(token)
+	if err != nil {
+		return err
+	}
+
 	votes := make([]tkv1.CastVote, 0)
 	for _, eligibleTicket := range eligibleTickets {
 		var voteBitHex string
@@ -572,7 +831,38 @@ func (p *Politeia) CastVotes(ctx context.Context, wallet *wallet.Wallet, eligibl
 		votes = append(votes, singleVote)
 	}
 
-	return p.client.sendVotes(votes)
+	receipts, err := client.sendVotes(votes)
+	if err != nil {
+		return err
+	}
+
+	addresses := make(map[string]string, len(eligibleTickets))
+	for _, eligibleTicket := range eligibleTickets {
+		addresses[eligibleTicket.Ticket.Hash] = eligibleTicket.Ticket.Address
+	}
+
+	// Save the receipts of the accepted votes even if others were rejected,
+	// the first rejection is returned.
+	var voteErr error
+	accepted := make([]tkv1.CastVoteReply, 0, len(receipts))
+	for _, receipt := range receipts {
+		if receipt.ErrorContext != "" {
+			if voteErr == nil {
+				voteErr = fmt.Errorf(receipt.ErrorContext)
+			}
+			continue
+		}
+		accepted = append(accepted, receipt)
+	}
+
+	if len(accepted) > 0 {
+		if err = p.saveVoteReceipts(client.version.PubKey, votes, addresses, accepted); err != nil {
+			log.Errorf("error saving vote receipts: %v", err)
+		}
+		p.markProposalVoted(token, walletID)
+	}
+
+	return voteErr
 }
 
 func (p *Politeia) AddNotificationListener(notificationListener ProposalNotificationListener, uniqueIdentifier string) error {
@@ -633,6 +923,16 @@ func (p *Politeia) publishVoteFinished(proposal interface{}) {
 	}
 }
 
+func (p *Politeia) publishVersionUpdated(proposal interface{}) {
+	p.notificationListenersMu.Lock()
+	defer p.notificationListenersMu.Unlock()
+
+	for _, notificationListener := range p.notificationListeners {
+		data, _ := proposal.(*Proposal)
+		notificationListener.OnProposalVersionUpdated(data)
+	}
+}
+
 func getVotesCount(options []www.VoteOptionResult) (int32, int32) {
 	var yes, no int32
 
@@ -0,0 +1,156 @@
+package politeia
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/asdine/storm"
+)
+
+// threadIDs returns the comment IDs of the threads and of their replies,
+// nested as the threads are.
+func threadIDs(threads []*ProposalCommentThread) []interface{} {
+	ids := make([]interface{}, 0, len(threads))
+	for _, thread := range threads {
+		ids = append(ids, thread.CommentID)
+		if len(thread.Replies) > 0 {
+			ids = append(ids, threadIDs(thread.Replies))
+		}
+	}
+	return ids
+}
+
+func TestCommentThreads(t *testing.T) {
+	tests := []struct {
+		name     string
+		comments []Comment
+		expected []interface{}
+	}{
+		{"no comments", nil, []interface{}{}},
+		{"top level comments", []Comment{
+			{CommentID: 1},
+			{CommentID: 2},
+		}, []interface{}{uint32(1), uint32(2)}},
+		{"nested replies", []Comment{
+			{CommentID: 1},
+			{CommentID: 2, ParentID: 1},
+			{CommentID: 3},
+			{CommentID: 4, ParentID: 2},
+			{CommentID: 5, ParentID: 1},
+		}, []interface{}{
+			uint32(1), []interface{}{uint32(2), []interface{}{uint32(4)}, uint32(5)},
+			uint32(3),
+		}},
+		// Replies whose parent is missing are shown at the top level, in
+		// order with the other top level comments.
+		{"orphan replies", []Comment{
+			{CommentID: 1},
+			{CommentID: 3, ParentID: 2},
+			{CommentID: 4, ParentID: 3},
+			{CommentID: 5},
+		}, []interface{}{
+			uint32(1),
+			uint32(3), []interface{}{uint32(4)},
+			uint32(5),
+		}},
+		// Censored and deleted comments keep their place in the thread so
+		// that their replies are still nested under them.
+		{"deleted comments", []Comment{
+			{CommentID: 1, Deleted: true, Reason: "spam"},
+			{CommentID: 2, ParentID: 1},
+			{CommentID: 3, ParentID: 2, Deleted: true},
+		}, []interface{}{
+			uint32(1), []interface{}{uint32(2), []interface{}{uint32(3)}},
+		}},
+	}
+
+	for _, test := range tests {
+		threads := commentThreads(test.comments)
+		if ids := threadIDs(threads); !reflect.DeepEqual(ids, test.expected) {
+			t.Errorf("%s: expected threads %v, got %v", test.name, test.expected, ids)
+		}
+	}
+}
+
+func TestCommentThreadsDeleted(t *testing.T) {
+	threads := commentThreads([]Comment{
+		{CommentID: 1, Deleted: true, Reason: "spam"},
+		{CommentID: 2, ParentID: 1, Comment: "reply"},
+	})
+
+	if len(threads) != 1 || len(threads[0].Replies) != 1 {
+		t.Fatalf("expected a deleted comment with a reply, got %v", threadIDs(threads))
+	}
+	if !threads[0].Deleted || threads[0].Reason != "spam" {
+		t.Errorf("expected the comment to be deleted for spam, got %+v", threads[0].Comment)
+	}
+	if threads[0].Replies[0].Deleted || threads[0].Replies[0].Comment.Comment != "reply" {
+		t.Errorf("expected the reply to be kept, got %+v", threads[0].Replies[0].Comment)
+	}
+}
+
+func TestSaveProposalComments(t *testing.T) {
+	db, err := storm.Open(filepath.Join(t.TempDir(), "politeia.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	p, err := New("", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The comments of another proposal are not affected by the refreshes.
+	if err = p.saveProposalComments("other", []Comment{{ProposalToken: "other", CommentID: 1}}); err != nil {
+		t.Fatal(err)
+	}
+
+	refreshes := []struct {
+		name     string
+		comments []Comment
+	}{
+		{"first fetch", []Comment{
+			{ProposalToken: "token", CommentID: 1, Comment: "first"},
+			{ProposalToken: "token", CommentID: 2, ParentID: 1, Comment: "reply"},
+		}},
+		{"unchanged", []Comment{
+			{ProposalToken: "token", CommentID: 1, Comment: "first"},
+			{ProposalToken: "token", CommentID: 2, ParentID: 1, Comment: "reply"},
+		}},
+		{"new and censored comments", []Comment{
+			{ProposalToken: "token", CommentID: 1, Comment: "first", Upvotes: 3},
+			{ProposalToken: "token", CommentID: 2, ParentID: 1, Deleted: true, Reason: "spam"},
+			{ProposalToken: "token", CommentID: 3, Comment: "second"},
+		}},
+		{"removed comments", []Comment{
+			{ProposalToken: "token", CommentID: 3, Comment: "second"},
+		}},
+	}
+
+	for _, refresh := range refreshes {
+		if err = p.saveProposalComments("token", refresh.comments); err != nil {
+			t.Fatalf("%s: %v", refresh.name, err)
+		}
+
+		cached, err := p.GetProposalCommentsRaw("token")
+		if err != nil {
+			t.Fatalf("%s: %v", refresh.name, err)
+		}
+		for i := range cached {
+			cached[i].ID = 0
+		}
+		if !reflect.DeepEqual(cached, refresh.comments) {
+			t.Errorf("%s: expected cached comments %+v, got %+v", refresh.name, refresh.comments, cached)
+		}
+	}
+
+	other, err := p.GetProposalCommentsRaw("other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(other) != 1 {
+		t.Errorf("expected the other proposal's comment to be kept, got %+v", other)
+	}
+}
@@ -0,0 +1,307 @@
+package politeia
+
+import (
+	"fmt"
+	"time"
+
+	"decred.org/dcrwallet/v3/errors"
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+	tkv1 "github.com/decred/politeia/politeiawww/api/ticketvote/v1"
+	"github.com/decred/politeia/politeiawww/client"
+)
+
+// VoteReceipt is the proof of a vote cast on a proposal with a ticket of the
+// wallets. It holds the vote signed with the ticket's address and the server
+// signature of it. ServerPubKey is the key of the server that signed the
+// receipt, the receipt is verified against it and the key is checked against
+// the pinned server keys.
+type VoteReceipt struct {
+	ID      int    `storm:"id,increment"`
+	Token   string `json:"token" storm:"index"`
+	Ticket  string `json:"ticket" storm:"index"`
+	Address string `json:"address"`
+	// VoteBit is the hex encoded vote bit that was signed.
+	VoteBit   string `json:"votebit"`
+	Signature string `json:"signature"`
+	// Receipt is the server signature of the vote signature.
+	Receipt      string `json:"receipt"`
+	ServerPubKey string `json:"serverpubkey"`
+	Timestamp    int64  `json:"timestamp" storm:"index"`
+}
+
+// VoteReceiptVerification is the result of auditing a vote receipt.
+type VoteReceiptVerification struct {
+	VoteReceipt
+	// ReceiptError is set if the vote signature or the server receipt do not
+	// verify against the server key the receipt was saved with.
+	ReceiptError string
+	// ResultError is set if the vote results published by politeia do not
+	// include the vote as it was cast.
+	ResultError string
+	// KeyWarning is set to ErrServerKeyMismatch if the receipt was signed
+	// with a server key that was never pinned, to ErrServerKeyUnknown if no
+	// key was pinned yet, or to ErrServerKeyRotated if the server no longer
+	// uses the pinned key. It does not invalidate the receipt, the server
+	// key may be re-pinned with RepinServerPubKey once the user trusts it.
+	KeyWarning string
+}
+
+// Valid returns true if the receipt verifies and the vote was counted as cast.
+func (v *VoteReceiptVerification) Valid() bool {
+	return v.ReceiptError == "" && v.ResultError == ""
+}
+
+// saveVoteReceipts saves the receipts of the votes accepted by the server
+// identified by serverPubKey.
+func (p *Politeia) saveVoteReceipts(serverPubKey string, votes []tkv1.CastVote, addresses map[string]string, receipts []tkv1.CastVoteReply) error {
+	castVotes := make(map[string]tkv1.CastVote, len(votes))
+	for _, vote := range votes {
+		castVotes[vote.Ticket] = vote
+	}
+
+	tx, err := p.db.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().Unix()
+	for _, receipt := range receipts {
+		vote, ok := castVotes[receipt.Ticket]
+		if !ok {
+			continue
+		}
+
+		err = tx.Save(&VoteReceipt{
+			Token:        vote.Token,
+			Ticket:       vote.Ticket,
+			Address:      addresses[vote.Ticket],
+			VoteBit:      vote.VoteBit,
+			Signature:    vote.Signature,
+			Receipt:      receipt.Receipt,
+			ServerPubKey: serverPubKey,
+			Timestamp:    now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetVoteReceipts returns the saved receipts of the votes cast on the
+// proposal, or on all proposals if no token is provided, newest first.
+func (p *Politeia) GetVoteReceipts(token string) ([]VoteReceipt, error) {
+	query := p.db.Select()
+	if token != "" {
+		query = p.db.Select(q.Eq("Token", token))
+	}
+
+	var receipts []VoteReceipt
+	err := query.OrderBy("Timestamp").Reverse().Find(&receipts)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, fmt.Errorf("error fetching vote receipts: %s", err.Error())
+	}
+
+	return receipts, nil
+}
+
+// pinServerPubKey pins the public key of the server identity if none was
+// pinned yet. A server using another identity is logged, VerifyVoteReceipts
+// warns about it until the user re-pins the key.
+func (p *Politeia) pinServerPubKey(pubKey string) {
+	pinned := p.PinnedServerPubKey()
+	switch {
+	case pinned == "":
+		if err := p.db.Set(configDBBkt, ServerPubKeyConfigKey, pubKey); err != nil {
+			log.Errorf("error pinning the politeia server key: %v", err)
+		}
+	case pinned != pubKey:
+		log.Warnf("politeia server key %s differs from the pinned key %s", pubKey, pinned)
+	}
+}
+
+// PinnedServerPubKey returns the pinned public key of the server identity,
+// or an empty string if the server was never reached.
+func (p *Politeia) PinnedServerPubKey() string {
+	var pubKey string
+	err := p.db.Get(configDBBkt, ServerPubKeyConfigKey, &pubKey)
+	if err != nil && err != storm.ErrNotFound {
+		log.Errorf("error reading the pinned politeia server key: %v", err)
+	}
+	return pubKey
+}
+
+// trustedServerPubKeys returns the pinned server key and the keys pinned
+// before it.
+func (p *Politeia) trustedServerPubKeys() map[string]bool {
+	var previous []string
+	err := p.db.Get(configDBBkt, PreviousServerPubKeysConfigKey, &previous)
+	if err != nil && err != storm.ErrNotFound {
+		log.Errorf("error reading the previous politeia server keys: %v", err)
+	}
+
+	trusted := make(map[string]bool, len(previous)+1)
+	for _, pubKey := range previous {
+		trusted[pubKey] = true
+	}
+	if pinned := p.PinnedServerPubKey(); pinned != "" {
+		trusted[pinned] = true
+	}
+	return trusted
+}
+
+// ServerPubKey returns the public key of the identity of the politeia server
+// currently connected to.
+func (p *Politeia) ServerPubKey() (string, error) {
+	client, err := p.getClient()
+	if err != nil {
+		return "", err
+	}
+	return client.version.PubKey, nil
+}
+
+// RepinServerPubKey pins the server key in place of the pinned key, e.g.
+// after the server identity was rotated or the politeia host was changed.
+// It must only be called once the user confirmed the new key. The receipts
+// signed with the previously pinned keys are still trusted.
+func (p *Politeia) RepinServerPubKey(pubKey string) error {
+	if pubKey == "" {
+		return errors.New(ErrInvalid)
+	}
+
+	pinned := p.PinnedServerPubKey()
+	if pinned == pubKey {
+		return nil
+	}
+
+	tx, err := p.db.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if pinned != "" {
+		var previous []string
+		err = tx.Get(configDBBkt, PreviousServerPubKeysConfigKey, &previous)
+		if err != nil && err != storm.ErrNotFound {
+			return err
+		}
+		if err = tx.Set(configDBBkt, PreviousServerPubKeysConfigKey, append(previous, pinned)); err != nil {
+			return err
+		}
+	}
+	if err = tx.Set(configDBBkt, ServerPubKeyConfigKey, pubKey); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// VerifyVoteReceipt checks the vote signature against the ticket's address
+// and the server receipt against the server key the receipt was saved with,
+// and checks that key against the pinned server keys. It does not require
+// access to the server.
+func (p *Politeia) VerifyVoteReceipt(receipt *VoteReceipt) *VoteReceiptVerification {
+	verification := &VoteReceiptVerification{
+		VoteReceipt: *receipt,
+		KeyWarning:  receiptKeyWarning(receipt, p.trustedServerPubKeys()),
+	}
+	if err := verifyVoteReceipt(receipt); err != nil {
+		verification.ReceiptError = err.Error()
+	}
+	return verification
+}
+
+// receiptKeyWarning returns ErrServerKeyMismatch if the receipt was signed
+// with none of the trusted server keys, or ErrServerKeyUnknown if no key is
+// trusted yet.
+func receiptKeyWarning(receipt *VoteReceipt, trusted map[string]bool) string {
+	switch {
+	case len(trusted) == 0:
+		return ErrServerKeyUnknown
+	case !trusted[receipt.ServerPubKey]:
+		return ErrServerKeyMismatch
+	}
+	return ""
+}
+
+// verifyVoteReceipt checks the vote signature against the ticket's address
+// and the server receipt against the server key the receipt was saved with.
+func verifyVoteReceipt(receipt *VoteReceipt) error {
+	return client.CastVoteDetailsVerify(tkv1.CastVoteDetails{
+		Token:     receipt.Token,
+		Ticket:    receipt.Ticket,
+		VoteBit:   receipt.VoteBit,
+		Address:   receipt.Address,
+		Signature: receipt.Signature,
+		Receipt:   receipt.Receipt,
+	}, receipt.ServerPubKey)
+}
+
+// VerifyVoteReceipts verifies the saved receipts of the votes cast on the
+// proposal like VerifyVoteReceipt and checks them against the vote results
+// published by politeia. The receipts are flagged with ErrServerKeyRotated
+// if the server no longer uses the pinned key.
+func (p *Politeia) VerifyVoteReceipts(token string) ([]*VoteReceiptVerification, error) {
+	receipts, err := p.GetVoteReceipts(token)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	client, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := client.voteResults(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return verifyVoteReceipts(receipts, results.Votes, p.trustedServerPubKeys(),
+		p.PinnedServerPubKey(), client.version.PubKey), nil
+}
+
+// verifyVoteReceipts verifies the receipts against the keys they were saved
+// with and the trusted server keys, and against the vote results published
+// by the server using serverPubKey.
+func verifyVoteReceipts(receipts []VoteReceipt, results []tkv1.CastVoteDetails, trusted map[string]bool,
+	pinnedPubKey, serverPubKey string,
+) []*VoteReceiptVerification {
+	counted := make(map[string]tkv1.CastVoteDetails, len(results))
+	for _, vote := range results {
+		counted[vote.Ticket] = vote
+	}
+
+	verifications := make([]*VoteReceiptVerification, len(receipts))
+	for i := range receipts {
+		verification := &VoteReceiptVerification{
+			VoteReceipt: receipts[i],
+			KeyWarning:  receiptKeyWarning(&receipts[i], trusted),
+		}
+		if verification.KeyWarning == "" && serverPubKey != pinnedPubKey {
+			verification.KeyWarning = ErrServerKeyRotated
+		}
+		if err := verifyVoteReceipt(&receipts[i]); err != nil {
+			verification.ReceiptError = err.Error()
+		}
+
+		vote, ok := counted[receipts[i].Ticket]
+		switch {
+		case !ok:
+			verification.ResultError = ErrVoteNotCounted
+		case vote.VoteBit != receipts[i].VoteBit || vote.Signature != receipts[i].Signature:
+			verification.ResultError = ErrVoteMismatch
+		}
+
+		verifications[i] = verification
+	}
+
+	return verifications
+}
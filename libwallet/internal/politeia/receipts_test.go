@@ -0,0 +1,177 @@
+package politeia
+
+import (
+	"bytes"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+
+	"github.com/asdine/storm"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/wire"
+	"github.com/decred/politeia/politeiad/api/v1/identity"
+	tkv1 "github.com/decred/politeia/politeiawww/api/ticketvote/v1"
+)
+
+// signedReceipt returns the receipt of a vote signed with a new ticket key
+// and the server identity.
+func signedReceipt(t *testing.T, server *identity.FullIdentity, token, ticket, voteBit string) VoteReceipt {
+	key, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkHash := dcrutil.Hash160(key.PubKey().SerializeCompressed())
+	addr, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(pkHash, chaincfg.MainNetParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	wire.WriteVarString(&buf, 0, "Decred Signed Message:\n")
+	wire.WriteVarString(&buf, 0, token+ticket+voteBit)
+	signature := hex.EncodeToString(ecdsa.SignCompact(key, chainhash.HashB(buf.Bytes()), true))
+
+	receipt := server.SignMessage([]byte(signature))
+
+	return VoteReceipt{
+		Token:        token,
+		Ticket:       ticket,
+		Address:      addr.String(),
+		VoteBit:      voteBit,
+		Signature:    signature,
+		Receipt:      hex.EncodeToString(receipt[:]),
+		ServerPubKey: server.Public.String(),
+	}
+}
+
+func TestVerifyVoteReceipts(t *testing.T) {
+	server, err := identity.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherServer, err := identity.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverKey, otherKey := server.Public.String(), otherServer.Public.String()
+
+	counted := signedReceipt(t, server, "token", "ticket1", "2")
+	tampered := signedReceipt(t, server, "token", "ticket2", "2")
+	tampered.VoteBit = "1"
+	notCounted := signedReceipt(t, server, "token", "ticket3", "2")
+	changed := signedReceipt(t, server, "token", "ticket4", "2")
+	// The receipt is valid for the key saved with it, but that key was never
+	// pinned.
+	unpinned := signedReceipt(t, otherServer, "token", "ticket5", "2")
+	// The receipt claims the server key but was signed by another server.
+	forged := signedReceipt(t, otherServer, "token", "ticket6", "2")
+	forged.ServerPubKey = serverKey
+
+	results := []tkv1.CastVoteDetails{
+		{Ticket: counted.Ticket, VoteBit: counted.VoteBit, Signature: counted.Signature},
+		{Ticket: tampered.Ticket, VoteBit: tampered.VoteBit, Signature: tampered.Signature},
+		{Ticket: changed.Ticket, VoteBit: "1", Signature: changed.Signature},
+		{Ticket: unpinned.Ticket, VoteBit: unpinned.VoteBit, Signature: unpinned.Signature},
+		{Ticket: forged.Ticket, VoteBit: forged.VoteBit, Signature: forged.Signature},
+	}
+	receipts := []VoteReceipt{counted, tampered, notCounted, changed, unpinned, forged}
+
+	tests := []struct {
+		name         string
+		receiptValid bool
+		resultError  string
+		keyWarning   string
+	}{
+		{"counted", true, "", ""},
+		{"tampered", false, "", ""},
+		{"not counted", true, ErrVoteNotCounted, ""},
+		{"changed", true, ErrVoteMismatch, ""},
+		{"unpinned key", true, "", ErrServerKeyMismatch},
+		{"forged", false, "", ""},
+	}
+
+	check := func(desc string, verifications []*VoteReceiptVerification, rotated bool) {
+		for i, test := range tests {
+			v := verifications[i]
+			if (v.ReceiptError == "") != test.receiptValid {
+				t.Errorf("%s: %s: unexpected receipt error %q", desc, test.name, v.ReceiptError)
+			}
+			if v.ResultError != test.resultError {
+				t.Errorf("%s: %s: got result error %q, want %q", desc, test.name, v.ResultError, test.resultError)
+			}
+			keyWarning := test.keyWarning
+			if rotated && keyWarning == "" {
+				keyWarning = ErrServerKeyRotated
+			}
+			if v.KeyWarning != keyWarning {
+				t.Errorf("%s: %s: got key warning %q, want %q", desc, test.name, v.KeyWarning, keyWarning)
+			}
+		}
+	}
+
+	trusted := map[string]bool{serverKey: true}
+	check("pinned key", verifyVoteReceipts(receipts, results, trusted, serverKey, serverKey), false)
+
+	// A server key rotation is only a warning, the receipts are still
+	// verified against the keys they were saved with.
+	verifications := verifyVoteReceipts(receipts, results, trusted, serverKey, otherKey)
+	check("rotated key", verifications, true)
+	if !verifications[0].Valid() {
+		t.Errorf("rotated key: counted receipt not valid")
+	}
+}
+
+func TestRepinServerPubKey(t *testing.T) {
+	db, err := storm.Open(filepath.Join(t.TempDir(), "politeia.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	p, err := New("", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server, err := identity.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	newServer, err := identity.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldReceipt := signedReceipt(t, server, "token", "ticket1", "2")
+	newReceipt := signedReceipt(t, newServer, "token", "ticket2", "2")
+
+	if v := p.VerifyVoteReceipt(&oldReceipt); v.KeyWarning != ErrServerKeyUnknown {
+		t.Errorf("no pinned key: got key warning %q, want %q", v.KeyWarning, ErrServerKeyUnknown)
+	}
+
+	p.pinServerPubKey(server.Public.String())
+	// Reaching a server with another key does not replace the pinned key.
+	p.pinServerPubKey(newServer.Public.String())
+	if v := p.VerifyVoteReceipt(&newReceipt); v.KeyWarning != ErrServerKeyMismatch || !v.Valid() {
+		t.Errorf("new key before re-pin: got key warning %q, receipt error %q", v.KeyWarning, v.ReceiptError)
+	}
+
+	if err = p.RepinServerPubKey(newServer.Public.String()); err != nil {
+		t.Fatal(err)
+	}
+	if pinned := p.PinnedServerPubKey(); pinned != newServer.Public.String() {
+		t.Errorf("expected the new key to be pinned, got %s", pinned)
+	}
+
+	// The receipts signed with the previously pinned key are still trusted.
+	for _, receipt := range []VoteReceipt{oldReceipt, newReceipt} {
+		if v := p.VerifyVoteReceipt(&receipt); v.KeyWarning != "" || !v.Valid() {
+			t.Errorf("%s: got key warning %q, receipt error %q", receipt.Ticket, v.KeyWarning, v.ReceiptError)
+		}
+	}
+}
@@ -0,0 +1,464 @@
+package politeia
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+)
+
+const (
+	SearchIndexVersionConfigKey = "politeia_search_index_version"
+
+	// searchIndexVersion is bumped whenever the indexed proposal fields or
+	// the way search terms are extracted change, to rebuild the index.
+	searchIndexVersion = 1
+
+	// minSearchTermLength is the length of the shortest word indexed.
+	minSearchTermLength = 2
+)
+
+const (
+	// ProposalOutcomeAny does not filter proposals by vote outcome.
+	ProposalOutcomeAny int32 = iota
+	// ProposalOutcomeApproved filters the proposals approved by the vote.
+	ProposalOutcomeApproved
+	// ProposalOutcomeRejected filters the proposals rejected by the vote.
+	ProposalOutcomeRejected
+)
+
+const (
+	// ProposalVotedAny does not filter proposals by the wallets' votes.
+	ProposalVotedAny int32 = iota
+	// ProposalVotedByMe filters the proposals voted on with the tickets of
+	// the filtered wallet, from this app or any other.
+	ProposalVotedByMe
+	// ProposalNotVotedByMe filters the proposals not voted on with the
+	// tickets of the filtered wallet.
+	ProposalNotVotedByMe
+)
+
+// ProposalSearchTerm is an entry of the local full-text index of the
+// proposals. A term is saved for every distinct word of a proposal's name,
+// author and description.
+type ProposalSearchTerm struct {
+	ID            int    `storm:"id,increment"`
+	Term          string `storm:"index"`
+	ProposalToken string `storm:"index"`
+}
+
+// ProposalFilter holds the criteria used to search the saved proposals. Zero
+// values do not filter.
+type ProposalFilter struct {
+	// Categories restricts the proposals to the listed categories.
+	Categories []int32
+	// Query matches the proposals whose name, author or description
+	// contain all of its words.
+	Query string
+	// MinAmount and MaxAmount bound the requested budget in cents.
+	MinAmount uint64
+	MaxAmount uint64
+	Author    string
+	// From and To bound the proposal publication unix timestamp.
+	From        int64
+	To          int64
+	VoteOutcome int32
+	Voted       int32
+	// WalletID restricts Voted to the votes of the wallet's tickets. The
+	// votes of every wallet are used if zero.
+	WalletID   int
+	Bookmarked bool
+
+	Offset      int32
+	Limit       int32
+	NewestFirst bool
+}
+
+// SearchProposals returns the saved proposals matching the filter. Each
+// criterion is read from the storm index of its field and the results are
+// intersected, so that the proposals are not all scanned.
+func (p *Politeia) SearchProposals(filter *ProposalFilter) ([]Proposal, error) {
+	// matches holds the proposals matching the criteria applied so far,
+	// keyed by token. A nil map matches every proposal.
+	var matches map[string]*Proposal
+	intersect := func(proposals []Proposal) {
+		next := make(map[string]*Proposal, len(proposals))
+		for i := range proposals {
+			if _, ok := matches[proposals[i].Token]; matches == nil || ok {
+				next[proposals[i].Token] = &proposals[i]
+			}
+		}
+		matches = next
+	}
+
+	find := func(field string, value interface{}) ([]Proposal, error) {
+		var proposals []Proposal
+		err := p.db.Find(field, value, &proposals)
+		if err != nil && err != storm.ErrNotFound {
+			return nil, fmt.Errorf("error searching proposals: %s", err.Error())
+		}
+		return proposals, nil
+	}
+
+	categories := filter.Categories
+	switch filter.VoteOutcome {
+	case ProposalOutcomeApproved:
+		categories = intersectCategories(categories, ProposalCategoryApproved)
+	case ProposalOutcomeRejected:
+		categories = intersectCategories(categories, ProposalCategoryRejected)
+	}
+	if len(categories) > 0 || filter.VoteOutcome != ProposalOutcomeAny {
+		var proposals []Proposal
+		for _, category := range categories {
+			found, err := find("Category", category)
+			if err != nil {
+				return nil, err
+			}
+			proposals = append(proposals, found...)
+		}
+		intersect(proposals)
+	}
+
+	if filter.Author != "" {
+		proposals, err := find("Username", filter.Author)
+		if err != nil {
+			return nil, err
+		}
+		intersect(proposals)
+	}
+
+	if filter.Bookmarked {
+		proposals, err := find("Bookmarked", true)
+		if err != nil {
+			return nil, err
+		}
+		intersect(proposals)
+	}
+
+	if filter.MinAmount > 0 || filter.MaxAmount > 0 {
+		maxAmount := filter.MaxAmount
+		if maxAmount == 0 {
+			maxAmount = math.MaxUint64
+		}
+		var proposals []Proposal
+		err := p.db.Range("Amount", filter.MinAmount, maxAmount, &proposals)
+		if err != nil && err != storm.ErrNotFound {
+			return nil, fmt.Errorf("error searching proposals: %s", err.Error())
+		}
+		intersect(proposals)
+	}
+
+	if filter.From > 0 || filter.To > 0 {
+		to := filter.To
+		if to == 0 {
+			to = math.MaxInt64
+		}
+		var proposals []Proposal
+		err := p.db.Range("PublishedAt", filter.From, to, &proposals)
+		if err != nil && err != storm.ErrNotFound {
+			return nil, fmt.Errorf("error searching proposals: %s", err.Error())
+		}
+		intersect(proposals)
+	}
+
+	// The proposals matching the query and the wallet votes are known by
+	// token.
+	var tokenSets []map[string]bool
+	if terms := searchTerms(filter.Query); len(terms) > 0 {
+		tokens, err := p.searchIndex(terms)
+		if err != nil {
+			return nil, err
+		}
+		tokenSets = append(tokenSets, tokenSet(tokens))
+	}
+
+	var notVoted map[string]bool
+	if filter.Voted != ProposalVotedAny {
+		voted, err := p.votedProposals(filter.WalletID)
+		if err != nil {
+			return nil, err
+		}
+		if filter.Voted == ProposalVotedByMe {
+			tokenSets = append(tokenSets, voted)
+		} else {
+			notVoted = voted
+		}
+	}
+
+	sort.Slice(tokenSets, func(i, j int) bool {
+		return len(tokenSets[i]) < len(tokenSets[j])
+	})
+	for _, tokens := range tokenSets {
+		if matches == nil {
+			// Only the proposals of the smallest set are read.
+			matches = make(map[string]*Proposal, len(tokens))
+			for token := range tokens {
+				proposal := new(Proposal)
+				err := p.db.One("Token", token, proposal)
+				if err == storm.ErrNotFound {
+					continue
+				}
+				if err != nil {
+					return nil, fmt.Errorf("error searching proposals: %s", err.Error())
+				}
+				matches[token] = proposal
+			}
+			continue
+		}
+
+		for token := range matches {
+			if !tokens[token] {
+				delete(matches, token)
+			}
+		}
+	}
+
+	var proposals []Proposal
+	if matches == nil {
+		// No criterion restricts the proposals, all are read in order.
+		err := p.db.AllByIndex("PublishedAt", &proposals)
+		if err != nil && err != storm.ErrNotFound {
+			return nil, fmt.Errorf("error searching proposals: %s", err.Error())
+		}
+	} else {
+		proposals = make([]Proposal, 0, len(matches))
+		for _, proposal := range matches {
+			proposals = append(proposals, *proposal)
+		}
+		sort.Slice(proposals, func(i, j int) bool {
+			return proposals[i].PublishedAt < proposals[j].PublishedAt
+		})
+	}
+
+	if notVoted != nil {
+		filtered := proposals[:0]
+		for _, proposal := range proposals {
+			if !notVoted[proposal.Token] {
+				filtered = append(filtered, proposal)
+			}
+		}
+		proposals = filtered
+	}
+
+	if filter.NewestFirst {
+		for i, j := 0, len(proposals)-1; i < j; i, j = i+1, j-1 {
+			proposals[i], proposals[j] = proposals[j], proposals[i]
+		}
+	}
+
+	if filter.Offset > 0 {
+		if int(filter.Offset) >= len(proposals) {
+			return nil, nil
+		}
+		proposals = proposals[filter.Offset:]
+	}
+	if filter.Limit > 0 && int(filter.Limit) < len(proposals) {
+		proposals = proposals[:filter.Limit]
+	}
+
+	if len(proposals) == 0 {
+		return nil, nil
+	}
+	return proposals, nil
+}
+
+// intersectCategories returns the category if it is one of the categories,
+// or if no category was given.
+func intersectCategories(categories []int32, category int32) []int32 {
+	if len(categories) == 0 {
+		return []int32{category}
+	}
+	for _, c := range categories {
+		if c == category {
+			return []int32{category}
+		}
+	}
+	return nil
+}
+
+// votedProposals returns the tokens of the proposals voted on by the
+// wallet's tickets, or by any wallet's tickets if walletID is zero.
+func (p *Politeia) votedProposals(walletID int) (map[string]bool, error) {
+	var voted []VotedProposal
+	var err error
+	if walletID > 0 {
+		err = p.db.Find("WalletID", walletID, &voted)
+	} else {
+		err = p.db.All(&voted)
+	}
+	if err != nil && err != storm.ErrNotFound {
+		return nil, fmt.Errorf("error searching voted proposals: %s", err.Error())
+	}
+
+	tokens := make(map[string]bool, len(voted))
+	for _, v := range voted {
+		tokens[v.ProposalToken] = true
+	}
+	return tokens, nil
+}
+
+func tokenSet(tokens []string) map[string]bool {
+	set := make(map[string]bool, len(tokens))
+	for _, token := range tokens {
+		set[token] = true
+	}
+	return set
+}
+
+// searchIndex returns the tokens of the proposals indexed under all the
+// terms.
+func (p *Politeia) searchIndex(terms []string) ([]string, error) {
+	var tokens map[string]bool
+	for _, term := range terms {
+		var entries []ProposalSearchTerm
+		err := p.db.Find("Term", term, &entries)
+		if err != nil && err != storm.ErrNotFound {
+			return nil, fmt.Errorf("error searching proposals index: %s", err.Error())
+		}
+
+		matches := make(map[string]bool, len(entries))
+		for _, entry := range entries {
+			if tokens == nil || tokens[entry.ProposalToken] {
+				matches[entry.ProposalToken] = true
+			}
+		}
+		tokens = matches
+
+		if len(tokens) == 0 {
+			return nil, nil
+		}
+	}
+
+	result := make([]string, 0, len(tokens))
+	for token := range tokens {
+		result = append(result, token)
+	}
+	return result, nil
+}
+
+// indexProposal replaces the search terms of the proposal with the words of
+// its name, author and description.
+func (p *Politeia) indexProposal(proposal *Proposal) error {
+	tx, err := p.db.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.Select(q.Eq("ProposalToken", proposal.Token)).Delete(new(ProposalSearchTerm))
+	if err != nil && err != storm.ErrNotFound {
+		return err
+	}
+
+	text := strings.Join([]string{proposal.Name, proposal.Username, proposal.IndexFile}, " ")
+	for _, term := range searchTerms(text) {
+		err = tx.Save(&ProposalSearchTerm{Term: term, ProposalToken: proposal.Token})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// indexProposals fetches the description and budget of the saved proposals
+// whose latest version was not indexed yet, and indexes them.
+func (p *Politeia) indexProposals(client *politeiaClient) error {
+	proposals, err := p.getProposalsRaw(ProposalCategoryAll, 0, 0, true, false)
+	if err != nil {
+		return err
+	}
+
+	for i := range proposals {
+		// Check if politeia has been shutdown and exit if true.
+		if p.ctx.Err() != nil {
+			return p.ctx.Err()
+		}
+
+		proposal := &proposals[i]
+		if proposal.IndexFileVersion == proposal.Version {
+			continue
+		}
+
+		proposalVersion, err := p.fetchProposalVersion(client, proposal.Token, proposal.Version)
+		if err != nil {
+			log.Errorf("error fetching proposal %s for indexing: %v", proposal.Token, err)
+			continue
+		}
+
+		if err = p.saveProposalDescription(proposal, proposalVersion); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// saveProposalDescription saves the description and budget of the version
+// to the proposal and re-indexes it.
+func (p *Politeia) saveProposalDescription(proposal *Proposal, proposalVersion *ProposalVersion) error {
+	proposal.IndexFile = proposalVersion.IndexFile
+	// index file version will be used to determine if the
+	// saved file is out of date when compared to version.
+	proposal.IndexFileVersion = proposalVersion.Version
+	proposal.Amount = proposalVersion.Amount
+	err := p.saveOrOverwiteProposal(proposal)
+	if err != nil {
+		return fmt.Errorf("error saving proposal description: %s", err.Error())
+	}
+
+	return p.indexProposal(proposal)
+}
+
+// upgradeSearchIndex rebuilds the storm indexes of the proposals and their
+// search terms if they were built by an older version of the search index.
+func (p *Politeia) upgradeSearchIndex() error {
+	var version int
+	err := p.db.Get(configDBBkt, SearchIndexVersionConfigKey, &version)
+	if err != nil && err != storm.ErrNotFound {
+		return err
+	}
+	if version >= searchIndexVersion {
+		return nil
+	}
+
+	if err = p.db.ReIndex(&Proposal{}); err != nil {
+		return err
+	}
+
+	proposals, err := p.getProposalsRaw(ProposalCategoryAll, 0, 0, true, false)
+	if err != nil {
+		return err
+	}
+
+	for i := range proposals {
+		if err = p.indexProposal(&proposals[i]); err != nil {
+			return err
+		}
+	}
+
+	return p.db.Set(configDBBkt, SearchIndexVersionConfigKey, searchIndexVersion)
+}
+
+// searchTerms returns the distinct lower case words of the text.
+func searchTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]bool, len(words))
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		if len(word) < minSearchTermLength || seen[word] {
+			continue
+		}
+		seen[word] = true
+		terms = append(terms, word)
+	}
+
+	return terms
+}
@@ -0,0 +1,134 @@
+package politeia
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/asdine/storm"
+)
+
+func TestSearchTerms(t *testing.T) {
+	got := searchTerms("Decred Marketing: Q1-2024, decred's *budget* a")
+	want := []string{"decred", "marketing", "q1", "2024", "budget"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSearchProposals(t *testing.T) {
+	db, err := storm.Open(filepath.Join(t.TempDir(), "politeia.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	p, err := New("", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proposals := []Proposal{{
+		Token:       "a",
+		Name:        "Marketing campaign",
+		Username:    "alice",
+		Category:    ProposalCategoryApproved,
+		PublishedAt: 100,
+		Amount:      500000,
+		IndexFile:   "Print billboards for Decred",
+	}, {
+		Token:       "b",
+		Name:        "Decred development",
+		Username:    "bob",
+		Category:    ProposalCategoryRejected,
+		PublishedAt: 200,
+		Amount:      2000000,
+	}, {
+		Token:       "c",
+		Name:        "Decred marketing research",
+		Username:    "alice",
+		Category:    ProposalCategoryActive,
+		PublishedAt: 300,
+		Bookmarked:  true,
+	}}
+	for i := range proposals {
+		if err := p.saveOrOverwiteProposal(&proposals[i]); err != nil {
+			t.Fatal(err)
+		}
+		if err := p.indexProposal(&proposals[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p.markProposalVoted("a", 1)
+	p.markProposalVoted("b", 2)
+	p.markProposalVoted("a", 2)
+
+	tests := []struct {
+		name   string
+		filter ProposalFilter
+		want   []string
+	}{
+		{"all", ProposalFilter{}, []string{"a", "b", "c"}},
+		{"description", ProposalFilter{Query: "billboards"}, []string{"a"}},
+		{"all words", ProposalFilter{Query: "Decred marketing"}, []string{"a", "c"}},
+		{"no match", ProposalFilter{Query: "mining"}, nil},
+		{"author", ProposalFilter{Author: "alice"}, []string{"a", "c"}},
+		{"budget", ProposalFilter{MinAmount: 100000, MaxAmount: 1000000}, []string{"a"}},
+		{"dates", ProposalFilter{From: 150, To: 300}, []string{"b", "c"}},
+		{"approved", ProposalFilter{VoteOutcome: ProposalOutcomeApproved}, []string{"a"}},
+		{"voted", ProposalFilter{Voted: ProposalVotedByMe}, []string{"a", "b"}},
+		{"not voted", ProposalFilter{Voted: ProposalNotVotedByMe}, []string{"c"}},
+		{"voted by wallet", ProposalFilter{Voted: ProposalVotedByMe, WalletID: 1}, []string{"a"}},
+		{"not voted by wallet", ProposalFilter{Voted: ProposalNotVotedByMe, WalletID: 1}, []string{"b", "c"}},
+		{"voted by wallet with author", ProposalFilter{Voted: ProposalVotedByMe, WalletID: 2, Author: "bob"}, []string{"b"}},
+		{"query and budget", ProposalFilter{Query: "decred", MinAmount: 1000000}, []string{"b"}},
+		{"from only", ProposalFilter{From: 200}, []string{"b", "c"}},
+		{"rejected within categories", ProposalFilter{VoteOutcome: ProposalOutcomeRejected, Categories: []int32{ProposalCategoryActive}}, nil},
+		{"bookmarked", ProposalFilter{Bookmarked: true}, []string{"c"}},
+		{"categories", ProposalFilter{Categories: []int32{ProposalCategoryActive, ProposalCategoryRejected}}, []string{"b", "c"}},
+	}
+
+	for _, test := range tests {
+		result, err := p.SearchProposals(&test.filter)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+
+		var got []string
+		for _, proposal := range result {
+			got = append(got, proposal.Token)
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+
+	// The proposals are ordered by publication time before being paged.
+	result, err := p.SearchProposals(&ProposalFilter{Author: "alice", NewestFirst: true, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 1 || result[0].Token != "c" {
+		t.Errorf("expected the newest proposal of alice, got %v", result)
+	}
+	result, err = p.SearchProposals(&ProposalFilter{Offset: 1, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 1 || result[0].Token != "b" {
+		t.Errorf("expected the second oldest proposal, got %v", result)
+	}
+
+	// The proposal is marked voted once any wallet voted on it.
+	for _, token := range []string{"a", "b", "c"} {
+		proposal, err := p.GetProposalRaw(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := token != "c"; proposal.Voted != want {
+			t.Errorf("%s: expected voted %v, got %v", token, want, proposal.Voted)
+		}
+	}
+}
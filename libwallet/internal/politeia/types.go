@@ -9,10 +9,10 @@ type Proposal struct {
 	Status           int32  `json:"status"`
 	Timestamp        int64  `json:"timestamp"`
 	UserID           string `json:"userid"`
-	Username         string `json:"username"`
+	Username         string `json:"username" storm:"index"`
 	NumComments      int32  `json:"numcomments"`
 	Version          string `json:"version"`
-	PublishedAt      int64  `json:"publishedat"`
+	PublishedAt      int64  `json:"publishedat" storm:"index"`
 	IndexFile        string `json:"indexfile"`
 	IndexFileVersion string `json:"fileversion"`
 	VoteStatus       int32  `json:"votestatus"`
@@ -22,6 +22,91 @@ type Proposal struct {
 	EligibleTickets  int32  `json:"eligibletickets"`
 	QuorumPercentage int32  `json:"quorumpercentage"`
 	PassPercentage   int32  `json:"passpercentage"`
+	EndBlockHeight   int32  `json:"endblockheight"`
+	Amount           uint64 `json:"amount" storm:"index"` // Funding amount in cents
+
+	// Voted and Bookmarked are local to this app and are kept when the
+	// proposal is updated from the server. Voted is set once tickets of any
+	// of the wallets are found to have voted on the proposal, the votes of
+	// each wallet are recorded as a VotedProposal.
+	Voted      bool `json:"voted" storm:"index"`
+	Bookmarked bool `json:"bookmarked" storm:"index"`
+}
+
+// VotedProposal records that tickets of a wallet voted on a proposal, from
+// this app or any other.
+type VotedProposal struct {
+	ID            int    `storm:"id,increment"`
+	ProposalToken string `storm:"index"`
+	WalletID      int    `storm:"index"`
+}
+
+// Comment is a single comment on a proposal's discussion as cached from
+// the politeia comments API.
+type Comment struct {
+	ID            int    `storm:"id,increment"`
+	ProposalToken string `json:"token" storm:"index"`
+	CommentID     uint32 `json:"commentid"`
+	ParentID      uint32 `json:"parentid"`
+	UserID        string `json:"userid"`
+	Username      string `json:"username"`
+	Comment       string `json:"comment"`
+	Version       uint32 `json:"version"`
+	CreatedAt     int64  `json:"createdat"`
+	Timestamp     int64  `json:"timestamp"`
+	Upvotes       uint64 `json:"upvotes"`
+	Downvotes     uint64 `json:"downvotes"`
+	Deleted       bool   `json:"deleted"`
+	Reason        string `json:"reason"`
+}
+
+// ProposalCommentThread is a comment together with its nested replies.
+type ProposalCommentThread struct {
+	*Comment
+	Replies []*ProposalCommentThread
+}
+
+// ProposalVersion is a snapshot of a proposal's metadata and description at
+// a given version. Snapshots saved from the proposals inventory during sync
+// only hold the metadata, IndexFile is set once the version's full record is
+// fetched.
+type ProposalVersion struct {
+	ID            int    `storm:"id,increment"`
+	ProposalToken string `json:"token" storm:"index"`
+	Version       string `json:"version"`
+	Name          string `json:"name"`
+	Username      string `json:"username"`
+	Timestamp     int64  `json:"timestamp"`
+	IndexFile     string `json:"indexfile"`
+	Amount        uint64 `json:"amount"` // Funding amount in cents
+	StartDate     int64  `json:"startdate"`
+	EndDate       int64  `json:"enddate"`
+	Domain        string `json:"domain"`
+}
+
+// Proposal metadata fields compared between versions.
+const (
+	ProposalFieldName      = "name"
+	ProposalFieldAmount    = "amount"
+	ProposalFieldStartDate = "startdate"
+	ProposalFieldEndDate   = "enddate"
+	ProposalFieldDomain    = "domain"
+)
+
+// ProposalFieldChange describes a metadata field that differs between two
+// versions of a proposal.
+type ProposalFieldChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+// ProposalVersionDiff holds the changes between two versions of a proposal.
+type ProposalVersionDiff struct {
+	From        *ProposalVersion
+	To          *ProposalVersion
+	Fields      []ProposalFieldChange
+	Description []DiffLine
 }
 
 type ProposalOverview struct {
@@ -55,4 +140,5 @@ type ProposalNotificationListener interface {
 	OnNewProposal(proposal interface{})
 	OnProposalVoteStarted(proposal interface{})
 	OnProposalVoteFinished(proposal interface{})
+	OnProposalVersionUpdated(proposal interface{})
 }
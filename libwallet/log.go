@@ -9,6 +9,7 @@ import (
 	"os"
 
 	"decred.org/dcrwallet/v3/errors"
+	"github.com/crypto-power/cryptopower/libwallet/internal/electrum"
 	"github.com/crypto-power/cryptopower/libwallet/internal/loader"
 	"github.com/crypto-power/cryptopower/libwallet/internal/politeia"
 	"github.com/crypto-power/cryptopower/libwallet/internal/vsp"
@@ -47,6 +48,7 @@ var (
 
 	vspcLog     = backendLog.Logger("VSPC")
 	politeiaLog = backendLog.Logger("POLT")
+	electrumLog = backendLog.Logger("ELCT")
 )
 
 var log = slog.Disabled
@@ -56,6 +58,7 @@ var subsystemLoggers = map[string]slog.Logger{
 	"DLWL": log,
 	"VSPC": vspcLog,
 	"POLT": politeiaLog,
+	"ELCT": electrumLog,
 }
 
 // initLogRotator initializes the logging rotater to write logs to logFile and
@@ -77,6 +80,7 @@ func UseLogger(logger slog.Logger) {
 	loader.UseLogger(logger)
 	vsp.UseLogger(vspcLog)
 	politeia.UseLogger(politeiaLog)
+	electrum.UseLogger(electrumLog)
 }
 
 // RegisterLogger should be called before logRotator is initialized.
@@ -39,6 +39,61 @@ const (
 	ProposalCategoryRejected = politeia.ProposalCategoryRejected
 	// ProposalCategoryAbandoned is the int value for identifying abandoned proposals.
 	ProposalCategoryAbandoned = politeia.ProposalCategoryAbandoned
+
+	// DiffEqual identifies a line unchanged between proposal versions.
+	DiffEqual = politeia.DiffEqual
+	// DiffInsert identifies a line added in the newer proposal version.
+	DiffInsert = politeia.DiffInsert
+	// DiffDelete identifies a line removed from the older proposal version.
+	DiffDelete = politeia.DiffDelete
+
+	// ProposalFieldName identifies a change of the proposal name.
+	ProposalFieldName = politeia.ProposalFieldName
+	// ProposalFieldAmount identifies a change of the proposal budget.
+	ProposalFieldAmount = politeia.ProposalFieldAmount
+	// ProposalFieldStartDate identifies a change of the proposal start date.
+	ProposalFieldStartDate = politeia.ProposalFieldStartDate
+	// ProposalFieldEndDate identifies a change of the proposal end date.
+	ProposalFieldEndDate = politeia.ProposalFieldEndDate
+	// ProposalFieldDomain identifies a change of the proposal domain.
+	ProposalFieldDomain = politeia.ProposalFieldDomain
+
+	// AutoVoteRuleFollowVoters votes like the majority of trusted voters.
+	AutoVoteRuleFollowVoters = politeia.AutoVoteRuleFollowVoters
+	// AutoVoteRuleBudget votes a given option for proposals under a budget.
+	AutoVoteRuleBudget = politeia.AutoVoteRuleBudget
+	// AutoVoteRuleAbstain skips voting.
+	AutoVoteRuleAbstain = politeia.AutoVoteRuleAbstain
+
+	// ProposalOutcomeAny does not filter proposals by vote outcome.
+	ProposalOutcomeAny = politeia.ProposalOutcomeAny
+	// ProposalOutcomeApproved filters approved proposals.
+	ProposalOutcomeApproved = politeia.ProposalOutcomeApproved
+	// ProposalOutcomeRejected filters rejected proposals.
+	ProposalOutcomeRejected = politeia.ProposalOutcomeRejected
+
+	// ProposalVotedAny does not filter proposals by the wallets' votes.
+	ProposalVotedAny = politeia.ProposalVotedAny
+	// ProposalVotedByMe filters proposals voted on by the wallets.
+	ProposalVotedByMe = politeia.ProposalVotedByMe
+	// ProposalNotVotedByMe filters proposals not voted on by the wallets.
+	ProposalNotVotedByMe = politeia.ProposalNotVotedByMe
+
+	// ErrVoteNotCounted is the result error of a vote receipt whose vote is
+	// missing from the proposal's vote results.
+	ErrVoteNotCounted = politeia.ErrVoteNotCounted
+	// ErrVoteMismatch is the result error of a vote receipt whose vote was
+	// counted with another vote bit or signature.
+	ErrVoteMismatch = politeia.ErrVoteMismatch
+	// ErrServerKeyMismatch is the key warning of a vote receipt signed with
+	// a server key that was never pinned.
+	ErrServerKeyMismatch = politeia.ErrServerKeyMismatch
+	// ErrServerKeyUnknown is the key warning of a vote receipt verified
+	// before the politeia server key was pinned.
+	ErrServerKeyUnknown = politeia.ErrServerKeyUnknown
+	// ErrServerKeyRotated is the key warning of the vote receipts verified
+	// against a politeia server that no longer uses the pinned key.
+	ErrServerKeyRotated = politeia.ErrServerKeyRotated
 )
 
 type Proposal struct {
@@ -65,6 +120,42 @@ type ProposalVote struct {
 	politeia.ProposalVote
 }
 
+type Comment struct {
+	politeia.Comment
+}
+
+// ProposalCommentThread is an alias rather than a wrapper so that the nested
+// Replies can be walked by packages outside libwallet.
+type ProposalCommentThread = politeia.ProposalCommentThread
+
+type ProposalVersion struct {
+	politeia.ProposalVersion
+}
+
+type AutoVoteRule = politeia.AutoVoteRule
+
+type AutoVotePolicy = politeia.AutoVotePolicy
+
+type AutoVoteDecision = politeia.AutoVoteDecision
+
+type AutoVoteLog struct {
+	politeia.AutoVoteLog
+}
+
+type ProposalFilter = politeia.ProposalFilter
+
+// ProposalVersionDiff is an alias so that the changed fields and lines can be
+// read by packages outside libwallet.
+type ProposalVersionDiff = politeia.ProposalVersionDiff
+
+// VoteReceipt is an alias so that the saved receipt fields can be read by
+// packages outside libwallet.
+type VoteReceipt = politeia.VoteReceipt
+
+// VoteReceiptVerification is an alias so that the verified receipt fields
+// can be read by packages outside libwallet.
+type VoteReceiptVerification = politeia.VoteReceiptVerification
+
 // WrapVote, wraps vote type of politeia.ProposalVote into libwallet.ProposalVote
 func WrapVote(hash, address, bit string) *ProposalVote {
 	return &ProposalVote{
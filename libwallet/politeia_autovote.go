@@ -0,0 +1,237 @@
+package libwallet
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"decred.org/dcrwallet/v3/errors"
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	"github.com/crypto-power/cryptopower/libwallet/internal/politeia"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const autoVoteListenerID = "auto_vote"
+
+// autoVoteInterval is the time between two evaluations of the auto-vote
+// policy on the proposals being voted on. Rules such as following trusted
+// voters may only apply once voting is under way.
+const autoVoteInterval = 30 * time.Minute
+
+// autoVoter evaluates the auto-vote policy on the proposals being voted on
+// for the whole voting window, and casts the decided votes with the tickets
+// of the wallets that have auto-voting enabled. The wallets are never
+// unlocked here: the votes decided for a locked wallet are kept pending
+// until the user unlocks it with CastPendingAutoVotes.
+type autoVoter struct {
+	mgr *AssetsManager
+
+	// trigger requests an evaluation ahead of the next tick.
+	trigger chan struct{}
+
+	// evaluateMu serializes the evaluations so that a vote is not cast
+	// twice with the same tickets.
+	evaluateMu sync.Mutex
+
+	mu sync.RWMutex
+	// pending holds the votes decided for locked wallets, keyed by wallet
+	// ID and proposal token.
+	pending map[int]map[string]*politeia.AutoVoteDecision
+}
+
+func newAutoVoter(mgr *AssetsManager) *autoVoter {
+	return &autoVoter{
+		mgr:     mgr,
+		trigger: make(chan struct{}, 1),
+		pending: make(map[int]map[string]*politeia.AutoVoteDecision),
+	}
+}
+
+func (av *autoVoter) OnNewProposal(_ interface{})            {}
+func (av *autoVoter) OnProposalVoteFinished(_ interface{})   {}
+func (av *autoVoter) OnProposalVersionUpdated(_ interface{}) {}
+func (av *autoVoter) OnProposalsSynced()                     { av.evaluateSoon() }
+func (av *autoVoter) OnProposalVoteStarted(_ interface{})    { av.evaluateSoon() }
+
+// evaluateSoon requests an evaluation without holding up the caller, e.g.
+// the sync that published a notification.
+func (av *autoVoter) evaluateSoon() {
+	select {
+	case av.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// run evaluates the auto-vote policy periodically and whenever requested
+// until ctx is canceled.
+func (av *autoVoter) run(ctx context.Context) {
+	t := time.NewTicker(autoVoteInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+		case <-av.trigger:
+		case <-ctx.Done():
+			return
+		}
+
+		av.evaluate(ctx)
+	}
+}
+
+// evaluate evaluates the auto-vote policy on the active proposals for every
+// wallet with auto-voting enabled.
+func (av *autoVoter) evaluate(ctx context.Context) {
+	if !av.mgr.IsHTTPAPIPrivacyModeOff(utils.GovernanceHTTPAPI) {
+		return
+	}
+
+	proposals, err := av.mgr.Politeia.GetProposalsRaw(ProposalCategoryActive, 0, 0, true)
+	if err != nil {
+		log.Errorf("Error loading the proposals to auto-vote on: %v", err)
+		return
+	}
+
+	for _, wallet := range av.mgr.AllDCRWallets() {
+		asset, ok := wallet.(*dcr.Asset)
+		if !ok {
+			continue
+		}
+		if !asset.IsAutoVoteEnabled() || asset.IsWatchingOnlyWallet() || !asset.IsSynced() {
+			av.clearPending(asset.ID)
+			continue
+		}
+
+		av.evaluateWallet(ctx, asset, proposals)
+	}
+}
+
+// evaluateWallet casts the votes decided on the proposals with the wallet's
+// eligible tickets. The decisions are kept pending if the wallet is locked.
+// The decisions to not vote are recorded once per proposal in the auto-vote
+// logs, a vote may still be cast if a rule applies later on.
+func (av *autoVoter) evaluateWallet(ctx context.Context, asset *dcr.Asset, proposals []politeia.Proposal) {
+	av.evaluateMu.Lock()
+	defer av.evaluateMu.Unlock()
+
+	pending := make(map[string]*politeia.AutoVoteDecision)
+	defer func() {
+		av.mu.Lock()
+		av.pending[asset.ID] = pending
+		av.mu.Unlock()
+	}()
+
+	for _, proposal := range proposals {
+		if ctx.Err() != nil {
+			return
+		}
+
+		decision, err := av.mgr.Politeia.PreviewAutoVote(ctx, asset.Internal().DCR, proposal.Token)
+		if err != nil {
+			log.Errorf("[%d] Auto-vote on proposal %s failed: %v", asset.ID, proposal.Token, err)
+			continue
+		}
+		if decision.VoteOption == "" || decision.NumTickets == 0 {
+			logged, err := av.mgr.Politeia.HasAutoVoteLog(asset.ID, decision.Token)
+			if err == nil && !logged {
+				err = av.mgr.Politeia.SaveAutoVoteLog(asset.ID, decision, nil)
+			}
+			if err != nil {
+				log.Errorf("[%d] Error logging the auto-vote decision on proposal %s: %v", asset.ID, decision.Token, err)
+			}
+			continue
+		}
+
+		if asset.IsLocked() {
+			// The locked wallet is recorded once in the auto-vote logs,
+			// when the vote is first decided.
+			if !av.isPending(asset.ID, decision.Token) {
+				log.Infof("[%d] Auto-vote on proposal %s needs the wallet to be unlocked", asset.ID, decision.Token)
+				err := av.mgr.Politeia.SaveAutoVoteLog(asset.ID, decision, errors.New(politeia.ErrWalletLocked))
+				if err != nil {
+					log.Errorf("[%d] Error logging the auto-vote decision on proposal %s: %v", asset.ID, decision.Token, err)
+				}
+			}
+			pending[decision.Token] = decision
+			continue
+		}
+
+		if err := av.mgr.Politeia.CastAutoVote(ctx, asset.Internal().DCR, asset.ID, decision); err != nil {
+			log.Errorf("[%d] Auto-vote on proposal %s failed: %v", asset.ID, decision.Token, err)
+			continue
+		}
+		log.Infof("[%d] Auto-vote on proposal %s: option %q on %d tickets (%s)",
+			asset.ID, decision.Token, decision.VoteOption, decision.NumTickets, decision.Reason)
+	}
+}
+
+func (av *autoVoter) isPending(walletID int, token string) bool {
+	av.mu.RLock()
+	defer av.mu.RUnlock()
+	_, ok := av.pending[walletID][token]
+	return ok
+}
+
+func (av *autoVoter) clearPending(walletID int) {
+	av.mu.Lock()
+	delete(av.pending, walletID)
+	av.mu.Unlock()
+}
+
+// pendingDecisions returns the votes decided for the wallet that could not
+// be cast because it is locked, ordered by proposal name.
+func (av *autoVoter) pendingDecisions(walletID int) []*politeia.AutoVoteDecision {
+	av.mu.RLock()
+	defer av.mu.RUnlock()
+
+	decisions := make([]*politeia.AutoVoteDecision, 0, len(av.pending[walletID]))
+	for _, decision := range av.pending[walletID] {
+		decisions = append(decisions, decision)
+	}
+	sort.Slice(decisions, func(i, j int) bool {
+		return decisions[i].ProposalName < decisions[j].ProposalName
+	})
+	return decisions
+}
+
+// EvaluateAutoVotes evaluates the auto-vote policy on the active proposals
+// in the background, e.g. once auto-voting was enabled or its rules changed.
+func (mgr *AssetsManager) EvaluateAutoVotes() {
+	mgr.autoVoter.evaluateSoon()
+}
+
+// PendingAutoVotes returns the votes decided by the auto-vote policy that
+// could not be cast because the wallet is locked. They are cast by
+// CastPendingAutoVotes.
+func (mgr *AssetsManager) PendingAutoVotes(walletID int) []*AutoVoteDecision {
+	return mgr.autoVoter.pendingDecisions(walletID)
+}
+
+// CastPendingAutoVotes unlocks the wallet, evaluates the auto-vote policy on
+// the active proposals again and casts the decided votes. The wallet is
+// locked again afterwards if it was locked.
+func (mgr *AssetsManager) CastPendingAutoVotes(walletID int, privatePassphrase string) error {
+	asset, ok := mgr.WalletWithID(walletID).(*dcr.Asset)
+	if !ok {
+		return errors.New(utils.ErrNotExist)
+	}
+
+	proposals, err := mgr.Politeia.GetProposalsRaw(ProposalCategoryActive, 0, 0, true)
+	if err != nil {
+		return err
+	}
+
+	if asset.IsLocked() {
+		if err := asset.UnlockWallet(privatePassphrase); err != nil {
+			return err
+		}
+		defer asset.LockWallet()
+	}
+
+	ctx, cancel := asset.ShutdownContextWithCancel()
+	defer cancel()
+	mgr.autoVoter.evaluateWallet(ctx, asset, proposals)
+	return nil
+}
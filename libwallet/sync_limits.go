@@ -0,0 +1,64 @@
+package libwallet
+
+import (
+	"context"
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+)
+
+// syncLimitsCheckInterval is the time between two checks of the wallets sync
+// schedule and daily data limit.
+const syncLimitsCheckInterval = time.Minute
+
+// enforceSyncLimits stops the sync of the wallets that are outside of their
+// sync schedule or have reached their daily data limit, and restarts it once
+// they may sync again. Only the wallets stopped here, or set to sync
+// automatically, are restarted so that a sync stopped by the user stays
+// stopped.
+func (mgr *AssetsManager) enforceSyncLimits(ctx context.Context) {
+	paused := make(map[int]bool)
+
+	t := time.NewTicker(syncLimitsCheckInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return
+		}
+
+		for _, wallet := range mgr.AllWallets() {
+			if !wallet.WalletOpened() {
+				continue
+			}
+
+			walletID := wallet.GetWalletID()
+			allowed := wallet.SyncAllowed() == nil
+			connected := wallet.IsConnectedToNetwork()
+
+			switch {
+			case connected && !allowed:
+				log.Infof("Stopping sync of wallet (%s), its sync limits are reached", wallet.GetWalletName())
+				wallet.CancelSync()
+				paused[walletID] = true
+
+			case !connected && !allowed && wallet.ReadBoolConfigValueForKey(sharedW.AutoSyncConfigKey, false):
+				// The sync was not started on launch because of the limits.
+				paused[walletID] = true
+
+			case !connected && allowed && paused[walletID]:
+				log.Infof("Resuming sync of wallet (%s)", wallet.GetWalletName())
+				if err := wallet.SpvSync(); err != nil {
+					log.Errorf("Resuming sync of wallet (%s) failed: %v", wallet.GetWalletName(), err)
+					continue
+				}
+				delete(paused, walletID)
+
+			case connected:
+				delete(paused, walletID)
+			}
+		}
+	}
+}
@@ -0,0 +1,440 @@
+package libwallet
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+	"github.com/crypto-power/cryptopower/libwallet/ext"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// treasurySpendHeightMargin is the number of blocks searched before and
+	// after the heights estimated for a day, block times drift from the
+	// target block time.
+	treasurySpendHeightMargin = 1000
+
+	// proposalPaymentGracePeriod is how long after the end date of a
+	// proposal's funding period a treasury spend may still pay it. Invoices
+	// are billed monthly and paid once approved.
+	proposalPaymentGracePeriod = 60 * 24 * time.Hour
+
+	// treasurySpendBlocksPerRequest is the most blocks requested at once
+	// when searching for treasury spends.
+	treasurySpendBlocksPerRequest = 500
+
+	// treasurySpendTxsPerRequest is the most transactions requested at once
+	// when searching for treasury spends.
+	treasurySpendTxsPerRequest = 250
+)
+
+// TreasuryDay is the treasury's inflow and outflow in a day, cached from
+// dcrdata. The amounts are in DCR.
+type TreasuryDay struct {
+	// Time is the unix timestamp of the start of the day.
+	Time     int64 `storm:"id"`
+	Received float64
+	Sent     float64
+	// SpendsFetched is true once the treasury spends mined in the day are
+	// cached.
+	SpendsFetched bool
+}
+
+// TreasurySpend is a treasury spend transaction mined on chain.
+type TreasurySpend struct {
+	TxHash    string `storm:"id"`
+	Height    uint32
+	Timestamp int64 `storm:"index"`
+	// Amount is the DCR paid out of the treasury.
+	Amount float64
+	// Payees are the addresses paid by the spend.
+	Payees []string
+}
+
+// TreasuryBalance is the treasury balance at the end of a day.
+type TreasuryBalance struct {
+	Time    int64
+	Balance float64
+}
+
+// TreasuryMonth is the amount added to and spent from the treasury in a
+// month.
+type TreasuryMonth struct {
+	// Time is the unix timestamp of the start of the month.
+	Time  int64
+	Added float64
+	Spent float64
+}
+
+// LinkedTreasurySpend is a treasury spend and the approved proposals it
+// likely paid.
+type LinkedTreasurySpend struct {
+	*TreasurySpend
+	// Proposals are the approved proposals whose funding period covers the
+	// time of the spend. Treasury spends don't reference the proposals they
+	// pay on chain so this is an estimate, a spend usually pays the
+	// invoices of several proposals.
+	Proposals []*Proposal
+}
+
+// TreasuryAnalytics is the history of the treasury built from the cached
+// treasury data.
+type TreasuryAnalytics struct {
+	Balance []*TreasuryBalance
+	// Months are ordered oldest first.
+	Months []*TreasuryMonth
+	// Spends are ordered newest first.
+	Spends []*LinkedTreasurySpend
+}
+
+// SyncTreasuryHistory fetches the treasury's daily inflows and outflows and
+// the treasury spends from dcrdata and caches them. Only the days whose
+// spends are not cached yet are searched for spends.
+func (mgr *AssetsManager) SyncTreasuryHistory(ctx context.Context) error {
+	if !mgr.IsHTTPAPIPrivacyModeOff(utils.GovernanceHTTPAPI) {
+		return errors.New(utils.ErrUnavailable)
+	}
+
+	treasuryIO, err := mgr.ExternalService.GetTreasuryIO(ext.TreasuryDayGrouping)
+	if err != nil {
+		return err
+	}
+	if len(treasuryIO.Received) != len(treasuryIO.Time) || len(treasuryIO.Sent) != len(treasuryIO.Time) {
+		return errors.New(utils.ErrInvalid)
+	}
+
+	var pendingDays []*TreasuryDay
+	for i := range treasuryIO.Time {
+		day := &TreasuryDay{
+			Time:     treasuryIO.Time[i],
+			Received: treasuryIO.Received[i],
+			Sent:     treasuryIO.Sent[i],
+		}
+
+		// Spends mined in a day after it was cached change its outflow,
+		// they are fetched again.
+		var savedDay TreasuryDay
+		if err := mgr.params.DB.One("Time", day.Time, &savedDay); err == nil {
+			day.SpendsFetched = savedDay.SpendsFetched && savedDay.Sent == day.Sent
+		}
+		if err := mgr.params.DB.Save(day); err != nil {
+			return err
+		}
+
+		if day.Sent > 0 && !day.SpendsFetched {
+			pendingDays = append(pendingDays, day)
+		}
+	}
+
+	if len(pendingDays) > 0 {
+		bestHeight := mgr.ExternalService.GetBestBlock()
+		bestTime := mgr.ExternalService.GetBestBlockTimeStamp()
+		if bestHeight <= 0 || bestTime <= 0 {
+			return errors.New(utils.ErrUnavailable)
+		}
+
+		params := mgr.chainsParams.DCR
+		spans := treasurySpendSpans(pendingDays, uint32(bestHeight), bestTime,
+			uint32(params.TreasuryVoteInterval), int64(params.TargetTimePerBlock.Seconds()))
+		for _, span := range spans {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if err := mgr.fetchTreasurySpends(span, uint32(params.TreasuryVoteInterval)); err != nil {
+				log.Errorf("Error fetching the treasury spends of %s to %s: %v",
+					time.Unix(span.days[0].Time, 0).UTC().Format("2006-01-02"),
+					time.Unix(span.days[len(span.days)-1].Time, 0).UTC().Format("2006-01-02"), err)
+				continue
+			}
+
+			for _, day := range span.days {
+				day.SpendsFetched = true
+				if err := mgr.params.DB.Save(day); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// Fetch the funding periods of the approved proposals, they are used to
+	// link the spends to the proposals. The versions already cached are not
+	// fetched again.
+	proposals, err := mgr.Politeia.GetProposalsRaw(ProposalCategoryApproved, 0, 0, true)
+	if err != nil {
+		return err
+	}
+	for _, proposal := range proposals {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if mgr.proposalVersionCached(proposal.Token, proposal.Version) {
+			continue
+		}
+		if _, err := mgr.Politeia.FetchProposalVersion(proposal.Token, proposal.Version); err != nil {
+			log.Errorf("Error fetching version %s of proposal %s: %v", proposal.Version, proposal.Token, err)
+		}
+	}
+
+	return nil
+}
+
+// proposalVersionCached returns true if the version of the proposal is
+// cached with its funding period or as the complete record.
+func (mgr *AssetsManager) proposalVersionCached(token, version string) bool {
+	versions, err := mgr.Politeia.GetProposalVersionsRaw(token)
+	if err != nil {
+		return false
+	}
+	for _, v := range versions {
+		if v.Version == version && (v.IndexFile != "" || (v.StartDate != 0 && v.EndDate != 0)) {
+			return true
+		}
+	}
+	return false
+}
+
+// treasurySpendSpan is a range of blocks at treasury vote intervals that
+// holds the treasury spends of consecutive days.
+type treasurySpendSpan struct {
+	from, to uint32
+	days     []*TreasuryDay
+	// empty is true if no vote interval block can be mined in the days.
+	empty bool
+}
+
+// treasurySpendSpans groups the days into the ranges of blocks searched for
+// their treasury spends. Treasury spends can only be mined in blocks at
+// treasury vote intervals, the heights of a day are estimated from the best
+// block and the ranges of days that overlap are merged so that they are
+// requested at once. Days that can't hold a vote interval block get an empty
+// span.
+func treasurySpendSpans(days []*TreasuryDay, bestHeight uint32, bestTime int64, tvi uint32, blockTime int64) []*treasurySpendSpan {
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Time < days[j].Time
+	})
+
+	estimateHeight := func(timestamp int64) int64 {
+		return int64(bestHeight) - (bestTime-timestamp)/blockTime
+	}
+
+	var spans []*treasurySpendSpan
+	var last *treasurySpendSpan
+	for _, day := range days {
+		dayEnd := day.Time + int64((24 * time.Hour).Seconds())
+		from := estimateHeight(day.Time) - treasurySpendHeightMargin
+		to := estimateHeight(dayEnd) + treasurySpendHeightMargin
+		if from < 0 {
+			from = 0
+		}
+		if to > int64(bestHeight) {
+			to = int64(bestHeight)
+		}
+
+		// Start from a vote interval so that only those blocks are
+		// returned.
+		start := uint32(from) + tvi - 1
+		start -= start % tvi
+		if from > to || start > uint32(to) {
+			spans = append(spans, &treasurySpendSpan{days: []*TreasuryDay{day}, empty: true})
+			continue
+		}
+
+		if last != nil && start <= last.to+tvi && (uint32(to)-last.from)/tvi < treasurySpendBlocksPerRequest {
+			if uint32(to) > last.to {
+				last.to = uint32(to)
+			}
+			last.days = append(last.days, day)
+			continue
+		}
+
+		last = &treasurySpendSpan{from: start, to: uint32(to), days: []*TreasuryDay{day}}
+		spans = append(spans, last)
+	}
+	return spans
+}
+
+// fetchTreasurySpends caches the treasury spends mined in the days of the
+// span. The stake transactions of the blocks of the span are fetched in
+// batches.
+func (mgr *AssetsManager) fetchTreasurySpends(span *treasurySpendSpan, tvi uint32) error {
+	if span.empty {
+		return nil
+	}
+
+	blocks, err := mgr.ExternalService.GetBlockRange(span.from, span.to, tvi)
+	if err != nil {
+		return err
+	}
+
+	var stxHashes []string
+	blockHeights := make(map[string]uint32)
+	blockTimes := make(map[uint32]int64)
+	for _, block := range blocks {
+		blockTimestamp := block.Time.UNIX()
+		if !span.holds(blockTimestamp) {
+			continue
+		}
+
+		blockTxs, err := mgr.ExternalService.GetBlockTransactions(block.Height)
+		if err != nil {
+			return err
+		}
+		for _, txHash := range blockTxs.STx {
+			stxHashes = append(stxHashes, txHash)
+			blockHeights[txHash] = block.Height
+		}
+		blockTimes[block.Height] = blockTimestamp
+	}
+	for len(stxHashes) > 0 {
+		batch := stxHashes
+		if len(batch) > treasurySpendTxsPerRequest {
+			batch = batch[:treasurySpendTxsPerRequest]
+		}
+		stxHashes = stxHashes[len(batch):]
+
+		txs, err := mgr.ExternalService.GetTransactions(batch)
+		if err != nil {
+			return err
+		}
+
+		for _, tx := range txs {
+			if len(tx.Vin) == 0 || !tx.Vin[0].IsTreasurySpend() {
+				continue
+			}
+
+			height := blockHeights[tx.TxID]
+			spend := &TreasurySpend{
+				TxHash:    tx.TxID,
+				Height:    height,
+				Timestamp: blockTimes[height],
+			}
+			for _, out := range tx.Vout {
+				spend.Amount += out.Value
+				spend.Payees = append(spend.Payees, out.ScriptPubKeyDecoded.Addresses...)
+			}
+			if err := mgr.params.DB.Save(spend); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// holds returns true if the timestamp is in one of the days of the span.
+func (span *treasurySpendSpan) holds(timestamp int64) bool {
+	for _, day := range span.days {
+		if timestamp >= day.Time && timestamp < day.Time+int64((24*time.Hour).Seconds()) {
+			return true
+		}
+	}
+	return false
+}
+
+// TreasuryAnalytics returns the treasury balance history, the monthly
+// inflows and outflows and the treasury spends linked to the approved
+// proposals they likely paid, built from the data cached by
+// SyncTreasuryHistory.
+func (mgr *AssetsManager) TreasuryAnalytics() (*TreasuryAnalytics, error) {
+	var days []TreasuryDay
+	err := mgr.params.DB.Select(q.True()).OrderBy("Time").Find(&days)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+
+	var spends []TreasurySpend
+	err = mgr.params.DB.AllByIndex("Timestamp", &spends, storm.Reverse())
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+
+	fundingPeriods, err := mgr.proposalFundingPeriods()
+	if err != nil {
+		return nil, err
+	}
+
+	return buildTreasuryAnalytics(days, spends, fundingPeriods), nil
+}
+
+// buildTreasuryAnalytics builds the treasury history from the days ordered
+// oldest first and the spends ordered newest first. A spend is linked to
+// the proposals whose funding period covers it.
+func buildTreasuryAnalytics(days []TreasuryDay, spends []TreasurySpend, fundingPeriods []*proposalFundingPeriod) *TreasuryAnalytics {
+	analytics := &TreasuryAnalytics{}
+	var balance float64
+	var month *TreasuryMonth
+	for _, day := range days {
+		balance += day.Received - day.Sent
+		analytics.Balance = append(analytics.Balance, &TreasuryBalance{
+			Time:    day.Time,
+			Balance: balance,
+		})
+
+		dayTime := time.Unix(day.Time, 0).UTC()
+		monthStart := time.Date(dayTime.Year(), dayTime.Month(), 1, 0, 0, 0, 0, time.UTC).Unix()
+		if month == nil || month.Time != monthStart {
+			month = &TreasuryMonth{Time: monthStart}
+			analytics.Months = append(analytics.Months, month)
+		}
+		month.Added += day.Received
+		month.Spent += day.Sent
+	}
+
+	for i := range spends {
+		linked := &LinkedTreasurySpend{TreasurySpend: &spends[i]}
+		for _, period := range fundingPeriods {
+			if spends[i].Timestamp >= period.start && spends[i].Timestamp <= period.end {
+				linked.Proposals = append(linked.Proposals, period.proposal)
+			}
+		}
+		analytics.Spends = append(analytics.Spends, linked)
+	}
+
+	return analytics
+}
+
+type proposalFundingPeriod struct {
+	proposal   *Proposal
+	start, end int64
+}
+
+// proposalFundingPeriods returns the periods during which the approved
+// proposals can be paid, ordered by their start. Proposals whose funding
+// period is not cached are skipped.
+func (mgr *AssetsManager) proposalFundingPeriods() ([]*proposalFundingPeriod, error) {
+	proposals, err := mgr.Politeia.GetProposalsRaw(ProposalCategoryApproved, 0, 0, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var periods []*proposalFundingPeriod
+	for i := range proposals {
+		versions, err := mgr.Politeia.GetProposalVersionsRaw(proposals[i].Token)
+		if err != nil {
+			return nil, err
+		}
+
+		// Use the latest version that has a funding period.
+		for j := len(versions) - 1; j >= 0; j-- {
+			if versions[j].StartDate == 0 || versions[j].EndDate == 0 {
+				continue
+			}
+			periods = append(periods, &proposalFundingPeriod{
+				proposal: &Proposal{Proposal: proposals[i]},
+				start:    versions[j].StartDate,
+				end:      versions[j].EndDate + int64(proposalPaymentGracePeriod.Seconds()),
+			})
+			break
+		}
+	}
+
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].start < periods[j].start
+	})
+	return periods, nil
+}
@@ -0,0 +1,137 @@
+package libwallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/internal/politeia"
+)
+
+func TestBuildTreasuryAnalytics(t *testing.T) {
+	dayTime := func(year int, month time.Month, day int) int64 {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix()
+	}
+
+	days := []TreasuryDay{
+		{Time: dayTime(2023, time.January, 30), Received: 100},
+		{Time: dayTime(2023, time.January, 31), Received: 50, Sent: 30},
+		{Time: dayTime(2023, time.February, 1), Received: 20, Sent: 100},
+	}
+	spends := []TreasurySpend{
+		{TxHash: "feb", Timestamp: dayTime(2023, time.February, 1) + 3600, Amount: 100},
+		{TxHash: "jan", Timestamp: dayTime(2023, time.January, 31) + 3600, Amount: 30},
+	}
+	janProposal := &Proposal{Proposal: politeia.Proposal{Token: "jan"}}
+	bothProposal := &Proposal{Proposal: politeia.Proposal{Token: "both"}}
+	periods := []*proposalFundingPeriod{
+		{proposal: bothProposal, start: dayTime(2023, time.January, 1), end: dayTime(2023, time.March, 1)},
+		{proposal: janProposal, start: dayTime(2023, time.January, 1), end: dayTime(2023, time.February, 1)},
+	}
+
+	analytics := buildTreasuryAnalytics(days, spends, periods)
+
+	wantBalance := []float64{100, 120, 40}
+	if len(analytics.Balance) != len(wantBalance) {
+		t.Fatalf("expected %d balances, got %d", len(wantBalance), len(analytics.Balance))
+	}
+	for i, balance := range analytics.Balance {
+		if balance.Time != days[i].Time || balance.Balance != wantBalance[i] {
+			t.Errorf("balance %d: expected %v at %d, got %v at %d", i, wantBalance[i], days[i].Time, balance.Balance, balance.Time)
+		}
+	}
+
+	wantMonths := []TreasuryMonth{
+		{Time: dayTime(2023, time.January, 1), Added: 150, Spent: 30},
+		{Time: dayTime(2023, time.February, 1), Added: 20, Spent: 100},
+	}
+	if len(analytics.Months) != len(wantMonths) {
+		t.Fatalf("expected %d months, got %d", len(wantMonths), len(analytics.Months))
+	}
+	for i, month := range analytics.Months {
+		if *month != wantMonths[i] {
+			t.Errorf("month %d: expected %+v, got %+v", i, wantMonths[i], *month)
+		}
+	}
+
+	// A spend is linked to every proposal whose funding period covers it.
+	wantProposals := map[string][]string{
+		"feb": {"both"},
+		"jan": {"both", "jan"},
+	}
+	if len(analytics.Spends) != len(spends) {
+		t.Fatalf("expected %d spends, got %d", len(spends), len(analytics.Spends))
+	}
+	for i, spend := range analytics.Spends {
+		if spend.TxHash != spends[i].TxHash {
+			t.Errorf("spend %d: expected %s, got %s", i, spends[i].TxHash, spend.TxHash)
+		}
+		want := wantProposals[spend.TxHash]
+		if len(spend.Proposals) != len(want) {
+			t.Errorf("spend %s: expected proposals %v, got %d", spend.TxHash, want, len(spend.Proposals))
+			continue
+		}
+		for j, proposal := range spend.Proposals {
+			if proposal.Token != want[j] {
+				t.Errorf("spend %s: expected proposal %s, got %s", spend.TxHash, want[j], proposal.Token)
+			}
+		}
+	}
+}
+
+func TestTreasurySpendSpans(t *testing.T) {
+	const (
+		tvi       = 288
+		blockTime = 300
+	)
+	bestHeight := uint32(1000000)
+	bestTime := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC).Unix()
+	day := func(daysAgo int) *TreasuryDay {
+		return &TreasuryDay{Time: bestTime - int64(daysAgo)*int64((24*time.Hour).Seconds())}
+	}
+
+	// Consecutive days share their blocks and are requested at once, a
+	// day months apart gets its own range.
+	spans := treasurySpendSpans([]*TreasuryDay{day(2), day(90), day(3), day(1)}, bestHeight, bestTime, tvi, blockTime)
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	if len(spans[0].days) != 1 || len(spans[1].days) != 3 {
+		t.Fatalf("expected spans of 1 and 3 days, got %d and %d", len(spans[0].days), len(spans[1].days))
+	}
+	for _, span := range spans {
+		if span.empty || span.from%tvi != 0 || span.from > span.to || span.to > bestHeight {
+			t.Errorf("invalid span %d-%d", span.from, span.to)
+		}
+		for _, d := range span.days {
+			if !span.holds(d.Time) || !span.holds(d.Time+int64((24*time.Hour).Seconds())-1) {
+				t.Errorf("span %d-%d doesn't hold its day %d", span.from, span.to, d.Time)
+			}
+		}
+	}
+
+	// A day before the first block can't hold a treasury spend.
+	spans = treasurySpendSpans([]*TreasuryDay{day(5000)}, bestHeight, bestTime, tvi, blockTime)
+	if len(spans) != 1 || !spans[0].empty {
+		t.Errorf("expected an empty span, got %+v", spans)
+	}
+
+	// The ranges are limited to treasurySpendBlocksPerRequest blocks.
+	var days []*TreasuryDay
+	for i := 1; i <= 1200; i++ {
+		days = append(days, day(i))
+	}
+	spans = treasurySpendSpans(days, bestHeight, bestTime, tvi, blockTime)
+	if len(spans) < 3 {
+		t.Errorf("expected the days to be split in several spans, got %d", len(spans))
+	}
+	covered := 0
+	for _, span := range spans {
+		if (span.to-span.from)/tvi >= treasurySpendBlocksPerRequest {
+			t.Errorf("span %d-%d has too many blocks", span.from, span.to)
+		}
+		covered += len(span.days)
+	}
+	if covered != len(days) {
+		t.Errorf("expected the spans to cover %d days, got %d", len(days), covered)
+	}
+}
@@ -0,0 +1,211 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointType identifies a remote service whose hosts can be configured
+// by the user.
+type EndpointType string
+
+const (
+	PoliteiaEndpoint  EndpointType = "politeia"
+	DcrdataEndpoint   EndpointType = "dcrdata"
+	BlockbookEndpoint EndpointType = "blockbook"
+	VSPListEndpoint   EndpointType = "vsp_list"
+
+	// endpointRetryDelay is how long an endpoint that failed is tried after
+	// the other endpoints of its service.
+	endpointRetryDelay = 2 * time.Minute
+)
+
+// EndpointTypes lists the services whose hosts can be configured.
+var EndpointTypes = []EndpointType{PoliteiaEndpoint, DcrdataEndpoint, BlockbookEndpoint, VSPListEndpoint}
+
+// Endpoint is a host of a remote service. The endpoints of a service are
+// tried in order, the first one is the primary host and the others are
+// failed over to if it can't be reached.
+type Endpoint struct {
+	// URL is the base URL of the service, request paths are appended to it.
+	URL string `json:"url"`
+	// CertPin, if set, is the hex encoded SHA-256 hash of the public key of
+	// the server's certificate. Connections to servers that don't present a
+	// certificate with that key are rejected, the certificate authorities
+	// are not checked so that self-signed certificates can be pinned.
+	CertPin string `json:"cert_pin"`
+	// Disabled endpoints are never contacted.
+	Disabled bool `json:"disabled"`
+}
+
+// Validate returns an error if the URL or the certificate pin is invalid.
+func (e *Endpoint) Validate() error {
+	u, err := url.ParseRequestURI(e.URL)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return errors.New(ErrInvalidAddress)
+	}
+	if e.CertPin != "" {
+		if u.Scheme != "https" {
+			return errors.New(ErrInvalid)
+		}
+		if pin, err := hex.DecodeString(e.CertPin); err != nil || len(pin) != sha256.Size {
+			return errors.New(ErrInvalid)
+		}
+	}
+	return nil
+}
+
+// EndpointHealth is the result of a health check of an endpoint.
+type EndpointHealth struct {
+	URL     string
+	Latency time.Duration
+	// CertPin is the pin of the certificate presented by the server, it may
+	// be used to pin the endpoint to the current certificate.
+	CertPin   string
+	Error     string
+	CheckedAt time.Time
+}
+
+// Healthy returns true if the endpoint responded to the health check.
+func (h *EndpointHealth) Healthy() bool {
+	return h.Error == ""
+}
+
+var (
+	failuresMu       sync.Mutex
+	endpointFailures = make(map[string]time.Time) // url => time of last failure
+)
+
+// CertPin returns the pin of the certificate, the hex encoded SHA-256 hash
+// of its public key.
+func CertPin(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return hex.EncodeToString(hash[:])
+}
+
+// verifyCertPin returns a tls.Config VerifyConnection function that rejects
+// the connection if the server's certificate doesn't match the pin.
+func verifyCertPin(pin string) func(tls.ConnectionState) error {
+	return func(state tls.ConnectionState) error {
+		if len(state.PeerCertificates) == 0 || CertPin(state.PeerCertificates[0]) != pin {
+			return fmt.Errorf("the certificate of %s doesn't match the pinned key", state.ServerName)
+		}
+		return nil
+	}
+}
+
+// EndpointURL appends the path to the endpoint's base URL.
+func EndpointURL(baseURL, path string) string {
+	if path == "" {
+		return baseURL
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+// OrderEndpoints returns the enabled endpoints, the ones that failed in the
+// last endpointRetryDelay are moved after the others.
+func OrderEndpoints(endpoints []Endpoint) []Endpoint {
+	failuresMu.Lock()
+	defer failuresMu.Unlock()
+
+	enabled := make([]Endpoint, 0, len(endpoints))
+	for _, e := range endpoints {
+		if !e.Disabled {
+			enabled = append(enabled, e)
+		}
+	}
+	sort.SliceStable(enabled, func(i, j int) bool {
+		return !recentlyFailed(enabled[i].URL) && recentlyFailed(enabled[j].URL)
+	})
+	return enabled
+}
+
+// EndpointFailed returns true if the last request to the endpoint failed in
+// the last endpointRetryDelay.
+func EndpointFailed(endpointURL string) bool {
+	failuresMu.Lock()
+	defer failuresMu.Unlock()
+	return recentlyFailed(endpointURL)
+}
+
+func recentlyFailed(endpointURL string) bool {
+	failedAt, ok := endpointFailures[endpointURL]
+	return ok && time.Since(failedAt) < endpointRetryDelay
+}
+
+func setEndpointFailed(endpointURL string, failed bool) {
+	failuresMu.Lock()
+	defer failuresMu.Unlock()
+	if failed {
+		endpointFailures[endpointURL] = time.Now()
+	} else {
+		delete(endpointFailures, endpointURL)
+	}
+}
+
+// EndpointRequest sends the request to the enabled endpoints in order until
+// one of them responds. reqConfig.HTTPURL holds the path of the request,
+// which is appended to the URL of each endpoint. An endpoint that responds
+// with a client error is not failed over from.
+func EndpointRequest(endpoints []Endpoint, reqConfig *ReqConfig, respObj interface{}) (*http.Response, error) {
+	endpoints = OrderEndpoints(endpoints)
+	if len(endpoints) == 0 {
+		return nil, errors.New(ErrUnavailable)
+	}
+
+	var err error
+	for _, e := range endpoints {
+		req := *reqConfig
+		req.HTTPURL = EndpointURL(e.URL, reqConfig.HTTPURL)
+		req.CertPin = e.CertPin
+
+		var resp *http.Response
+		resp, err = HTTPRequest(&req, respObj)
+		var statusErr *statusError
+		if err == nil || (errors.As(err, &statusErr) && statusErr.code < http.StatusInternalServerError) {
+			setEndpointFailed(e.URL, false)
+			return resp, err
+		}
+
+		setEndpointFailed(e.URL, true)
+	}
+	return nil, err
+}
+
+// CheckEndpoint requests the path from the endpoint and reports whether it
+// responded and how long it took.
+func CheckEndpoint(e Endpoint, path string) *EndpointHealth {
+	health := &EndpointHealth{URL: e.URL, CheckedAt: time.Now()}
+	if err := e.Validate(); err != nil {
+		health.Error = err.Error()
+		return health
+	}
+
+	var body []byte
+	req := &ReqConfig{
+		Method:    http.MethodGet,
+		HTTPURL:   EndpointURL(e.URL, path),
+		IsRetByte: true,
+		CertPin:   e.CertPin,
+	}
+	resp, err := HTTPRequest(req, &body)
+	health.Latency = time.Since(health.CheckedAt)
+	if err == nil && resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		health.CertPin = CertPin(resp.TLS.PeerCertificates[0])
+	}
+	if err != nil {
+		health.Error = err.Error()
+	}
+	setEndpointFailed(e.URL, err != nil)
+	return health
+}
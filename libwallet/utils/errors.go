@@ -36,6 +36,10 @@ const (
 	ErrSyncAlreadyInProgress        = "sync_already_in_progress"
 	ErrNoPeers                      = "no_peers"
 	ErrInvalidPeers                 = "invalid_peers"
+	ErrPeerBanned                   = "peer_banned"
+	ErrSyncNotAllowed               = "sync_not_allowed"
+	ErrDcrdRPCLocked                = "dcrd_rpc_locked"
+	ErrDBDriverNotSupported         = "db_driver_not_supported"
 	ErrListenerAlreadyExist         = "listener_already_exist"
 	ErrLoggerAlreadyRegistered      = "logger_already_registered"
 	ErrLogRotatorAlreadyInitialized = "log_rotator_already_initialized"
@@ -3,6 +3,7 @@ package utils
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"sync"
 	"sync/atomic"
 	"time"
 )
@@ -48,6 +50,9 @@ type (
 		// If IsRetByte is set to true, client.Do will delegate
 		// response processing to caller.
 		IsRetByte bool
+		// CertPin, if set, is the pin of the certificate the server must
+		// present, see Endpoint.CertPin.
+		CertPin string
 	}
 
 	monitorNetwork struct {
@@ -61,6 +66,7 @@ type (
 
 var (
 	netC       monitorNetwork
+	clientsMu  sync.Mutex
 	activeAPIs map[string]*Client
 )
 
@@ -81,27 +87,40 @@ func init() {
 	netC = monitorNetwork{}
 
 	// activeAPIs allows a previous successful client connection to be reused
-	// shortening the time it takes to get a response.
+	// shortening the time it takes to get a response. The clients are keyed
+	// by host and certificate pin.
 	activeAPIs = make(map[string]*Client)
 }
 
-// newClient configures and returns a new client
-func newClient() (c *Client) {
+// newClient configures and returns a new client. If a certificate pin is
+// provided, the client only connects to servers presenting the pinned key.
+func newClient(pin string) (c *Client) {
 	// Initialize context use to cancel all pending requests when shutdown request is made.
 	ctx, cancel := context.WithCancel(context.Background())
 
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if pin != "" {
+		// The pinned key replaces the verification of the certificate chain.
+		transport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true,
+			VerifyConnection:   verifyCertPin(pin),
+		}
+	}
+
 	return &Client{
 		context:    ctx,
 		cancelFunc: cancel,
 		HTTPClient: &http.Client{
 			Timeout:   defaultHTTPClientTimeout,
-			Transport: http.DefaultTransport.(*http.Transport).Clone(),
+			Transport: transport,
 		},
 	}
 }
 
 // ShutdownHTTPClients shutdowns any active connection by cancelling the context.
 func ShutdownHTTPClients() {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
 	for _, c := range activeAPIs {
 		c.cancelFunc()
 	}
@@ -130,6 +149,18 @@ func (c *Client) getRequestBody(method string, body interface{}) ([]byte, error)
 	return nil, errors.New("invalid request body")
 }
 
+// statusError is returned by query if the server responds with a status
+// other than 200 OK.
+type statusError struct {
+	code   int
+	status string
+	body   []byte
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("error: status: %v resp: %s", e.status, e.body)
+}
+
 // query prepares and process HTTP request to backend resources.
 func (c *Client) query(reqConfig *ReqConfig) (rawData []byte, resp *http.Response, err error) {
 	// package the request body for POST and PUT requests
@@ -182,7 +213,7 @@ func (c *Client) query(reqConfig *ReqConfig) (rawData []byte, resp *http.Respons
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, resp, fmt.Errorf("error: status: %v resp: %s", resp.Status, body)
+		return nil, resp, &statusError{code: resp.StatusCode, status: resp.Status, body: body}
 	}
 
 	return body, resp, nil
@@ -199,10 +230,17 @@ func HTTPRequest(reqConfig *ReqConfig, respObj interface{}) (*http.Response, err
 		return nil, fmt.Errorf("error: url not properly constituted: %v", err)
 	}
 
-	// Reuse the same client for requests that share a host.
-	client, ok := activeAPIs[urlPath.Host]
+	// Reuse the same client for requests that share a host and pin, so that
+	// a connection opened with one pin is never used for another.
+	clientKey := urlPath.Host
+	if reqConfig.CertPin != "" {
+		clientKey += "#" + reqConfig.CertPin
+	}
+	clientsMu.Lock()
+	client, ok := activeAPIs[clientKey]
+	clientsMu.Unlock()
 	if !ok {
-		client = newClient()
+		client = newClient(reqConfig.CertPin)
 	}
 
 	body, httpResp, err := client.query(reqConfig)
@@ -211,7 +249,11 @@ func HTTPRequest(reqConfig *ReqConfig, respObj interface{}) (*http.Response, err
 	}
 
 	// cache a new client connection since it was successful
-	activeAPIs[urlPath.Host] = client
+	clientsMu.Lock()
+	if activeAPIs != nil {
+		activeAPIs[clientKey] = client
+	}
+	clientsMu.Unlock()
 
 	// if IsRetByte is option is true. Response from the resource queried
 	// is not in json format, don't unmarshal return response byte slice to
@@ -0,0 +1,316 @@
+package utils
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+// NetLimiter limits the number of peer connections and the bandwidth used by
+// the connections it dials, and reports the bytes sent and received through
+// them. A nil *NetLimiter dials without any limit.
+type NetLimiter struct {
+	mu sync.Mutex
+	// maxConns is the maximum number of open connections. It is not enforced
+	// if it is zero or less.
+	maxConns int
+	conns    int
+	// connFreed is closed and replaced each time a connection is closed.
+	connFreed chan struct{}
+
+	// bucket throttles the reads and writes.
+	bucket *tokenBucket
+	// onTraffic is called with the bytes sent and received.
+	onTraffic func(sent, received int64)
+}
+
+// NewNetLimiter returns a limiter that keeps at most maxConns connections open
+// and shares bytesPerSec between them. A limit that is zero or less is not
+// enforced. onTraffic may be nil.
+func NewNetLimiter(maxConns int, bytesPerSec int64, onTraffic func(sent, received int64)) *NetLimiter {
+	return &NetLimiter{
+		maxConns:  maxConns,
+		connFreed: make(chan struct{}),
+		bucket:    newTokenBucket(bytesPerSec),
+		onTraffic: onTraffic,
+	}
+}
+
+// SetLimits changes the limits of the limiter. The connections open beyond
+// the new maximum are not closed, no new connection is dialed until enough of
+// them are closed.
+func (l *NetLimiter) SetLimits(maxConns int, bytesPerSec int64) {
+	l.mu.Lock()
+	l.maxConns = maxConns
+	l.signalConnFreed()
+	l.mu.Unlock()
+
+	l.bucket.setRate(bytesPerSec)
+}
+
+// DialContext dials the address once a connection slot is available. It has
+// the signature of net.Dialer.DialContext.
+func (l *NetLimiter) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	return l.DialWith(ctx, netDial(network, addr))
+}
+
+// netDial returns a function that dials the address with a net.Dialer.
+func netDial(network, addr string) func(ctx context.Context) (net.Conn, error) {
+	return func(ctx context.Context) (net.Conn, error) {
+		d := &net.Dialer{
+			Timeout: defaultHTTPClientTimeout,
+		}
+		return d.DialContext(ctx, network, addr)
+	}
+}
+
+// DialWith calls dial once a connection slot is available and returns the
+// connection throttled and metered by the limiter. It allows limiting the
+// connections not dialed by a net.Dialer, e.g. through a proxy. Like with
+// net.Dialer, ctx only bounds the dial.
+func (l *NetLimiter) DialWith(ctx context.Context, dial func(ctx context.Context) (net.Conn, error)) (net.Conn, error) {
+	return l.dialWith(ctx, context.Background(), dial)
+}
+
+// dialWith dials like DialWith, the throttled reads and writes of the
+// connection stop waiting once connCtx is canceled.
+func (l *NetLimiter) dialWith(ctx, connCtx context.Context, dial func(ctx context.Context) (net.Conn, error)) (net.Conn, error) {
+	if l == nil {
+		return dial(ctx)
+	}
+
+	release, err := l.acquireSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := dial(ctx)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return &limitedConn{
+		Conn:    conn,
+		ctx:     connCtx,
+		closed:  make(chan struct{}),
+		limiter: l,
+		release: release,
+	}, nil
+}
+
+// Dialer returns a dialer like DialerFunc that dials through the limiter.
+// The connections dialed stop waiting for the limiter once ctx is canceled.
+func (l *NetLimiter) Dialer(ctx context.Context) Dailer {
+	return func(addr net.Addr) (net.Conn, error) {
+		return l.dialWith(ctx, ctx, netDial(addr.Network(), addr.String()))
+	}
+}
+
+// acquireSlot waits for a connection slot to be available. The returned
+// function frees the slot.
+func (l *NetLimiter) acquireSlot(ctx context.Context) (func(), error) {
+	timer := time.NewTimer(defaultHTTPClientTimeout)
+	defer timer.Stop()
+
+	for {
+		l.mu.Lock()
+		if l.maxConns <= 0 || l.conns < l.maxConns {
+			l.conns++
+			l.mu.Unlock()
+
+			var once sync.Once
+			return func() { once.Do(l.releaseSlot) }, nil
+		}
+		connFreed := l.connFreed
+		l.mu.Unlock()
+
+		select {
+		case <-connFreed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			return nil, ErrNetConnectionTimeout
+		}
+	}
+}
+
+func (l *NetLimiter) releaseSlot() {
+	l.mu.Lock()
+	l.conns--
+	l.signalConnFreed()
+	l.mu.Unlock()
+}
+
+// signalConnFreed wakes up the dials waiting for a connection slot. It must be
+// called with mu held.
+func (l *NetLimiter) signalConnFreed() {
+	close(l.connFreed)
+	l.connFreed = make(chan struct{})
+}
+
+// limitedConn is a connection throttled and metered by its limiter.
+type limitedConn struct {
+	net.Conn
+	// ctx and closed interrupt the waits for the limiter.
+	ctx       context.Context
+	closed    chan struct{}
+	closeOnce sync.Once
+	limiter   *NetLimiter
+	release   func()
+}
+
+// Read reads first and charges the limiter for the bytes read, so that short
+// reads into large buffers are not throttled as full reads. The next read
+// waits while the limiter is in deficit.
+func (c *limitedConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 && c.limiter.onTraffic != nil {
+		c.limiter.onTraffic(0, int64(n))
+	}
+	if waitErr := c.wait(c.limiter.bucket.charge(n)); err == nil {
+		err = waitErr
+	}
+	return n, err
+}
+
+func (c *limitedConn) Write(b []byte) (int, error) {
+	var written int
+	for written < len(b) {
+		chunk := b[written:]
+		taken, err := c.take(len(chunk))
+		if err != nil {
+			return written, err
+		}
+		n, err := c.Conn.Write(chunk[:taken])
+		written += n
+		if n > 0 && c.limiter.onTraffic != nil {
+			c.limiter.onTraffic(int64(n), 0)
+		}
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+func (c *limitedConn) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	c.release()
+	return c.Conn.Close()
+}
+
+// take waits until some tokens are available and returns the number of bytes,
+// at most n, that may be written.
+func (c *limitedConn) take(n int) (int, error) {
+	for {
+		taken, wait := c.limiter.bucket.tryTake(n)
+		if taken > 0 || n == 0 {
+			return taken, nil
+		}
+		// Other connections take the tokens refilled while waiting, the
+		// bucket is not locked.
+		if err := c.wait(wait); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// wait waits for d unless the connection is closed or its context canceled.
+func (c *limitedConn) wait(d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-c.closed:
+		return net.ErrClosed
+	case <-c.ctx.Done():
+		return c.ctx.Err()
+	}
+}
+
+// tokenBucket allows rate bytes per second with bursts of up to one second
+// worth of bytes. The rate is not limited if it is zero.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(bytesPerSec int64) *tokenBucket {
+	b := new(tokenBucket)
+	b.setRate(bytesPerSec)
+	return b
+}
+
+// setRate changes the rate of the bucket, a rate of zero or less is not
+// limited.
+func (b *tokenBucket) setRate(bytesPerSec int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if bytesPerSec < 0 {
+		bytesPerSec = 0
+	}
+	b.rate = float64(bytesPerSec)
+	b.tokens = b.rate
+	b.last = time.Now()
+}
+
+// tryTake takes up to n tokens if some are available. Otherwise it returns the
+// time to wait until one is.
+func (b *tokenBucket) tryTake(n int) (int, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rate <= 0 {
+		return n, 0
+	}
+
+	b.refill()
+	if b.tokens < 1 {
+		return 0, time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	}
+	if float64(n) > b.tokens {
+		n = int(b.tokens)
+	}
+	b.tokens -= float64(n)
+	return n, 0
+}
+
+// charge takes n tokens that were already used, leaving the bucket in
+// deficit if there were not enough. It returns the time to wait until the
+// deficit is refilled.
+func (b *tokenBucket) charge(n int) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rate <= 0 || n <= 0 {
+		return 0
+	}
+
+	b.refill()
+	b.tokens -= float64(n)
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// refill adds the tokens accumulated since the last refill. It must be
+// called with mu held.
+func (b *tokenBucket) refill() {
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.rate {
+		b.tokens = b.rate
+	}
+	b.last = now
+}
@@ -0,0 +1,250 @@
+package utils
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testConn returns a connection throttled by the bucket, without a peer.
+func testConn(b *tokenBucket) *limitedConn {
+	return &limitedConn{
+		ctx:     context.Background(),
+		closed:  make(chan struct{}),
+		limiter: &NetLimiter{bucket: b},
+	}
+}
+
+func TestTokenBucketTake(t *testing.T) {
+	b := newTokenBucket(1000)
+	c := testConn(b)
+
+	// A full bucket allows a burst of one second worth of bytes.
+	if n, _ := c.take(400); n != 400 {
+		t.Fatalf("expected 400 bytes, got %d", n)
+	}
+	if n, _ := c.take(1000); n != 600 {
+		t.Fatalf("expected the 600 bytes left, got %d", n)
+	}
+
+	// The empty bucket waits to be refilled.
+	start := time.Now()
+	if n, _ := c.take(100); n < 1 || n > 100 {
+		t.Fatalf("expected 1 to 100 bytes, got %d", n)
+	}
+	if elapsed := time.Since(start); elapsed < time.Millisecond/2 {
+		t.Errorf("expected to wait for the bucket to refill, waited %v", elapsed)
+	}
+
+	if n, _ := c.take(0); n != 0 {
+		t.Errorf("expected 0 bytes, got %d", n)
+	}
+
+	// An unlimited bucket never waits.
+	b.setRate(0)
+	if n, _ := c.take(1 << 20); n != 1<<20 {
+		t.Errorf("expected %d bytes, got %d", 1<<20, n)
+	}
+}
+
+func TestTokenBucketCharge(t *testing.T) {
+	b := newTokenBucket(1000)
+
+	// Charging more than the bucket holds leaves it in deficit until the
+	// extra bytes are refilled.
+	if wait := b.charge(400); wait != 0 {
+		t.Errorf("expected no wait within the burst, got %v", wait)
+	}
+	wait := b.charge(1100)
+	if wait < 400*time.Millisecond || wait > 500*time.Millisecond {
+		t.Errorf("expected to wait about 500ms, got %v", wait)
+	}
+	if n, wait := b.tryTake(100); n != 0 || wait < 400*time.Millisecond {
+		t.Errorf("expected the bucket in deficit to wait, took %d bytes and waits %v", n, wait)
+	}
+
+	b.setRate(0)
+	if wait := b.charge(1 << 20); wait != 0 {
+		t.Errorf("expected an unlimited bucket not to wait, got %v", wait)
+	}
+}
+
+func TestTokenBucketConcurrentTake(t *testing.T) {
+	const rate = 20000
+	b := newTokenBucket(rate)
+	b.charge(rate) // Empty the bucket.
+
+	// Takers waiting for the bucket to refill must not block each other, so
+	// the bytes taken in a second are close to the rate.
+	var mu sync.Mutex
+	var taken int
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c := testConn(b)
+			for ctx.Err() == nil {
+				n, _ := c.take(100)
+				mu.Lock()
+				taken += n
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if taken < rate/2 || taken > 2*rate {
+		t.Errorf("expected about %d bytes taken in a second, got %d", rate, taken)
+	}
+}
+
+func TestLimitedConnShortReads(t *testing.T) {
+	l := NewNetLimiter(0, 1000, nil)
+	client, server := net.Pipe()
+	defer server.Close()
+	conn, err := l.DialWith(context.Background(), func(ctx context.Context) (net.Conn, error) {
+		return client, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// Small messages read into a large buffer are only charged for the
+	// bytes read, so the 200 bytes fit in the one second burst.
+	go func() {
+		for i := 0; i < 20; i++ {
+			if _, err := server.Write(make([]byte, 10)); err != nil {
+				return
+			}
+		}
+	}()
+
+	start := time.Now()
+	buf := make([]byte, 4096)
+	for read := 0; read < 200; {
+		n, err := conn.Read(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		read += n
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the short reads not to be throttled, took %v", elapsed)
+	}
+}
+
+func TestLimitedConnCloseInterruptsWait(t *testing.T) {
+	l := NewNetLimiter(0, 100, nil)
+	client, server := net.Pipe()
+	defer server.Close()
+	conn, err := l.DialWith(context.Background(), func(ctx context.Context) (net.Conn, error) {
+		return client, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Reading 1000 bytes at 100 bytes per second leaves a deficit of about
+	// 9 seconds, closing the connection ends the wait.
+	go server.Write(make([]byte, 1000))
+	time.AfterFunc(50*time.Millisecond, func() { conn.Close() })
+
+	start := time.Now()
+	n, err := conn.Read(make([]byte, 1000))
+	if n != 1000 || err != net.ErrClosed {
+		t.Errorf("expected 1000 bytes read and %v, got %d bytes and %v", net.ErrClosed, n, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the wait to end when the connection closed, took %v", elapsed)
+	}
+}
+
+func TestNetLimiterConnections(t *testing.T) {
+	var mu sync.Mutex
+	var sent, received int64
+	l := NewNetLimiter(1, 0, func(s, r int64) {
+		mu.Lock()
+		sent += s
+		received += r
+		mu.Unlock()
+	})
+
+	var peers []net.Conn
+	dial := func(ctx context.Context) (net.Conn, error) {
+		client, server := net.Pipe()
+		peers = append(peers, server)
+		return client, nil
+	}
+	defer func() {
+		for _, peer := range peers {
+			peer.Close()
+		}
+	}()
+
+	conn, err := l.DialWith(context.Background(), dial)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		buf := make([]byte, 5)
+		if _, err := peers[0].Read(buf); err == nil {
+			peers[0].Write([]byte("pong"))
+		}
+	}()
+	if _, err := conn.Write([]byte("ping!")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.Read(make([]byte, 4)); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	if sent != 5 || received != 4 {
+		t.Errorf("expected 5 bytes sent and 4 received, got %d and %d", sent, received)
+	}
+	mu.Unlock()
+
+	// The second connection waits for the first one to close.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := l.DialWith(ctx, dial); err != context.DeadlineExceeded {
+		t.Fatalf("expected the dial to time out, got %v", err)
+	}
+
+	dialed := make(chan error, 1)
+	go func() {
+		conn, err := l.DialWith(context.Background(), dial)
+		if err == nil {
+			conn.Close()
+		}
+		dialed <- err
+	}()
+	conn.Close()
+	select {
+	case err := <-dialed:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the dial did not proceed once the connection closed")
+	}
+
+	// The connections are not limited once the limit is removed.
+	l.SetLimits(0, 0)
+	conns := make([]net.Conn, 3)
+	for i := range conns {
+		if conns[i], err = l.DialWith(context.Background(), dial); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, conn := range conns {
+		conn.Close()
+	}
+}
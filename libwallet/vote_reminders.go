@@ -0,0 +1,286 @@
+package libwallet
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"decred.org/dcrwallet/v3/errors"
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// defaultVoteReminderIntervals are the hours before the end of a vote at
+// which reminders are raised if none were configured.
+var defaultVoteReminderIntervals = []int{72, 24, 6}
+
+// voteReminderCheckInterval is the time between two checks of the governance
+// vote deadlines.
+const voteReminderCheckInterval = 15 * time.Minute
+
+// VoteDeadline is the voting progress of a wallet's tickets on an ongoing
+// proposal or consensus agenda vote.
+type VoteDeadline struct {
+	WalletID   int
+	WalletName string
+	// IsAgenda is true for consensus agenda votes and false for proposal
+	// votes.
+	IsAgenda bool
+	// ID is the proposal token or the agenda ID.
+	ID   string
+	Name string
+
+	EligibleTickets int
+	UnvotedTickets  int
+	EndBlockHeight  int32
+	BlocksRemaining int32
+	// EndTime is the estimated unix timestamp at which the vote ends.
+	EndTime int64
+}
+
+// TimeRemaining returns the estimated time left until the vote ends.
+func (d *VoteDeadline) TimeRemaining() time.Duration {
+	return time.Until(time.Unix(d.EndTime, 0))
+}
+
+// VoteReminder is a reminder raised for a vote whose deadline is within one
+// of the configured reminder intervals.
+type VoteReminder struct {
+	*VoteDeadline
+	// Hours is the reminder interval that was reached.
+	Hours int
+}
+
+// VoteReminderListener is notified of the vote reminders raised while the
+// assets manager is running.
+type VoteReminderListener interface {
+	OnVoteReminder(reminder *VoteReminder)
+}
+
+// VoteDeadlines returns the voting progress of every DCR wallet's tickets on
+// the proposal votes and consensus agendas in progress, ordered by the vote
+// end time. Watch-only wallets and wallets that are not synced are skipped,
+// as are the votes whose details could not be read.
+func (mgr *AssetsManager) VoteDeadlines(ctx context.Context) ([]*VoteDeadline, error) {
+	if !mgr.IsHTTPAPIPrivacyModeOff(utils.GovernanceHTTPAPI) {
+		return nil, nil
+	}
+
+	proposals, err := mgr.Politeia.GetProposalsRaw(ProposalCategoryActive, 0, 0, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var deadlines []*VoteDeadline
+	for _, wallet := range mgr.AllDCRWallets() {
+		asset, ok := wallet.(*dcr.Asset)
+		if !ok || asset.IsWatchingOnlyWallet() || !asset.IsSynced() {
+			continue
+		}
+
+		bestBlock := asset.GetBestBlockHeight()
+		blockTime := time.Duration(asset.TargetTimePerBlockMinutes() * float64(time.Minute))
+		endTime := func(blocksRemaining int32) int64 {
+			return time.Now().Add(time.Duration(blocksRemaining) * blockTime).Unix()
+		}
+
+		for _, proposal := range proposals {
+			if proposal.EndBlockHeight <= bestBlock {
+				continue
+			}
+
+			voteDetails, err := mgr.Politeia.ProposalVoteDetailsRaw(ctx, asset.Internal().DCR, asset.ID, proposal.Token)
+			if err != nil {
+				log.Errorf("[%d] Error fetching vote details of proposal %s: %v", asset.ID, proposal.Token, err)
+				continue
+			}
+
+			blocksRemaining := proposal.EndBlockHeight - bestBlock
+			deadlines = append(deadlines, &VoteDeadline{
+				WalletID:        asset.ID,
+				WalletName:      asset.GetWalletName(),
+				ID:              proposal.Token,
+				Name:            proposal.Name,
+				EligibleTickets: len(voteDetails.EligibleTickets) + len(voteDetails.Votes),
+				UnvotedTickets:  len(voteDetails.EligibleTickets),
+				EndBlockHeight:  proposal.EndBlockHeight,
+				BlocksRemaining: blocksRemaining,
+				EndTime:         endTime(blocksRemaining),
+			})
+		}
+
+		agendas, err := asset.AllVoteAgendas("", false)
+		if err != nil {
+			log.Errorf("[%d] Error fetching consensus agendas: %v", asset.ID, err)
+			continue
+		}
+
+		blocksRemaining := asset.RuleChangeIntervalBlocksRemaining()
+		for _, agenda := range agendas {
+			if agenda.Status != dcr.AgendaStatusInProgress.String() {
+				continue
+			}
+
+			eligible, unvoted, err := asset.AgendaTicketChoices(agenda.AgendaID)
+			if err != nil {
+				log.Errorf("[%d] Error reading vote choices of agenda %s: %v", asset.ID, agenda.AgendaID, err)
+				continue
+			}
+
+			deadlines = append(deadlines, &VoteDeadline{
+				WalletID:        asset.ID,
+				WalletName:      asset.GetWalletName(),
+				IsAgenda:        true,
+				ID:              agenda.AgendaID,
+				Name:            agenda.AgendaID,
+				EligibleTickets: eligible,
+				UnvotedTickets:  unvoted,
+				EndBlockHeight:  bestBlock + blocksRemaining,
+				BlocksRemaining: blocksRemaining,
+				EndTime:         endTime(blocksRemaining),
+			})
+		}
+	}
+
+	sort.SliceStable(deadlines, func(i, j int) bool {
+		return deadlines[i].EndTime < deadlines[j].EndTime
+	})
+
+	return deadlines, nil
+}
+
+// DueVoteReminders returns a reminder for every vote with unvoted tickets
+// whose deadline has come within one of the configured reminder intervals
+// since the last check. Each reminder is only returned once.
+func (mgr *AssetsManager) DueVoteReminders(ctx context.Context) ([]*VoteReminder, error) {
+	intervals := mgr.GetVoteReminderIntervals()
+	if len(intervals) == 0 {
+		return nil, nil
+	}
+
+	deadlines, err := mgr.VoteDeadlines(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sent := make(map[string]int64)
+	mgr.db.ReadWalletConfigValue(sharedW.VoteRemindersSentConfigKey, &sent)
+
+	reminders, stillSent := dueVoteReminders(deadlines, intervals, sent, time.Now())
+	mgr.db.SaveWalletConfigValue(sharedW.VoteRemindersSentConfigKey, stillSent)
+
+	return reminders, nil
+}
+
+// dueVoteReminders returns the reminders due at now for the deadlines that
+// are not in sent, the reminders already raised keyed by voteReminderKey.
+// The reminders raised for the deadlines, including the due ones, are
+// returned to be saved in place of sent.
+func dueVoteReminders(deadlines []*VoteDeadline, intervals []int, sent map[string]int64, now time.Time) ([]*VoteReminder, map[string]int64) {
+	// Only the reminders of ongoing votes are kept.
+	stillSent := make(map[string]int64, len(sent))
+	var reminders []*VoteReminder
+	for _, deadline := range deadlines {
+		remaining := time.Unix(deadline.EndTime, 0).Sub(now)
+
+		// The smallest interval reached is the most relevant reminder.
+		hours := -1
+		for _, h := range intervals {
+			if remaining <= time.Duration(h)*time.Hour && (hours == -1 || h < hours) {
+				hours = h
+			}
+		}
+
+		for key, timestamp := range sent {
+			if strings.HasPrefix(key, voteReminderPrefix(deadline)) {
+				stillSent[key] = timestamp
+			}
+		}
+
+		if hours == -1 || deadline.UnvotedTickets == 0 {
+			continue
+		}
+
+		key := voteReminderKey(deadline, hours)
+		if _, ok := sent[key]; ok {
+			continue
+		}
+
+		stillSent[key] = now.Unix()
+		reminders = append(reminders, &VoteReminder{VoteDeadline: deadline, Hours: hours})
+	}
+
+	return reminders, stillSent
+}
+
+// AddVoteReminderListener registers a listener for the vote reminders. The
+// vote deadlines are only checked while a listener is registered so that no
+// reminder is lost.
+func (mgr *AssetsManager) AddVoteReminderListener(listener VoteReminderListener, uniqueIdentifier string) error {
+	mgr.voteReminderListenersMu.Lock()
+	defer mgr.voteReminderListenersMu.Unlock()
+
+	if _, ok := mgr.voteReminderListeners[uniqueIdentifier]; ok {
+		return errors.New(utils.ErrListenerAlreadyExist)
+	}
+
+	mgr.voteReminderListeners[uniqueIdentifier] = listener
+	return nil
+}
+
+// RemoveVoteReminderListener unregisters the vote reminders listener.
+func (mgr *AssetsManager) RemoveVoteReminderListener(uniqueIdentifier string) {
+	mgr.voteReminderListenersMu.Lock()
+	defer mgr.voteReminderListenersMu.Unlock()
+
+	delete(mgr.voteReminderListeners, uniqueIdentifier)
+}
+
+// remindVoteDeadlines periodically checks the governance votes in progress
+// and notifies the listeners of the reminders that are due.
+func (mgr *AssetsManager) remindVoteDeadlines(ctx context.Context) {
+	t := time.NewTicker(voteReminderCheckInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return
+		}
+
+		mgr.voteReminderListenersMu.RLock()
+		hasListeners := len(mgr.voteReminderListeners) > 0
+		mgr.voteReminderListenersMu.RUnlock()
+		if !hasListeners {
+			continue
+		}
+
+		reminders, err := mgr.DueVoteReminders(ctx)
+		if err != nil {
+			log.Errorf("Error checking vote deadlines: %v", err)
+			continue
+		}
+
+		mgr.voteReminderListenersMu.RLock()
+		for _, reminder := range reminders {
+			for _, listener := range mgr.voteReminderListeners {
+				listener.OnVoteReminder(reminder)
+			}
+		}
+		mgr.voteReminderListenersMu.RUnlock()
+	}
+}
+
+func voteReminderKey(deadline *VoteDeadline, hours int) string {
+	return fmt.Sprintf("%s%d", voteReminderPrefix(deadline), hours)
+}
+
+func voteReminderPrefix(deadline *VoteDeadline) string {
+	// The end height separates the reminders of the successive rule change
+	// intervals of an agenda vote.
+	return fmt.Sprintf("%d-%t-%s-%d-", deadline.WalletID, deadline.IsAgenda, deadline.ID, deadline.EndBlockHeight)
+}
@@ -0,0 +1,72 @@
+package libwallet
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDueVoteReminders(t *testing.T) {
+	now := time.Now()
+	intervals := []int{72, 24, 6}
+	deadline := func(id string, endsIn time.Duration, unvoted int) *VoteDeadline {
+		return &VoteDeadline{
+			WalletID:        1,
+			ID:              id,
+			EndBlockHeight:  1000,
+			UnvotedTickets:  unvoted,
+			EligibleTickets: 5,
+			EndTime:         now.Add(endsIn).Unix(),
+		}
+	}
+
+	far := deadline("far", 100*time.Hour, 5)
+	soon := deadline("soon", 20*time.Hour, 5)
+	voted := deadline("voted", 20*time.Hour, 0)
+	closing := deadline("closing", time.Hour, 2)
+	deadlines := []*VoteDeadline{far, soon, voted, closing}
+
+	// The smallest interval reached is reminded, and only for the votes
+	// with unvoted tickets.
+	reminders, sent := dueVoteReminders(deadlines, intervals, nil, now)
+	got := make(map[string]int)
+	for _, reminder := range reminders {
+		got[reminder.ID] = reminder.Hours
+	}
+	want := map[string]int{"soon": 24, "closing": 6}
+	if len(got) != len(want) || got["soon"] != want["soon"] || got["closing"] != want["closing"] {
+		t.Fatalf("expected reminders %v, got %v", want, got)
+	}
+	if len(sent) != 2 {
+		t.Fatalf("expected 2 sent reminders, got %v", sent)
+	}
+
+	// A reminder is only raised once.
+	reminders, sent = dueVoteReminders(deadlines, intervals, sent, now)
+	if len(reminders) != 0 {
+		t.Fatalf("expected no new reminders, got %d", len(reminders))
+	}
+	if len(sent) != 2 {
+		t.Fatalf("expected the sent reminders to be kept, got %v", sent)
+	}
+
+	// The next interval of a vote is reminded once it is reached.
+	later := now.Add(15 * time.Hour)
+	reminders, sent = dueVoteReminders(deadlines, intervals, sent, later)
+	if len(reminders) != 1 || reminders[0].ID != "soon" || reminders[0].Hours != 6 {
+		t.Fatalf("expected the 6 hours reminder of soon, got %+v", reminders)
+	}
+
+	// The reminders of the votes that ended are dropped.
+	_, sent = dueVoteReminders([]*VoteDeadline{far}, intervals, sent, later)
+	if len(sent) != 0 {
+		t.Errorf("expected the reminders of ended votes to be dropped, got %v", sent)
+	}
+
+	// A new vote on the same agenda is reminded again.
+	next := deadline("closing", time.Hour, 2)
+	next.EndBlockHeight = 2000
+	reminders, _ = dueVoteReminders([]*VoteDeadline{next}, intervals, map[string]int64{voteReminderKey(closing, 6): now.Unix()}, now)
+	if len(reminders) != 1 {
+		t.Errorf("expected the next vote to be reminded, got %d reminders", len(reminders))
+	}
+}
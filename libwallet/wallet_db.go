@@ -0,0 +1,43 @@
+package libwallet
+
+import (
+	"path/filepath"
+
+	"decred.org/dcrwallet/v3/errors"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// walletBackupsDirName is the directory of the root dir the wallet database
+// backups are written to.
+const walletBackupsDirName = "backups"
+
+// WalletBackupsDir returns the directory the wallet database backups are
+// written to.
+func (mgr *AssetsManager) WalletBackupsDir() string {
+	return filepath.Join(mgr.params.RootDir, walletBackupsDirName)
+}
+
+// BackupWalletDB writes a consistent copy of the database of the wallet to
+// the backups directory while the wallet is in use. It returns the path of
+// the copy.
+func (mgr *AssetsManager) BackupWalletDB(walletID int) (string, error) {
+	wallet := mgr.WalletWithID(walletID)
+	if wallet == nil {
+		return "", errors.New(utils.ErrNotExist)
+	}
+	return wallet.BackupDB(mgr.WalletBackupsDir())
+}
+
+// MigrateWalletDB converts the database of the wallet to the driver, or
+// compacts it if the wallet database already uses the driver. The wallet
+// must not be syncing or rescanning.
+func (mgr *AssetsManager) MigrateWalletDB(walletID int, driver string) error {
+	wallet := mgr.WalletWithID(walletID)
+	if wallet == nil {
+		return errors.New(utils.ErrNotExist)
+	}
+	if wallet.IsConnectedToNetwork() || wallet.IsRescanning() {
+		return errors.New(utils.ErrSyncAlreadyInProgress)
+	}
+	return wallet.MigrateDB(driver)
+}
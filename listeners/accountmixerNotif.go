@@ -1,6 +1,9 @@
 package listeners
 
-import "github.com/crypto-power/cryptopower/wallet"
+import (
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	"github.com/crypto-power/cryptopower/wallet"
+)
 
 // AccountMixerNotificationListener satisfies libwallet
 // AccountMixerNotificationListener interface. Consumers interested in mixer
@@ -32,6 +35,16 @@ func (am *AccountMixerNotificationListener) OnAccountMixerEnded(walletID int) {
 	})
 }
 
+// OnAccountMixerStats is a callback func called when the statistics of a
+// running mixer session are updated.
+func (am *AccountMixerNotificationListener) OnAccountMixerStats(walletID int, stats *dcr.MixerStats) {
+	am.UpdateNotification(wallet.AccountMixer{
+		WalletID:  walletID,
+		RunStatus: wallet.MixerRunning,
+		Stats:     stats,
+	})
+}
+
 func (am *AccountMixerNotificationListener) UpdateNotification(signal wallet.AccountMixer) {
 	select {
 	case am.MixerChan <- signal:
@@ -59,6 +59,18 @@ func (pn *ProposalNotificationListener) OnProposalVoteFinished(proposal interfac
 	pn.sendNotification(update)
 }
 
+func (pn *ProposalNotificationListener) OnProposalVersionUpdated(proposal interface{}) {
+	p, ok := proposal.(*libwallet.Proposal)
+	if !ok {
+		p = &libwallet.Proposal{}
+	}
+	update := wallet.Proposal{
+		ProposalStatus: wallet.VersionUpdated,
+		Proposal:       p,
+	}
+	pn.sendNotification(update)
+}
+
 func (pn *ProposalNotificationListener) sendNotification(signal wallet.Proposal) {
 	if signal.Proposal != nil {
 		select {
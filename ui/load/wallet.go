@@ -21,7 +21,7 @@ type WalletItem struct {
 
 type WalletLoad struct {
 	AssetsManager *libwallet.AssetsManager
-	TxAuthor      dcr.TxAuthor
+	TxAuthor      *dcr.TxAuthor
 
 	Wallet *wallet.Wallet
 
@@ -49,6 +49,9 @@ func (w *WalletMapping) SetAPIFeeRate(feerate string) (int64, error) {
 	case *ltc.Asset:
 		amount = asset.ToAmount(rate)
 		setUserFeeRate = asset.SetUserFeeRate
+	case *dcr.Asset:
+		amount = asset.ToAmount(rate)
+		setUserFeeRate = asset.SetUserFeeRate
 	default:
 		return 0, w.invalidWallet()
 	}
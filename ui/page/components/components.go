@@ -664,6 +664,22 @@ func SecondsToDays(totalTimeLeft int64) string {
 	return timeLeft.String()
 }
 
+// FormatDataSize returns the size in bytes in the largest unit it is at
+// least one of.
+func FormatDataSize(size int64) string {
+	units := []string{"B", "KB", "MB", "GB", "TB"}
+	value := float64(size)
+	unit := 0
+	for value >= 1000 && unit < len(units)-1 {
+		value /= 1000
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%d %s", size, units[unit])
+	}
+	return fmt.Sprintf("%.2f %s", value, units[unit])
+}
+
 // divMod divides a numerator by a denominator and returns its quotient and remainder.
 func divMod(numerator, denominator int64) (quotient, remainder int64) {
 	quotient = numerator / denominator // integer division, decimals are truncated
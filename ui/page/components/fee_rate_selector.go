@@ -4,13 +4,13 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-	"time"
 
 	"gioui.org/font"
 	"gioui.org/layout"
 	"gioui.org/widget"
 	"gioui.org/widget/material"
 	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
@@ -105,10 +105,6 @@ func (fs *FeeRateSelector) ShowSizeAndCost() *FeeRateSelector {
 	return fs
 }
 
-func (fs *FeeRateSelector) isFeerateAPIApproved() bool {
-	return fs.WL.AssetsManager.IsHTTPAPIPrivacyModeOff(libutils.FeeRateHTTPAPI)
-}
-
 // Layout draws the UI components.
 func (fs *FeeRateSelector) Layout(gtx C) D {
 	return fs.ContainerInset.Layout(gtx, func(gtx C) D {
@@ -164,16 +160,6 @@ func (fs *FeeRateSelector) Layout(gtx C) D {
 															})
 														}
 
-														str := fs.FetchRates.Text
-														if !fs.isFeerateAPIApproved() {
-															str = values.StringF(values.StrNotAllowed, values.String(values.StrFeeRates))
-															fs.FetchRates.SetEnabled(false)
-														} else if fs.selectedWalletType() == libutils.LTCWalletAsset {
-															// TODO: Add fee rate API query for LTC
-															str = values.StringF(values.StrNotSupported, values.String(values.StrFeeRateAPI))
-															fs.FetchRates.SetEnabled(false)
-														}
-														fs.FetchRates.Text = str
 														return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, fs.FetchRates.Layout)
 													}),
 												)
@@ -254,8 +240,9 @@ func (fs *FeeRateSelector) FetchFeeRate(window app.WindowNavigator, selectedWall
 		fs.fetchingRate = false
 	}()
 
-	feeRates, err := selectedWallet.GetAPIFeeRate()
+	feeTargets, err := selectedWallet.FeeTargets()
 	if err != nil {
+		log.Error(err)
 		return
 	}
 
@@ -267,11 +254,19 @@ func (fs *FeeRateSelector) FetchFeeRate(window app.WindowNavigator, selectedWall
 		return val + "s"
 	}
 
+	targetStr := func(target *sharedW.FeeTarget) string {
+		return fmt.Sprintf("%s - %v (~%v)", fs.priorityName(target.Priority), blocksStr(target.ConfirmationBlocks),
+			TimeFormat(int(target.EstimatedConfirmationTime.Seconds()), true))
+	}
+
 	radiogroupbtns := new(widget.Enum)
 	items := make([]layout.FlexChild, 0)
-	for index, feerate := range feeRates {
+	for index, target := range feeTargets {
 		key := strconv.Itoa(index)
-		value := fs.addRatesUnits(feerate.Feerate.ToInt()) + " - " + blocksStr(feerate.ConfirmedBlocks)
+		value := targetStr(target) + " - " + fs.addRatesUnits(target.FeeRate.ToInt())
+		if target.TotalFee != nil {
+			value += " - " + target.TotalFee.String()
+		}
 		radioBtn := fs.Load.Theme.RadioButton(radiogroupbtns, key, value,
 			fs.Load.Theme.Color.DeepBlue, fs.Load.Theme.Color.Primary)
 		items = append(items, layout.Rigid(radioBtn.Layout))
@@ -287,8 +282,11 @@ func (fs *FeeRateSelector) FetchFeeRate(window app.WindowNavigator, selectedWall
 		SetPositiveButtonText(values.String(values.StrSave)).
 		SetPositiveButtonCallback(func(isChecked bool, im *modal.InfoModal) bool {
 			fields := strings.Fields(radiogroupbtns.Value)
+			if len(fields) == 0 {
+				return false
+			}
 			index, _ := strconv.Atoi(fields[0])
-			rate := strconv.Itoa(int(feeRates[index].Feerate.ToInt()))
+			rate := strconv.Itoa(int(feeTargets[index].FeeRate.ToInt()))
 			rateInt, err := selectedWallet.SetAPIFeeRate(rate)
 			if err != nil {
 				log.Error(err)
@@ -297,9 +295,7 @@ func (fs *FeeRateSelector) FetchFeeRate(window app.WindowNavigator, selectedWall
 
 			fs.feeRateText = fs.addRatesUnits(rateInt)
 			fs.rateEditMode = false
-			blocks := feeRates[index].ConfirmedBlocks
-			timeBefore := time.Now().Add(time.Duration(-10*blocks) * time.Minute)
-			fs.priority = fmt.Sprintf("%v (~%v)", blocksStr(blocks), TimeAgo(timeBefore.Unix()))
+			fs.priority = targetStr(feeTargets[index])
 			im.Dismiss()
 			return true
 		})
@@ -329,10 +325,24 @@ func (fs *FeeRateSelector) addRatesUnits(rates int64) string {
 	return fs.Load.Printer.Sprintf("%d %s", rates, fs.ratesUnit())
 }
 
+// priorityName returns the translated name of the fee priority.
+func (fs *FeeRateSelector) priorityName(priority sharedW.FeePriority) string {
+	switch priority {
+	case sharedW.FeePriorityHigh:
+		return values.String(values.StrHighPriority)
+	case sharedW.FeePriorityMedium:
+		return values.String(values.StrMediumPriority)
+	default:
+		return values.String(values.StrLowPriority)
+	}
+}
+
 func (fs *FeeRateSelector) ratesUnit() string {
 	switch fs.selectedWalletType() {
 	case libutils.LTCWalletAsset:
 		return "Lit/kvB"
+	case libutils.DCRWalletAsset:
+		return "Atoms/kB"
 	default:
 		return "Sat/kvB"
 	}
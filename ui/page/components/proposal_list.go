@@ -209,27 +209,46 @@ func LoadProposals(l *load.Load, category, offset, pageSize int32, newestFirst b
 	proposals, err := l.WL.AssetsManager.Politeia.GetProposalsRaw(category, offset, pageSize, newestFirst)
 	if err == nil {
 		for i := 0; i < len(proposals); i++ {
-			proposal := proposals[i]
-			item := &ProposalItem{
-				Proposal: libwallet.Proposal{Proposal: proposal},
-				voteBar:  NewVoteBar(l),
-			}
-
-			if proposal.Category == libwallet.ProposalCategoryPre {
-				tooltipLabel := l.Theme.Caption("")
-				tooltipLabel.Color = l.Theme.Color.GrayText2
-				if proposal.VoteStatus == 1 {
-					tooltipLabel.Text = values.String(values.StrWaitingAuthor)
-				} else if proposal.VoteStatus == 2 {
-					tooltipLabel.Text = values.String(values.StrWaitingForAdmin)
-				}
+			proposalItems = append(proposalItems, newProposalItem(l, libwallet.Proposal{Proposal: proposals[i]}))
+		}
+	}
+	return proposalItems
+}
 
-				item.tooltip = l.Theme.Tooltip()
-				item.tooltipLabel = tooltipLabel
-			}
+// SearchProposals returns the saved proposals matching the filter.
+func SearchProposals(l *load.Load, filter *libwallet.ProposalFilter) []*ProposalItem {
+	proposalItems := make([]*ProposalItem, 0)
 
-			proposalItems = append(proposalItems, item)
-		}
+	proposals, err := l.WL.AssetsManager.Politeia.SearchProposals(filter)
+	if err != nil {
+		log.Errorf("Error searching proposals: %v", err)
+		return proposalItems
+	}
+
+	for i := 0; i < len(proposals); i++ {
+		proposalItems = append(proposalItems, newProposalItem(l, libwallet.Proposal{Proposal: proposals[i]}))
 	}
 	return proposalItems
 }
+
+func newProposalItem(l *load.Load, proposal libwallet.Proposal) *ProposalItem {
+	item := &ProposalItem{
+		Proposal: proposal,
+		voteBar:  NewVoteBar(l),
+	}
+
+	if proposal.Category == libwallet.ProposalCategoryPre {
+		tooltipLabel := l.Theme.Caption("")
+		tooltipLabel.Color = l.Theme.Color.GrayText2
+		if proposal.VoteStatus == 1 {
+			tooltipLabel.Text = values.String(values.StrWaitingAuthor)
+		} else if proposal.VoteStatus == 2 {
+			tooltipLabel.Text = values.String(values.StrWaitingForAdmin)
+		}
+
+		item.tooltip = l.Theme.Tooltip()
+		item.tooltipLabel = tooltipLabel
+	}
+
+	return item
+}
@@ -100,14 +100,14 @@ func layoutVoteChoice(l *load.Load, treasuryItem *TreasuryItem) layout.Widget {
 			}),
 			layout.Rigid(func(gtx C) D {
 				return layout.Inset{Top: values.MarginPadding10, Left: values.MarginPadding0}.Layout(gtx, func(gtx C) D {
-					return layout.Flex{Axis: layout.Horizontal}.Layout(gtx, layoutItems(l, treasuryItem)...)
+					return layout.Flex{Axis: layout.Horizontal}.Layout(gtx, layoutChoices(l, treasuryItem.OptionsRadioGroup)...)
 				})
 			}),
 		)
 	}
 }
 
-func layoutItems(l *load.Load, treasuryItem *TreasuryItem) []layout.FlexChild {
+func layoutChoices(l *load.Load, optionsRadioGroup *widget.Enum) []layout.FlexChild {
 	voteChoices := [...]string{
 		strings.ToLower(values.String(values.StrYes)),
 		strings.ToLower(values.String(values.StrNo)),
@@ -115,7 +115,7 @@ func layoutItems(l *load.Load, treasuryItem *TreasuryItem) []layout.FlexChild {
 	}
 	items := make([]layout.FlexChild, 0)
 	for _, voteChoice := range voteChoices {
-		radioBtn := l.Theme.RadioButton(treasuryItem.OptionsRadioGroup, voteChoice, voteChoice, l.Theme.Color.DeepBlue, l.Theme.Color.Primary)
+		radioBtn := l.Theme.RadioButton(optionsRadioGroup, voteChoice, voteChoice, l.Theme.Color.DeepBlue, l.Theme.Color.Primary)
 		radioItem := layout.Rigid(radioBtn.Layout)
 		items = append(items, radioItem)
 	}
@@ -169,3 +169,113 @@ func LoadPolicies(l *load.Load, selectedWallet sharedW.Asset, pikey string) []*T
 	}
 	return treasuryItems
 }
+
+// TSpendItem is a treasury spend the wallet can vote on.
+type TSpendItem struct {
+	TSpend            dcr.TSpend
+	OptionsRadioGroup *widget.Enum
+	SetChoiceButton   cryptomaterial.Button
+	// SetTicketChoiceButton sets the selected choice for a single ticket.
+	SetTicketChoiceButton cryptomaterial.Button
+}
+
+func TSpendItemWidget(gtx C, l *load.Load, item *TSpendItem) D {
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	wal := l.WL.SelectedWallet.Wallet
+
+	row := func(title, value string) layout.Widget {
+		return func(gtx C) D {
+			lbl := l.Theme.Label(values.TextSize14, title)
+			lbl.Color = l.Theme.Color.GrayText2
+			return layout.Inset{Top: values.MarginPadding5}.Layout(gtx, func(gtx C) D {
+				return EndToEndRow(gtx, lbl.Layout, l.Theme.Label(values.TextSize14, value).Layout)
+			})
+		}
+	}
+
+	expiry := values.StringF(values.StrTSpendExpiry, item.TSpend.Expiry,
+		int32(item.TSpend.Expiry)-wal.GetBestBlockHeight())
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			lbl := l.Theme.Label(values.TextSize20, values.String(values.StrTreasurySpend))
+			lbl.Font.Weight = font.SemiBold
+			return lbl.Layout(gtx)
+		}),
+		layout.Rigid(row(values.String(values.StrHash), item.TSpend.Hash)),
+		layout.Rigid(row(values.String(values.StrAmount), wal.ToAmount(item.TSpend.Amount).String())),
+	}
+	for _, payee := range item.TSpend.Payees {
+		children = append(children, layout.Rigid(row(values.StringF(values.StrPayee, payee.Address),
+			wal.ToAmount(payee.Amount).String())))
+	}
+	children = append(children,
+		layout.Rigid(row(values.String(values.StrExpiry), expiry)),
+		layout.Rigid(row(values.String(values.StrPiKey), item.TSpend.PiKey)),
+	)
+
+	if wal.IsWatchingOnlyWallet() {
+		warning := l.Theme.Label(values.TextSize16, values.String(values.StrWarningVote))
+		warning.Color = l.Theme.Color.Danger
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding5}.Layout(gtx, warning.Layout)
+		}))
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	}
+
+	children = append(children,
+		layout.Rigid(func(gtx C) D {
+			lbl := l.Theme.Label(values.TextSize16, values.String(values.StrSetTSpendPolicy))
+			lbl.Font.Weight = font.SemiBold
+			return layout.Inset{Top: values.MarginPadding15}.Layout(gtx, lbl.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx, layoutChoices(l, item.OptionsRadioGroup)...)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					enabled := item.OptionsRadioGroup.Value != "" && item.OptionsRadioGroup.Value != item.TSpend.Policy
+					return layoutChoiceButton(gtx, l, &item.SetChoiceButton, enabled)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+						return layoutChoiceButton(gtx, l, &item.SetTicketChoiceButton, item.OptionsRadioGroup.Value != "")
+					})
+				}),
+			)
+		}),
+	)
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+// layoutChoiceButton draws a button that sets a vote choice.
+func layoutChoiceButton(gtx C, l *load.Load, button *cryptomaterial.Button, enabled bool) D {
+	gtx.Constraints.Min.X, gtx.Constraints.Max.X = gtx.Dp(unit.Dp(150)), gtx.Dp(unit.Dp(200))
+	button.Background = l.Theme.Color.Gray3
+	if enabled {
+		button.Background = l.Theme.Color.Primary
+	}
+	button.SetEnabled(enabled)
+	return layout.Inset{Top: values.MarginPadding15}.Layout(gtx, button.Layout)
+}
+
+func LoadTSpends(l *load.Load, selectedWallet sharedW.Asset) []*TSpendItem {
+	tspends, err := selectedWallet.(*dcr.Asset).TSpends()
+	if err != nil {
+		return nil
+	}
+
+	items := make([]*TSpendItem, len(tspends))
+	for i, tspend := range tspends {
+		items[i] = &TSpendItem{
+			TSpend:                *tspend,
+			OptionsRadioGroup:     new(widget.Enum),
+			SetChoiceButton:       l.Theme.Button(values.String(values.StrSetChoice)),
+			SetTicketChoiceButton: l.Theme.Button(values.String(values.StrSetTicketChoice)),
+		}
+		items[i].OptionsRadioGroup.Value = tspend.Policy
+	}
+	return items
+}
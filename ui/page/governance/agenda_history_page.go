@@ -0,0 +1,197 @@
+package governance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const AgendaHistoryPageID = "agenda_history"
+
+// AgendaHistoryPage lists the past consensus deployments with their outcome
+// and the votes the selected wallet's tickets cast on them.
+type AgendaHistoryPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
+	dcrImpl *dcr.Asset
+
+	deployments []*dcr.AgendaDeployment
+	loading     bool
+	errMsg      string
+
+	scrollbarList *widget.List
+	card          cryptomaterial.Card
+	backButton    cryptomaterial.IconButton
+}
+
+func NewAgendaHistoryPage(l *load.Load) *AgendaHistoryPage {
+	pg := &AgendaHistoryPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(AgendaHistoryPageID),
+		scrollbarList: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		card: l.Theme.Card(),
+	}
+
+	pg.dcrImpl, _ = l.WL.SelectedWallet.Wallet.(*dcr.Asset)
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *AgendaHistoryPage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+	if pg.dcrImpl != nil {
+		pg.loadHistory()
+	}
+}
+
+func (pg *AgendaHistoryPage) loadHistory() {
+	pg.loading = true
+	pg.errMsg = ""
+	go func() {
+		deployments, err := pg.dcrImpl.AgendaDeploymentHistory()
+		if err != nil {
+			log.Errorf("Error loading agenda history: %v", err)
+			pg.errMsg = err.Error()
+		} else {
+			pg.deployments = deployments
+		}
+		pg.loading = false
+		pg.ParentWindow().Reload()
+	}()
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *AgendaHistoryPage) HandleUserInteractions() {}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *AgendaHistoryPage) OnNavigatedFrom() {
+	if pg.ctxCancel != nil {
+		pg.ctxCancel()
+	}
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *AgendaHistoryPage) Layout(gtx C) D {
+	body := func(gtx C) D {
+		page := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrAgendaHistory),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: pg.layoutHistory,
+		}
+		return page.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, false, body)
+	}
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *AgendaHistoryPage) layoutHistory(gtx C) D {
+	var w []layout.Widget
+	switch {
+	case pg.loading:
+		w = append(w, pg.Theme.Body1(values.String(values.StrLoading)).Layout)
+	case pg.errMsg != "":
+		lbl := pg.Theme.Body1(pg.errMsg)
+		lbl.Color = pg.Theme.Color.Danger
+		w = append(w, lbl.Layout)
+	case len(pg.deployments) == 0:
+		lbl := pg.Theme.Body1(values.String(values.StrNoAgendaHistory))
+		lbl.Color = pg.Theme.Color.GrayText2
+		w = append(w, lbl.Layout)
+	default:
+		for _, deployment := range pg.deployments {
+			w = append(w, pg.deploymentWidget(deployment))
+		}
+	}
+
+	return pg.card.Layout(gtx, func(gtx C) D {
+		return pg.Theme.List(pg.scrollbarList).Layout(gtx, len(w), func(gtx C, i int) D {
+			return layout.UniformInset(values.MarginPadding16).Layout(gtx, w[i])
+		})
+	})
+}
+
+func (pg *AgendaHistoryPage) deploymentWidget(deployment *dcr.AgendaDeployment) layout.Widget {
+	caser := cases.Title(language.Und)
+
+	name := pg.Theme.Body1(deployment.AgendaID)
+	name.Font.Weight = font.SemiBold
+	description := pg.Theme.Body2(deployment.Description)
+	description.Color = pg.Theme.Color.GrayText2
+
+	outcome := pg.Theme.Body2(caser.String(deployment.Status) + " · " + values.StringF(values.StrStakeVersion, deployment.StakeVersion))
+	switch dcr.AgendaStatusFromStr(deployment.Status) {
+	case dcr.AgendaStatusFailed:
+		outcome.Color = pg.Theme.Color.Danger
+	case dcr.AgendaStatusLockedIn, dcr.AgendaStatusFinished:
+		outcome.Color = pg.Theme.Color.Success
+	}
+
+	var votes []string
+	for _, choice := range deployment.Choices {
+		if count := deployment.Votes[choice.Id]; count > 0 {
+			votes = append(votes, fmt.Sprintf("%s %d", caser.String(choice.Id), count))
+		}
+	}
+	myVotes := pg.Theme.Body2(values.String(values.StrNoVotesCast))
+	if len(votes) > 0 {
+		myVotes.Text = values.StringF(values.StrMyVotes, strings.Join(votes, ", "))
+	}
+
+	return func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(name.Layout),
+			layout.Rigid(description.Layout),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, outcome.Layout)
+			}),
+			layout.Rigid(myVotes.Layout),
+		)
+	}
+}
@@ -0,0 +1,511 @@
+package governance
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet"
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const AutoVotePageID = "auto_vote"
+
+// AutoVotePage configures the rules used to auto-cast votes on proposals,
+// enables auto-voting for the selected wallet and shows the votes cast.
+type AutoVotePage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
+	dcrImpl *dcr.Asset
+
+	enableSwitch    *cryptomaterial.Switch
+	unlockAndVote   *cryptomaterial.Clickable
+	addFollowVoters *cryptomaterial.Clickable
+	addBudgetYes    *cryptomaterial.Clickable
+	addBudgetNo     *cryptomaterial.Clickable
+	addAbstain      *cryptomaterial.Clickable
+
+	policy      *libwallet.AutoVotePolicy
+	removeRules []*cryptomaterial.Clickable
+
+	activeProposals []*libwallet.Proposal
+	previewButtons  []*cryptomaterial.Clickable
+
+	pendingVotes []*libwallet.AutoVoteDecision
+	logs         []*libwallet.AutoVoteLog
+
+	scrollbarList *widget.List
+	card          cryptomaterial.Card
+	backButton    cryptomaterial.IconButton
+}
+
+func NewAutoVotePage(l *load.Load) *AutoVotePage {
+	pg := &AutoVotePage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(AutoVotePageID),
+		enableSwitch:     l.Theme.Switch(),
+		unlockAndVote:    l.Theme.NewClickable(true),
+		addFollowVoters:  l.Theme.NewClickable(true),
+		addBudgetYes:     l.Theme.NewClickable(true),
+		addBudgetNo:      l.Theme.NewClickable(true),
+		addAbstain:       l.Theme.NewClickable(true),
+		policy:           &libwallet.AutoVotePolicy{},
+		scrollbarList: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		card: l.Theme.Card(),
+	}
+
+	pg.dcrImpl, _ = l.WL.SelectedWallet.Wallet.(*dcr.Asset)
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *AutoVotePage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+
+	if pg.dcrImpl != nil {
+		pg.enableSwitch.SetChecked(pg.dcrImpl.IsAutoVoteEnabled())
+	}
+
+	policy, err := pg.WL.AssetsManager.Politeia.AutoVotePolicy()
+	if err != nil {
+		log.Errorf("Error loading auto-vote policy: %v", err)
+	} else {
+		pg.setPolicy(policy)
+	}
+
+	pg.loadActiveProposals()
+	pg.loadLogs()
+}
+
+func (pg *AutoVotePage) setPolicy(policy *libwallet.AutoVotePolicy) {
+	pg.policy = policy
+	pg.removeRules = make([]*cryptomaterial.Clickable, len(policy.Rules))
+	for i := range pg.removeRules {
+		pg.removeRules[i] = pg.Theme.NewClickable(true)
+	}
+}
+
+func (pg *AutoVotePage) loadActiveProposals() {
+	proposals, err := pg.WL.AssetsManager.Politeia.GetProposalsRaw(libwallet.ProposalCategoryActive, 0, 0, true)
+	if err != nil {
+		log.Errorf("Error loading active proposals: %v", err)
+		return
+	}
+
+	pg.activeProposals = make([]*libwallet.Proposal, len(proposals))
+	pg.previewButtons = make([]*cryptomaterial.Clickable, len(proposals))
+	for i := range proposals {
+		pg.activeProposals[i] = &libwallet.Proposal{Proposal: proposals[i]}
+		pg.previewButtons[i] = pg.Theme.NewClickable(true)
+	}
+}
+
+func (pg *AutoVotePage) loadLogs() {
+	if pg.dcrImpl == nil {
+		return
+	}
+
+	pg.pendingVotes = pg.WL.AssetsManager.PendingAutoVotes(pg.dcrImpl.ID)
+
+	logs, err := pg.WL.AssetsManager.Politeia.AutoVoteLogs(pg.dcrImpl.ID)
+	if err != nil {
+		log.Errorf("Error loading auto-vote logs: %v", err)
+		return
+	}
+
+	pg.logs = make([]*libwallet.AutoVoteLog, len(logs))
+	for i := range logs {
+		pg.logs[i] = &libwallet.AutoVoteLog{AutoVoteLog: logs[i]}
+	}
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *AutoVotePage) HandleUserInteractions() {
+	if pg.dcrImpl == nil {
+		return
+	}
+
+	if pg.enableSwitch.Changed() {
+		pg.dcrImpl.SetAutoVoteEnabled(pg.enableSwitch.IsChecked())
+		if pg.enableSwitch.IsChecked() {
+			pg.WL.AssetsManager.EvaluateAutoVotes()
+		}
+	}
+
+	if pg.unlockAndVote.Clicked() {
+		pg.castPendingVotes()
+	}
+
+	if pg.addFollowVoters.Clicked() {
+		pg.showFollowVotersModal()
+	}
+
+	if pg.addBudgetYes.Clicked() {
+		pg.showBudgetModal(libwallet.VoteBitYes)
+	}
+
+	if pg.addBudgetNo.Clicked() {
+		pg.showBudgetModal(libwallet.VoteBitNo)
+	}
+
+	if pg.addAbstain.Clicked() {
+		pg.addRule(libwallet.AutoVoteRule{Type: libwallet.AutoVoteRuleAbstain})
+	}
+
+	for i, btn := range pg.removeRules {
+		if btn.Clicked() {
+			rules := append([]libwallet.AutoVoteRule{}, pg.policy.Rules[:i]...)
+			rules = append(rules, pg.policy.Rules[i+1:]...)
+			pg.savePolicy(&libwallet.AutoVotePolicy{Rules: rules})
+			break
+		}
+	}
+
+	for i, btn := range pg.previewButtons {
+		if btn.Clicked() {
+			pg.previewAutoVote(pg.activeProposals[i])
+		}
+	}
+}
+
+// castPendingVotes unlocks the wallet to cast the votes that were decided
+// while it was locked. The wallet is locked again once they are cast.
+func (pg *AutoVotePage) castPendingVotes() {
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrUnlockAndVote)).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			if err := pg.WL.AssetsManager.CastPendingAutoVotes(pg.dcrImpl.ID, password); err != nil {
+				pm.SetError(err.Error())
+				pm.SetLoading(false)
+				return false
+			}
+
+			pg.loadLogs()
+			pm.Dismiss()
+			pg.ParentWindow().Reload()
+			return false
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+func (pg *AutoVotePage) showFollowVotersModal() {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrTrustedVoterAddresses)).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(text string, tim *modal.TextInputModal) bool {
+			var addresses []string
+			for _, address := range strings.Split(text, ",") {
+				if address = strings.TrimSpace(address); address != "" {
+					addresses = append(addresses, address)
+				}
+			}
+
+			if len(addresses) == 0 {
+				tim.SetError(values.String(values.StrInvalidAddress))
+				tim.SetLoading(false)
+				return false
+			}
+
+			pg.addRule(libwallet.AutoVoteRule{
+				Type:      libwallet.AutoVoteRuleFollowVoters,
+				Addresses: addresses,
+			})
+			return true
+		})
+	textModal.Title(values.String(values.StrFollowTrustedVoters)).
+		SetPositiveButtonText(values.String(values.StrAdd)).
+		SetNegativeButtonText(values.String(values.StrCancel))
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+func (pg *AutoVotePage) showBudgetModal(voteOption string) {
+	title := values.String(values.StrApproveUnderBudget)
+	if voteOption == libwallet.VoteBitNo {
+		title = values.String(values.StrRejectUnderBudget)
+	}
+
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrMaxBudgetUSD)).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(text string, tim *modal.TextInputModal) bool {
+			amount, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+			if err != nil || amount < 0 {
+				tim.SetError(values.String(values.StrInvalidAmount))
+				tim.SetLoading(false)
+				return false
+			}
+
+			pg.addRule(libwallet.AutoVoteRule{
+				Type:       libwallet.AutoVoteRuleBudget,
+				MaxAmount:  uint64(amount * 100),
+				VoteOption: voteOption,
+			})
+			return true
+		})
+	textModal.Title(title).
+		SetPositiveButtonText(values.String(values.StrAdd)).
+		SetNegativeButtonText(values.String(values.StrCancel))
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+func (pg *AutoVotePage) addRule(rule libwallet.AutoVoteRule) {
+	rules := append([]libwallet.AutoVoteRule{}, pg.policy.Rules...)
+	pg.savePolicy(&libwallet.AutoVotePolicy{Rules: append(rules, rule)})
+}
+
+func (pg *AutoVotePage) savePolicy(policy *libwallet.AutoVotePolicy) {
+	if err := pg.WL.AssetsManager.Politeia.SaveAutoVotePolicy(policy); err != nil {
+		pg.ParentWindow().ShowModal(modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc()))
+		return
+	}
+
+	pg.setPolicy(policy)
+	pg.WL.AssetsManager.EvaluateAutoVotes()
+	pg.ParentWindow().Reload()
+}
+
+// previewAutoVote shows the vote the saved rules would cast on the proposal
+// without casting it.
+func (pg *AutoVotePage) previewAutoVote(proposal *libwallet.Proposal) {
+	go func() {
+		decision, err := pg.WL.AssetsManager.Politeia.PreviewAutoVote(pg.ctx, pg.dcrImpl.Internal().DCR, proposal.Token)
+		if err != nil {
+			pg.ParentWindow().ShowModal(modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc()))
+			return
+		}
+
+		info := modal.NewCustomModal(pg.Load).
+			Title(proposal.Name).
+			Body(values.StringF(values.StrAutoVotePreview, voteOptionLabel(decision.VoteOption), decision.NumTickets, decision.Reason)).
+			SetCancelable(true).
+			SetPositiveButtonText(values.String(values.StrGotIt))
+		pg.ParentWindow().ShowModal(info)
+	}()
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *AutoVotePage) OnNavigatedFrom() {
+	if pg.ctxCancel != nil {
+		pg.ctxCancel()
+	}
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *AutoVotePage) Layout(gtx C) D {
+	body := func(gtx C) D {
+		page := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrAutoVote),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: pg.layoutContent,
+		}
+		return page.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, false, body)
+	}
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *AutoVotePage) layoutContent(gtx C) D {
+	if pg.dcrImpl == nil {
+		return D{}
+	}
+
+	w := []layout.Widget{
+		func(gtx C) D {
+			info := pg.Theme.Body2(values.String(values.StrAutoVoteInfo))
+			info.Color = pg.Theme.Color.GrayText2
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, func(gtx C) D {
+					return layout.Inset{Right: values.MarginPadding16}.Layout(gtx, info.Layout)
+				}),
+				layout.Rigid(pg.enableSwitch.Layout),
+			)
+		},
+	}
+
+	if len(pg.pendingVotes) > 0 {
+		w = append(w, func(gtx C) D {
+			lbl := pg.Theme.Body2(values.StringF(values.StrPendingAutoVotes, len(pg.pendingVotes)))
+			lbl.Color = pg.Theme.Color.Danger
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, lbl.Layout),
+				layout.Rigid(pg.clickableText(pg.unlockAndVote, values.String(values.StrUnlockAndVote), pg.Theme.Color.Primary)),
+			)
+		})
+	}
+
+	w = append(w, pg.sectionTitle(values.String(values.StrAutoVoteRules)))
+
+	if len(pg.policy.Rules) == 0 {
+		w = append(w, pg.grayLabel(values.String(values.StrNoAutoVoteRules)))
+	}
+	for i := range pg.policy.Rules {
+		rule, remove := pg.policy.Rules[i], pg.removeRules[i]
+		w = append(w, func(gtx C) D {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, pg.Theme.Body1(fmt.Sprintf("%d. %s", i+1, autoVoteRuleLabel(rule))).Layout),
+				layout.Rigid(pg.clickableText(remove, values.String(values.StrRemove), pg.Theme.Color.Danger)),
+			)
+		})
+	}
+
+	w = append(w, func(gtx C) D {
+		return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+			return layout.Flex{}.Layout(gtx,
+				layout.Rigid(pg.clickableText(pg.addFollowVoters, values.String(values.StrFollowTrustedVoters), pg.Theme.Color.Primary)),
+				layout.Rigid(pg.clickableText(pg.addBudgetYes, values.String(values.StrApproveUnderBudget), pg.Theme.Color.Primary)),
+				layout.Rigid(pg.clickableText(pg.addBudgetNo, values.String(values.StrRejectUnderBudget), pg.Theme.Color.Primary)),
+				layout.Rigid(pg.clickableText(pg.addAbstain, values.String(values.StrAbstain), pg.Theme.Color.Primary)),
+			)
+		})
+	})
+
+	if len(pg.activeProposals) > 0 {
+		w = append(w, pg.sectionTitle(values.String(values.StrVotingInProgress)))
+		for i := range pg.activeProposals {
+			proposal, preview := pg.activeProposals[i], pg.previewButtons[i]
+			w = append(w, func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, pg.Theme.Body1(proposal.Name).Layout),
+					layout.Rigid(pg.clickableText(preview, values.String(values.StrPreview), pg.Theme.Color.Primary)),
+				)
+			})
+		}
+	}
+
+	w = append(w, pg.sectionTitle(values.String(values.StrAutoVoteHistory)))
+	if len(pg.logs) == 0 {
+		w = append(w, pg.grayLabel(values.String(values.StrNoAutoVoteHistory)))
+	}
+	for _, l := range pg.logs {
+		w = append(w, pg.layoutLog(l))
+	}
+
+	return pg.card.Layout(gtx, func(gtx C) D {
+		return pg.Theme.List(pg.scrollbarList).Layout(gtx, len(w), func(gtx C, i int) D {
+			return layout.Inset{
+				Left:  values.MarginPadding16,
+				Right: values.MarginPadding16,
+				Top:   values.MarginPadding8,
+			}.Layout(gtx, w[i])
+		})
+	})
+}
+
+func (pg *AutoVotePage) layoutLog(l *libwallet.AutoVoteLog) layout.Widget {
+	return func(gtx C) D {
+		date := time.Unix(l.Timestamp, 0).Format("Jan 2, 2006 15:04")
+		summary := values.StringF(values.StrAutoVotePreview, voteOptionLabel(l.VoteOption), l.NumTickets, l.Reason)
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{}.Layout(gtx,
+					layout.Flexed(1, pg.Theme.Body1(l.ProposalName).Layout),
+					layout.Rigid(pg.grayLabel(date)),
+				)
+			}),
+			layout.Rigid(pg.grayLabel(summary)),
+			layout.Rigid(func(gtx C) D {
+				if l.Error == "" {
+					return D{}
+				}
+				lbl := pg.Theme.Body2(l.Error)
+				lbl.Color = pg.Theme.Color.Danger
+				return lbl.Layout(gtx)
+			}),
+		)
+	}
+}
+
+func (pg *AutoVotePage) sectionTitle(title string) layout.Widget {
+	return func(gtx C) D {
+		lbl := pg.Theme.H6(title)
+		lbl.Font.Weight = font.SemiBold
+		return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, lbl.Layout)
+	}
+}
+
+func (pg *AutoVotePage) grayLabel(text string) layout.Widget {
+	return func(gtx C) D {
+		lbl := pg.Theme.Body2(text)
+		lbl.Color = pg.Theme.Color.GrayText2
+		return lbl.Layout(gtx)
+	}
+}
+
+func (pg *AutoVotePage) clickableText(btn *cryptomaterial.Clickable, text string, col color.NRGBA) layout.Widget {
+	return func(gtx C) D {
+		return btn.Layout(gtx, func(gtx C) D {
+			lbl := pg.Theme.Body2(text)
+			lbl.Color = col
+			return layout.UniformInset(values.MarginPadding8).Layout(gtx, lbl.Layout)
+		})
+	}
+}
+
+func autoVoteRuleLabel(rule libwallet.AutoVoteRule) string {
+	switch rule.Type {
+	case libwallet.AutoVoteRuleFollowVoters:
+		return values.StringF(values.StrFollowVotersRule, len(rule.Addresses))
+	case libwallet.AutoVoteRuleBudget:
+		return values.StringF(values.StrBudgetRule, voteOptionLabel(rule.VoteOption), fmt.Sprintf("%.2f", float64(rule.MaxAmount)/100))
+	}
+	return values.String(values.StrAbstain)
+}
+
+func voteOptionLabel(option string) string {
+	switch option {
+	case libwallet.VoteBitYes:
+		return values.String(values.StrYes)
+	case libwallet.VoteBitNo:
+		return values.String(values.StrNo)
+	}
+	return values.String(values.StrNoVote)
+}
@@ -46,6 +46,8 @@ type ConsensusPage struct {
 
 	infoButton            cryptomaterial.IconButton
 	navigateToSettingsBtn cryptomaterial.Button
+	ticketChoicesBtn      *cryptomaterial.Clickable
+	agendaHistoryBtn      *cryptomaterial.Clickable
 
 	syncCompleted bool
 	isSyncing     bool
@@ -64,6 +66,8 @@ func NewConsensusPage(l *load.Load) *ConsensusPage {
 		redirectIcon:        l.Theme.Icons.RedirectIcon,
 		viewVotingDashboard: l.Theme.NewClickable(true),
 		copyRedirectURL:     l.Theme.NewClickable(false),
+		ticketChoicesBtn:    l.Theme.NewClickable(true),
+		agendaHistoryBtn:    l.Theme.NewClickable(true),
 	}
 
 	_, pg.infoButton = components.SubpageHeaderButtons(l)
@@ -157,6 +161,14 @@ func (pg *ConsensusPage) HandleUserInteractions() {
 		pg.FetchAgendas()
 	}
 
+	if pg.ticketChoicesBtn.Clicked() {
+		pg.ParentNavigator().Display(NewTicketVoteChoicesPage(pg.Load))
+	}
+
+	if pg.agendaHistoryBtn.Clicked() {
+		pg.ParentNavigator().Display(NewAgendaHistoryPage(pg.Load))
+	}
+
 	if pg.infoButton.Button.Clicked() {
 		infoModal := modal.NewCustomModal(pg.Load).
 			Title(values.String(values.StrConsensusChange)).
@@ -291,6 +303,8 @@ func (pg *ConsensusPage) layoutDesktop(gtx layout.Context) layout.Dimensions {
 						layout.Rigid(func(gtx C) D {
 							return layout.Inset{Top: values.MarginPadding3}.Layout(gtx, pg.infoButton.Layout)
 						}),
+						layout.Rigid(pg.layoutTicketChoicesButton),
+						layout.Rigid(pg.layoutAgendaHistoryButton),
 					)
 				}),
 				layout.Flexed(1, func(gtx C) D {
@@ -323,6 +337,8 @@ func (pg *ConsensusPage) layoutMobile(gtx layout.Context) layout.Dimensions {
 					return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
 						layout.Rigid(pg.Theme.Label(values.TextSize20, values.String(values.StrConsensusChange)).Layout), // Do we really need to display the title? nav is proposals already
 						layout.Rigid(pg.infoButton.Layout),
+						layout.Rigid(pg.layoutTicketChoicesButton),
+						layout.Rigid(pg.layoutAgendaHistoryButton),
 					)
 				}),
 				layout.Flexed(1, func(gtx C) D {
@@ -358,6 +374,25 @@ func (pg *ConsensusPage) layoutMobile(gtx layout.Context) layout.Dimensions {
 	)
 }
 
+func (pg *ConsensusPage) layoutTicketChoicesButton(gtx C) D {
+	if pg.WL.SelectedWallet.Wallet.IsWatchingOnlyWallet() {
+		return D{}
+	}
+	return pg.ticketChoicesBtn.Layout(gtx, func(gtx C) D {
+		lbl := pg.Theme.Body2(values.String(values.StrTicketVoteChoices))
+		lbl.Color = pg.Theme.Color.Primary
+		return layout.UniformInset(values.MarginPadding8).Layout(gtx, lbl.Layout)
+	})
+}
+
+func (pg *ConsensusPage) layoutAgendaHistoryButton(gtx C) D {
+	return pg.agendaHistoryBtn.Layout(gtx, func(gtx C) D {
+		lbl := pg.Theme.Body2(values.String(values.StrAgendaHistory))
+		lbl.Color = pg.Theme.Color.Primary
+		return layout.UniformInset(values.MarginPadding8).Layout(gtx, lbl.Layout)
+	})
+}
+
 func (pg *ConsensusPage) layoutRedirectVoting(gtx C) D {
 	return layout.Flex{Axis: layout.Vertical, Alignment: layout.End}.Layout(gtx,
 		layout.Rigid(func(gtx C) D {
@@ -3,6 +3,7 @@ package governance
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"gioui.org/font"
@@ -57,6 +58,9 @@ type ProposalDetails struct {
 
 	viewInPoliteiaBtn *cryptomaterial.Clickable
 	copyRedirectURL   *cryptomaterial.Clickable
+	versionHistoryBtn *cryptomaterial.Clickable
+	voteReceiptsBtn   *cryptomaterial.Clickable
+	bookmarkBtn       *cryptomaterial.Clickable
 
 	descriptionCard cryptomaterial.Card
 	vote            cryptomaterial.Button
@@ -64,6 +68,11 @@ type ProposalDetails struct {
 
 	voteBar            *components.VoteBar
 	loadingDescription bool
+
+	commentWidgets    []layout.Widget
+	commentClickables map[string]*widget.Clickable
+	loadingComments   bool
+	commentsFetched   bool
 }
 
 func NewProposalDetailsPage(l *load.Load, proposal *libwallet.Proposal) *ProposalDetails {
@@ -84,6 +93,9 @@ func NewProposalDetailsPage(l *load.Load, proposal *libwallet.Proposal) *Proposa
 		successIcon:       l.Theme.Icons.ActionCheckCircle,
 		viewInPoliteiaBtn: l.Theme.NewClickable(true),
 		copyRedirectURL:   l.Theme.NewClickable(false),
+		versionHistoryBtn: l.Theme.NewClickable(true),
+		voteReceiptsBtn:   l.Theme.NewClickable(true),
+		bookmarkBtn:       l.Theme.NewClickable(true),
 		voteBar:           components.NewVoteBar(l),
 	}
 
@@ -126,10 +138,34 @@ func (pg *ProposalDetails) HandleUserInteractions() {
 		}
 	}
 
+	for location, clickable := range pg.commentClickables {
+		if clickable.Clicked() {
+			components.GoToURL(location)
+		}
+	}
+
 	if pg.vote.Clicked() {
 		pg.ParentWindow().ShowModal(newVoteModal(pg.Load, pg.proposal))
 	}
 
+	if pg.versionHistoryBtn.Clicked() {
+		pg.ParentNavigator().Display(NewProposalVersionsPage(pg.Load, pg.proposal))
+	}
+
+	if pg.voteReceiptsBtn.Clicked() {
+		pg.ParentNavigator().Display(NewVoteReceiptsPage(pg.Load, pg.proposal.Token))
+	}
+
+	if pg.bookmarkBtn.Clicked() {
+		bookmarked := !pg.proposal.Bookmarked
+		err := pg.WL.AssetsManager.Politeia.SetProposalBookmarked(pg.proposal.Token, bookmarked)
+		if err != nil {
+			log.Errorf("Error bookmarking proposal: %v", err)
+		} else {
+			pg.proposal.Bookmarked = bookmarked
+		}
+	}
+
 	for pg.viewInPoliteiaBtn.Clicked() {
 		host := "https://proposals.decred.org/record/" + pg.proposal.Token
 		if pg.WL.AssetsManager.NetType() == libwallet.Testnet {
@@ -203,6 +239,9 @@ func (pg *ProposalDetails) listenForSyncNotifications() {
 					proposal, err := pg.WL.AssetsManager.Politeia.GetProposalRaw(pg.proposal.Token)
 					if err == nil {
 						pg.proposal = &libwallet.Proposal{Proposal: *proposal}
+						// Sync refreshes the cached discussion of proposals
+						// whose comments count changed.
+						pg.renderCachedComments()
 						pg.ParentWindow().Reload()
 					}
 				}
@@ -435,7 +474,10 @@ func (pg *ProposalDetails) layoutDescription(gtx C) D {
 		func(gtx C) D {
 			lbl := pg.Theme.H5(proposal.Name)
 			lbl.Font.Weight = font.SemiBold
-			return lbl.Layout(gtx)
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, lbl.Layout),
+				layout.Rigid(pg.layoutBookmark),
+			)
 		},
 		pg.lineSeparator(layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding16}),
 		func(gtx C) D {
@@ -473,6 +515,32 @@ func (pg *ProposalDetails) layoutDescription(gtx C) D {
 		w = append(w, loading)
 	}
 
+	if version, _ := strconv.Atoi(proposal.Version); version > 1 {
+		w = append(w, pg.layoutVersionHistory)
+	}
+
+	if proposal.Voted {
+		w = append(w, pg.layoutVoteReceipts)
+	}
+
+	w = append(w, pg.layoutCommentsHeader)
+	switch {
+	case len(pg.commentWidgets) > 0:
+		w = append(w, pg.commentWidgets...)
+	case pg.commentsFetched:
+		w = append(w, func(gtx C) D {
+			lbl := pg.Theme.Body2(values.String(values.StrNoComments))
+			lbl.Color = grayCol
+			return lbl.Layout(gtx)
+		})
+	default:
+		w = append(w, func(gtx C) D {
+			lbl := pg.Theme.Body2(values.String(values.StrLoadingComments))
+			lbl.Color = grayCol
+			return lbl.Layout(gtx)
+		})
+	}
+
 	w = append(w, pg.layoutRedirect(values.String(values.StrViewOnPoliteia), pg.redirectIcon, pg.viewInPoliteiaBtn))
 
 	return pg.descriptionCard.Layout(gtx, func(gtx C) D {
@@ -486,6 +554,178 @@ func (pg *ProposalDetails) layoutDescription(gtx C) D {
 	})
 }
 
+func (pg *ProposalDetails) layoutBookmark(gtx C) D {
+	text := values.String(values.StrBookmark)
+	if pg.proposal.Bookmarked {
+		text = values.String(values.StrRemoveBookmark)
+	}
+
+	return pg.bookmarkBtn.Layout(gtx, func(gtx C) D {
+		lbl := pg.Theme.Body2(text)
+		lbl.Color = pg.Theme.Color.Primary
+		return layout.UniformInset(values.MarginPadding8).Layout(gtx, lbl.Layout)
+	})
+}
+
+func (pg *ProposalDetails) layoutVersionHistory(gtx C) D {
+	return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+		return pg.versionHistoryBtn.Layout(gtx, func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			lbl := pg.Theme.Body1(values.String(values.StrVersionHistory))
+			lbl.Color = pg.Theme.Color.Primary
+			return layout.UniformInset(values.MarginPadding4).Layout(gtx, lbl.Layout)
+		})
+	})
+}
+
+func (pg *ProposalDetails) layoutVoteReceipts(gtx C) D {
+	return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+		return pg.voteReceiptsBtn.Layout(gtx, func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			lbl := pg.Theme.Body1(values.String(values.StrVoteReceipts))
+			lbl.Color = pg.Theme.Color.Primary
+			return layout.UniformInset(values.MarginPadding4).Layout(gtx, lbl.Layout)
+		})
+	})
+}
+
+func (pg *ProposalDetails) layoutCommentsHeader(gtx C) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(pg.lineSeparator(layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding16})),
+		layout.Rigid(func(gtx C) D {
+			lbl := pg.Theme.H6(fmt.Sprintf("%s (%d)", values.String(values.StrComments), pg.proposal.NumComments))
+			lbl.Font.Weight = font.SemiBold
+			return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+		}),
+	)
+}
+
+// loadComments displays the cached discussion of the proposal straight away
+// and then fetches the latest comments from politeia in the background.
+func (pg *ProposalDetails) loadComments() {
+	if pg.commentsFetched || pg.loadingComments {
+		return
+	}
+
+	pg.loadingComments = true
+	go func() {
+		pg.renderCachedComments()
+
+		threads, err := pg.WL.AssetsManager.Politeia.FetchProposalComments(pg.proposal.Token)
+		if err != nil {
+			log.Errorf("Error loading proposal comments: %v", err)
+			time.Sleep(7 * time.Second)
+			pg.loadingComments = false
+			return
+		}
+
+		pg.renderComments(threads)
+		pg.commentsFetched = true
+		pg.loadingComments = false
+		pg.ParentWindow().Reload()
+	}()
+}
+
+func (pg *ProposalDetails) renderCachedComments() {
+	threads, err := pg.WL.AssetsManager.Politeia.GetProposalCommentThreads(pg.proposal.Token)
+	if err != nil {
+		log.Errorf("Error reading cached proposal comments: %v", err)
+		return
+	}
+
+	if len(threads) > 0 {
+		pg.renderComments(threads)
+	}
+}
+
+func (pg *ProposalDetails) renderComments(threads []*libwallet.ProposalCommentThread) {
+	clickables := make(map[string]*widget.Clickable)
+	pg.commentWidgets = pg.commentThreadWidgets(threads, 0, clickables)
+	pg.commentClickables = clickables
+}
+
+// commentThreadWidgets renders the comments and their replies depth first,
+// indenting each reply under its parent comment.
+func (pg *ProposalDetails) commentThreadWidgets(threads []*libwallet.ProposalCommentThread, depth int, clickables map[string]*widget.Clickable) []layout.Widget {
+	const maxIndentDepth = 4
+
+	indent := values.MarginPadding16 * unit.Dp(depth)
+	if depth > maxIndentDepth {
+		indent = values.MarginPadding16 * maxIndentDepth
+	}
+
+	var w []layout.Widget
+	for _, thread := range threads {
+		comment := thread.Comment
+		body := pg.commentBodyWidgets(comment.Comment, comment.Deleted, comment.Reason, clickables)
+		header := pg.commentHeader(comment.Username, comment.CreatedAt, comment.Version > 1, comment.Upvotes, comment.Downvotes)
+
+		w = append(w, func(gtx C) D {
+			return layout.Inset{Left: indent, Top: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+				children := []layout.FlexChild{layout.Rigid(header)}
+				for _, bw := range body {
+					children = append(children, layout.Rigid(bw))
+				}
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+			})
+		})
+		w = append(w, pg.commentThreadWidgets(thread.Replies, depth+1, clickables)...)
+	}
+
+	return w
+}
+
+func (pg *ProposalDetails) commentHeader(username string, createdAt int64, edited bool, upvotes, downvotes uint64) layout.Widget {
+	return func(gtx C) D {
+		grayCol := pg.Theme.Color.GrayText2
+
+		userLabel := pg.Theme.Body2(username)
+		userLabel.Font.Weight = font.SemiBold
+
+		timeText := components.TimeAgo(createdAt)
+		if edited {
+			timeText += " (" + values.String(values.StrEdited) + ")"
+		}
+		timeLabel := pg.Theme.Body2(timeText)
+		timeLabel.Color = grayCol
+
+		votesLabel := pg.Theme.Body2(fmt.Sprintf("+%d / -%d", upvotes, downvotes))
+		votesLabel.Color = grayCol
+
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(userLabel.Layout),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, timeLabel.Layout)
+			}),
+			layout.Flexed(1, func(gtx C) D {
+				return layout.E.Layout(gtx, votesLabel.Layout)
+			}),
+		)
+	}
+}
+
+func (pg *ProposalDetails) commentBodyWidgets(text string, censored bool, reason string, clickables map[string]*widget.Clickable) []layout.Widget {
+	if censored {
+		msg := values.String(values.StrCommentCensored)
+		if reason != "" {
+			msg += ": " + reason
+		}
+		return []layout.Widget{func(gtx C) D {
+			lbl := pg.Theme.Body2(msg)
+			lbl.Font.Style = font.Italic
+			lbl.Color = pg.Theme.Color.GrayText3
+			return lbl.Layout(gtx)
+		}}
+	}
+
+	r := renderers.RenderMarkdown(layout.Context{}, pg.Theme, text)
+	widgets, links := r.Layout()
+	for location, clickable := range links {
+		clickables[location] = clickable
+	}
+	return widgets
+}
+
 func (pg *ProposalDetails) layoutRedirect(text string, icon *cryptomaterial.Image, btn *cryptomaterial.Clickable) layout.Widget {
 	return func(gtx C) D {
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
@@ -554,6 +794,7 @@ func (pg *ProposalDetails) layoutDesktop(gtx layout.Context) layout.Dimensions {
 			pg.loadingDescription = false
 		}()
 	}
+	pg.loadComments()
 
 	body := func(gtx C) D {
 		page := components.SubPage{
@@ -621,6 +862,7 @@ func (pg *ProposalDetails) layoutMobile(gtx layout.Context) layout.Dimensions {
 			pg.loadingDescription = false
 		}()
 	}
+	pg.loadComments()
 
 	body := func(gtx C) D {
 		page := components.SubPage{
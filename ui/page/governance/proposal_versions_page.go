@@ -0,0 +1,275 @@
+package governance
+
+import (
+	"strconv"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const ProposalVersionsPageID = "proposal_versions"
+
+// ProposalVersionsPage shows the changes made to a proposal's metadata and
+// description by each of its versions.
+type ProposalVersionsPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	proposal *libwallet.Proposal
+
+	// versionButtons[i] selects the changes made by version i+2.
+	versionButtons  []*cryptomaterial.Clickable
+	selectedVersion int
+	diff            *libwallet.ProposalVersionDiff
+	loading         bool
+	errMsg          string
+
+	versionsList  *layout.List
+	scrollbarList *widget.List
+	card          cryptomaterial.Card
+	backButton    cryptomaterial.IconButton
+}
+
+func NewProposalVersionsPage(l *load.Load, proposal *libwallet.Proposal) *ProposalVersionsPage {
+	pg := &ProposalVersionsPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(ProposalVersionsPageID),
+		proposal:         proposal,
+		versionsList:     &layout.List{Axis: layout.Horizontal},
+		scrollbarList: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		card: l.Theme.Card(),
+	}
+
+	latest, _ := strconv.Atoi(proposal.Version)
+	for v := 2; v <= latest; v++ {
+		pg.versionButtons = append(pg.versionButtons, l.Theme.NewClickable(true))
+	}
+	pg.selectedVersion = latest
+
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *ProposalVersionsPage) OnNavigatedTo() {
+	if pg.diff == nil {
+		pg.loadDiff(pg.selectedVersion)
+	}
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *ProposalVersionsPage) HandleUserInteractions() {
+	for i, btn := range pg.versionButtons {
+		if btn.Clicked() && !pg.loading {
+			pg.loadDiff(i + 2)
+		}
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *ProposalVersionsPage) OnNavigatedFrom() {}
+
+// loadDiff fetches the changes made by the specified version compared to
+// the version before it. Versions not yet saved locally are fetched from
+// politeia.
+func (pg *ProposalVersionsPage) loadDiff(version int) {
+	if version < 2 {
+		return
+	}
+
+	pg.selectedVersion = version
+	pg.loading = true
+	pg.errMsg = ""
+	go func() {
+		from, to := strconv.Itoa(version-1), strconv.Itoa(version)
+		diff, err := pg.WL.AssetsManager.Politeia.DiffProposalVersions(pg.proposal.Token, from, to)
+		if err != nil {
+			log.Errorf("Error loading proposal versions diff: %v", err)
+			pg.errMsg = err.Error()
+		} else {
+			pg.diff = diff
+		}
+		pg.loading = false
+		pg.ParentWindow().Reload()
+	}()
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *ProposalVersionsPage) Layout(gtx C) D {
+	body := func(gtx C) D {
+		page := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrVersionHistory),
+			SubTitle:   components.TruncateString(pg.proposal.Name, 40),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, pg.layoutVersions)
+					}),
+					layout.Flexed(1, pg.layoutDiff),
+				)
+			},
+		}
+		return page.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, false, body)
+	}
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *ProposalVersionsPage) layoutVersions(gtx C) D {
+	return pg.versionsList.Layout(gtx, len(pg.versionButtons), func(gtx C, i int) D {
+		version := i + 2
+		lbl := pg.Theme.Body2(values.String(values.StrVersion) + " " + strconv.Itoa(version))
+		card := pg.Theme.Card()
+		if version == pg.selectedVersion {
+			card.Color = pg.Theme.Color.Primary
+			lbl.Color = pg.Theme.Color.Surface
+		}
+
+		return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+			return card.Layout(gtx, func(gtx C) D {
+				return pg.versionButtons[i].Layout(gtx, func(gtx C) D {
+					return layout.UniformInset(values.MarginPadding8).Layout(gtx, lbl.Layout)
+				})
+			})
+		})
+	})
+}
+
+func (pg *ProposalVersionsPage) layoutDiff(gtx C) D {
+	var w []layout.Widget
+	switch {
+	case pg.loading:
+		w = append(w, pg.Theme.Body1(values.String(values.StrLoading)).Layout)
+	case pg.errMsg != "":
+		lbl := pg.Theme.Body1(pg.errMsg)
+		lbl.Color = pg.Theme.Color.Danger
+		w = append(w, lbl.Layout)
+	case pg.diff != nil:
+		w = pg.diffWidgets(pg.diff)
+	}
+
+	return pg.card.Layout(gtx, func(gtx C) D {
+		return pg.Theme.List(pg.scrollbarList).Layout(gtx, len(w), func(gtx C, i int) D {
+			return layout.Inset{
+				Left:  values.MarginPadding16,
+				Right: values.MarginPadding16,
+				Top:   values.MarginPadding4,
+			}.Layout(gtx, w[i])
+		})
+	})
+}
+
+func (pg *ProposalVersionsPage) diffWidgets(diff *libwallet.ProposalVersionDiff) []layout.Widget {
+	title := pg.Theme.H6(values.StringF(values.StrCompareWithPrevious, diff.From.Version, diff.To.Version))
+	title.Font.Weight = font.SemiBold
+	w := []layout.Widget{func(gtx C) D {
+		return layout.Inset{Top: values.MarginPadding12, Bottom: values.MarginPadding8}.Layout(gtx, title.Layout)
+	}}
+
+	changedLines := 0
+	for _, line := range diff.Description {
+		if line.Op != libwallet.DiffEqual {
+			changedLines++
+		}
+	}
+
+	if len(diff.Fields) == 0 && changedLines == 0 {
+		lbl := pg.Theme.Body1(values.String(values.StrNoVersionChanges))
+		lbl.Color = pg.Theme.Color.GrayText2
+		return append(w, lbl.Layout)
+	}
+
+	for _, change := range diff.Fields {
+		change := change
+		w = append(w, func(gtx C) D {
+			from := pg.Theme.Body2(change.From)
+			from.Color = pg.Theme.Color.Danger
+			to := pg.Theme.Body2(change.To)
+			to.Color = pg.Theme.Color.Success
+			return layout.Flex{}.Layout(gtx,
+				layout.Flexed(0.3, pg.Theme.Body2(proposalFieldLabel(change.Field)).Layout),
+				layout.Flexed(0.35, from.Layout),
+				layout.Flexed(0.35, to.Layout),
+			)
+		})
+	}
+
+	if changedLines > 0 {
+		lbl := pg.Theme.Body1(values.String(values.StrProposalDescription))
+		lbl.Font.Weight = font.SemiBold
+		w = append(w, func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+		})
+
+		for _, line := range diff.Description {
+			lbl := pg.Theme.Body2("  " + line.Text)
+			switch line.Op {
+			case libwallet.DiffInsert:
+				lbl.Text = "+ " + line.Text
+				lbl.Color = pg.Theme.Color.Success
+			case libwallet.DiffDelete:
+				lbl.Text = "- " + line.Text
+				lbl.Color = pg.Theme.Color.Danger
+			default:
+				lbl.Color = pg.Theme.Color.GrayText2
+			}
+			w = append(w, lbl.Layout)
+		}
+	}
+
+	return w
+}
+
+func proposalFieldLabel(field string) string {
+	switch field {
+	case libwallet.ProposalFieldName:
+		return values.String(values.StrProposalName)
+	case libwallet.ProposalFieldAmount:
+		return values.String(values.StrAmount)
+	case libwallet.ProposalFieldStartDate:
+		return values.String(values.StrStartDate)
+	case libwallet.ProposalFieldEndDate:
+		return values.String(values.StrEndDate)
+	case libwallet.ProposalFieldDomain:
+		return values.String(values.StrDomain)
+	}
+	return field
+}
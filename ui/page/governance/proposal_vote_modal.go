@@ -75,7 +75,7 @@ func newVoteModal(l *load.Load, proposal *libwallet.Proposal) *voteModal {
 			vm.ParentWindow().Reload()
 
 			go func() {
-				voteDetails, err := vm.WL.AssetsManager.Politeia.ProposalVoteDetailsRaw(ctx, w.Internal().DCR, vm.proposal.Token)
+				voteDetails, err := vm.WL.AssetsManager.Politeia.ProposalVoteDetailsRaw(ctx, w.Internal().DCR, w.GetWalletID(), vm.proposal.Token)
 				vm.detailsMu.Lock()
 				if !components.ContextDone(ctx) {
 					vm.voteDetails = &libwallet.ProposalVoteDetails{ProposalVoteDetails: *voteDetails}
@@ -143,8 +143,8 @@ func (vm *voteModal) sendVotes() {
 		Title(values.String(values.StrVoteConfirm)).
 		SetNegativeButtonCallback(func() { vm.isVoting = false }).
 		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
-			w := vm.walletSelector.selectedWallet.Internal().DCR
-			err := vm.WL.AssetsManager.Politeia.CastVotes(ctx, w, libwallet.ConvertVotes(votes), vm.proposal.Token, password)
+			w := vm.walletSelector.selectedWallet
+			err := vm.WL.AssetsManager.Politeia.CastVotes(ctx, w.Internal().DCR, w.GetWalletID(), libwallet.ConvertVotes(votes), vm.proposal.Token, password)
 			if err != nil {
 				pm.SetError(err.Error())
 				pm.SetLoading(false)
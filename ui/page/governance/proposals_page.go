@@ -2,6 +2,9 @@ package governance
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"gioui.org/layout"
@@ -46,13 +49,22 @@ type ProposalsPage struct {
 	ctxCancel      context.CancelFunc
 	assetsManager  *libwallet.AssetsManager
 	scroll         *components.Scroll
-	previousFilter int32
+	previousFilter string
 	statusDropDown *cryptomaterial.DropDown
 	proposalsList  *cryptomaterial.ClickableList
 	syncButton     *widget.Clickable
 	searchEditor   cryptomaterial.Editor
+	filtersBtn     *cryptomaterial.Clickable
 
-	infoButton cryptomaterial.IconButton
+	// filter holds the search criteria set in the filters modal, the
+	// category and search query are set from the dropdown and search editor
+	// when fetching the proposals.
+	filter libwallet.ProposalFilter
+
+	infoButton       cryptomaterial.IconButton
+	autoVoteBtn      *cryptomaterial.Clickable
+	voteDeadlinesBtn *cryptomaterial.Clickable
+	voteReceiptsBtn  *cryptomaterial.Clickable
 
 	updatedIcon *cryptomaterial.Icon
 
@@ -74,6 +86,10 @@ func NewProposalsPage(l *load.Load) *ProposalsPage {
 	pg.updatedIcon.Color = pg.Theme.Color.Success
 
 	pg.syncButton = new(widget.Clickable)
+	pg.autoVoteBtn = l.Theme.NewClickable(true)
+	pg.voteDeadlinesBtn = l.Theme.NewClickable(true)
+	pg.voteReceiptsBtn = l.Theme.NewClickable(true)
+	pg.filtersBtn = l.Theme.NewClickable(true)
 	pg.scroll = components.NewScroll(l, pageSize, pg.fetchProposals)
 
 	pg.proposalsList = pg.Theme.NewClickableList(layout.Vertical)
@@ -89,6 +105,7 @@ func NewProposalsPage(l *load.Load) *ProposalsPage {
 		{Text: values.String(values.StrApproved)},
 		{Text: values.String(values.StrRejected)},
 		{Text: values.String(values.StrAbandoned)},
+		{Text: values.String(values.StrBookmarked)},
 	}, values.ProposalDropdownGroup, 0)
 
 	return pg
@@ -116,40 +133,33 @@ func (pg *ProposalsPage) OnNavigatedTo() {
 // fetchProposals is thread safe and on completing proposals fetch it triggers
 // UI update with the new proposals list.
 func (pg *ProposalsPage) fetchProposals(offset, pageSize int32) (interface{}, int, bool, error) {
-	var proposalFilter int32
-	selectedType := pg.statusDropDown.Selected()
-	switch selectedType {
+	filter := pg.filter
+	filter.Query = pg.searchEditor.Editor.Text()
+	filter.Bookmarked = false
+	switch pg.statusDropDown.Selected() {
+	case values.String(values.StrUnderReview):
+		// group 'In discussion' and 'Active' proposals into under review
+		filter.Categories = []int32{libwallet.ProposalCategoryPre, libwallet.ProposalCategoryActive}
 	case values.String(values.StrApproved):
-		proposalFilter = libwallet.ProposalCategoryApproved
+		filter.Categories = []int32{libwallet.ProposalCategoryApproved}
 	case values.String(values.StrRejected):
-		proposalFilter = libwallet.ProposalCategoryRejected
+		filter.Categories = []int32{libwallet.ProposalCategoryRejected}
 	case values.String(values.StrAbandoned):
-		proposalFilter = libwallet.ProposalCategoryAbandoned
-	default:
-		proposalFilter = libwallet.ProposalCategoryAll
+		filter.Categories = []int32{libwallet.ProposalCategoryAbandoned}
+	case values.String(values.StrBookmarked):
+		filter.Bookmarked = true
 	}
 
-	isReset := pg.previousFilter != proposalFilter
+	filterKey := fmt.Sprintf("%+v", filter)
+	isReset := pg.previousFilter != filterKey
 	if isReset {
 		// reset the offset to zero
 		offset = 0
-		pg.previousFilter = proposalFilter
+		pg.previousFilter = filterKey
 	}
 
-	proposalItems := components.LoadProposals(pg.Load, proposalFilter, offset, pageSize, true)
-	listItems := make([]*components.ProposalItem, 0)
-
-	if selectedType == values.String(values.StrUnderReview) {
-		// group 'In discussion' and 'Active' proposals into under review
-		for _, item := range proposalItems {
-			if item.Proposal.Category == libwallet.ProposalCategoryPre ||
-				item.Proposal.Category == libwallet.ProposalCategoryActive {
-				listItems = append(listItems, item)
-			}
-		}
-	} else {
-		listItems = proposalItems
-	}
+	filter.Offset, filter.Limit, filter.NewestFirst = offset, pageSize, true
+	listItems := components.SearchProposals(pg.Load, &filter)
 
 	return listItems, len(listItems), isReset, nil
 }
@@ -169,7 +179,17 @@ func (pg *ProposalsPage) HandleUserInteractions() {
 	}
 
 	pg.searchEditor.EditorIconButtonEvent = func() {
-		// TODO: Proposals search functionality
+		pg.scroll.FetchScrollData(false, pg.ParentWindow())
+	}
+
+	for _, evt := range pg.searchEditor.Editor.Events() {
+		if _, ok := evt.(widget.SubmitEvent); ok {
+			pg.scroll.FetchScrollData(false, pg.ParentWindow())
+		}
+	}
+
+	if pg.filtersBtn.Clicked() {
+		pg.showFiltersModal()
 	}
 
 	if clicked, selectedItem := pg.proposalsList.ItemClicked(); clicked {
@@ -178,6 +198,18 @@ func (pg *ProposalsPage) HandleUserInteractions() {
 		pg.ParentNavigator().Display(NewProposalDetailsPage(pg.Load, &selectedProposal))
 	}
 
+	if pg.autoVoteBtn.Clicked() {
+		pg.ParentNavigator().Display(NewAutoVotePage(pg.Load))
+	}
+
+	if pg.voteDeadlinesBtn.Clicked() {
+		pg.ParentNavigator().Display(NewVoteDeadlinesPage(pg.Load))
+	}
+
+	if pg.voteReceiptsBtn.Clicked() {
+		pg.ParentNavigator().Display(NewVoteReceiptsPage(pg.Load, ""))
+	}
+
 	for pg.syncButton.Clicked() {
 		go pg.assetsManager.Politeia.Sync(context.Background())
 		pg.isSyncing = true
@@ -296,8 +328,11 @@ func (pg *ProposalsPage) layoutMobile(gtx layout.Context) layout.Dimensions {
 }
 
 func (pg *ProposalsPage) layoutContent(gtx C) D {
-	return layout.Stack{}.Layout(gtx,
-		layout.Expanded(func(gtx C) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, pg.layoutSearch)
+		}),
+		layout.Flexed(1, func(gtx C) D {
 			return pg.scroll.List().Layout(gtx, 1, func(gtx C, i int) D {
 				return layout.Inset{Right: values.MarginPadding2}.Layout(gtx, func(gtx C) D {
 					return pg.Theme.Card().Layout(gtx, func(gtx C) D {
@@ -352,6 +387,33 @@ func (pg *ProposalsPage) layoutSectionHeader(gtx C) D {
 				layout.Rigid(func(gtx C) D {
 					return layout.Inset{Top: values.MarginPadding3}.Layout(gtx, pg.infoButton.Layout)
 				}),
+				layout.Rigid(func(gtx C) D {
+					if pg.WL.SelectedWallet.Wallet.IsWatchingOnlyWallet() {
+						return D{}
+					}
+					return pg.autoVoteBtn.Layout(gtx, func(gtx C) D {
+						lbl := pg.Theme.Body2(values.String(values.StrAutoVote))
+						lbl.Color = pg.Theme.Color.Primary
+						return layout.UniformInset(values.MarginPadding8).Layout(gtx, lbl.Layout)
+					})
+				}),
+				layout.Rigid(func(gtx C) D {
+					if pg.WL.SelectedWallet.Wallet.IsWatchingOnlyWallet() {
+						return D{}
+					}
+					return pg.voteDeadlinesBtn.Layout(gtx, func(gtx C) D {
+						lbl := pg.Theme.Body2(values.String(values.StrVoteDeadlines))
+						lbl.Color = pg.Theme.Color.Primary
+						return layout.UniformInset(values.MarginPadding8).Layout(gtx, lbl.Layout)
+					})
+				}),
+				layout.Rigid(func(gtx C) D {
+					return pg.voteReceiptsBtn.Layout(gtx, func(gtx C) D {
+						lbl := pg.Theme.Body2(values.String(values.StrVoteReceipts))
+						lbl.Color = pg.Theme.Color.Primary
+						return layout.UniformInset(values.MarginPadding8).Layout(gtx, lbl.Layout)
+					})
+				}),
 			)
 		}),
 		layout.Flexed(1, func(gtx C) D {
@@ -413,3 +475,169 @@ func (pg *ProposalsPage) listenForSyncNotifications() {
 		}
 	}()
 }
+
+func (pg *ProposalsPage) layoutSearch(gtx C) D {
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+		layout.Flexed(1, func(gtx C) D {
+			return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+				return layout.UniformInset(values.MarginPadding4).Layout(gtx, pg.searchEditor.Layout)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			return pg.filtersBtn.Layout(gtx, func(gtx C) D {
+				lbl := pg.Theme.Body2(values.String(values.StrFilters))
+				lbl.Color = pg.Theme.Color.Primary
+				return layout.UniformInset(values.MarginPadding8).Layout(gtx, lbl.Layout)
+			})
+		}),
+	)
+}
+
+// showFiltersModal lets the user filter the proposals by budget, author,
+// publication date, vote outcome and whether the wallets voted on them.
+func (pg *ProposalsPage) showFiltersModal() {
+	const dateLayout = "2006-01-02"
+
+	minBudgetEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrMinBudgetUSD))
+	maxBudgetEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrMaxBudgetUSD))
+	authorEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrAuthor))
+	fromEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrFromDate))
+	toEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrToDate))
+	editors := []*cryptomaterial.Editor{&minBudgetEditor, &maxBudgetEditor, &authorEditor, &fromEditor, &toEditor}
+	for _, editor := range editors {
+		editor.Editor.SingleLine = true
+	}
+
+	if pg.filter.MinAmount > 0 {
+		minBudgetEditor.Editor.SetText(strconv.FormatFloat(float64(pg.filter.MinAmount)/100, 'f', -1, 64))
+	}
+	if pg.filter.MaxAmount > 0 {
+		maxBudgetEditor.Editor.SetText(strconv.FormatFloat(float64(pg.filter.MaxAmount)/100, 'f', -1, 64))
+	}
+	authorEditor.Editor.SetText(pg.filter.Author)
+	if pg.filter.From > 0 {
+		fromEditor.Editor.SetText(time.Unix(pg.filter.From, 0).UTC().Format(dateLayout))
+	}
+	if pg.filter.To > 0 {
+		toEditor.Editor.SetText(time.Unix(pg.filter.To, 0).UTC().Format(dateLayout))
+	}
+
+	outcomeGroup := &widget.Enum{Value: strconv.Itoa(int(pg.filter.VoteOutcome))}
+	votedGroup := &widget.Enum{Value: strconv.Itoa(int(pg.filter.Voted))}
+	radioButtons := func(group *widget.Enum, labels map[int32]string) []layout.FlexChild {
+		items := make([]layout.FlexChild, 0, len(labels))
+		for key := int32(0); key < int32(len(labels)); key++ {
+			radioBtn := pg.Theme.RadioButton(group, strconv.Itoa(int(key)), labels[key], pg.Theme.Color.DeepBlue, pg.Theme.Color.Primary)
+			items = append(items, layout.Rigid(radioBtn.Layout))
+		}
+		return items
+	}
+	outcomeButtons := radioButtons(outcomeGroup, map[int32]string{
+		libwallet.ProposalOutcomeAny:      values.String(values.StrAny),
+		libwallet.ProposalOutcomeApproved: values.String(values.StrApproved),
+		libwallet.ProposalOutcomeRejected: values.String(values.StrRejected),
+	})
+	votedButtons := radioButtons(votedGroup, map[int32]string{
+		libwallet.ProposalVotedAny:     values.String(values.StrAny),
+		libwallet.ProposalVotedByMe:    values.String(values.StrIVoted),
+		libwallet.ProposalNotVotedByMe: values.String(values.StrIHaventVoted),
+	})
+
+	parseAmount := func(editor *cryptomaterial.Editor) (uint64, bool) {
+		text := strings.TrimSpace(editor.Editor.Text())
+		if text == "" {
+			return 0, true
+		}
+		amount, err := strconv.ParseFloat(text, 64)
+		if err != nil || amount < 0 {
+			editor.SetError(values.String(values.StrInvalidAmount))
+			return 0, false
+		}
+		return uint64(amount * 100), true
+	}
+	parseDate := func(editor *cryptomaterial.Editor) (time.Time, bool) {
+		text := strings.TrimSpace(editor.Editor.Text())
+		if text == "" {
+			return time.Time{}, true
+		}
+		date, err := time.Parse(dateLayout, text)
+		if err != nil {
+			editor.SetError(values.String(values.StrInvalidDate))
+			return time.Time{}, false
+		}
+		return date, true
+	}
+
+	editorInset := layout.Inset{Top: values.MarginPadding10}
+	sectionTitle := func(title string) layout.FlexChild {
+		return layout.Rigid(func(gtx C) D {
+			return editorInset.Layout(gtx, pg.Theme.Body1(title).Layout)
+		})
+	}
+	filtersModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrFilters)).
+		UseCustomWidget(func(gtx C) D {
+			children := make([]layout.FlexChild, 0)
+			for _, editor := range editors {
+				editor := editor
+				children = append(children, layout.Rigid(func(gtx C) D {
+					return editorInset.Layout(gtx, editor.Layout)
+				}))
+			}
+			children = append(children, sectionTitle(values.String(values.StrVoteOutcome)))
+			children = append(children, outcomeButtons...)
+			children = append(children, sectionTitle(values.String(values.StrMyVote)))
+			children = append(children, votedButtons...)
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		}).
+		// Not cancelable since dismissing the modal runs the negative button
+		// callback, which clears the filters.
+		SetCancelable(false).
+		SetNegativeButtonText(values.String(values.StrClear)).
+		SetNegativeButtonCallback(func() {
+			pg.filter = libwallet.ProposalFilter{}
+			pg.scroll.FetchScrollData(false, pg.ParentWindow())
+		}).
+		SetPositiveButtonText(values.String(values.StrApply)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			minAmount, ok := parseAmount(&minBudgetEditor)
+			if !ok {
+				return false
+			}
+			maxAmount, ok := parseAmount(&maxBudgetEditor)
+			if !ok {
+				return false
+			}
+			from, ok := parseDate(&fromEditor)
+			if !ok {
+				return false
+			}
+			to, ok := parseDate(&toEditor)
+			if !ok {
+				return false
+			}
+
+			filter := libwallet.ProposalFilter{
+				MinAmount: minAmount,
+				MaxAmount: maxAmount,
+				Author:    strings.TrimSpace(authorEditor.Editor.Text()),
+			}
+			if !from.IsZero() {
+				filter.From = from.Unix()
+			}
+			if !to.IsZero() {
+				// include the proposals published on the end date
+				filter.To = to.AddDate(0, 0, 1).Unix() - 1
+			}
+			outcome, _ := strconv.Atoi(outcomeGroup.Value)
+			filter.VoteOutcome = int32(outcome)
+			voted, _ := strconv.Atoi(votedGroup.Value)
+			filter.Voted = int32(voted)
+			filter.WalletID = pg.WL.SelectedWallet.Wallet.GetWalletID()
+
+			pg.filter = filter
+			pg.scroll.FetchScrollData(false, pg.ParentWindow())
+			return true
+		})
+	pg.ParentWindow().ShowModal(filtersModal)
+}
@@ -154,7 +154,6 @@ func (tsm *ticketSelectorModal) title(title string) *ticketSelectorModal {
 
 func (tsm *ticketSelectorModal) ticketSelected(callback func(*sharedW.Transaction)) *ticketSelectorModal {
 	tsm.ticketSelectedCallback = callback
-	tsm.Dismiss()
 	return tsm
 }
 
@@ -0,0 +1,514 @@
+package governance
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"sort"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const TicketVoteChoicesPageID = "ticket_vote_choices"
+
+// TicketVoteChoicesPage shows the agenda, treasury and tspend vote choices of
+// the selected wallet's live tickets, sets a choice on several tickets at once
+// and checks that the VSPs record the same choices as the wallet.
+type TicketVoteChoicesPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
+	dcrImpl *dcr.Asset
+
+	tickets  []*dcr.TicketVoteChoices
+	selected []*widget.Bool
+	agendas  []*dcr.Agenda
+	loading  bool
+	errMsg   string
+
+	mismatches  []*dcr.VoteChoiceMismatch
+	vspChecked  bool
+	vspCheckErr string
+
+	selectAll   *widget.Bool
+	editBtn     *cryptomaterial.Clickable
+	checkVSPBtn *cryptomaterial.Clickable
+	syncVSPBtn  *cryptomaterial.Clickable
+
+	scrollbarList *widget.List
+	card          cryptomaterial.Card
+	backButton    cryptomaterial.IconButton
+}
+
+func NewTicketVoteChoicesPage(l *load.Load) *TicketVoteChoicesPage {
+	pg := &TicketVoteChoicesPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(TicketVoteChoicesPageID),
+		selectAll:        new(widget.Bool),
+		editBtn:          l.Theme.NewClickable(true),
+		checkVSPBtn:      l.Theme.NewClickable(true),
+		syncVSPBtn:       l.Theme.NewClickable(true),
+		scrollbarList: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		card: l.Theme.Card(),
+	}
+
+	pg.dcrImpl, _ = l.WL.SelectedWallet.Wallet.(*dcr.Asset)
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *TicketVoteChoicesPage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+	if pg.dcrImpl != nil {
+		pg.agendas = pg.dcrImpl.CurrentAgendas()
+		pg.loadTickets()
+	}
+}
+
+func (pg *TicketVoteChoicesPage) loadTickets() {
+	pg.loading = true
+	pg.errMsg = ""
+	go func() {
+		tickets, err := pg.dcrImpl.TicketsVoteChoices()
+		if err != nil {
+			log.Errorf("Error loading ticket vote choices: %v", err)
+			pg.errMsg = err.Error()
+		} else {
+			pg.tickets = tickets
+			pg.selected = make([]*widget.Bool, len(tickets))
+			for i := range pg.selected {
+				pg.selected[i] = new(widget.Bool)
+			}
+			pg.selectAll.Value = false
+		}
+		pg.loading = false
+		pg.ParentWindow().Reload()
+	}()
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *TicketVoteChoicesPage) HandleUserInteractions() {
+	if pg.dcrImpl == nil {
+		return
+	}
+
+	if pg.selectAll.Changed() {
+		for _, selected := range pg.selected {
+			selected.Value = pg.selectAll.Value
+		}
+	}
+
+	if pg.editBtn.Clicked() {
+		if hashes := pg.selectedTickets(); len(hashes) == 0 {
+			pg.Toast.NotifyError(values.String(values.StrNoTicketsSelected))
+		} else {
+			pg.showEditModal(hashes)
+		}
+	}
+
+	if pg.checkVSPBtn.Clicked() {
+		pg.checkVSPChoices()
+	}
+
+	if pg.syncVSPBtn.Clicked() {
+		pg.syncVSPChoices()
+	}
+}
+
+func (pg *TicketVoteChoicesPage) selectedTickets() []string {
+	var hashes []string
+	for i, selected := range pg.selected {
+		if selected.Value {
+			hashes = append(hashes, pg.tickets[i].TicketHash)
+		}
+	}
+	return hashes
+}
+
+// showEditModal asks what to vote on and the choice to set on the tickets.
+func (pg *TicketVoteChoicesPage) showEditModal(ticketHashes []string) {
+	type target struct {
+		choiceType dcr.VoteChoiceType
+		id         string
+		choices    []string
+	}
+
+	treasuryChoices := []string{"yes", "no", "abstain"}
+	targets := make(map[string]*target)
+	targetGroup, choiceGroup := new(widget.Enum), new(widget.Enum)
+	var targetItems []layout.FlexChild
+	addTarget := func(t *target) {
+		key := fmt.Sprintf("%d:%s", t.choiceType, t.id)
+		targets[key] = t
+		if targetGroup.Value == "" {
+			targetGroup.Value = key
+		}
+		rb := pg.Theme.RadioButton(targetGroup, key, voteChoiceTargetLabel(t.choiceType, t.id), pg.Theme.Color.DeepBlue, pg.Theme.Color.Primary)
+		targetItems = append(targetItems, layout.Rigid(rb.Layout))
+	}
+
+	for _, agenda := range pg.agendas {
+		choices := make([]string, len(agenda.Choices))
+		for i := range agenda.Choices {
+			choices[i] = agenda.Choices[i].Id
+		}
+		addTarget(&target{dcr.AgendaVoteChoice, agenda.AgendaID, choices})
+	}
+	// The pi keys and tspends are the same for all the tickets.
+	ticket := pg.tickets[0]
+	for _, pikey := range sortedKeys(ticket.TreasuryPolicies) {
+		addTarget(&target{dcr.TreasuryVoteChoice, pikey, treasuryChoices})
+	}
+	for _, tspend := range sortedKeys(ticket.TSpendPolicies) {
+		addTarget(&target{dcr.TSpendVoteChoice, tspend, treasuryChoices})
+	}
+
+	caser := cases.Title(language.Und)
+	editModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrVoteChoice)).
+		UseCustomWidget(func(gtx C) D {
+			selected := targets[targetGroup.Value]
+			choiceItems := []layout.FlexChild{layout.Rigid(pg.sectionTitle(values.String(values.StrChoice)))}
+			if selected != nil {
+				for _, choice := range selected.choices {
+					rb := pg.Theme.RadioButton(choiceGroup, choice, caser.String(choice), pg.Theme.Color.DeepBlue, pg.Theme.Color.Primary)
+					choiceItems = append(choiceItems, layout.Rigid(rb.Layout))
+				}
+			}
+
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(pg.sectionTitle(values.String(values.StrVoteOn))),
+				layout.Rigid(func(gtx C) D {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx, targetItems...)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx, choiceItems...)
+				}),
+			)
+		}).
+		SetCancelable(true).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrSave)).
+		SetPositiveButtonCallback(func(_ bool, im *modal.InfoModal) bool {
+			selected := targets[targetGroup.Value]
+			if selected == nil {
+				return false
+			}
+			// The choice may be left over from another target.
+			var valid bool
+			for _, choice := range selected.choices {
+				valid = valid || choice == choiceGroup.Value
+			}
+			if !valid {
+				return false
+			}
+			im.Dismiss()
+			pg.confirmEdit(selected.choiceType, selected.id, choiceGroup.Value, ticketHashes)
+			return true
+		})
+	pg.ParentWindow().ShowModal(editModal)
+}
+
+func (pg *TicketVoteChoicesPage) confirmEdit(choiceType dcr.VoteChoiceType, id, choice string, ticketHashes []string) {
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrConfirmVote)).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			err := pg.dcrImpl.SetTicketsVoteChoice(choiceType, id, choice, ticketHashes, password)
+			if err != nil {
+				pm.SetError(err.Error())
+				pm.SetLoading(false)
+				return false
+			}
+			pg.loadTickets()
+			successModal := modal.NewSuccessModal(pg.Load, values.String(values.StrVoteUpdated), modal.DefaultClickFunc())
+			pg.ParentWindow().ShowModal(successModal)
+			pm.Dismiss()
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// checkVSPChoices requests the tickets' vote choices recorded by the VSPs.
+// The wallet must be unlocked to sign the requests.
+func (pg *TicketVoteChoicesPage) checkVSPChoices() {
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrCheckVSPChoices)).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			mismatches, err := pg.dcrImpl.VSPVoteChoiceMismatches(password)
+			if err != nil && mismatches == nil {
+				pm.SetError(err.Error())
+				pm.SetLoading(false)
+				return false
+			}
+
+			pg.mismatches = mismatches
+			pg.vspChecked = true
+			pg.vspCheckErr = ""
+			if err != nil {
+				pg.vspCheckErr = err.Error()
+			}
+			pm.Dismiss()
+			pg.ParentWindow().Reload()
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// syncVSPChoices sends the wallet's vote choices to the VSPs of the tickets
+// whose choices differ.
+func (pg *TicketVoteChoicesPage) syncVSPChoices() {
+	var hashes []string
+	seen := make(map[string]bool)
+	for _, mismatch := range pg.mismatches {
+		if !seen[mismatch.TicketHash] {
+			seen[mismatch.TicketHash] = true
+			hashes = append(hashes, mismatch.TicketHash)
+		}
+	}
+
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrUpdateVSP)).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			err := pg.dcrImpl.SyncVSPVoteChoices(hashes, password)
+			if err != nil {
+				pm.SetError(err.Error())
+				pm.SetLoading(false)
+				return false
+			}
+
+			pg.mismatches = nil
+			pg.vspChecked = false
+			successModal := modal.NewSuccessModal(pg.Load, values.String(values.StrVoteUpdated), modal.DefaultClickFunc())
+			pg.ParentWindow().ShowModal(successModal)
+			pm.Dismiss()
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *TicketVoteChoicesPage) OnNavigatedFrom() {
+	if pg.ctxCancel != nil {
+		pg.ctxCancel()
+	}
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *TicketVoteChoicesPage) Layout(gtx C) D {
+	body := func(gtx C) D {
+		page := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrTicketVoteChoices),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: pg.layoutContent,
+		}
+		return page.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, false, body)
+	}
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *TicketVoteChoicesPage) layoutContent(gtx C) D {
+	if pg.dcrImpl == nil {
+		return D{}
+	}
+
+	var w []layout.Widget
+	switch {
+	case pg.loading:
+		w = append(w, pg.grayLabel(values.String(values.StrLoading)))
+	case pg.errMsg != "":
+		w = append(w, pg.dangerLabel(pg.errMsg))
+	case len(pg.tickets) == 0:
+		w = append(w, pg.grayLabel(values.String(values.StrNoActiveTickets)))
+	default:
+		w = append(w, func(gtx C) D {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, pg.Theme.CheckBox(pg.selectAll, values.String(values.StrSelectAll)).Layout),
+				layout.Rigid(pg.clickableText(pg.editBtn, values.StringF(values.StrEditSelected, len(pg.selectedTickets())), pg.Theme.Color.Primary)),
+				layout.Rigid(pg.clickableText(pg.checkVSPBtn, values.String(values.StrCheckVSPChoices), pg.Theme.Color.Primary)),
+			)
+		})
+
+		if pg.vspChecked {
+			w = append(w, pg.layoutMismatches()...)
+		}
+
+		w = append(w, pg.sectionTitle(values.String(values.StrTickets)))
+		for i := range pg.tickets {
+			w = append(w, pg.layoutTicket(pg.tickets[i], pg.selected[i]))
+		}
+	}
+
+	return pg.card.Layout(gtx, func(gtx C) D {
+		return pg.Theme.List(pg.scrollbarList).Layout(gtx, len(w), func(gtx C, i int) D {
+			return layout.Inset{
+				Left:   values.MarginPadding16,
+				Right:  values.MarginPadding16,
+				Top:    values.MarginPadding8,
+				Bottom: values.MarginPadding8,
+			}.Layout(gtx, w[i])
+		})
+	})
+}
+
+func (pg *TicketVoteChoicesPage) layoutMismatches() []layout.Widget {
+	w := []layout.Widget{pg.sectionTitle(values.String(values.StrVoteChoiceMismatches))}
+	if pg.vspCheckErr != "" {
+		w = append(w, pg.dangerLabel(pg.vspCheckErr))
+	}
+
+	if len(pg.mismatches) == 0 {
+		return append(w, pg.grayLabel(values.String(values.StrNoVoteChoiceMismatches)))
+	}
+
+	for _, mismatch := range pg.mismatches {
+		text := values.StringF(values.StrVoteChoiceMismatch, voteChoiceTargetLabel(mismatch.Type, mismatch.ID),
+			mismatch.LocalChoice, mismatch.VSPChoice)
+		ticket := components.TruncateString(mismatch.TicketHash, 16) + " · " + mismatch.VSP
+		w = append(w, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(pg.Theme.Body1(text).Layout),
+				layout.Rigid(pg.grayLabel(ticket)),
+			)
+		})
+	}
+
+	return append(w, pg.clickableText(pg.syncVSPBtn, values.String(values.StrUpdateVSP), pg.Theme.Color.Primary))
+}
+
+func (pg *TicketVoteChoicesPage) layoutTicket(ticket *dcr.TicketVoteChoices, selected *widget.Bool) layout.Widget {
+	vsp := ticket.VSP
+	if vsp == "" {
+		vsp = values.String(values.StrNoVSP)
+	}
+
+	var choices []string
+	for _, agendaID := range sortedKeys(ticket.AgendaChoices) {
+		choices = append(choices, agendaID+": "+ticket.AgendaChoices[agendaID])
+	}
+	for _, pikey := range sortedKeys(ticket.TreasuryPolicies) {
+		choices = append(choices, voteChoiceTargetLabel(dcr.TreasuryVoteChoice, pikey)+": "+ticket.TreasuryPolicies[pikey])
+	}
+	for _, tspend := range sortedKeys(ticket.TSpendPolicies) {
+		choices = append(choices, voteChoiceTargetLabel(dcr.TSpendVoteChoice, tspend)+": "+ticket.TSpendPolicies[tspend])
+	}
+
+	return func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				cb := pg.Theme.CheckBox(selected, ticket.TicketHash)
+				cb.Font.Weight = font.SemiBold
+				return cb.Layout(gtx)
+			}),
+			layout.Rigid(pg.grayLabel(vsp)),
+			layout.Rigid(pg.Theme.Body2(strings.Join(choices, "\n")).Layout),
+		)
+	}
+}
+
+func (pg *TicketVoteChoicesPage) sectionTitle(title string) layout.Widget {
+	return func(gtx C) D {
+		lbl := pg.Theme.H6(title)
+		lbl.Font.Weight = font.SemiBold
+		return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+	}
+}
+
+func (pg *TicketVoteChoicesPage) grayLabel(text string) layout.Widget {
+	return func(gtx C) D {
+		lbl := pg.Theme.Body2(text)
+		lbl.Color = pg.Theme.Color.GrayText2
+		return lbl.Layout(gtx)
+	}
+}
+
+func (pg *TicketVoteChoicesPage) dangerLabel(text string) layout.Widget {
+	return func(gtx C) D {
+		lbl := pg.Theme.Body2(text)
+		lbl.Color = pg.Theme.Color.Danger
+		return lbl.Layout(gtx)
+	}
+}
+
+func (pg *TicketVoteChoicesPage) clickableText(btn *cryptomaterial.Clickable, text string, col color.NRGBA) layout.Widget {
+	return func(gtx C) D {
+		return btn.Layout(gtx, func(gtx C) D {
+			lbl := pg.Theme.Body2(text)
+			lbl.Color = col
+			return layout.UniformInset(values.MarginPadding8).Layout(gtx, lbl.Layout)
+		})
+	}
+}
+
+// voteChoiceTargetLabel returns the label of the agenda, pi key or tspend a
+// vote choice is cast on.
+func voteChoiceTargetLabel(choiceType dcr.VoteChoiceType, id string) string {
+	switch choiceType {
+	case dcr.TreasuryVoteChoice:
+		return values.StringF(values.StrTreasuryKeyLabel, components.TruncateString(id, 16))
+	case dcr.TSpendVoteChoice:
+		return values.StringF(values.StrTSpendLabel, components.TruncateString(id, 16))
+	}
+	return id
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
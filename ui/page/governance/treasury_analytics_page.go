@@ -0,0 +1,376 @@
+package governance
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/page/settings"
+	"github.com/crypto-power/cryptopower/ui/values"
+	"github.com/decred/dcrd/dcrutil/v4"
+)
+
+const TreasuryAnalyticsPageID = "treasury_analytics"
+
+// treasurySpendItem is a treasury spend and the buttons opening the
+// proposals it is linked to.
+type treasurySpendItem struct {
+	*libwallet.LinkedTreasurySpend
+	proposalBtns []*cryptomaterial.Clickable
+}
+
+// TreasuryAnalyticsPage charts the treasury balance and the monthly spending,
+// and lists the treasury spends with the approved proposals they likely
+// paid.
+type TreasuryAnalyticsPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
+	analytics *libwallet.TreasuryAnalytics
+	spends    []*treasurySpendItem
+	syncing   bool
+	errMsg    string
+
+	scrollbarList         *widget.List
+	card                  cryptomaterial.Card
+	backButton            cryptomaterial.IconButton
+	infoButton            cryptomaterial.IconButton
+	navigateToSettingsBtn cryptomaterial.Button
+}
+
+// NewTreasuryAnalyticsPage returns a page displaying the treasury history.
+func NewTreasuryAnalyticsPage(l *load.Load) *TreasuryAnalyticsPage {
+	pg := &TreasuryAnalyticsPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(TreasuryAnalyticsPageID),
+		scrollbarList: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		card: l.Theme.Card(),
+	}
+
+	pg.backButton, pg.infoButton = components.SubpageHeaderButtons(l)
+	pg.navigateToSettingsBtn = pg.Theme.Button(values.StringF(values.StrEnableAPI, values.String(values.StrGovernance)))
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *TreasuryAnalyticsPage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+	pg.loadAnalytics()
+	if pg.isTreasuryAPIAllowed() {
+		pg.syncHistory()
+	}
+}
+
+func (pg *TreasuryAnalyticsPage) isTreasuryAPIAllowed() bool {
+	return pg.WL.AssetsManager.IsHTTPAPIPrivacyModeOff(libutils.GovernanceHTTPAPI)
+}
+
+// loadAnalytics builds the treasury history from the cached data.
+func (pg *TreasuryAnalyticsPage) loadAnalytics() {
+	analytics, err := pg.WL.AssetsManager.TreasuryAnalytics()
+	if err != nil {
+		log.Errorf("Error loading treasury analytics: %v", err)
+		pg.errMsg = err.Error()
+		return
+	}
+
+	spends := make([]*treasurySpendItem, len(analytics.Spends))
+	for i, spend := range analytics.Spends {
+		spends[i] = &treasurySpendItem{LinkedTreasurySpend: spend}
+		for range spend.Proposals {
+			spends[i].proposalBtns = append(spends[i].proposalBtns, pg.Theme.NewClickable(true))
+		}
+	}
+	pg.analytics = analytics
+	pg.spends = spends
+}
+
+// syncHistory fetches the treasury data not cached yet and reloads the
+// history once done.
+func (pg *TreasuryAnalyticsPage) syncHistory() {
+	pg.syncing = true
+	pg.errMsg = ""
+	go func() {
+		err := pg.WL.AssetsManager.SyncTreasuryHistory(pg.ctx)
+		if err != nil && pg.ctx.Err() == nil {
+			log.Errorf("Error syncing treasury history: %v", err)
+			pg.errMsg = err.Error()
+		}
+		pg.loadAnalytics()
+		pg.syncing = false
+		pg.ParentWindow().Reload()
+	}()
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *TreasuryAnalyticsPage) HandleUserInteractions() {
+	for _, spend := range pg.spends {
+		for i, btn := range spend.proposalBtns {
+			if btn.Clicked() {
+				pg.ParentNavigator().Display(NewProposalDetailsPage(pg.Load, spend.Proposals[i]))
+			}
+		}
+	}
+
+	if pg.navigateToSettingsBtn.Button.Clicked() {
+		pg.ParentWindow().Display(settings.NewSettingsPage(pg.Load))
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *TreasuryAnalyticsPage) OnNavigatedFrom() {
+	pg.ctxCancel()
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *TreasuryAnalyticsPage) Layout(gtx C) D {
+	body := func(gtx C) D {
+		page := components.SubPage{
+			Load:         pg.Load,
+			Title:        values.String(values.StrTreasuryAnalytics),
+			BackButton:   pg.backButton,
+			InfoButton:   pg.infoButton,
+			InfoTemplate: values.String(values.StrLinkedProposalsInfo),
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: pg.layoutAnalytics,
+		}
+		return page.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, false, body)
+	}
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *TreasuryAnalyticsPage) layoutAnalytics(gtx C) D {
+	var w []layout.Widget
+	if !pg.isTreasuryAPIAllowed() {
+		w = append(w, pg.navigateToSettingsBtn.Layout)
+	}
+	if pg.syncing {
+		w = append(w, pg.Theme.Body1(values.String(values.StrSyncingState)).Layout)
+	}
+	if pg.errMsg != "" {
+		lbl := pg.Theme.Body1(pg.errMsg)
+		lbl.Color = pg.Theme.Color.Danger
+		w = append(w, lbl.Layout)
+	}
+
+	if pg.analytics == nil || len(pg.analytics.Balance) == 0 {
+		if !pg.syncing {
+			lbl := pg.Theme.Body1(values.String(values.StrNoTreasuryHistory))
+			lbl.Color = pg.Theme.Color.GrayText2
+			w = append(w, lbl.Layout)
+		}
+	} else {
+		w = append(w, pg.balanceChart, pg.monthlySpendingChart)
+		for i := len(pg.analytics.Months) - 1; i >= 0; i-- {
+			w = append(w, pg.monthWidget(pg.analytics.Months[i]))
+		}
+		w = append(w, pg.sectionTitle(values.String(values.StrTreasurySpends)))
+		for _, spend := range pg.spends {
+			w = append(w, pg.spendWidget(spend))
+		}
+	}
+
+	return pg.card.Layout(gtx, func(gtx C) D {
+		return pg.Theme.List(pg.scrollbarList).Layout(gtx, len(w), func(gtx C, i int) D {
+			return layout.UniformInset(values.MarginPadding16).Layout(gtx, w[i])
+		})
+	})
+}
+
+func (pg *TreasuryAnalyticsPage) sectionTitle(title string) layout.Widget {
+	lbl := pg.Theme.Body1(title)
+	lbl.Font.Weight = font.SemiBold
+	return lbl.Layout
+}
+
+func (pg *TreasuryAnalyticsPage) balanceChart(gtx C) D {
+	balances := pg.analytics.Balance
+	points := make([]float64, len(balances))
+	for i := range balances {
+		points[i] = balances[i].Balance
+	}
+
+	current := balances[len(balances)-1]
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return components.EndToEndRow(gtx,
+				pg.sectionTitle(values.String(values.StrTreasuryBalance)),
+				pg.Theme.Body1(formatDCR(current.Balance)).Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return pg.layoutChart(gtx, points, pg.Theme.Color.Primary)
+			})
+		}),
+		layout.Rigid(pg.chartRange(balances[0].Time, current.Time)),
+	)
+}
+
+func (pg *TreasuryAnalyticsPage) monthlySpendingChart(gtx C) D {
+	months := pg.analytics.Months
+	points := make([]float64, len(months))
+	for i := range months {
+		points[i] = months[i].Spent
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(pg.sectionTitle(values.String(values.StrMonthlySpending))),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return pg.layoutChart(gtx, points, pg.Theme.Color.Danger)
+			})
+		}),
+		layout.Rigid(pg.chartRange(months[0].Time, months[len(months)-1].Time)),
+	)
+}
+
+// chartRange labels the first and last dates of a chart.
+func (pg *TreasuryAnalyticsPage) chartRange(from, to int64) layout.Widget {
+	start := pg.Theme.Caption(time.Unix(from, 0).UTC().Format("Jan 2006"))
+	start.Color = pg.Theme.Color.GrayText2
+	end := pg.Theme.Caption(time.Unix(to, 0).UTC().Format("Jan 2006"))
+	end.Color = pg.Theme.Color.GrayText2
+	return func(gtx C) D {
+		return components.EndToEndRow(gtx, start.Layout, end.Layout)
+	}
+}
+
+// layoutChart draws the points as a column chart filling the available width.
+// If there are more points than pixels, the points are sampled.
+func (pg *TreasuryAnalyticsPage) layoutChart(gtx C, points []float64, col color.NRGBA) D {
+	width := gtx.Constraints.Max.X
+	height := gtx.Dp(values.MarginPadding120)
+	size := image.Point{X: width, Y: height}
+	if len(points) == 0 || width == 0 {
+		return D{Size: size}
+	}
+
+	var max float64
+	for _, p := range points {
+		if p > max {
+			max = p
+		}
+	}
+	if max <= 0 {
+		return D{Size: size}
+	}
+
+	columns := len(points)
+	if columns > width {
+		columns = width
+	}
+	gap := 0
+	if width/columns > 4 {
+		gap = 1
+	}
+	for c := 0; c < columns; c++ {
+		p := points[c*len(points)/columns]
+		if p <= 0 {
+			continue
+		}
+		x0, x1 := c*width/columns, (c+1)*width/columns-gap
+		y0 := height - int(p/max*float64(height))
+		rect := image.Rect(x0, y0, x1, height)
+		paint.FillShape(gtx.Ops, col, clip.Rect(rect).Op())
+	}
+	return D{Size: size}
+}
+
+func (pg *TreasuryAnalyticsPage) monthWidget(month *libwallet.TreasuryMonth) layout.Widget {
+	date := pg.Theme.Body2(time.Unix(month.Time, 0).UTC().Format("January 2006"))
+	amounts := pg.Theme.Body2(values.StringF(values.StrAddedSpent, formatDCR(month.Added), formatDCR(month.Spent)))
+	amounts.Color = pg.Theme.Color.GrayText2
+	return func(gtx C) D {
+		return components.EndToEndRow(gtx, date.Layout, amounts.Layout)
+	}
+}
+
+func (pg *TreasuryAnalyticsPage) spendWidget(spend *treasurySpendItem) layout.Widget {
+	amount := pg.Theme.Body1(formatDCR(spend.Amount))
+	amount.Font.Weight = font.SemiBold
+	date := pg.Theme.Body2(time.Unix(spend.Timestamp, 0).UTC().Format("Jan 2, 2006"))
+	date.Color = pg.Theme.Color.GrayText2
+	txHash := pg.Theme.Body2(spend.TxHash)
+	txHash.Color = pg.Theme.Color.GrayText2
+
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			return components.EndToEndRow(gtx, amount.Layout, date.Layout)
+		}),
+		layout.Rigid(txHash.Layout),
+	}
+
+	if len(spend.Proposals) == 0 {
+		lbl := pg.Theme.Body2(values.String(values.StrNoLinkedProposals))
+		lbl.Color = pg.Theme.Color.GrayText3
+		children = append(children, layout.Rigid(lbl.Layout))
+	} else {
+		lbl := pg.Theme.Body2(values.String(values.StrLinkedProposals))
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+		}))
+	}
+
+	for i, proposal := range spend.Proposals {
+		btn, name := spend.proposalBtns[i], pg.Theme.Body2(proposal.Name)
+		name.Color = pg.Theme.Color.Primary
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return btn.Layout(gtx, func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding4, Bottom: values.MarginPadding4}.Layout(gtx, name.Layout)
+			})
+		}))
+	}
+
+	return func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	}
+}
+
+func formatDCR(amount float64) string {
+	dcrAmount, _ := dcrutil.NewAmount(amount)
+	return dcrAmount.String()
+}
@@ -3,6 +3,7 @@ package governance
 import (
 	"context"
 	"encoding/hex"
+	"strings"
 	"time"
 
 	"gioui.org/layout"
@@ -37,10 +38,13 @@ type TreasuryPage struct {
 	assetsManager *libwallet.AssetsManager
 	wallets       []sharedW.Asset
 	treasuryItems []*components.TreasuryItem
+	tspendItems   []*components.TSpendItem
 
 	listContainer      *widget.List
 	viewGovernanceKeys *cryptomaterial.Clickable
 	copyRedirectURL    *cryptomaterial.Clickable
+	addTSpend          *cryptomaterial.Clickable
+	analyticsBtn       *cryptomaterial.Clickable
 	redirectIcon       *cryptomaterial.Image
 
 	searchEditor cryptomaterial.Editor
@@ -62,6 +66,8 @@ func NewTreasuryPage(l *load.Load) *TreasuryPage {
 		redirectIcon:       l.Theme.Icons.RedirectIcon,
 		viewGovernanceKeys: l.Theme.NewClickable(true),
 		copyRedirectURL:    l.Theme.NewClickable(false),
+		addTSpend:          l.Theme.NewClickable(true),
+		analyticsBtn:       l.Theme.NewClickable(true),
 	}
 
 	pg.searchEditor = l.Theme.IconEditor(new(widget.Editor), values.String(values.StrSearch), l.Theme.Icons.SearchIcon, true)
@@ -102,6 +108,23 @@ func (pg *TreasuryPage) HandleUserInteractions() {
 		}
 	}
 
+	for _, item := range pg.tspendItems {
+		if item.SetChoiceButton.Clicked() {
+			pg.updateTSpendPolicy(item, "")
+		}
+		if item.SetTicketChoiceButton.Clicked() {
+			pg.selectTSpendTicket(item)
+		}
+	}
+
+	if pg.addTSpend.Clicked() {
+		pg.showAddTSpendModal()
+	}
+
+	if pg.analyticsBtn.Clicked() {
+		pg.ParentNavigator().Display(NewTreasuryAnalyticsPage(pg.Load))
+	}
+
 	if pg.navigateToSettingsBtn.Button.Clicked() {
 		pg.ParentWindow().Display(settings.NewSettingsPage(pg.Load))
 	}
@@ -153,6 +176,7 @@ func (pg *TreasuryPage) FetchPolicies() {
 	key := hex.EncodeToString(pg.WL.AssetsManager.PiKeys()[0])
 	go func() {
 		pg.treasuryItems = components.LoadPolicies(pg.Load, selectedWallet, key)
+		pg.tspendItems = components.LoadTSpends(pg.Load, selectedWallet)
 		pg.isPolicyFetchInProgress = true
 		pg.ParentWindow().Reload()
 	}()
@@ -188,6 +212,7 @@ func (pg *TreasuryPage) layout(gtx C) D {
 						layout.Rigid(func(gtx C) D {
 							return layout.Inset{Top: values.MarginPadding3}.Layout(gtx, pg.infoButton.Layout)
 						}),
+						layout.Rigid(pg.layoutAnalyticsButton),
 					)
 				}),
 				layout.Flexed(1, func(gtx C) D {
@@ -209,6 +234,14 @@ func (pg *TreasuryPage) layout(gtx C) D {
 	)
 }
 
+func (pg *TreasuryPage) layoutAnalyticsButton(gtx C) D {
+	return pg.analyticsBtn.Layout(gtx, func(gtx C) D {
+		lbl := pg.Theme.Body2(values.String(values.StrTreasuryAnalytics))
+		lbl.Color = pg.Theme.Color.Primary
+		return layout.UniformInset(values.MarginPadding8).Layout(gtx, lbl.Layout)
+	})
+}
+
 func (pg *TreasuryPage) layoutVerifyGovernanceKeys(gtx C) D {
 	return layout.Inset{Top: values.MarginPadding5}.Layout(gtx, func(gtx C) D {
 		return pg.viewGovernanceKeys.Layout(gtx, func(gtx C) D {
@@ -229,7 +262,7 @@ func (pg *TreasuryPage) layoutVerifyGovernanceKeys(gtx C) D {
 }
 
 func (pg *TreasuryPage) layoutContent(gtx C) D {
-	if len(pg.treasuryItems) == 0 {
+	if len(pg.treasuryItems) == 0 && len(pg.tspendItems) == 0 {
 		return components.LayoutNoPoliciesFound(gtx, pg.Load, pg.isPolicyFetchInProgress)
 	}
 
@@ -238,7 +271,10 @@ func (pg *TreasuryPage) layoutContent(gtx C) D {
 			list := layout.List{Axis: layout.Vertical}
 			return pg.Theme.List(pg.listContainer).Layout(gtx, 1, func(gtx C, i int) D {
 				return layout.Inset{Right: values.MarginPadding2}.Layout(gtx, func(gtx C) D {
-					return list.Layout(gtx, len(pg.treasuryItems), func(gtx C, i int) D {
+					return list.Layout(gtx, len(pg.treasuryItems)+1, func(gtx C, i int) D {
+						if i == len(pg.treasuryItems) {
+							return pg.layoutTSpends(gtx)
+						}
 						return cryptomaterial.LinearLayout{
 							Orientation: layout.Vertical,
 							Width:       cryptomaterial.MatchParent,
@@ -259,6 +295,125 @@ func (pg *TreasuryPage) layoutContent(gtx C) D {
 	)
 }
 
+// layoutTSpends draws the treasury spends being voted on.
+func (pg *TreasuryPage) layoutTSpends(gtx C) D {
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding15, Bottom: values.MarginPadding5}.Layout(gtx, func(gtx C) D {
+				title := pg.Theme.Label(values.TextSize20, values.String(values.StrPendingTSpends))
+				addBtn := func(gtx C) D {
+					return pg.addTSpend.Layout(gtx, pg.Theme.Label(values.TextSize16, values.String(values.StrAddTSpend)).Layout)
+				}
+				return components.EndToEndRow(gtx, title.Layout, addBtn)
+			})
+		}),
+	}
+
+	if len(pg.tspendItems) == 0 {
+		children = append(children, layout.Rigid(func(gtx C) D {
+			lbl := pg.Theme.Body1(values.String(values.StrNoPendingTSpends))
+			lbl.Color = pg.Theme.Color.GrayText3
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, lbl.Layout)
+		}))
+	}
+
+	for _, item := range pg.tspendItems {
+		item := item
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return cryptomaterial.LinearLayout{
+				Orientation: layout.Vertical,
+				Width:       cryptomaterial.MatchParent,
+				Height:      cryptomaterial.WrapContent,
+				Background:  pg.Theme.Color.Surface,
+				Direction:   layout.W,
+				Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(14)},
+				Padding:     layout.UniformInset(values.MarginPadding15),
+				Margin:      layout.Inset{Bottom: values.MarginPadding4, Top: values.MarginPadding4},
+			}.
+				Layout2(gtx, func(gtx C) D {
+					return components.TSpendItemWidget(gtx, pg.Load, item)
+				})
+		}))
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+// showAddTSpendModal fetches a treasury spend by its hash so that it can be
+// voted on before the wallet receives it from the network.
+func (pg *TreasuryPage) showAddTSpendModal() {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrTransactionID)).
+		PositiveButtonStyle(pg.Load.Theme.Color.Primary, pg.Load.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(txHash string, tim *modal.TextInputModal) bool {
+			// The tspend is fetched from dcrdata in the background.
+			go func() {
+				txHex, err := pg.WL.AssetsManager.ExternalService.GetRawTransaction(strings.TrimSpace(txHash))
+				if err == nil {
+					_, err = pg.WL.SelectedWallet.Wallet.(*dcr.Asset).AddTSpend(txHex)
+				}
+				if err != nil {
+					tim.SetError(err.Error())
+					tim.SetLoading(false)
+					return
+				}
+				tim.Dismiss()
+				pg.FetchPolicies()
+			}()
+			return false
+		})
+	textModal.Title(values.String(values.StrAddTSpend)).
+		SetPositiveButtonText(values.String(values.StrAdd)).
+		SetNegativeButtonText(values.String(values.StrCancel))
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+// selectTSpendTicket sets the selected vote choice for the treasury spend
+// for a single ticket.
+func (pg *TreasuryPage) selectTSpendTicket(item *components.TSpendItem) {
+	tickets, err := pg.WL.SelectedWallet.Wallet.(*dcr.Asset).UnspentUnexpiredTickets()
+	if err != nil {
+		pg.ParentWindow().ShowModal(modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc()))
+		return
+	}
+
+	liveTickets := make([]*sharedW.Transaction, len(tickets))
+	for i := range tickets {
+		liveTickets[i] = &tickets[i]
+	}
+	ticketModal := newTicketSelectorModal(pg.Load, liveTickets).
+		title(values.String(values.StrSelectTicket)).
+		ticketSelected(func(ticket *sharedW.Transaction) {
+			pg.updateTSpendPolicy(item, ticket.Hash)
+		})
+	pg.ParentWindow().ShowModal(ticketModal)
+}
+
+// updateTSpendPolicy sets the selected vote choice for the treasury spend for
+// the wallet, or for the ticket if a ticket hash is provided.
+func (pg *TreasuryPage) updateTSpendPolicy(item *components.TSpendItem, ticketHash string) {
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrConfirmVote)).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			asset := pg.WL.SelectedWallet.Wallet.(*dcr.Asset)
+			votingPreference := item.OptionsRadioGroup.Value
+			err := asset.SetTSpendPolicy(item.TSpend.Hash, votingPreference, ticketHash, password)
+			if err != nil {
+				pm.SetError(err.Error())
+				pm.SetLoading(false)
+				return false
+			}
+			go pg.FetchPolicies() // re-fetch policies when voting is done.
+			infoModal := modal.NewSuccessModal(pg.Load, values.String(values.StrPolicySetSuccessful), modal.DefaultClickFunc())
+			pg.ParentWindow().ShowModal(infoModal)
+
+			pm.Dismiss()
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
 func (pg *TreasuryPage) updatePolicyPreference(treasuryItem *components.TreasuryItem) {
 	passwordModal := modal.NewCreatePasswordModal(pg.Load).
 		EnableName(false).
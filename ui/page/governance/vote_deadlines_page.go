@@ -0,0 +1,178 @@
+package governance
+
+import (
+	"context"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const VoteDeadlinesPageID = "vote_deadlines"
+
+// VoteDeadlinesPage shows how many of the wallets' tickets have voted on the
+// proposal votes and consensus agendas in progress, and when each vote ends.
+type VoteDeadlinesPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
+	deadlines []*libwallet.VoteDeadline
+	loading   bool
+	errMsg    string
+
+	scrollbarList *widget.List
+	card          cryptomaterial.Card
+	backButton    cryptomaterial.IconButton
+}
+
+func NewVoteDeadlinesPage(l *load.Load) *VoteDeadlinesPage {
+	pg := &VoteDeadlinesPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(VoteDeadlinesPageID),
+		scrollbarList: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		card: l.Theme.Card(),
+	}
+
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *VoteDeadlinesPage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+	pg.loadDeadlines()
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *VoteDeadlinesPage) HandleUserInteractions() {}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *VoteDeadlinesPage) OnNavigatedFrom() {
+	pg.ctxCancel()
+}
+
+func (pg *VoteDeadlinesPage) loadDeadlines() {
+	pg.loading = true
+	pg.errMsg = ""
+	go func() {
+		deadlines, err := pg.WL.AssetsManager.VoteDeadlines(pg.ctx)
+		if err != nil {
+			log.Errorf("Error loading vote deadlines: %v", err)
+			pg.errMsg = err.Error()
+		} else {
+			pg.deadlines = deadlines
+		}
+		pg.loading = false
+		pg.ParentWindow().Reload()
+	}()
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *VoteDeadlinesPage) Layout(gtx C) D {
+	body := func(gtx C) D {
+		page := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrVoteDeadlines),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: pg.layoutDeadlines,
+		}
+		return page.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, false, body)
+	}
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *VoteDeadlinesPage) layoutDeadlines(gtx C) D {
+	var w []layout.Widget
+	switch {
+	case pg.loading:
+		w = append(w, pg.Theme.Body1(values.String(values.StrLoading)).Layout)
+	case pg.errMsg != "":
+		lbl := pg.Theme.Body1(pg.errMsg)
+		lbl.Color = pg.Theme.Color.Danger
+		w = append(w, lbl.Layout)
+	case len(pg.deadlines) == 0:
+		lbl := pg.Theme.Body1(values.String(values.StrNoVoteDeadlines))
+		lbl.Color = pg.Theme.Color.GrayText2
+		w = append(w, lbl.Layout)
+	default:
+		for _, deadline := range pg.deadlines {
+			w = append(w, pg.deadlineWidget(deadline))
+		}
+	}
+
+	return pg.card.Layout(gtx, func(gtx C) D {
+		return pg.Theme.List(pg.scrollbarList).Layout(gtx, len(w), func(gtx C, i int) D {
+			return layout.UniformInset(values.MarginPadding16).Layout(gtx, w[i])
+		})
+	})
+}
+
+func (pg *VoteDeadlinesPage) deadlineWidget(deadline *libwallet.VoteDeadline) layout.Widget {
+	kind := values.String(values.StrProposal)
+	if deadline.IsAgenda {
+		kind = values.String(values.StrAgendas)
+	}
+
+	name := pg.Theme.Body1(deadline.Name)
+	name.Font.Weight = font.SemiBold
+	info := pg.Theme.Body2(kind + " · " + deadline.WalletName)
+	info.Color = pg.Theme.Color.GrayText2
+
+	tickets := pg.Theme.Body2(values.StringF(values.StrUnvotedTickets, deadline.UnvotedTickets, deadline.EligibleTickets))
+	if deadline.UnvotedTickets > 0 {
+		tickets.Color = pg.Theme.Color.Danger
+	}
+
+	timeLeft := components.TimeFormat(int(deadline.TimeRemaining().Seconds()), true)
+	endsIn := pg.Theme.Body2(values.StringF(values.StrVoteEndsIn, timeLeft, deadline.BlocksRemaining))
+
+	return func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(name.Layout),
+			layout.Rigid(info.Layout),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, tickets.Layout)
+			}),
+			layout.Rigid(endsIn.Layout),
+		)
+	}
+}
@@ -0,0 +1,385 @@
+package governance
+
+import (
+	"context"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const VoteReceiptsPageID = "vote_receipts"
+
+// receiptsGroup holds the vote receipts of a proposal and the result of their
+// last verification against politeia's vote results.
+type receiptsGroup struct {
+	token     string
+	name      string
+	receipts  []*libwallet.VoteReceiptVerification
+	verified  bool
+	verifying bool
+	errMsg    string
+	verifyBtn *cryptomaterial.Clickable
+}
+
+// VoteReceiptsPage lists the receipts of the proposal votes cast by the
+// wallets and verifies them against the server public key and the published
+// vote results.
+type VoteReceiptsPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
+	// token limits the receipts displayed to a single proposal if set.
+	token string
+
+	groups  []*receiptsGroup
+	loading bool
+	errMsg  string
+
+	// trustKeyBtn re-pins the politeia server key once the user confirmed
+	// it, it is shown when the server key was rotated.
+	trustKeyBtn *cryptomaterial.Clickable
+
+	scrollbarList *widget.List
+	card          cryptomaterial.Card
+	backButton    cryptomaterial.IconButton
+}
+
+// NewVoteReceiptsPage returns a page listing the vote receipts of the
+// proposal with the provided token, or of all proposals if token is empty.
+func NewVoteReceiptsPage(l *load.Load, token string) *VoteReceiptsPage {
+	pg := &VoteReceiptsPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(VoteReceiptsPageID),
+		token:            token,
+		scrollbarList: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		card:        l.Theme.Card(),
+		trustKeyBtn: l.Theme.NewClickable(true),
+	}
+
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *VoteReceiptsPage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+	pg.loadReceipts()
+}
+
+// loadReceipts reads the saved receipts and checks their signatures, which
+// does not require access to the politeia server.
+func (pg *VoteReceiptsPage) loadReceipts() {
+	pg.loading = true
+	pg.errMsg = ""
+	go func() {
+		defer func() {
+			pg.loading = false
+			pg.ParentWindow().Reload()
+		}()
+
+		receipts, err := pg.WL.AssetsManager.Politeia.GetVoteReceipts(pg.token)
+		if err != nil {
+			log.Errorf("Error loading vote receipts: %v", err)
+			pg.errMsg = err.Error()
+			return
+		}
+
+		var groups []*receiptsGroup
+		groupsByToken := make(map[string]*receiptsGroup)
+		for i := range receipts {
+			group, ok := groupsByToken[receipts[i].Token]
+			if !ok {
+				group = &receiptsGroup{
+					token:     receipts[i].Token,
+					name:      receipts[i].Token,
+					verifyBtn: pg.Theme.NewClickable(true),
+				}
+				if proposal, err := pg.WL.AssetsManager.Politeia.GetProposalRaw(group.token); err == nil {
+					group.name = proposal.Name
+				}
+				groupsByToken[group.token] = group
+				groups = append(groups, group)
+			}
+
+			group.receipts = append(group.receipts, pg.WL.AssetsManager.Politeia.VerifyVoteReceipt(&receipts[i]))
+		}
+		pg.groups = groups
+	}()
+}
+
+func (pg *VoteReceiptsPage) verifyReceipts(group *receiptsGroup) {
+	if !pg.WL.AssetsManager.IsHTTPAPIPrivacyModeOff(libutils.GovernanceHTTPAPI) {
+		pg.Toast.NotifyError(values.StringF(values.StrEnableAPI, values.String(values.StrGovernance)))
+		return
+	}
+
+	group.verifying = true
+	group.errMsg = ""
+	go func() {
+		verifications, err := pg.WL.AssetsManager.Politeia.VerifyVoteReceipts(group.token)
+		if err != nil {
+			log.Errorf("Error verifying vote receipts: %v", err)
+			group.errMsg = err.Error()
+		} else {
+			group.receipts = verifications
+			group.verified = true
+		}
+		group.verifying = false
+		pg.ParentWindow().Reload()
+	}()
+}
+
+// keyRotated returns true if the last verification found that the politeia
+// server no longer uses the pinned key.
+func (pg *VoteReceiptsPage) keyRotated() bool {
+	for _, group := range pg.groups {
+		for _, receipt := range group.receipts {
+			if receipt.KeyWarning == libwallet.ErrServerKeyRotated {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// confirmServerKey asks the user to trust the key the politeia server uses
+// now and re-pins it if confirmed.
+func (pg *VoteReceiptsPage) confirmServerKey() {
+	go func() {
+		pubKey, err := pg.WL.AssetsManager.Politeia.ServerPubKey()
+		if err != nil {
+			pg.ParentWindow().ShowModal(modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc()))
+			return
+		}
+		pinned := pg.WL.AssetsManager.Politeia.PinnedServerPubKey()
+
+		confirmModal := modal.NewCustomModal(pg.Load).
+			Title(values.String(values.StrTrustServerKey)).
+			Body(values.StringF(values.StrTrustServerKeyInfo, pubKey, pinned)).
+			SetCancelable(true).
+			SetNegativeButtonText(values.String(values.StrCancel)).
+			SetPositiveButtonText(values.String(values.StrConfirm)).
+			SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+				if err := pg.WL.AssetsManager.Politeia.RepinServerPubKey(pubKey); err != nil {
+					pg.Toast.NotifyError(err.Error())
+					return false
+				}
+				pg.loadReceipts()
+				return true
+			})
+		pg.ParentWindow().ShowModal(confirmModal)
+	}()
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *VoteReceiptsPage) HandleUserInteractions() {
+	if pg.trustKeyBtn.Clicked() {
+		pg.confirmServerKey()
+	}
+
+	for _, group := range pg.groups {
+		if group.verifyBtn.Clicked() && !group.verifying {
+			pg.verifyReceipts(group)
+		}
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *VoteReceiptsPage) OnNavigatedFrom() {
+	pg.ctxCancel()
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *VoteReceiptsPage) Layout(gtx C) D {
+	body := func(gtx C) D {
+		page := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrVoteReceipts),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: pg.layoutReceipts,
+		}
+		return page.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, false, body)
+	}
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *VoteReceiptsPage) layoutReceipts(gtx C) D {
+	var w []layout.Widget
+	switch {
+	case pg.loading:
+		w = append(w, pg.Theme.Body1(values.String(values.StrLoading)).Layout)
+	case pg.errMsg != "":
+		lbl := pg.Theme.Body1(pg.errMsg)
+		lbl.Color = pg.Theme.Color.Danger
+		w = append(w, lbl.Layout)
+	case len(pg.groups) == 0:
+		lbl := pg.Theme.Body1(values.String(values.StrNoVoteReceipts))
+		lbl.Color = pg.Theme.Color.GrayText2
+		w = append(w, lbl.Layout)
+	default:
+		if pg.keyRotated() {
+			w = append(w, pg.serverKeyRotated)
+		}
+		for _, group := range pg.groups {
+			w = append(w, pg.groupHeader(group))
+			for _, receipt := range group.receipts {
+				w = append(w, pg.receiptWidget(receipt, group.verified))
+			}
+		}
+	}
+
+	return pg.card.Layout(gtx, func(gtx C) D {
+		return pg.Theme.List(pg.scrollbarList).Layout(gtx, len(w), func(gtx C, i int) D {
+			return layout.UniformInset(values.MarginPadding16).Layout(gtx, w[i])
+		})
+	})
+}
+
+func (pg *VoteReceiptsPage) serverKeyRotated(gtx C) D {
+	warning := pg.Theme.Body2(values.String(values.StrServerKeyRotated))
+	warning.Color = pg.Theme.Color.Danger
+
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+		layout.Flexed(1, warning.Layout),
+		layout.Rigid(func(gtx C) D {
+			return pg.trustKeyBtn.Layout(gtx, func(gtx C) D {
+				lbl := pg.Theme.Body2(values.String(values.StrTrustServerKey))
+				lbl.Color = pg.Theme.Color.Primary
+				return layout.UniformInset(values.MarginPadding8).Layout(gtx, lbl.Layout)
+			})
+		}),
+	)
+}
+
+func (pg *VoteReceiptsPage) groupHeader(group *receiptsGroup) layout.Widget {
+	name := pg.Theme.Body1(group.name)
+	name.Font.Weight = font.SemiBold
+
+	return func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, name.Layout),
+					layout.Rigid(func(gtx C) D {
+						text := values.String(values.StrVerify)
+						if group.verifying {
+							text = values.String(values.StrVerifying)
+						}
+						return group.verifyBtn.Layout(gtx, func(gtx C) D {
+							lbl := pg.Theme.Body2(text)
+							lbl.Color = pg.Theme.Color.Primary
+							return layout.UniformInset(values.MarginPadding8).Layout(gtx, lbl.Layout)
+						})
+					}),
+				)
+			}),
+			layout.Rigid(func(gtx C) D {
+				if group.errMsg == "" {
+					return D{}
+				}
+				lbl := pg.Theme.Body2(group.errMsg)
+				lbl.Color = pg.Theme.Color.Danger
+				return lbl.Layout(gtx)
+			}),
+		)
+	}
+}
+
+func (pg *VoteReceiptsPage) receiptWidget(receipt *libwallet.VoteReceiptVerification, verified bool) layout.Widget {
+	vote := values.String(values.StrNo)
+	if receipt.VoteBit == "2" {
+		vote = values.String(values.StrYes)
+	}
+	date := time.Unix(receipt.Timestamp, 0).Format("Jan 2, 2006 15:04")
+
+	ticket := pg.Theme.Body2(values.String(values.StrTicket) + " " + receipt.Ticket)
+	info := pg.Theme.Body2(vote + " · " + date)
+	info.Color = pg.Theme.Color.GrayText2
+
+	status := pg.Theme.Body2(values.String(values.StrReceiptSignatureValid))
+	status.Color = pg.Theme.Color.Success
+	switch {
+	case receipt.ReceiptError != "":
+		status.Text = values.String(values.StrReceiptSignatureInvalid)
+		status.Color = pg.Theme.Color.Danger
+	case receipt.ResultError == libwallet.ErrVoteNotCounted:
+		status.Text = values.String(values.StrVoteNotCounted)
+		status.Color = pg.Theme.Color.Danger
+	case receipt.ResultError == libwallet.ErrVoteMismatch:
+		status.Text = values.String(values.StrVoteCountedDifferently)
+		status.Color = pg.Theme.Color.Danger
+	case verified:
+		status.Text = values.String(values.StrVoteCounted)
+	}
+
+	// The key warnings do not invalidate the receipt, they are shown below
+	// its status.
+	keyWarning := pg.Theme.Body2("")
+	keyWarning.Color = pg.Theme.Color.GrayText2
+	switch receipt.KeyWarning {
+	case libwallet.ErrServerKeyMismatch:
+		keyWarning.Text = values.String(values.StrServerKeyMismatch)
+		keyWarning.Color = pg.Theme.Color.Danger
+	case libwallet.ErrServerKeyUnknown:
+		keyWarning.Text = values.String(values.StrServerKeyUnknown)
+	case libwallet.ErrServerKeyRotated:
+		keyWarning.Text = values.String(values.StrServerKeyRotated)
+	}
+
+	return func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(ticket.Layout),
+			layout.Rigid(info.Layout),
+			layout.Rigid(status.Layout),
+			layout.Rigid(func(gtx C) D {
+				if keyWarning.Text == "" {
+					return D{}
+				}
+				return keyWarning.Layout(gtx)
+			}),
+		)
+	}
+}
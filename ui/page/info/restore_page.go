@@ -1,8 +1,11 @@
 package info
 
 import (
+	"errors"
 	"image"
+	"strconv"
 	"strings"
+	"time"
 
 	"gioui.org/font"
 	"gioui.org/io/key"
@@ -44,6 +47,7 @@ type Restore struct {
 	toggleSeedInput   *cryptomaterial.Switch
 	seedInputEditor   cryptomaterial.Editor
 	confirmSeedButton cryptomaterial.Button
+	birthdayEditor    cryptomaterial.Editor
 	restoreInProgress bool
 }
 
@@ -71,9 +75,63 @@ func NewRestorePage(l *load.Load, walletName string, walletType libutils.AssetTy
 	pg.confirmSeedButton.Font.Weight = font.Medium
 	pg.confirmSeedButton.SetEnabled(false)
 
+	pg.birthdayEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrWalletBirthdayHint))
+	pg.birthdayEditor.Editor.SingleLine = true
+	pg.seedRestorePage.walletBirthday = pg.walletBirthday
+
 	return pg
 }
 
+// supportsBirthday returns true if the first sync of the restored wallet can
+// skip the blocks mined before the wallet birthday.
+func (pg *Restore) supportsBirthday() bool {
+	return pg.walletType == libutils.BTCWalletAsset || pg.walletType == libutils.LTCWalletAsset
+}
+
+// walletBirthday parses the wallet birthday entered as a date or a block
+// height. It returns nil if no birthday was entered.
+func (pg *Restore) walletBirthday() (*sharedW.WalletBirthday, error) {
+	pg.birthdayEditor.SetError("")
+	text := strings.TrimSpace(pg.birthdayEditor.Editor.Text())
+	if text == "" || !pg.supportsBirthday() {
+		return nil, nil
+	}
+
+	birthday := new(sharedW.WalletBirthday)
+	if height, err := strconv.ParseInt(text, 10, 32); err == nil {
+		birthday.Height = int32(height)
+	} else if date, err := time.Parse("2006-01-02", text); err == nil {
+		birthday.Time = date
+	} else {
+		birthday = nil
+	}
+
+	if birthday == nil || birthday.Validate() != nil {
+		errMsg := values.String(values.StrInvalidBirthday)
+		pg.birthdayEditor.SetError(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	return birthday, nil
+}
+
+func (pg *Restore) birthdayLayout(gtx C) D {
+	if !pg.supportsBirthday() {
+		return D{}
+	}
+
+	return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(pg.Theme.Label(values.TextSize16, values.String(values.StrWalletBirthday)).Layout),
+			layout.Rigid(func(gtx C) D {
+				lbl := pg.Theme.Label(values.TextSize12, values.String(values.StrWalletBirthdayInfo))
+				lbl.Color = pg.Theme.Color.GrayText2
+				return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+			}),
+			layout.Rigid(pg.birthdayEditor.Layout),
+		)
+	})
+}
+
 // OnNavigatedTo is called when the page is about to be displayed and
 // may be used to initialize page features that are only relevant when
 // the page is displayed.
@@ -135,6 +193,7 @@ func (pg *Restore) restoreLayout(gtx layout.Context) layout.Dimensions {
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 			layout.Rigid(pg.tabLayout),
 			layout.Rigid(pg.Theme.Separator().Layout),
+			layout.Rigid(pg.birthdayLayout),
 			layout.Rigid(func(gtx C) D {
 				if pg.tabIndex == 1 {
 					return D{}
@@ -218,6 +277,7 @@ func (pg *Restore) restoreMobileLayout(gtx layout.Context) layout.Dimensions {
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 			layout.Rigid(pg.tabLayout),
 			layout.Rigid(pg.Theme.Separator().Layout),
+			layout.Rigid(pg.birthdayLayout),
 			layout.Flexed(1, func(gtx C) D {
 				return layout.Inset{Top: values.MarginPadding5}.Layout(gtx, pg.indexLayout)
 			}),
@@ -390,13 +450,19 @@ func (pg *Restore) restoreFromSeedEditor() {
 		return
 	}
 
+	birthday, err := pg.walletBirthday()
+	if err != nil {
+		pg.restoreInProgress = false
+		return
+	}
+
 	walletPasswordModal := modal.NewCreatePasswordModal(pg.Load).
 		Title(values.String(values.StrEnterWalDetails)).
 		EnableName(false).
 		ShowWalletInfoTip(true).
 		SetParent(pg).
 		SetPositiveButtonCallback(func(walletName, password string, m *modal.CreatePasswordModal) bool {
-			_, err := pg.WL.AssetsManager.RestoreWallet(pg.walletType, pg.walletName, seedOrHex, password, sharedW.PassphraseTypePass)
+			_, err := pg.WL.AssetsManager.RestoreWallet(pg.walletType, pg.walletName, seedOrHex, password, sharedW.PassphraseTypePass, birthday)
 			if err != nil {
 				errString := err.Error()
 				if err.Error() == libutils.ErrExist {
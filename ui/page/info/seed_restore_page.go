@@ -75,6 +75,9 @@ type SeedRestore struct {
 	selectedSeedEditor       int // stores the current focus index of seed editors
 
 	walletType libutils.AssetType
+
+	// walletBirthday returns the birthday entered for the restored wallet.
+	walletBirthday func() (*sharedW.WalletBirthday, error)
 }
 
 func NewSeedRestorePage(l *load.Load, walletName string, walletType libutils.AssetType, onRestoreComplete func()) *SeedRestore {
@@ -559,6 +562,14 @@ func (pg *SeedRestore) HandleUserInteractions() {
 			return
 		}
 
+		var birthday *sharedW.WalletBirthday
+		if pg.walletBirthday != nil {
+			var err error
+			if birthday, err = pg.walletBirthday(); err != nil {
+				return
+			}
+		}
+
 		pg.isRestoring = true
 		walletPasswordModal := modal.NewCreatePasswordModal(pg.Load).
 			Title(values.String(values.StrEnterWalDetails)).
@@ -566,7 +577,7 @@ func (pg *SeedRestore) HandleUserInteractions() {
 			ShowWalletInfoTip(true).
 			SetParent(pg).
 			SetPositiveButtonCallback(func(walletName, password string, m *modal.CreatePasswordModal) bool {
-				_, err := pg.WL.AssetsManager.RestoreWallet(pg.walletType, pg.walletName, pg.seedPhrase, password, sharedW.PassphraseTypePass)
+				_, err := pg.WL.AssetsManager.RestoreWallet(pg.walletType, pg.walletName, pg.seedPhrase, password, sharedW.PassphraseTypePass, birthday)
 				if err != nil {
 					errString := err.Error()
 					if err.Error() == libutils.ErrExist {
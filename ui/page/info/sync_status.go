@@ -316,6 +316,18 @@ func (pg *WalletInfo) rescanDetailsLayout(gtx C, inset layout.Inset) D {
 							return components.EndToEndRow(gtx, progressTitleLabel.Layout, blocksScannedLabel.Layout)
 						})
 					}),
+					layout.Rigid(func(gtx C) D {
+						if rescanUpdate.ProgressReport.BlocksPerSecond <= 0 {
+							return D{}
+						}
+						speedTitleLabel := pg.Theme.Body2(values.String(values.StrRescanSpeed))
+						speedTitleLabel.Color = pg.Theme.Color.GrayText2
+
+						speedLabel := pg.Theme.Body1(values.StringF(values.StrBlocksPerSecond, rescanUpdate.ProgressReport.BlocksPerSecond))
+						return inset.Layout(gtx, func(gtx C) D {
+							return components.EndToEndRow(gtx, speedTitleLabel.Layout, speedLabel.Layout)
+						})
+					}),
 				)
 			})
 		})
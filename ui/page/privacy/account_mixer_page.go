@@ -2,8 +2,15 @@ package privacy
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"gioui.org/layout"
+	"gioui.org/widget"
 	"github.com/decred/dcrd/dcrutil/v3"
 
 	"github.com/crypto-power/cryptopower/app"
@@ -42,6 +49,7 @@ type AccountMixerPage struct {
 	unmixedAccount      *cryptomaterial.Clickable
 	mixedAccount        *cryptomaterial.Clickable
 	coordinationServer  *cryptomaterial.Clickable
+	mixingSchedule      *cryptomaterial.Clickable
 	toggleMixer         *cryptomaterial.Switch
 	mixerProgress       cryptomaterial.ProgressBarStyle
 
@@ -52,6 +60,7 @@ type AccountMixerPage struct {
 	MixerAccounts []preference.ItemPreference
 
 	mixerCompleted bool
+	mixerStats     *dcr.MixerStats
 	dcrImpl        *dcr.Asset
 }
 
@@ -72,6 +81,7 @@ func NewAccountMixerPage(l *load.Load) *AccountMixerPage {
 		unmixedAccount:      l.Theme.NewClickable(false),
 		mixedAccount:        l.Theme.NewClickable(false),
 		coordinationServer:  l.Theme.NewClickable(false),
+		mixingSchedule:      l.Theme.NewClickable(false),
 		pageContainer:       layout.List{Axis: layout.Vertical},
 
 		dcrImpl: impl,
@@ -93,6 +103,7 @@ func (pg *AccountMixerPage) OnNavigatedTo() {
 	}
 
 	pg.toggleMixer.SetChecked(pg.dcrImpl.IsAccountMixerActive())
+	pg.mixerStats = pg.dcrImpl.MixerStats()
 	pg.mixerProgress.Height = values.MarginPadding18
 	pg.mixerProgress.Radius = cryptomaterial.Radius(2)
 	totalBalance, _ := components.CalculateTotalWalletsBalance(pg.Load) // TODO - handle error
@@ -241,6 +252,16 @@ func (pg *AccountMixerPage) mixerHeaderContent() layout.FlexChild {
 					)
 				})
 			}),
+			layout.Rigid(func(gtx C) D {
+				if !pg.dcrImpl.IsAccountMixerActive() || pg.mixerStats == nil {
+					return D{}
+				}
+				stats := values.StringF(values.StrMixerStats, pg.mixerStats.RoundsAttempted,
+					pg.mixerStats.RoundsSucceeded, pg.mixerStats.AveragePeers())
+				txt := pg.Theme.Label(values.TextSize14, stats)
+				txt.Color = pg.Theme.Color.GrayText3
+				return layout.Inset{Left: values.MarginPadding22, Bottom: values.MarginPadding16}.Layout(gtx, txt.Layout)
+			}),
 		)
 	})
 }
@@ -302,6 +323,7 @@ func (pg *AccountMixerPage) mixerSettings(l *load.Load) layout.FlexChild {
 									layout.Rigid(pg.bottomSectionLabel(pg.mixedAccount, values.String(values.StrMixedAccount))),
 									layout.Rigid(pg.bottomSectionLabel(pg.unmixedAccount, values.String(values.StrUnmixedAccount))),
 									layout.Rigid(pg.bottomSectionLabel(pg.coordinationServer, values.String(values.StrCoordinationServer))),
+									layout.Rigid(pg.bottomSectionLabel(pg.mixingSchedule, values.String(values.StrMixingSchedule))),
 								)
 							})
 						},
@@ -462,19 +484,179 @@ func (pg *AccountMixerPage) HandleUserInteractions() {
 	}
 
 	for pg.coordinationServer.Clicked() {
-		textModal := modal.NewTextInputModal(pg.Load).
-			Hint(values.String(values.StrCoordinationServer)).
-			PositiveButtonStyle(pg.Load.Theme.Color.Primary, pg.Load.Theme.Color.InvText).
-			SetPositiveButtonCallback(func(newName string, tim *modal.TextInputModal) bool {
-				// Todo - implement custom CSPP server
-				return true
-			})
+		pg.showCoordinationServerModal()
+	}
 
-		textModal.SetNegativeButtonCallback(func() {}).
-			SetPositiveButtonText(values.String(values.StrSave))
+	for pg.mixingSchedule.Clicked() {
+		pg.showMixingScheduleModal()
+	}
+}
 
-		pg.ParentWindow().ShowModal(textModal)
+// showCoordinationServerModal lets the user set the CSPP server of the wallet
+// and the certificate it is verified against.
+func (pg *AccountMixerPage) showCoordinationServerModal() {
+	serverEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrServerAddress))
+	certEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrCertFilePath))
+	fingerprintEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrCertFingerprint))
+	for _, editor := range []*cryptomaterial.Editor{&serverEditor, &certEditor, &fingerprintEditor} {
+		editor.Editor.SingleLine = true
 	}
+
+	serverCfg := pg.dcrImpl.CSPPServerConfig()
+	serverEditor.Editor.SetText(net.JoinHostPort(serverCfg.Host, serverCfg.Port))
+	fingerprintEditor.Editor.SetText(serverCfg.CertFingerprint)
+
+	editorInset := layout.Inset{Top: values.MarginPadding10}
+	serverModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrCoordinationServer)).
+		UseCustomWidget(func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(pg.Theme.Body2(values.String(values.StrCSPPServerInfo)).Layout),
+				layout.Rigid(func(gtx C) D { return editorInset.Layout(gtx, serverEditor.Layout) }),
+				layout.Rigid(func(gtx C) D { return editorInset.Layout(gtx, certEditor.Layout) }),
+				layout.Rigid(func(gtx C) D { return editorInset.Layout(gtx, fingerprintEditor.Layout) }),
+			)
+		}).
+		SetCancelable(true).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrSave)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			server := strings.TrimSpace(serverEditor.Editor.Text())
+			host, port, err := net.SplitHostPort(server)
+			if err != nil {
+				serverEditor.SetError(values.StringF(values.StrValidateHostErr, server))
+				return false
+			}
+
+			newCfg := &dcr.CSPPServerConfig{
+				Host:            host,
+				Port:            port,
+				CertFingerprint: strings.TrimSpace(fingerprintEditor.Editor.Text()),
+			}
+			if certPath := strings.TrimSpace(certEditor.Editor.Text()); certPath != "" {
+				certPEM, err := os.ReadFile(certPath)
+				if err != nil {
+					certEditor.SetError(err.Error())
+					return false
+				}
+				newCfg.CertPEM = string(certPEM)
+			} else if host == serverCfg.Host {
+				// Keep the certificate of an unchanged server.
+				newCfg.CertPEM = serverCfg.CertPEM
+			}
+
+			if err := pg.dcrImpl.SetCSPPServerConfig(newCfg); err != nil {
+				serverEditor.SetError(err.Error())
+				return false
+			}
+			return true
+		})
+	pg.ParentWindow().ShowModal(serverModal)
+}
+
+// showMixingScheduleModal lets the user restrict when the mixer runs and when
+// a mixing session ends.
+func (pg *AccountMixerPage) showMixingScheduleModal() {
+	startEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrMixWindowStart))
+	endEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrMixWindowEnd))
+	maxMixesEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrMaxMixes))
+	minBalanceEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrMinUnmixedBalance))
+	editors := []*cryptomaterial.Editor{&startEditor, &endEditor, &maxMixesEditor, &minBalanceEditor}
+	for _, editor := range editors {
+		editor.Editor.SingleLine = true
+	}
+
+	schedule := pg.dcrImpl.MixerSchedule()
+	if schedule.WindowStart != schedule.WindowEnd {
+		startEditor.Editor.SetText(formatMinuteOfDay(schedule.WindowStart))
+		endEditor.Editor.SetText(formatMinuteOfDay(schedule.WindowEnd))
+	}
+	if schedule.MaxMixes > 0 {
+		maxMixesEditor.Editor.SetText(strconv.Itoa(schedule.MaxMixes))
+	}
+	if schedule.MinUnmixedBalance > 0 {
+		minBalanceEditor.Editor.SetText(strconv.FormatFloat(dcrutil.Amount(schedule.MinUnmixedBalance).ToCoin(), 'f', -1, 64))
+	}
+
+	editorInset := layout.Inset{Top: values.MarginPadding10}
+	scheduleModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrMixingSchedule)).
+		UseCustomWidget(func(gtx C) D {
+			children := []layout.FlexChild{
+				layout.Rigid(pg.Theme.Body2(values.String(values.StrMixingScheduleInfo)).Layout),
+			}
+			for _, editor := range editors {
+				editor := editor
+				children = append(children, layout.Rigid(func(gtx C) D {
+					return editorInset.Layout(gtx, editor.Layout)
+				}))
+			}
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		}).
+		SetCancelable(true).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrSave)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			newSchedule := &dcr.MixerSchedule{}
+			valid := true
+
+			startText := strings.TrimSpace(startEditor.Editor.Text())
+			endText := strings.TrimSpace(endEditor.Editor.Text())
+			if startText != "" || endText != "" {
+				var ok bool
+				if newSchedule.WindowStart, ok = parseMinuteOfDay(startText); !ok {
+					startEditor.SetError(values.String(values.StrInvalidSchedule))
+					valid = false
+				}
+				if newSchedule.WindowEnd, ok = parseMinuteOfDay(endText); !ok {
+					endEditor.SetError(values.String(values.StrInvalidSchedule))
+					valid = false
+				}
+			}
+
+			if text := strings.TrimSpace(maxMixesEditor.Editor.Text()); text != "" {
+				maxMixes, err := strconv.Atoi(text)
+				if err != nil || maxMixes < 0 {
+					maxMixesEditor.SetError(values.String(values.StrInvalidSchedule))
+					valid = false
+				}
+				newSchedule.MaxMixes = maxMixes
+			}
+
+			if text := strings.TrimSpace(minBalanceEditor.Editor.Text()); text != "" {
+				minBalance, err := strconv.ParseFloat(text, 64)
+				amount, amountErr := dcrutil.NewAmount(minBalance)
+				if err != nil || amountErr != nil || amount < 0 {
+					minBalanceEditor.SetError(values.String(values.StrInvalidSchedule))
+					valid = false
+				}
+				newSchedule.MinUnmixedBalance = int64(amount)
+			}
+
+			if !valid {
+				return false
+			}
+
+			if err := pg.dcrImpl.SetMixerSchedule(newSchedule); err != nil {
+				startEditor.SetError(err.Error())
+				return false
+			}
+			return true
+		})
+	pg.ParentWindow().ShowModal(scheduleModal)
+}
+
+// parseMinuteOfDay parses a HH:MM time into minutes past midnight.
+func parseMinuteOfDay(text string) (int, bool) {
+	t, err := time.Parse("15:04", text)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+func formatMinuteOfDay(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
 }
 
 func (pg *AccountMixerPage) getMixerAccounts(isFilterMixed bool) []preference.ItemPreference {
@@ -552,6 +734,11 @@ func (pg *AccountMixerPage) listenForMixerNotifications() {
 					pg.ParentWindow().Reload()
 				}
 
+				if n.RunStatus == wallet.MixerRunning {
+					pg.mixerStats = n.Stats
+					pg.ParentWindow().Reload()
+				}
+
 				if n.RunStatus == wallet.MixerEnded {
 					pg.mixerCompleted = true
 					pg.getMixerBalance()
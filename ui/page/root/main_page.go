@@ -882,6 +882,8 @@ func (mp *MainPage) postDesktopNotification(notifier interface{}) {
 			notification = values.StringF(values.StrVoteStartedNotif, t.Proposal.Name)
 		case t.ProposalStatus == wallet.VoteFinished:
 			notification = values.StringF(values.StrVoteEndedNotif, t.Proposal.Name)
+		case t.ProposalStatus == wallet.VersionUpdated:
+			notification = values.StringF(values.StrProposalVersionUpdatedNotif, t.Proposal.Name)
 		default:
 			notification = values.StringF(values.StrNewProposalUpdate, t.Proposal.Name)
 		}
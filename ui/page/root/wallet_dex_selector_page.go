@@ -10,6 +10,7 @@ import (
 	"gioui.org/widget"
 
 	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
@@ -328,6 +329,12 @@ func (pg *WalletDexServerSelector) startSyncing(wallet sharedW.Asset, unlock loa
 	}
 	unlock(true)
 
+	// The password of a trusted dcrd node must be decrypted before syncing.
+	if dcrImpl, ok := wallet.(*dcr.Asset); ok && dcrImpl.IsDcrdRPCLocked() {
+		pg.unlockDcrdRPCForSyncing(dcrImpl, unlock)
+		return
+	}
+
 	if pg.isConnected {
 		// once network connection has been established proceed to
 		// start the wallet sync.
@@ -420,3 +427,29 @@ func (pg *WalletDexServerSelector) unlockWalletForSyncing(wal sharedW.Asset, unl
 		})
 	pg.ParentWindow().ShowModal(spendingPasswordModal)
 }
+
+// unlockDcrdRPCForSyncing asks for the spending password that decrypts the
+// password of the trusted dcrd node the wallet syncs through. The wallet
+// itself is left locked.
+func (pg *WalletDexServerSelector) unlockDcrdRPCForSyncing(wal *dcr.Asset, unlock load.NeedUnlockRestore) {
+	spendingPasswordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrUnlockDcrdRPCTitle)).
+		SetDescription(values.String(values.StrUnlockDcrdRPCInfo)).
+		PasswordHint(values.String(values.StrSpendingPassword)).
+		SetPositiveButtonText(values.String(values.StrUnlock)).
+		SetCancelable(true).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			err := wal.UnlockDcrdRPCConfig(password)
+			if err != nil {
+				pm.SetError(err.Error())
+				pm.SetLoading(false)
+				return false
+			}
+			pm.Dismiss()
+			pg.startSyncing(wal, unlock)
+			return true
+		})
+	pg.ParentWindow().ShowModal(spendingPasswordModal)
+}
@@ -2,6 +2,8 @@ package root
 
 import (
 	"context"
+	"math"
+	"os"
 	"strconv"
 	"strings"
 
@@ -18,6 +20,7 @@ import (
 	"github.com/crypto-power/cryptopower/ui/page/components"
 	"github.com/crypto-power/cryptopower/ui/page/security"
 	s "github.com/crypto-power/cryptopower/ui/page/settings"
+	"github.com/crypto-power/cryptopower/ui/preference"
 	"github.com/crypto-power/cryptopower/ui/utils"
 	"github.com/crypto-power/cryptopower/ui/values"
 )
@@ -54,6 +57,12 @@ type WalletSettingsPage struct {
 	changeWalletName, addAccount, deleteWallet *cryptomaterial.Clickable
 	verifyMessage, validateAddr, signMessage   *cryptomaterial.Clickable
 	updateConnectToPeer, setGapLimit           *cryptomaterial.Clickable
+	coinSelectionStrategy                      *cryptomaterial.Clickable
+	feeSource, feeEstimatesURL                 *cryptomaterial.Clickable
+	networkBackend, managePeers                *cryptomaterial.Clickable
+	syncLimits                                 *cryptomaterial.Clickable
+	dbDriver, compactDB, backupDB              *cryptomaterial.Clickable
+	verifyTxIndex                              *cryptomaterial.Clickable
 
 	backButton cryptomaterial.IconButton
 	infoButton cryptomaterial.IconButton
@@ -86,6 +95,17 @@ func NewWalletSettingsPage(l *load.Load) *WalletSettingsPage {
 		signMessage:         l.Theme.NewClickable(false),
 		updateConnectToPeer: l.Theme.NewClickable(false),
 
+		coinSelectionStrategy: l.Theme.NewClickable(false),
+		feeSource:             l.Theme.NewClickable(false),
+		feeEstimatesURL:       l.Theme.NewClickable(false),
+		networkBackend:        l.Theme.NewClickable(false),
+		managePeers:           l.Theme.NewClickable(false),
+		syncLimits:            l.Theme.NewClickable(false),
+		dbDriver:              l.Theme.NewClickable(false),
+		compactDB:             l.Theme.NewClickable(false),
+		backupDB:              l.Theme.NewClickable(false),
+		verifyTxIndex:         l.Theme.NewClickable(false),
+
 		fetchProposal:     l.Theme.Switch(),
 		proposalNotif:     l.Theme.Switch(),
 		spendUnconfirmed:  l.Theme.Switch(),
@@ -231,6 +251,68 @@ func (pg *WalletSettingsPage) generalSection() layout.Widget {
 				}
 				return D{}
 			}),
+			layout.Rigid(func(gtx C) D {
+				if pg.wallet.GetAssetType() == libutils.DCRWalletAsset {
+					return D{}
+				}
+
+				strategy := pg.wallet.DefaultCoinSelectionStrategy().String()
+				strategyRow := clickableRowData{
+					title:     values.String(values.StrDefaultCoinSelection),
+					clickable: pg.coinSelectionStrategy,
+					labelText: values.String(preference.GetKeyValue(strategy, preference.CoinSelectionOptions)),
+				}
+				return pg.clickableRow(gtx, strategyRow)
+			}),
+			layout.Rigid(func(gtx C) D {
+				if pg.wallet.GetAssetType() == libutils.DCRWalletAsset {
+					return D{}
+				}
+
+				feeSourceRow := clickableRowData{
+					title:     values.String(values.StrFeeSource),
+					clickable: pg.feeSource,
+					labelText: values.String(preference.GetKeyValue(pg.preferredFeeSource(), preference.FeeEstimatorOptions)),
+				}
+				return pg.clickableRow(gtx, feeSourceRow)
+			}),
+			layout.Rigid(func(gtx C) D {
+				if pg.wallet.GetAssetType() == libutils.DCRWalletAsset {
+					return D{}
+				}
+
+				feeURL := pg.wallet.FeeEstimatorURL()
+				if feeURL == "" {
+					feeURL = values.String(values.StrNone)
+				}
+				feeURLRow := clickableRowData{
+					title:     values.String(values.StrFeeEstimatesURL),
+					clickable: pg.feeEstimatesURL,
+					labelText: feeURL,
+				}
+				return pg.clickableRow(gtx, feeURLRow)
+			}),
+			layout.Rigid(func(gtx C) D {
+				var backend string
+				if pg.wallet.GetAssetType() == libutils.DCRWalletAsset {
+					backend = values.String(values.StrSPV)
+					if cfg := pg.wallet.(*dcr.Asset).DcrdRPCConfig(); cfg != nil {
+						backend = cfg.Host
+					}
+				} else {
+					backend = values.String(values.StrNeutrino)
+					if cfg := pg.wallet.ElectrumServerConfig(); cfg != nil {
+						backend = cfg.Host
+					}
+				}
+				backendRow := clickableRowData{
+					title:     values.String(values.StrNetworkBackend),
+					clickable: pg.networkBackend,
+					labelText: backend,
+				}
+				return pg.clickableRow(gtx, backendRow)
+			}),
+			layout.Rigid(pg.sectionContent(pg.syncLimits, values.String(values.StrSyncLimits))),
 			layout.Rigid(func(gtx C) D {
 				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 					layout.Rigid(pg.subSectionSwitch(values.String(values.StrConnectToSpecificPeer), pg.connectToPeer)),
@@ -271,6 +353,12 @@ func (pg *WalletSettingsPage) debug() layout.Widget {
 	dims := func(gtx C) D {
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 			layout.Rigid(pg.sectionContent(pg.rescan, values.String(values.StrRescanBlockchain))),
+			layout.Rigid(func(gtx C) D {
+				if pg.wallet.GetAssetType() == libutils.DCRWalletAsset {
+					return pg.sectionDimension(gtx, pg.verifyTxIndex, values.String(values.StrVerifyTxIndex))
+				}
+				return D{}
+			}),
 			layout.Rigid(func(gtx C) D {
 				if pg.wallet.GetAssetType() == libutils.DCRWalletAsset {
 					return pg.sectionDimension(gtx, pg.setGapLimit, values.String(values.StrSetGapLimit))
@@ -279,6 +367,17 @@ func (pg *WalletSettingsPage) debug() layout.Widget {
 			}),
 			layout.Rigid(pg.sectionContent(pg.checklog, values.String(values.StrCheckWalletLog))),
 			layout.Rigid(pg.sectionContent(pg.checkStats, values.String(values.StrCheckStatistics))),
+			layout.Rigid(pg.sectionContent(pg.managePeers, values.String(values.StrManagePeers))),
+			layout.Rigid(func(gtx C) D {
+				driverRow := clickableRowData{
+					title:     values.String(values.StrDatabaseBackend),
+					clickable: pg.dbDriver,
+					labelText: values.String(preference.GetKeyValue(pg.wallet.DatabaseDriver(), preference.DBDriverOptions)),
+				}
+				return pg.clickableRow(gtx, driverRow)
+			}),
+			layout.Rigid(pg.sectionContent(pg.compactDB, values.String(values.StrCompactDatabase))),
+			layout.Rigid(pg.sectionContent(pg.backupDB, values.String(values.StrBackupDatabase))),
 		)
 	}
 
@@ -542,6 +641,491 @@ func (pg *WalletSettingsPage) renameWalletModal() {
 	pg.ParentWindow().ShowModal(textModal)
 }
 
+// showFeeSourceModal lets the user pick the fee estimator tried first. The
+// remaining estimators keep their relative order as fallbacks.
+func (pg *WalletSettingsPage) showFeeSourceModal() {
+	options := make([]preference.ItemPreference, 0, len(preference.FeeEstimatorOptions))
+	for _, option := range preference.FeeEstimatorOptions {
+		if pg.isFeeSourceAvailable(option.Key) {
+			options = append(options, option)
+		}
+	}
+
+	feeSourceModal := preference.NewListPreference(pg.Load, "", pg.preferredFeeSource(), options).
+		Title(values.StrFeeSource).
+		UpdateValues(func(val string) {
+			order := []string{val}
+			for _, name := range pg.wallet.FeeEstimatorOrder() {
+				if name != val {
+					order = append(order, name)
+				}
+			}
+			if err := pg.wallet.SetFeeEstimatorOrder(order); err != nil {
+				log.Error(err)
+			}
+		})
+	pg.ParentWindow().ShowModal(feeSourceModal)
+}
+
+// showDBDriverModal lets the user convert the dcr wallet database to another
+// driver.
+func (pg *WalletSettingsPage) showDBDriverModal() {
+	current := pg.wallet.DatabaseDriver()
+	driverModal := preference.NewListPreference(pg.Load, "", current, preference.DBDriverOptions).
+		Title(values.StrDatabaseBackend).
+		UpdateValues(func(val string) {
+			if val != current {
+				go pg.migrateWalletDB(val)
+			}
+		})
+	pg.ParentWindow().ShowModal(driverModal)
+}
+
+// showCompactDBModal asks the user to confirm the compaction of the wallet
+// database.
+func (pg *WalletSettingsPage) showCompactDBModal() {
+	compactModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrCompactDatabase)).
+		Body(values.String(values.StrCompactDatabaseInfo)).
+		SetCancelable(true).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.Surface).
+		SetPositiveButtonText(values.String(values.StrCompact)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			go pg.migrateWalletDB(pg.wallet.DatabaseDriver())
+			return true
+		})
+	pg.ParentWindow().ShowModal(compactModal)
+}
+
+// migrateWalletDB converts the wallet database to the driver, or compacts it
+// if it already uses the driver, and reports the outcome.
+func (pg *WalletSettingsPage) migrateWalletDB(driver string) {
+	if err := pg.WL.AssetsManager.MigrateWalletDB(pg.wallet.GetWalletID(), driver); err != nil {
+		pg.ParentWindow().ShowModal(modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc()))
+		return
+	}
+	pg.ParentWindow().ShowModal(modal.NewSuccessModal(pg.Load, values.String(values.StrDatabaseUpdated), modal.DefaultClickFunc()))
+}
+
+// backupWalletDB writes a copy of the wallet database to the backups
+// directory and shows where it was written.
+func (pg *WalletSettingsPage) backupWalletDB() {
+	backupPath, err := pg.WL.AssetsManager.BackupWalletDB(pg.wallet.GetWalletID())
+	if err != nil {
+		pg.ParentWindow().ShowModal(modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc()))
+		return
+	}
+	pg.ParentWindow().ShowModal(modal.NewSuccessModal(pg.Load, values.StringF(values.StrDatabaseBackedUp, backupPath), modal.DefaultClickFunc()))
+}
+
+// isFeeSourceAvailable returns false for the fee estimators that cannot be
+// used by the wallet.
+func (pg *WalletSettingsPage) isFeeSourceAvailable(name string) bool {
+	switch name {
+	case sharedW.FeeEstimatorBlockstream:
+		// Blockstream doesn't provide litecoin fee estimates.
+		return pg.wallet.GetAssetType() != libutils.LTCWalletAsset
+	case sharedW.FeeEstimatorCustom:
+		return pg.wallet.FeeEstimatorURL() != ""
+	default:
+		return true
+	}
+}
+
+// preferredFeeSource returns the first usable fee estimator in the wallet's
+// fee estimator order.
+func (pg *WalletSettingsPage) preferredFeeSource() string {
+	for _, name := range pg.wallet.FeeEstimatorOrder() {
+		if pg.isFeeSourceAvailable(name) {
+			return name
+		}
+	}
+	return sharedW.FeeEstimatorLocal
+}
+
+func (pg *WalletSettingsPage) showFeeEstimatesURLDialog() {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrFeeEstimatesURL)).
+		PositiveButtonStyle(pg.Load.Theme.Color.Primary, pg.Load.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(feeURL string, tim *modal.TextInputModal) bool {
+			if err := pg.wallet.SetFeeEstimatorURL(strings.TrimSpace(feeURL)); err != nil {
+				tim.SetError(err.Error())
+				tim.SetLoading(false)
+				return false
+			}
+			return true
+		})
+	textModal.Title(values.String(values.StrFeeEstimatesURL)).
+		SetPositiveButtonText(values.String(values.StrSave))
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+// showNetworkBackendModal lets the user sync the DCR wallet through a trusted
+// dcrd node. Saving an empty host switches the wallet back to SPV.
+func (pg *WalletSettingsPage) showNetworkBackendModal() {
+	dcrImpl := pg.wallet.(*dcr.Asset)
+
+	hostEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrDcrdRPCHost))
+	userEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrRPCUsername))
+	passEditor := pg.Theme.EditorPassword(new(widget.Editor), values.String(values.StrRPCPassword))
+	certEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrRPCCertPath))
+	// The RPC password is encrypted with the spending password.
+	spendingPassEditor := pg.Theme.EditorPassword(new(widget.Editor), values.String(values.StrSpendingPassword))
+	for _, editor := range []*cryptomaterial.Editor{&hostEditor, &userEditor, &passEditor, &certEditor, &spendingPassEditor} {
+		editor.Editor.SingleLine = true
+	}
+
+	cfg := dcrImpl.DcrdRPCConfig()
+	if cfg != nil {
+		hostEditor.Editor.SetText(cfg.Host)
+		userEditor.Editor.SetText(cfg.User)
+		passEditor.Hint = values.String(values.StrRPCPasswordKeep)
+	}
+
+	editorInset := layout.Inset{Top: values.MarginPadding10}
+	backendModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrNetworkBackend)).
+		UseCustomWidget(func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(pg.Theme.Body2(values.String(values.StrNetworkBackendInfo)).Layout),
+				layout.Rigid(func(gtx C) D { return editorInset.Layout(gtx, hostEditor.Layout) }),
+				layout.Rigid(func(gtx C) D { return editorInset.Layout(gtx, userEditor.Layout) }),
+				layout.Rigid(func(gtx C) D { return editorInset.Layout(gtx, passEditor.Layout) }),
+				layout.Rigid(func(gtx C) D { return editorInset.Layout(gtx, certEditor.Layout) }),
+				layout.Rigid(func(gtx C) D { return editorInset.Layout(gtx, spendingPassEditor.Layout) }),
+			)
+		}).
+		SetCancelable(true).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrSave)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			host := strings.TrimSpace(hostEditor.Editor.Text())
+			if host == "" {
+				dcrImpl.ResetDcrdRPCConfig()
+				return true
+			}
+
+			newCfg := &dcr.DcrdRPCConfig{
+				Host: host,
+				User: strings.TrimSpace(userEditor.Editor.Text()),
+				Pass: passEditor.Editor.Text(),
+			}
+			if certPath := strings.TrimSpace(certEditor.Editor.Text()); certPath != "" {
+				certPEM, err := os.ReadFile(certPath)
+				if err != nil {
+					certEditor.SetError(err.Error())
+					return false
+				}
+				newCfg.CertPEM = string(certPEM)
+			} else if cfg != nil && cfg.Host == host {
+				// Keep the certificate of an unchanged node.
+				newCfg.CertPEM = cfg.CertPEM
+			}
+
+			err := dcrImpl.SetDcrdRPCConfig(newCfg, spendingPassEditor.Editor.Text())
+			if err != nil {
+				if err.Error() == libutils.ErrInvalidPassphrase {
+					spendingPassEditor.SetError(values.String(values.StrInvalidPassphrase))
+				} else {
+					hostEditor.SetError(err.Error())
+				}
+				return false
+			}
+			return true
+		})
+	pg.ParentWindow().ShowModal(backendModal)
+}
+
+// showElectrumServerModal lets the user sync the BTC or LTC wallet through an
+// Electrum server. Saving an empty host switches the wallet back to neutrino.
+func (pg *WalletSettingsPage) showElectrumServerModal() {
+	hostEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrElectrumServerHost))
+	certEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrTLSCertPath))
+	proxyEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrSOCKS5Proxy))
+	for _, editor := range []*cryptomaterial.Editor{&hostEditor, &certEditor, &proxyEditor} {
+		editor.Editor.SingleLine = true
+	}
+	tlsCheckBox := pg.Theme.CheckBox(new(widget.Bool), values.String(values.StrUseTLS))
+
+	cfg := pg.wallet.ElectrumServerConfig()
+	if cfg != nil {
+		hostEditor.Editor.SetText(cfg.Host)
+		proxyEditor.Editor.SetText(cfg.Proxy)
+		tlsCheckBox.CheckBox.Value = cfg.TLS
+	} else {
+		tlsCheckBox.CheckBox.Value = true
+	}
+
+	editorInset := layout.Inset{Top: values.MarginPadding10}
+	electrumModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrNetworkBackend)).
+		UseCustomWidget(func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(pg.Theme.Body2(values.String(values.StrElectrumBackendInfo)).Layout),
+				layout.Rigid(func(gtx C) D { return editorInset.Layout(gtx, hostEditor.Layout) }),
+				layout.Rigid(func(gtx C) D { return editorInset.Layout(gtx, tlsCheckBox.Layout) }),
+				layout.Rigid(func(gtx C) D { return editorInset.Layout(gtx, certEditor.Layout) }),
+				layout.Rigid(func(gtx C) D { return editorInset.Layout(gtx, proxyEditor.Layout) }),
+			)
+		}).
+		SetCancelable(true).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrSave)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			host := strings.TrimSpace(hostEditor.Editor.Text())
+			if host == "" {
+				if err := pg.wallet.SetElectrumServerConfig(nil); err != nil {
+					hostEditor.SetError(err.Error())
+					return false
+				}
+				return true
+			}
+
+			newCfg := &sharedW.ElectrumServerConfig{
+				Host:  host,
+				TLS:   tlsCheckBox.CheckBox.Value,
+				Proxy: strings.TrimSpace(proxyEditor.Editor.Text()),
+			}
+			if certPath := strings.TrimSpace(certEditor.Editor.Text()); certPath != "" {
+				certPEM, err := os.ReadFile(certPath)
+				if err != nil {
+					certEditor.SetError(err.Error())
+					return false
+				}
+				newCfg.CertPEM = string(certPEM)
+			} else if cfg != nil && cfg.Host == host {
+				// Keep the certificate of an unchanged server.
+				newCfg.CertPEM = cfg.CertPEM
+			}
+
+			if err := pg.wallet.SetElectrumServerConfig(newCfg); err != nil {
+				hostEditor.SetError(err.Error())
+				return false
+			}
+			return true
+		})
+	pg.ParentWindow().ShowModal(electrumModal)
+}
+
+// showRescanModal lets the user rescan a range of blocks or resume the
+// rescan that was stopped.
+func (pg *WalletSettingsPage) showRescanModal() {
+	startEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrRescanStartHeight))
+	endEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrRescanEndHeight))
+	for _, editor := range []*cryptomaterial.Editor{&startEditor, &endEditor} {
+		editor.Editor.SingleLine = true
+	}
+
+	checkpoint := pg.wallet.RescanCheckpoint()
+	var resumeCheckBox cryptomaterial.CheckBoxStyle
+	if checkpoint != nil {
+		resumeCheckBox = pg.Theme.CheckBox(new(widget.Bool), values.StringF(values.StrResumePreviousRescan, checkpoint.ScannedThrough))
+		resumeCheckBox.CheckBox.Value = true
+	}
+
+	parseHeight := func(editor *cryptomaterial.Editor) (int32, bool) {
+		text := strings.TrimSpace(editor.Editor.Text())
+		if text == "" {
+			return 0, true
+		}
+		height, err := strconv.ParseInt(text, 10, 32)
+		if err != nil || height < 0 {
+			editor.SetError(values.String(values.StrInvalidBlockHeight))
+			return 0, false
+		}
+		return int32(height), true
+	}
+
+	editorInset := layout.Inset{Top: values.MarginPadding10}
+	rescanModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrRescanBlockchain)).
+		UseCustomWidget(func(gtx C) D {
+			resuming := checkpoint != nil && resumeCheckBox.CheckBox.Value
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(pg.Theme.Body2(values.String(values.StrRescanInfo)).Layout),
+				layout.Rigid(func(gtx C) D {
+					if checkpoint == nil {
+						return D{}
+					}
+					return editorInset.Layout(gtx, resumeCheckBox.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					if resuming {
+						return D{}
+					}
+					return editorInset.Layout(gtx, startEditor.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					if resuming {
+						return D{}
+					}
+					return editorInset.Layout(gtx, endEditor.Layout)
+				}),
+			)
+		}).
+		SetCancelable(true).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.Surface).
+		SetPositiveButtonText(values.String(values.StrRescan)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			var err error
+			if checkpoint != nil && resumeCheckBox.CheckBox.Value {
+				err = pg.wallet.ResumeRescan()
+			} else {
+				startHeight, startOk := parseHeight(&startEditor)
+				endHeight, endOk := parseHeight(&endEditor)
+				if !startOk || !endOk {
+					return false
+				}
+				if checkpoint != nil {
+					pg.wallet.DeleteRescanCheckpoint()
+				}
+				err = pg.wallet.RescanBlocksWithOptions(&sharedW.RescanOptions{
+					StartHeight: startHeight,
+					EndHeight:   endHeight,
+				})
+			}
+			if err != nil {
+				errorModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
+				pg.ParentWindow().ShowModal(errorModal)
+			}
+			return true
+		})
+	pg.ParentWindow().ShowModal(rescanModal)
+}
+
+// maxTxIndexIssues is the number of transaction index issues listed in the
+// verification report.
+const maxTxIndexIssues = 10
+
+// verifyTransactionIndex compares the transaction index of the dcr wallet
+// with its transaction store and offers to repair the issues found.
+func (pg *WalletSettingsPage) verifyTransactionIndex() {
+	asset := pg.wallet.(*dcr.Asset)
+	report, err := asset.VerifyTxIndex()
+	if err != nil {
+		pg.ParentWindow().ShowModal(modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc()))
+		return
+	}
+
+	if report.IsConsistent() {
+		msg := values.StringF(values.StrTxIndexConsistent, report.IndexedTxs)
+		pg.ParentWindow().ShowModal(modal.NewSuccessModal(pg.Load, msg, modal.DefaultClickFunc()))
+		return
+	}
+
+	issues := make([]string, 0, maxTxIndexIssues)
+	for _, hash := range report.Missing {
+		issues = append(issues, values.StringF(values.StrTxIndexMissing, hash))
+	}
+	for _, hash := range report.Orphaned {
+		issues = append(issues, values.StringF(values.StrTxIndexOrphaned, hash))
+	}
+	for _, mismatch := range report.Mismatched {
+		issues = append(issues, values.StringF(values.StrTxIndexMismatched, mismatch.Hash, strings.Join(mismatch.Fields, ", ")))
+	}
+	if len(issues) > maxTxIndexIssues {
+		more := len(issues) - maxTxIndexIssues
+		issues = append(issues[:maxTxIndexIssues], values.StringF(values.StrAndMore, more))
+	}
+
+	body := values.StringF(values.StrTxIndexInconsistent, len(report.Missing), len(report.Orphaned),
+		len(report.Mismatched), report.WalletTxs) + "\n\n" + strings.Join(issues, "\n")
+	repairModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrVerifyTxIndex)).
+		Body(body).
+		SetCancelable(true).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.Surface).
+		SetPositiveButtonText(values.String(values.StrRepair)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			go func() {
+				if _, err := asset.RepairTxIndex(); err != nil {
+					pg.ParentWindow().ShowModal(modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc()))
+					return
+				}
+				msg := values.String(values.StrTxIndexRepaired)
+				pg.ParentWindow().ShowModal(modal.NewSuccessModal(pg.Load, msg, modal.DefaultClickFunc()))
+			}()
+			return true
+		})
+	pg.ParentWindow().ShowModal(repairModal)
+}
+
+// showSyncLimitsModal lets the user limit the peers, the bandwidth and the
+// data used to sync the wallet, and the hours it syncs at.
+func (pg *WalletSettingsPage) showSyncLimitsModal() {
+	limits := pg.wallet.SyncLimits()
+	fields := []struct {
+		hint  string
+		value *int32
+		max   int64
+	}{
+		{values.String(values.StrMaxPeers), &limits.MaxPeers, math.MaxInt32},
+		{values.String(values.StrBandwidthLimit), &limits.BandwidthKBps, math.MaxInt32},
+		{values.String(values.StrDailyDataLimit), &limits.DailyDataLimitMB, math.MaxInt32},
+		{values.String(values.StrSyncScheduleStart), &limits.ScheduleStartHour, 23},
+		{values.String(values.StrSyncScheduleEnd), &limits.ScheduleEndHour, 23},
+	}
+
+	editors := make([]cryptomaterial.Editor, len(fields))
+	for i, field := range fields {
+		editors[i] = pg.Theme.Editor(new(widget.Editor), field.hint)
+		editors[i].Editor.SingleLine = true
+		if *field.value > 0 {
+			editors[i].Editor.SetText(strconv.Itoa(int(*field.value)))
+		}
+	}
+
+	editorInset := layout.Inset{Top: values.MarginPadding10}
+	limitsModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrSyncLimits)).
+		UseCustomWidget(func(gtx C) D {
+			children := []layout.FlexChild{
+				layout.Rigid(pg.Theme.Body2(values.String(values.StrSyncLimitsInfo)).Layout),
+			}
+			for i := range editors {
+				editor := &editors[i]
+				children = append(children, layout.Rigid(func(gtx C) D {
+					return editorInset.Layout(gtx, editor.Layout)
+				}))
+			}
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		}).
+		SetCancelable(true).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.Surface).
+		SetPositiveButtonText(values.String(values.StrSave)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			valid := true
+			for i, field := range fields {
+				text := strings.TrimSpace(editors[i].Editor.Text())
+				if text == "" {
+					*field.value = 0
+					continue
+				}
+				value, err := strconv.ParseInt(text, 10, 32)
+				if err != nil || value < 0 || value > field.max {
+					editors[i].SetError(values.String(values.StrInvalidLimit))
+					valid = false
+					continue
+				}
+				*field.value = int32(value)
+			}
+			if !valid {
+				return false
+			}
+
+			if err := pg.wallet.SetSyncLimits(limits); err != nil {
+				errorModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
+				pg.ParentWindow().ShowModal(errorModal)
+			}
+			return true
+		})
+	pg.ParentWindow().ShowModal(limitsModal)
+}
+
 func (pg *WalletSettingsPage) showSPVPeerDialog() {
 	textModal := modal.NewTextInputModal(pg.Load).
 		Hint(values.String(values.StrIPAddress)).
@@ -615,35 +1199,45 @@ func (pg *WalletSettingsPage) isProposalsAPIAllowed() bool {
 // displayed.
 // Part of the load.Page interface.
 func (pg *WalletSettingsPage) HandleUserInteractions() {
+	if pg.coinSelectionStrategy.Clicked() {
+		strategyModal := preference.NewListPreference(pg.Load, "",
+			pg.wallet.DefaultCoinSelectionStrategy().String(), preference.CoinSelectionOptions).
+			Title(values.StrDefaultCoinSelection).
+			UpdateValues(func(val string) {
+				if strategy, ok := sharedW.ParseCoinSelectionStrategy(val); ok {
+					pg.wallet.SetDefaultCoinSelectionStrategy(strategy)
+				}
+			})
+		pg.ParentWindow().ShowModal(strategyModal)
+	}
+
+	if pg.feeSource.Clicked() {
+		pg.showFeeSourceModal()
+	}
+
+	if pg.feeEstimatesURL.Clicked() {
+		pg.showFeeEstimatesURLDialog()
+	}
+
+	if pg.networkBackend.Clicked() {
+		if pg.wallet.GetAssetType() == libutils.DCRWalletAsset {
+			pg.showNetworkBackendModal()
+		} else {
+			pg.showElectrumServerModal()
+		}
+	}
+
 	for pg.changePass.Clicked() {
 		pg.changeSpendingPasswordModal()
 		break
 	}
 
 	if pg.rescan.Clicked() {
-		go func() {
-			info := modal.NewCustomModal(pg.Load).
-				Title(values.String(values.StrRescanBlockchain)).
-				Body(values.String(values.StrRescanInfo)).
-				SetNegativeButtonText(values.String(values.StrCancel)).
-				PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.Surface).
-				SetPositiveButtonText(values.String(values.StrRescan)).
-				SetPositiveButtonCallback(func(_ bool, im *modal.InfoModal) bool {
-					err := pg.WL.SelectedWallet.Wallet.RescanBlocks()
-					if err != nil {
-						errorModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
-						pg.ParentWindow().ShowModal(errorModal)
-						im.Dismiss()
-
-						return false
-					}
-
-					im.Dismiss()
-					return true
-				})
+		pg.showRescanModal()
+	}
 
-			pg.ParentWindow().ShowModal(info)
-		}()
+	if pg.verifyTxIndex.Clicked() && pg.wallet.GetAssetType() == libutils.DCRWalletAsset {
+		go pg.verifyTransactionIndex()
 	}
 
 	for pg.setGapLimit.Clicked() {
@@ -770,6 +1364,26 @@ func (pg *WalletSettingsPage) HandleUserInteractions() {
 		pg.ParentNavigator().Display(s.NewStatPage(pg.Load))
 	}
 
+	if pg.syncLimits.Clicked() {
+		pg.showSyncLimitsModal()
+	}
+
+	if pg.managePeers.Clicked() {
+		pg.ParentNavigator().Display(s.NewPeersPage(pg.Load))
+	}
+
+	if pg.dbDriver.Clicked() && pg.wallet.GetAssetType() == libutils.DCRWalletAsset {
+		pg.showDBDriverModal()
+	}
+
+	if pg.compactDB.Clicked() {
+		pg.showCompactDBModal()
+	}
+
+	if pg.backupDB.Clicked() {
+		go pg.backupWalletDB()
+	}
+
 	if pg.proposalNotif.Changed() {
 		pg.WL.SelectedWallet.Wallet.SaveUserConfigValue(sharedW.ProposalNotificationConfigKey, pg.proposalNotif.IsChecked())
 	}
@@ -6,9 +6,9 @@ import (
 	"gioui.org/layout"
 	"gioui.org/widget"
 
-	libUtil "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/preference"
 	"github.com/crypto-power/cryptopower/ui/values"
 )
 
@@ -52,6 +52,7 @@ func (pg *Page) initLayoutWidgets() {
 	pg.txLabelInputEditor.Editor.MaxLen = MaxTxLabelSize
 
 	pg.toCoinSelection = pg.Theme.NewClickable(false)
+	pg.toCoinSelectionStrategy = pg.Theme.NewClickable(false)
 }
 
 func (pg *Page) topNav(gtx layout.Context) layout.Dimensions {
@@ -91,12 +92,7 @@ func (pg *Page) layoutDesktop(gtx layout.Context) layout.Dimensions {
 		pg.toSection,
 		pg.coinSelectionSection,
 		pg.txLabelSection,
-	}
-
-	// Display the transaction fee rate selection only for btc and ltc wallets.
-	switch pg.selectedWallet.GetAssetType() {
-	case libUtil.BTCWalletAsset, libUtil.LTCWalletAsset:
-		pageContent = append(pageContent, pg.feeRateSelector.Layout)
+		pg.feeRateSelector.Layout,
 	}
 
 	// Add the bottom spacing section as the last.
@@ -322,21 +318,18 @@ func (pg *Page) coinSelectionSection(gtx layout.Context) D {
 	return pg.Theme.Card().Layout(gtx, func(gtx C) D {
 		inset := layout.UniformInset(values.MarginPadding15)
 		return inset.Layout(gtx, func(gtx C) D {
-			textLabel := pg.Theme.Label(values.TextSize16, values.String(values.StrCoinSelection))
-			return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
-				layout.Rigid(textLabel.Layout),
-				layout.Flexed(1, func(gtx C) D {
-					return layout.E.Layout(gtx, func(gtx C) D {
-						return cryptomaterial.LinearLayout{
-							Width:       cryptomaterial.WrapContent,
-							Height:      cryptomaterial.WrapContent,
-							Orientation: layout.Horizontal,
-							Alignment:   layout.Middle,
-							Clickable:   pg.toCoinSelection,
-						}.Layout(gtx,
-							layout.Rigid(pg.Theme.Label(values.TextSize16, selectedOption).Layout),
-							layout.Rigid(pg.Theme.Icons.ChevronRight.Layout24dp),
-						)
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return pg.coinSelectionRow(gtx, values.String(values.StrCoinSelection), selectedOption, pg.toCoinSelection)
+				}),
+				layout.Rigid(func(gtx C) D {
+					if _, ok := pg.selectedWallet.Asset.(coinSelector); !ok || selectedOption == manualCoinSelection {
+						return D{}
+					}
+
+					strategy := values.String(preference.GetKeyValue(pg.coinSelection.String(), preference.CoinSelectionOptions))
+					return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+						return pg.coinSelectionRow(gtx, values.String(values.StrCoinSelectionStrategy), strategy, pg.toCoinSelectionStrategy)
 					})
 				}),
 			)
@@ -344,6 +337,27 @@ func (pg *Page) coinSelectionSection(gtx layout.Context) D {
 	})
 }
 
+func (pg *Page) coinSelectionRow(gtx C, title, selectedOption string, clickable *cryptomaterial.Clickable) D {
+	textLabel := pg.Theme.Label(values.TextSize16, title)
+	return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+		layout.Rigid(textLabel.Layout),
+		layout.Flexed(1, func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return cryptomaterial.LinearLayout{
+					Width:       cryptomaterial.WrapContent,
+					Height:      cryptomaterial.WrapContent,
+					Orientation: layout.Horizontal,
+					Alignment:   layout.Middle,
+					Clickable:   clickable,
+				}.Layout(gtx,
+					layout.Rigid(pg.Theme.Label(values.TextSize16, selectedOption).Layout),
+					layout.Rigid(pg.Theme.Icons.ChevronRight.Layout24dp),
+				)
+			})
+		}),
+	)
+}
+
 func (pg *Page) txLabelSection(gtx layout.Context) D {
 	return pg.Theme.Card().Layout(gtx, func(gtx C) D {
 		topContainer := layout.UniformInset(values.MarginPadding15)
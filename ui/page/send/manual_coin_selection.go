@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 
 	"gioui.org/font"
 	"gioui.org/io/clipboard"
@@ -14,7 +15,9 @@ import (
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
 	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/preference"
 	"github.com/crypto-power/cryptopower/ui/values"
 )
 
@@ -51,10 +54,14 @@ type ManualCoinSelectionPage struct {
 
 	actionButton cryptomaterial.Button
 	clearButton  cryptomaterial.Button
+	freezeButton cryptomaterial.Button
+	tagButton    cryptomaterial.Button
+	originButton cryptomaterial.Button
 
 	selectedUTXOs cryptomaterial.Label
 	txSize        cryptomaterial.Label
 	totalAmount   cryptomaterial.Label
+	tagsWarning   cryptomaterial.Label
 
 	selectedUTXOrows []*sharedW.UnspentOutput
 	selectedAmount   float64
@@ -112,6 +119,9 @@ func NewManualCoinSelectionPage(l *load.Load, sendPage *Page) *ManualCoinSelecti
 
 		actionButton: l.Theme.Button(values.String(values.StrDone)),
 		clearButton:  l.Theme.OutlineButton("— " + values.String(values.StrClearSelection)),
+		freezeButton: l.Theme.OutlineButton(values.String(values.StrFreeze)),
+		tagButton:    l.Theme.OutlineButton(values.String(values.StrTag)),
+		originButton: l.Theme.OutlineButton(values.String(values.StrOrigin)),
 
 		listContainer: &widget.List{
 			List: layout.List{Axis: layout.Vertical},
@@ -129,10 +139,16 @@ func NewManualCoinSelectionPage(l *load.Load, sendPage *Page) *ManualCoinSelecti
 	pg.clearButton.Color = l.Theme.Color.Danger
 	pg.clearButton.Inset = layout.UniformInset(values.MarginPadding4)
 	pg.clearButton.HighlightColor = cryptomaterial.GenHighlightColor(l.Theme.Color.Danger)
+	for _, btn := range []*cryptomaterial.Button{&pg.freezeButton, &pg.tagButton, &pg.originButton} {
+		btn.Font.Weight = font.SemiBold
+		btn.Inset = layout.UniformInset(values.MarginPadding4)
+	}
 
 	pg.txSize = pg.Theme.Label(values.TextSize14, "--")
 	pg.totalAmount = pg.Theme.Label(values.TextSize14, "--")
 	pg.selectedUTXOs = pg.Theme.Label(values.TextSize14, "--")
+	pg.tagsWarning = pg.Theme.Label(values.TextSize14, "")
+	pg.tagsWarning.Color = pg.Theme.Color.Danger
 
 	pg.txSize.Font.Weight = font.SemiBold
 	pg.totalAmount.Font.Weight = font.SemiBold
@@ -191,6 +207,7 @@ func (pg *ManualCoinSelectionPage) initializeFields() {
 	pg.selectedUTXOs.Text = "0"
 	pg.txSize.Text = pg.computeUTXOsSize()
 	pg.totalAmount.Text = "0 " + pg.strAssetType
+	pg.updateSelectionActions()
 }
 
 // OnNavigatedTo is called when the page is about to be displayed and
@@ -276,6 +293,18 @@ func (pg *ManualCoinSelectionPage) HandleUserInteractions() {
 		pg.initializeFields()
 	}
 
+	if pg.freezeButton.Clicked() {
+		pg.toggleSelectionFrozen()
+	}
+
+	if pg.tagButton.Clicked() {
+		pg.showTagSelectionModal()
+	}
+
+	if pg.originButton.Clicked() {
+		pg.showOriginSelectionModal()
+	}
+
 	if pg.accountCollapsible.IsExpanded() {
 		for pos, component := range pg.clickables {
 			if component == nil || !component.Clicked() {
@@ -331,6 +360,100 @@ func (pg *ManualCoinSelectionPage) updateSummaryInfo() {
 	pg.txSize.Text = pg.computeUTXOsSize()
 	pg.selectedUTXOs.Text = fmt.Sprintf("%d", len(pg.selectedUTXOrows))
 	pg.totalAmount.Text = fmt.Sprintf("%f %s", pg.selectedAmount, pg.strAssetType)
+	pg.updateSelectionActions()
+}
+
+// updateSelectionActions refreshes the freeze and tag buttons and warns when
+// the selected outputs carry different tags or origins.
+func (pg *ManualCoinSelectionPage) updateSelectionActions() {
+	hasSelection := len(pg.selectedUTXOrows) > 0
+	pg.freezeButton.SetEnabled(hasSelection)
+	pg.tagButton.SetEnabled(hasSelection)
+	pg.originButton.SetEnabled(hasSelection)
+
+	pg.freezeButton.Text = values.String(values.StrFreeze)
+	if hasSelection && pg.selectionFrozen() {
+		pg.freezeButton.Text = values.String(values.StrUnfreeze)
+	}
+
+	pg.tagsWarning.Text = ""
+	if tags := sharedW.UTXOSelectionTags(pg.selectedUTXOrows); len(tags) > 1 {
+		pg.tagsWarning.Text = values.StringF(values.StrUTXOTagsMixedWarning, strings.Join(tags, ", "))
+	}
+}
+
+// selectionFrozen returns true if all the selected outputs are frozen.
+func (pg *ManualCoinSelectionPage) selectionFrozen() bool {
+	for _, utxo := range pg.selectedUTXOrows {
+		if !utxo.Frozen {
+			return false
+		}
+	}
+	return true
+}
+
+// toggleSelectionFrozen unfreezes the selected outputs if they are all frozen
+// otherwise it freezes all of them.
+func (pg *ManualCoinSelectionPage) toggleSelectionFrozen() {
+	frozen := !pg.selectionFrozen()
+	wallet := pg.WL.SelectedWallet.Wallet
+	for _, utxo := range pg.selectedUTXOrows {
+		if err := wallet.SetUTXOFrozen(utxo.TxID, utxo.Vout, frozen); err != nil {
+			pg.Toast.NotifyError(err.Error())
+			return
+		}
+		utxo.Frozen = frozen
+	}
+	pg.updateSelectionActions()
+}
+
+func (pg *ManualCoinSelectionPage) showTagSelectionModal() {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrTag)).
+		PositiveButtonStyle(pg.Load.Theme.Color.Primary, pg.Load.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(tag string, tm *modal.TextInputModal) bool {
+			wallet := pg.WL.SelectedWallet.Wallet
+			for _, utxo := range pg.selectedUTXOrows {
+				if err := wallet.SetUTXOTag(utxo.TxID, utxo.Vout, tag); err != nil {
+					tm.SetError(err.Error())
+					tm.SetLoading(false)
+					return false
+				}
+				utxo.Tag = strings.TrimSpace(tag)
+			}
+			pg.updateSelectionActions()
+			return true
+		})
+	textModal.Title(values.String(values.StrTagUTXOs)).
+		SetPositiveButtonText(values.String(values.StrSave))
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+func (pg *ManualCoinSelectionPage) showOriginSelectionModal() {
+	current := preference.UTXOOriginUnknownKey
+	if origin := pg.selectedUTXOrows[0].Origin; origin != sharedW.UTXOOriginUnknown {
+		current = string(origin)
+	}
+
+	originModal := preference.NewListPreference(pg.Load, "", current, preference.UTXOOriginOptions).
+		Title(values.StrSetUTXOOrigin).
+		UpdateValues(func(val string) {
+			origin := sharedW.UTXOOrigin(val)
+			if val == preference.UTXOOriginUnknownKey {
+				origin = sharedW.UTXOOriginUnknown
+			}
+
+			wallet := pg.WL.SelectedWallet.Wallet
+			for _, utxo := range pg.selectedUTXOrows {
+				if err := wallet.SetUTXOOrigin(utxo.TxID, utxo.Vout, origin); err != nil {
+					pg.Toast.NotifyError(err.Error())
+					return
+				}
+				utxo.Origin = origin
+			}
+			pg.updateSelectionActions()
+		})
+	pg.ParentWindow().ShowModal(originModal)
 }
 
 func (pg *ManualCoinSelectionPage) computeUTXOsSize() string {
@@ -460,6 +583,12 @@ func (pg *ManualCoinSelectionPage) summarySection(gtx C) D {
 							}),
 						)
 					}),
+					layout.Rigid(func(gtx C) D {
+						if pg.tagsWarning.Text == "" {
+							return D{}
+						}
+						return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.tagsWarning.Layout)
+					}),
 				)
 			})
 		})
@@ -478,7 +607,20 @@ func (pg *ManualCoinSelectionPage) accountListSection(gtx C) D {
 					return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
 						layout.Rigid(textLabel.Layout),
 						layout.Flexed(1, func(gtx C) D {
-							return layout.E.Layout(gtx, pg.clearButton.Layout)
+							return layout.E.Layout(gtx, func(gtx C) D {
+								return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+									layout.Rigid(func(gtx C) D {
+										return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, pg.tagButton.Layout)
+									}),
+									layout.Rigid(func(gtx C) D {
+										return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, pg.originButton.Layout)
+									}),
+									layout.Rigid(func(gtx C) D {
+										return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, pg.freezeButton.Layout)
+									}),
+									layout.Rigid(pg.clearButton.Layout),
+								)
+							})
 						}),
 					)
 				}),
@@ -561,7 +703,23 @@ func (pg *ManualCoinSelectionPage) accountListItemsSection(gtx C, utxos []*UTXOI
 							}
 
 							addressComponent := func(gtx C) D {
-								return v.addressCopy.Layout(gtx, addresslabel.label.Layout)
+								return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+									layout.Rigid(func(gtx C) D {
+										return v.addressCopy.Layout(gtx, addresslabel.label.Layout)
+									}),
+									layout.Rigid(func(gtx C) D {
+										info := v.Tag
+										if v.Frozen {
+											info = strings.TrimSpace(info + " " + values.String(values.StrFrozen))
+										}
+										if info == "" {
+											return D{}
+										}
+										lbl := pg.Theme.Label(values.TextSize12, info)
+										lbl.Color = pg.Theme.Color.GrayText2
+										return lbl.Layout(gtx)
+									}),
+								)
 							}
 							return pg.rowItemsSection(gtx, checkButton, amountLabel, nil, addressComponent,
 								nil, confirmationsLabel, nil, dateLabel)
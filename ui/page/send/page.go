@@ -16,6 +16,7 @@ import (
 	"github.com/crypto-power/cryptopower/ui/load"
 	"github.com/crypto-power/cryptopower/ui/modal"
 	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/preference"
 	"github.com/crypto-power/cryptopower/ui/utils"
 	"github.com/crypto-power/cryptopower/ui/values"
 )
@@ -71,9 +72,18 @@ type Page struct {
 	selectedWallet  *load.WalletMapping
 	feeRateSelector *components.FeeRateSelector
 
-	toCoinSelection *cryptomaterial.Clickable
+	toCoinSelection         *cryptomaterial.Clickable
+	toCoinSelectionStrategy *cryptomaterial.Clickable
 
 	selectedUTXOs selectedUTXOsInfo
+	coinSelection sharedW.CoinSelectionStrategy
+}
+
+// coinSelector is implemented by the assets that support choosing the coin
+// selection strategy of a transaction.
+type coinSelector interface {
+	SetCoinSelectionStrategy(strategy sharedW.CoinSelectionStrategy) error
+	CoinSelectionPreview() ([]*sharedW.CoinSelectionPreview, error)
 }
 
 type authoredTxData struct {
@@ -112,6 +122,7 @@ func NewSendPage(l *load.Load) *Page {
 	pg.selectedWallet = &load.WalletMapping{
 		Asset: l.WL.SelectedWallet.Wallet,
 	}
+	pg.coinSelection = pg.selectedWallet.DefaultCoinSelectionStrategy()
 
 	callbackFunc := func() libUtil.AssetType {
 		return pg.selectedWallet.GetAssetType()
@@ -248,7 +259,7 @@ func (pg *Page) OnNavigatedTo() {
 		pg.validateAndConstructTx()
 	}
 
-	if pg.selectedWallet.GetAssetType() == libUtil.BTCWalletAsset && pg.isFeerateAPIApproved() {
+	if pg.selectedWallet.GetAssetType() != libUtil.DCRWalletAsset && pg.isFeerateAPIApproved() {
 		// This API call may take sometime to return. Call this before and cache
 		// results.
 		go pg.selectedWallet.GetAPIFeeRate()
@@ -355,6 +366,13 @@ func (pg *Page) constructTx() {
 		return
 	}
 
+	if selector, ok := pg.selectedWallet.Asset.(coinSelector); ok {
+		if err = selector.SetCoinSelectionStrategy(pg.coinSelection); err != nil {
+			pg.amountValidationError(err.Error())
+			return
+		}
+	}
+
 	err = pg.selectedWallet.AddSendDestination(destinationAddress, amountAtom, SendMax)
 	if err != nil {
 		if strings.Contains(err.Error(), "amount") {
@@ -471,6 +489,8 @@ func (pg *Page) HandleUserInteractions() {
 
 	if pg.feeRateSelector.EditRates.Clicked() {
 		pg.feeRateSelector.OnEditRateClicked(pg.selectedWallet)
+		// Refresh the fee estimates using the new fee rate.
+		pg.validateAndConstructTx()
 	}
 
 	pg.nextButton.SetEnabled(pg.validate())
@@ -500,6 +520,10 @@ func (pg *Page) HandleUserInteractions() {
 		}
 	}
 
+	if pg.toCoinSelectionStrategy.Clicked() {
+		pg.showCoinSelectionStrategyModal()
+	}
+
 	if pg.nextButton.Clicked() {
 		if pg.selectedWallet.IsUnsignedTxExist() {
 			pg.confirmTxModal = newSendConfirmModal(pg.Load, pg.authoredTxData, *pg.selectedWallet)
@@ -581,3 +605,49 @@ func (pg *Page) OnNavigatedFrom() {
 func (pg *Page) isFeerateAPIApproved() bool {
 	return pg.WL.AssetsManager.IsHTTPAPIPrivacyModeOff(libUtil.FeeRateHTTPAPI)
 }
+
+// showCoinSelectionStrategyModal lists the coin selection strategies along with
+// the size and fee of the current tx when built with each of them.
+func (pg *Page) showCoinSelectionStrategyModal() {
+	selector, ok := pg.selectedWallet.Asset.(coinSelector)
+	if !ok {
+		return
+	}
+
+	options := make([]preference.ItemPreference, 0, len(preference.CoinSelectionOptions))
+	for _, option := range preference.CoinSelectionOptions {
+		options = append(options, preference.ItemPreference{
+			Key:   option.Key,
+			Value: values.String(option.Value),
+		})
+	}
+
+	if pg.selectedWallet.IsUnsignedTxExist() && pg.validate() {
+		previews, err := selector.CoinSelectionPreview()
+		if err != nil {
+			log.Error(err)
+		}
+		for _, p := range previews {
+			if p.Err != nil {
+				continue
+			}
+			for i := range options {
+				if options[i].Key == p.Strategy.String() {
+					options[i].Value = values.StringF(values.StrCoinSelectionPreview, options[i].Value,
+						p.Inputs, p.EstimatedSignedSize, pg.selectedWallet.ToAmount(p.Fee.UnitValue).String())
+				}
+			}
+		}
+	}
+
+	strategyModal := preference.NewListPreference(pg.Load, "", pg.coinSelection.String(), options).
+		Title(values.StrCoinSelectionStrategy).
+		IsWallet(true).
+		UpdateValues(func(val string) {
+			if strategy, ok := sharedW.ParseCoinSelectionStrategy(val); ok {
+				pg.coinSelection = strategy
+				pg.validateAndConstructTx()
+			}
+		})
+	pg.ParentWindow().ShowModal(strategyModal)
+}
@@ -0,0 +1,403 @@
+package settings
+
+import (
+	"fmt"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const EndpointsPageID = "Endpoints"
+
+type endpointItem struct {
+	endpoint libutils.Endpoint
+	health   *libutils.EndpointHealth
+
+	enabled *cryptomaterial.Switch
+	primary cryptomaterial.Button
+	pin     cryptomaterial.Button
+	remove  cryptomaterial.Button
+}
+
+type endpointsSection struct {
+	endpointType libutils.EndpointType
+	items        []*endpointItem
+	checking     bool
+
+	add   cryptomaterial.Button
+	check cryptomaterial.Button
+	reset cryptomaterial.Button
+}
+
+// EndpointsPage lets the user configure the hosts of the remote services,
+// the order they are failed over in, their certificate pins and whether
+// they may be contacted.
+type EndpointsPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	sections []*endpointsSection
+
+	scrollbarList *widget.List
+	backButton    cryptomaterial.IconButton
+}
+
+func NewEndpointsPage(l *load.Load) *EndpointsPage {
+	pg := &EndpointsPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(EndpointsPageID),
+		scrollbarList: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+	}
+
+	for _, endpointType := range libutils.EndpointTypes {
+		pg.sections = append(pg.sections, &endpointsSection{
+			endpointType: endpointType,
+			add:          l.Theme.OutlineButton(values.String(values.StrAddEndpoint)),
+			check:        l.Theme.OutlineButton(values.String(values.StrCheckEndpoints)),
+			reset:        l.Theme.OutlineButton(values.String(values.StrResetEndpoints)),
+		})
+	}
+
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *EndpointsPage) OnNavigatedTo() {
+	for _, section := range pg.sections {
+		pg.loadSection(section)
+	}
+}
+
+// loadSection reloads the saved endpoints of the section. The health of the
+// endpoints still listed is kept.
+func (pg *EndpointsPage) loadSection(section *endpointsSection) {
+	health := make(map[string]*libutils.EndpointHealth, len(section.items))
+	for _, item := range section.items {
+		health[item.endpoint.URL] = item.health
+	}
+
+	endpoints := pg.WL.AssetsManager.GetEndpoints(section.endpointType)
+	section.items = make([]*endpointItem, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		item := &endpointItem{
+			endpoint: endpoint,
+			health:   health[endpoint.URL],
+			enabled:  pg.Theme.Switch(),
+			primary:  pg.Theme.OutlineButton(values.String(values.StrMakePrimary)),
+			pin:      pg.Theme.OutlineButton(values.String(values.StrPinCertificate)),
+			remove:   pg.Theme.DangerButton(values.String(values.StrRemove)),
+		}
+		item.enabled.SetChecked(!endpoint.Disabled)
+		if endpoint.CertPin != "" {
+			item.pin.Text = values.String(values.StrUnpinCertificate)
+		}
+		section.items = append(section.items, item)
+	}
+}
+
+// saveSection saves the endpoints of the section and reloads it.
+func (pg *EndpointsPage) saveSection(section *endpointsSection, endpoints []libutils.Endpoint) error {
+	err := pg.WL.AssetsManager.SetEndpoints(section.endpointType, endpoints)
+	pg.loadSection(section)
+	return err
+}
+
+func (pg *EndpointsPage) sectionEndpoints(section *endpointsSection) []libutils.Endpoint {
+	endpoints := make([]libutils.Endpoint, len(section.items))
+	for i, item := range section.items {
+		endpoints[i] = item.endpoint
+	}
+	return endpoints
+}
+
+func (pg *EndpointsPage) checkSection(section *endpointsSection) {
+	section.checking = true
+	endpoints := pg.sectionEndpoints(section)
+	go func() {
+		results := pg.WL.AssetsManager.CheckEndpoints(section.endpointType, endpoints)
+		for i, item := range section.items {
+			if i < len(results) && item.endpoint.URL == results[i].URL {
+				item.health = results[i]
+			}
+		}
+		section.checking = false
+		pg.ParentWindow().Reload()
+	}()
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *EndpointsPage) Layout(gtx C) D {
+	container := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrServiceEndpoints),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: pg.layoutBody,
+		}
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, true, container)
+	}
+	return components.UniformPadding(gtx, container)
+}
+
+func (pg *EndpointsPage) layoutBody(gtx C) D {
+	return pg.Theme.List(pg.scrollbarList).Layout(gtx, len(pg.sections), func(gtx C, i int) D {
+		return layout.Inset{Right: values.MarginPadding2}.Layout(gtx, func(gtx C) D {
+			return pg.layoutSection(gtx, pg.sections[i])
+		})
+	})
+}
+
+func (pg *EndpointsPage) layoutSection(gtx C, section *endpointsSection) D {
+	card := pg.Theme.Card()
+	card.Color = pg.Theme.Color.Surface
+
+	return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+		return card.Layout(gtx, func(gtx C) D {
+			return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+				children := []layout.FlexChild{
+					layout.Rigid(func(gtx C) D {
+						txt := pg.Theme.Label(values.TextSize14, endpointTypeTitle(section.endpointType))
+						txt.Color = pg.Theme.Color.GrayText2
+						return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, txt.Layout)
+					}),
+				}
+				for i, item := range section.items {
+					i, item := i, item
+					if i > 0 {
+						line := pg.Theme.Separator()
+						line.Color = pg.Theme.Color.Gray2
+						children = append(children, layout.Rigid(line.Layout))
+					}
+					children = append(children, layout.Rigid(func(gtx C) D {
+						return pg.layoutEndpoint(gtx, item, i == 0)
+					}))
+				}
+				children = append(children, layout.Rigid(func(gtx C) D {
+					check := section.check.Layout
+					if section.checking {
+						check = pg.Theme.Body2(values.String(values.StrChecking)).Layout
+					}
+					return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+						return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+							layout.Rigid(section.add.Layout),
+							layout.Rigid(func(gtx C) D {
+								return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, check)
+							}),
+							layout.Rigid(func(gtx C) D {
+								return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, section.reset.Layout)
+							}),
+						)
+					})
+				}))
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+			})
+		})
+	})
+}
+
+func (pg *EndpointsPage) layoutEndpoint(gtx C, item *endpointItem, isPrimary bool) D {
+	pin := values.String(values.StrNotPinned)
+	if item.endpoint.CertPin != "" {
+		pin = values.StringF(values.StrPinnedKey, shortPin(item.endpoint.CertPin))
+	}
+	lines := []cryptomaterial.Label{pg.Theme.Caption(pin)}
+	lines[0].Color = pg.Theme.Color.GrayText2
+
+	if item.health != nil {
+		status := pg.Theme.Caption(values.StringF(values.StrEndpointOnline, item.health.Latency.Milliseconds()))
+		status.Color = pg.Theme.Color.Success
+		if !item.health.Healthy() {
+			status.Text = values.StringF(values.StrEndpointOffline, item.health.Error)
+			status.Color = pg.Theme.Color.Danger
+		}
+		lines = append(lines, status)
+	}
+
+	return layout.Inset{Top: values.MarginPadding8, Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+		left := func(gtx C) D {
+			children := []layout.FlexChild{layout.Rigid(pg.Theme.Body1(item.endpoint.URL).Layout)}
+			for _, line := range lines {
+				children = append(children, layout.Rigid(line.Layout))
+			}
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		}
+		right := func(gtx C) D {
+			buttons := []layout.Widget{item.enabled.Layout}
+			if !isPrimary {
+				buttons = append(buttons, item.primary.Layout)
+			}
+			buttons = append(buttons, item.pin.Layout, item.remove.Layout)
+
+			children := make([]layout.FlexChild, 0, len(buttons))
+			for _, button := range buttons {
+				button := button
+				children = append(children, layout.Rigid(func(gtx C) D {
+					return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, button)
+				}))
+			}
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx, children...)
+		}
+		return components.EndToEndRow(gtx, left, right)
+	})
+}
+
+func endpointTypeTitle(endpointType libutils.EndpointType) string {
+	switch endpointType {
+	case libutils.PoliteiaEndpoint:
+		return values.String(values.StrPoliteiaServers)
+	case libutils.DcrdataEndpoint:
+		return values.String(values.StrDcrdataServers)
+	case libutils.BlockbookEndpoint:
+		return values.String(values.StrBlockbookServers)
+	case libutils.VSPListEndpoint:
+		return values.String(values.StrVSPListSources)
+	}
+	return string(endpointType)
+}
+
+// shortPin returns the start and the end of the certificate pin.
+func shortPin(pin string) string {
+	if len(pin) <= 16 {
+		return pin
+	}
+	return fmt.Sprintf("%s…%s", pin[:8], pin[len(pin)-8:])
+}
+
+func (pg *EndpointsPage) showAddEndpointModal(section *endpointsSection) {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrEndpointURL)).
+		PositiveButtonStyle(pg.Load.Theme.Color.Primary, pg.Load.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(url string, tim *modal.TextInputModal) bool {
+			endpoints := append(pg.sectionEndpoints(section), libutils.Endpoint{URL: url})
+			if err := pg.saveSection(section, endpoints); err != nil {
+				tim.SetError(err.Error())
+				tim.SetLoading(false)
+				return false
+			}
+			return true
+		})
+	textModal.Title(values.String(values.StrAddEndpoint)).
+		SetPositiveButtonText(values.String(values.StrSave)).
+		SetNegativeButtonText(values.String(values.StrCancel))
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+// showPinModal asks for the certificate pin of the endpoint, the pin of the
+// certificate presented during the last health check is suggested.
+func (pg *EndpointsPage) showPinModal(section *endpointsSection, index int) {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrCertificatePin)).
+		PositiveButtonStyle(pg.Load.Theme.Color.Primary, pg.Load.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(pin string, tim *modal.TextInputModal) bool {
+			endpoints := pg.sectionEndpoints(section)
+			endpoints[index].CertPin = pin
+			if err := pg.saveSection(section, endpoints); err != nil {
+				tim.SetError(err.Error())
+				tim.SetLoading(false)
+				return false
+			}
+			return true
+		})
+	if health := section.items[index].health; health != nil && health.CertPin != "" {
+		textModal.SetText(health.CertPin)
+	}
+	textModal.Title(values.String(values.StrPinCertificate)).
+		SetPositiveButtonText(values.String(values.StrSave)).
+		SetNegativeButtonText(values.String(values.StrCancel))
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+func (pg *EndpointsPage) showError(err error) {
+	errorModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
+	pg.ParentWindow().ShowModal(errorModal)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *EndpointsPage) HandleUserInteractions() {
+	for _, section := range pg.sections {
+		pg.handleSection(section)
+	}
+}
+
+func (pg *EndpointsPage) handleSection(section *endpointsSection) {
+	if section.add.Clicked() {
+		pg.showAddEndpointModal(section)
+	}
+	if section.check.Clicked() && !section.checking {
+		pg.checkSection(section)
+	}
+	if section.reset.Clicked() {
+		if err := pg.saveSection(section, nil); err != nil {
+			pg.showError(err)
+		}
+		return
+	}
+
+	for i, item := range section.items {
+		endpoints := pg.sectionEndpoints(section)
+		switch {
+		case item.enabled.Changed():
+			endpoints[i].Disabled = !item.enabled.IsChecked()
+		case item.primary.Clicked():
+			primary := endpoints[i]
+			copy(endpoints[1:i+1], endpoints[:i])
+			endpoints[0] = primary
+		case item.pin.Clicked():
+			if item.endpoint.CertPin == "" {
+				pg.showPinModal(section, i)
+				return
+			}
+			endpoints[i].CertPin = ""
+		case item.remove.Clicked():
+			endpoints = append(endpoints[:i], endpoints[i+1:]...)
+		default:
+			continue
+		}
+
+		if err := pg.saveSection(section, endpoints); err != nil {
+			pg.showError(err)
+		}
+		return
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *EndpointsPage) OnNavigatedFrom() {}
@@ -0,0 +1,315 @@
+package settings
+
+import (
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const (
+	PeersPageID = "Peers"
+
+	// peersRefreshInterval is the interval at which the connected peers are
+	// reloaded while the page is displayed.
+	peersRefreshInterval = 5 * time.Second
+)
+
+type peerItem struct {
+	info       sharedW.PeerInfo
+	disconnect cryptomaterial.Button
+	ban        cryptomaterial.Button
+}
+
+type bannedPeerItem struct {
+	peer  *sharedW.BannedPeer
+	unban cryptomaterial.Button
+}
+
+// PeersPage lists the peers of the selected wallet and lets the user connect
+// to, disconnect, ban and unban peers.
+type PeersPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet sharedW.Asset
+
+	peers       []*peerItem
+	bannedPeers []*bannedPeerItem
+	lastRefresh time.Time
+
+	scrollbarList *widget.List
+	connectPeer   cryptomaterial.Button
+	backButton    cryptomaterial.IconButton
+}
+
+func NewPeersPage(l *load.Load) *PeersPage {
+	pg := &PeersPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(PeersPageID),
+		wallet:           l.WL.SelectedWallet.Wallet,
+		scrollbarList: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		connectPeer: l.Theme.OutlineButton(values.String(values.StrConnectToSpecificPeer)),
+	}
+
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *PeersPage) OnNavigatedTo() {
+	pg.refresh()
+}
+
+// refresh reloads the connected and the banned peers. The buttons of the
+// peers still listed are kept so that pending clicks aren't lost.
+func (pg *PeersPage) refresh() {
+	pg.lastRefresh = time.Now()
+
+	infos, err := pg.wallet.PeerInfoRaw()
+	if err != nil {
+		log.Debugf("Error getting peers: %v", err)
+	}
+
+	existing := make(map[string]*peerItem, len(pg.peers))
+	for _, item := range pg.peers {
+		existing[item.info.Addr] = item
+	}
+	peers := make([]*peerItem, 0, len(infos))
+	for _, info := range infos {
+		item, ok := existing[info.Addr]
+		if !ok {
+			item = &peerItem{
+				disconnect: pg.Theme.OutlineButton(values.String(values.StrDisconnect)),
+				ban:        pg.Theme.DangerButton(values.String(values.StrBan)),
+			}
+		}
+		item.info = info
+		peers = append(peers, item)
+	}
+	pg.peers = peers
+
+	bannedPeers := pg.wallet.BannedPeers()
+	pg.bannedPeers = make([]*bannedPeerItem, 0, len(bannedPeers))
+	for _, peer := range bannedPeers {
+		pg.bannedPeers = append(pg.bannedPeers, &bannedPeerItem{
+			peer:  peer,
+			unban: pg.Theme.OutlineButton(values.String(values.StrUnban)),
+		})
+	}
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *PeersPage) Layout(gtx C) D {
+	container := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrManagePeers),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: pg.layoutBody,
+		}
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+
+	// Refresh frames every 1 second
+	op.InvalidateOp{At: time.Now().Add(time.Second * 1)}.Add(gtx.Ops)
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, true, container)
+	}
+	return components.UniformPadding(gtx, container)
+}
+
+func (pg *PeersPage) layoutBody(gtx C) D {
+	sections := []layout.Widget{
+		func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, pg.connectPeer.Layout)
+		},
+		pg.connectedPeersSection,
+		pg.bannedPeersSection,
+	}
+
+	return pg.Theme.List(pg.scrollbarList).Layout(gtx, len(sections), func(gtx C, i int) D {
+		return layout.Inset{Right: values.MarginPadding2}.Layout(gtx, sections[i])
+	})
+}
+
+func (pg *PeersPage) connectedPeersSection(gtx C) D {
+	rows := make([]layout.Widget, 0, len(pg.peers))
+	for _, item := range pg.peers {
+		item := item
+		rows = append(rows, func(gtx C) D {
+			info := item.info
+			details := values.StringF(values.StrPeerDetails, info.SubVer, info.Services, info.StartingHeight)
+			traffic := values.StringF(values.StrPeerTraffic, info.PingTime,
+				float64(info.BytesSent)/1e3, float64(info.BytesReceived)/1e3, info.BanScore)
+
+			return pg.peerRow(gtx, info.Addr, []string{details, traffic}, item.disconnect.Layout, item.ban.Layout)
+		})
+	}
+	return pg.section(gtx, values.String(values.StrConnectedPeers), values.String(values.StrNoConnectedPeers), rows)
+}
+
+func (pg *PeersPage) bannedPeersSection(gtx C) D {
+	rows := make([]layout.Widget, 0, len(pg.bannedPeers))
+	for _, item := range pg.bannedPeers {
+		item := item
+		rows = append(rows, func(gtx C) D {
+			bannedAt := time.Unix(item.peer.BannedAt, 0).Format("2006-01-02 15:04")
+			since := values.StringF(values.StrBannedSince, bannedAt)
+			return pg.peerRow(gtx, item.peer.Host, []string{since}, item.unban.Layout)
+		})
+	}
+	return pg.section(gtx, values.String(values.StrBannedPeers), values.String(values.StrNoBannedPeers), rows)
+}
+
+func (pg *PeersPage) section(gtx C, title, emptyText string, rows []layout.Widget) D {
+	card := pg.Theme.Card()
+	card.Color = pg.Theme.Color.Surface
+
+	return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+		return card.Layout(gtx, func(gtx C) D {
+			return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+				children := []layout.FlexChild{
+					layout.Rigid(func(gtx C) D {
+						txt := pg.Theme.Label(values.TextSize14, title)
+						txt.Color = pg.Theme.Color.GrayText2
+						return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, txt.Layout)
+					}),
+				}
+				if len(rows) == 0 {
+					children = append(children, layout.Rigid(pg.Theme.Body2(emptyText).Layout))
+				}
+				for i, row := range rows {
+					if i > 0 {
+						line := pg.Theme.Separator()
+						line.Color = pg.Theme.Color.Gray2
+						children = append(children, layout.Rigid(line.Layout))
+					}
+					children = append(children, layout.Rigid(row))
+				}
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+			})
+		})
+	})
+}
+
+func (pg *PeersPage) peerRow(gtx C, addr string, lines []string, buttons ...layout.Widget) D {
+	return layout.Inset{Top: values.MarginPadding8, Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+		left := func(gtx C) D {
+			children := []layout.FlexChild{layout.Rigid(pg.Theme.Body1(addr).Layout)}
+			for _, line := range lines {
+				lbl := pg.Theme.Caption(line)
+				lbl.Color = pg.Theme.Color.GrayText2
+				children = append(children, layout.Rigid(lbl.Layout))
+			}
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		}
+		right := func(gtx C) D {
+			children := make([]layout.FlexChild, 0, len(buttons))
+			for _, button := range buttons {
+				button := button
+				children = append(children, layout.Rigid(func(gtx C) D {
+					return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, button)
+				}))
+			}
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx, children...)
+		}
+		return components.EndToEndRow(gtx, left, right)
+	})
+}
+
+func (pg *PeersPage) showConnectPeerModal() {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrIPAddress)).
+		PositiveButtonStyle(pg.Load.Theme.Color.Primary, pg.Load.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(addr string, tim *modal.TextInputModal) bool {
+			if err := pg.wallet.ConnectPeer(addr); err != nil {
+				tim.SetError(err.Error())
+				tim.SetLoading(false)
+				return false
+			}
+			return true
+		})
+	textModal.Title(values.String(values.StrConnectToSpecificPeer)).
+		SetPositiveButtonText(values.String(values.StrConfirm)).
+		SetNegativeButtonText(values.String(values.StrCancel))
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+func (pg *PeersPage) showError(err error) {
+	errorModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
+	pg.ParentWindow().ShowModal(errorModal)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *PeersPage) HandleUserInteractions() {
+	if pg.connectPeer.Clicked() {
+		pg.showConnectPeerModal()
+	}
+
+	changed := false
+	for _, item := range pg.peers {
+		if item.disconnect.Clicked() {
+			if err := pg.wallet.DisconnectPeer(item.info.Addr); err != nil {
+				pg.showError(err)
+			}
+			changed = true
+		}
+		if item.ban.Clicked() {
+			if err := pg.wallet.BanPeer(item.info.Addr); err != nil {
+				pg.showError(err)
+			}
+			changed = true
+		}
+	}
+
+	for _, item := range pg.bannedPeers {
+		if item.unban.Clicked() {
+			if err := pg.wallet.UnbanPeer(item.peer.Host); err != nil {
+				pg.showError(err)
+			}
+			changed = true
+		}
+	}
+
+	if changed || time.Since(pg.lastRefresh) > peersRefreshInterval {
+		pg.refresh()
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *PeersPage) OnNavigatedFrom() {}
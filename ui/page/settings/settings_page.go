@@ -1,6 +1,10 @@
 package settings
 
 import (
+	"sort"
+	"strconv"
+	"strings"
+
 	"gioui.org/layout"
 	"gioui.org/widget"
 
@@ -55,6 +59,8 @@ type SettingPage struct {
 	networkInfoButton       cryptomaterial.IconButton
 	logLevel                *cryptomaterial.Clickable
 	viewLog                 *cryptomaterial.Clickable
+	voteReminders           *cryptomaterial.Clickable
+	endpoints               *cryptomaterial.Clickable
 
 	governanceAPI *cryptomaterial.Switch
 	exchangeAPI   *cryptomaterial.Switch
@@ -91,6 +97,8 @@ func NewSettingsPage(l *load.Load) *SettingPage {
 		appearanceMode:    l.Theme.NewClickable(false),
 		logLevel:          l.Theme.NewClickable(false),
 		viewLog:           l.Theme.NewClickable(false),
+		voteReminders:     l.Theme.NewClickable(false),
+		endpoints:         l.Theme.NewClickable(false),
 	}
 
 	_, pg.networkInfoButton = components.SubpageHeaderButtons(l)
@@ -250,6 +258,18 @@ func (pg *SettingPage) general() layout.Widget {
 				layout.Rigid(func(gtx C) D {
 					return pg.subSectionSwitch(gtx, values.String(values.StrTxNotification), pg.transactionNotification)
 				}),
+				layout.Rigid(func(gtx C) D {
+					label := values.String(values.StrOff)
+					if hours := pg.WL.AssetsManager.GetVoteReminderIntervals(); len(hours) > 0 {
+						label = formatReminderHours(hours)
+					}
+					voteRemindersRow := row{
+						title:     values.String(values.StrVoteReminders),
+						clickable: pg.voteReminders,
+						label:     pg.Theme.Body2(label),
+					}
+					return pg.clickableRow(gtx, voteRemindersRow)
+				}),
 			)
 		})
 	}
@@ -272,6 +292,14 @@ func (pg *SettingPage) networkSettings() layout.Widget {
 					}
 					return pg.clickableRow(gtx, exchangeRate)
 				}),
+				layout.Rigid(func(gtx C) D {
+					endpointsRow := row{
+						title:     values.String(values.StrServiceEndpoints),
+						clickable: pg.endpoints,
+						label:     pg.Theme.Body2(""),
+					}
+					return pg.clickableRow(gtx, endpointsRow)
+				}),
 				layout.Rigid(func(gtx C) D {
 					return pg.subSectionSwitch(gtx, values.String(values.StrGovernanceAPI), pg.governanceAPI)
 				}),
@@ -433,6 +461,14 @@ func (pg *SettingPage) HandleUserInteractions() {
 		pg.RefreshTheme(pg.ParentWindow())
 	}
 
+	if pg.voteReminders.Clicked() {
+		pg.showVoteRemindersModal()
+	}
+
+	if pg.endpoints.Clicked() {
+		pg.ParentNavigator().Display(NewEndpointsPage(pg.Load))
+	}
+
 	if pg.transactionNotification.Changed() {
 		pg.WL.AssetsManager.SetTransactionsNotifications(pg.transactionNotification.IsChecked())
 	}
@@ -599,6 +635,46 @@ func (pg *SettingPage) HandleUserInteractions() {
 	}
 }
 
+func (pg *SettingPage) showVoteRemindersModal() {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrVoteReminderHours)).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(text string, tim *modal.TextInputModal) bool {
+			var hours []int
+			for _, field := range strings.Split(text, ",") {
+				if field = strings.TrimSpace(field); field == "" {
+					continue
+				}
+				h, err := strconv.Atoi(field)
+				if err != nil || h <= 0 {
+					tim.SetError(values.String(values.StrInvalidReminderHours))
+					tim.SetLoading(false)
+					return false
+				}
+				hours = append(hours, h)
+			}
+
+			sort.Sort(sort.Reverse(sort.IntSlice(hours)))
+			pg.WL.AssetsManager.SetVoteReminderIntervals(hours)
+			return true
+		})
+	textModal.SetText(formatReminderHours(pg.WL.AssetsManager.GetVoteReminderIntervals())).
+		Title(values.String(values.StrVoteReminders)).
+		SetPositiveButtonText(values.String(values.StrSave)).
+		SetNegativeButtonText(values.String(values.StrCancel))
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+// formatReminderHours formats the vote reminder intervals as a comma
+// separated list of hours.
+func formatReminderHours(hours []int) string {
+	fields := make([]string, len(hours))
+	for i, h := range hours {
+		fields[i] = strconv.Itoa(h)
+	}
+	return strings.Join(fields, ", ")
+}
+
 func (pg *SettingPage) showNoticeSuccess(title string) {
 	info := modal.NewSuccessModal(pg.Load, title, modal.DefaultClickFunc())
 	pg.ParentWindow().ShowModal(info)
@@ -37,6 +37,8 @@ type StatPage struct {
 	scrollbarList *widget.List
 	startupTime   string
 	netType       string
+	dataUsage     []sharedW.DataUsage
+	dbHealth      *sharedW.DBHealth
 
 	backButton cryptomaterial.IconButton
 }
@@ -90,6 +92,14 @@ func (pg *StatPage) OnNavigatedTo() {
 	}
 
 	pg.appStartTime()
+	pg.dataUsage = pg.WL.SelectedWallet.Wallet.DataUsageHistory()
+
+	dbHealth, err := pg.WL.SelectedWallet.Wallet.DBHealth()
+	if err != nil {
+		log.Errorf("Error getting wallet database health: %s", err.Error())
+	} else {
+		pg.dbHealth = dbHealth
+	}
 }
 
 func (pg *StatPage) layoutStats(gtx C) D {
@@ -144,6 +154,49 @@ func (pg *StatPage) layoutStats(gtx C) D {
 		item(values.String(values.StrAccount)+"s", fmt.Sprintf("%d", len(pg.accounts.Accounts))),
 	}
 
+	dataUsage := func(usage sharedW.DataUsage) string {
+		return values.StringF(values.StrDataSentReceived, components.FormatDataSize(usage.Sent),
+			components.FormatDataSize(usage.Received))
+	}
+	today := time.Now().Format("2006-01-02")
+	todayUsage := sharedW.DataUsage{Date: today}
+	for _, usage := range pg.dataUsage {
+		if usage.Date == today {
+			todayUsage = usage
+		}
+	}
+	items = append(items, line.Layout, item(values.String(values.StrDataUsageToday), dataUsage(todayUsage)))
+	for _, usage := range pg.dataUsage {
+		if usage.Date != today {
+			items = append(items, line.Layout, item(values.StringF(values.StrDataUsageOn, usage.Date), dataUsage(usage)))
+		}
+	}
+
+	if health := pg.dbHealth; health != nil {
+		items = append(items,
+			line.Layout,
+			item(values.String(values.StrDatabaseBackend), health.Driver),
+			line.Layout,
+			item(values.String(values.StrDatabaseSize), values.StringF(values.StrDatabaseSizeValue,
+				components.FormatDataSize(health.Size), components.FormatDataSize(health.DataSize))),
+		)
+		for _, bucket := range health.Buckets {
+			items = append(items, line.Layout, item(values.StringF(values.StrDatabaseBucket, bucket.Name),
+				values.StringF(values.StrDatabaseBucketValue, bucket.Keys, bucket.NestedBuckets,
+					components.FormatDataSize(bucket.DataSize))))
+		}
+		items = append(items, line.Layout, item(values.String(values.StrTxIndexSize),
+			components.FormatDataSize(health.WalletDataSize)))
+		if health.Details != "" {
+			items = append(items, line.Layout, item(values.String(values.StrDatabaseDriverStats), health.Details))
+		}
+		compaction := values.String(values.StrCompactionNotNeeded)
+		if health.CompactionRecommended() {
+			compaction = values.StringF(values.StrCompactionRecommended, components.FormatDataSize(health.Reclaimable()))
+		}
+		items = append(items, line.Layout, item(values.String(values.StrCompaction), compaction))
+	}
+
 	return pg.Theme.List(pg.scrollbarList).Layout(gtx, 1, func(gtx C, i int) D {
 		return layout.Inset{Right: values.MarginPadding2}.Layout(gtx, func(gtx C) D {
 			return card.Layout(gtx, func(gtx C) D {
@@ -222,6 +275,7 @@ func (pg *StatPage) appStartTime() {
 // Part of the load.Page interface.
 func (pg *StatPage) HandleUserInteractions() {
 	pg.appStartTime()
+	pg.dataUsage = pg.WL.SelectedWallet.Wallet.DataUsageHistory()
 }
 
 // OnNavigatedFrom is called when the page is about to be removed from
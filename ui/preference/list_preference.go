@@ -18,6 +18,9 @@ type (
 	D = layout.Dimensions
 )
 
+// UTXOOriginUnknownKey is the UTXOOriginOptions key of the unknown origin.
+const UTXOOriginUnknownKey = "unknown"
+
 var (
 	// ExchOptions holds the configurable options for exchange servers.
 	ExchOptions = []ItemPreference{
@@ -33,6 +36,33 @@ var (
 		{Key: localizable.SPANISH, Value: values.StrSpanish},
 	}
 
+	// CoinSelectionOptions are the selectable btc and ltc coin selection
+	// strategies.
+	CoinSelectionOptions = []ItemPreference{
+		{Key: sharedW.CoinSelectionLargestFirst.String(), Value: values.StrLargestFirst},
+		{Key: sharedW.CoinSelectionBranchAndBound.String(), Value: values.StrBranchAndBound},
+		{Key: sharedW.CoinSelectionOldestFirst.String(), Value: values.StrOldestFirst},
+		{Key: sharedW.CoinSelectionPrivacy.String(), Value: values.StrPrivacyStrategy},
+		{Key: sharedW.CoinSelectionConsolidation.String(), Value: values.StrConsolidation},
+	}
+
+	// UTXOOriginOptions are the origins that can be assigned to unspent
+	// outputs. The unknown origin is keyed by UTXOOriginUnknownKey.
+	UTXOOriginOptions = []ItemPreference{
+		{Key: UTXOOriginUnknownKey, Value: values.StrOriginUnknown},
+		{Key: string(sharedW.UTXOOriginMixed), Value: values.StrOriginMixed},
+		{Key: string(sharedW.UTXOOriginExchange), Value: values.StrOriginExchange},
+		{Key: string(sharedW.UTXOOriginChange), Value: values.StrOriginChange},
+	}
+
+	// FeeEstimatorOptions are the selectable btc and ltc fee estimate sources.
+	FeeEstimatorOptions = []ItemPreference{
+		{Key: sharedW.FeeEstimatorBlockstream, Value: values.StrBlockstream},
+		{Key: sharedW.FeeEstimatorMempoolSpace, Value: values.StrMempoolSpace},
+		{Key: sharedW.FeeEstimatorCustom, Value: values.StrCustomEndpoint},
+		{Key: sharedW.FeeEstimatorLocal, Value: values.StrLocalFeeEstimator},
+	}
+
 	// LogOptions are the selectable debug levels.
 	LogOptions = []ItemPreference{
 		{Key: libutils.LogLevelTrace, Value: values.StrLogLevelTrace},
@@ -42,6 +72,12 @@ var (
 		{Key: libutils.LogLevelError, Value: values.StrLogLevelError},
 		{Key: libutils.LogLevelCritical, Value: values.StrLogLevelCritical},
 	}
+
+	// DBDriverOptions are the selectable dcr wallet database drivers.
+	DBDriverOptions = []ItemPreference{
+		{Key: sharedW.DBDriverBdb, Value: values.StrBoltDB},
+		{Key: sharedW.DBDriverBadger, Value: values.StrBadgerDB},
+	}
 )
 
 type ListPreferenceModal struct {
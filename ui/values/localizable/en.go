@@ -18,8 +18,11 @@ const EN = `
 "acctName" = "Account name"
 "acctNum" = "Account Number"
 "acctRenamed" = "Account renamed"
+"add" = "Add"
 "addAcctWarn" = "%v Accounts %v cannot %v be deleted once created.%v"
 "addDexServer" = "Add dex server"
+"addedSpent" = "Added %s · Spent %s"
+"addEndpoint" = "Add endpoint"
 "addNewAccount" = "Add account"
 "address" = "Address"
 "addressCopied" = "Address copied"
@@ -27,9 +30,11 @@ const EN = `
 "addressDiscoveryStarted" = "Address discovery started successfully"
 "addressDiscoveryStartedBody"    = "See wallet information page for progress"
 "addrNotOwned" = "Address not owned by any wallet"
+"addTSpend" = "Add treasury spend"
 "addVSP" = "Add a new VSP..."
 "addWallet" = "Add wallet"
 "adminToTriggerVoting" = "Waiting for admin to trigger the start of voting"
+"agendaHistory" = "Agenda history"
 "agendas" = "Agendas"
 "ago" = "ago"
 "all" = "All"
@@ -37,12 +42,17 @@ const EN = `
 "allowUnspendUnmixedAcct" = "%v Spendings from unmixed accounts could potentially be traced back to you %v Please type %v I am aware of the risks %v to allow spending from unmixed accounts.%v"
 "allTickets" = "All tickets"
 "amount" = "Amount"
+"andMore" = "and %d more"
+"any" = "Any"
 "appLog" = "Application log"
+"apply" = "Apply"
 "appName" = "Cryptopower"
 "approved" = "Approved"
+"approveUnderBudget" = "Approve under budget"
 "appTitle" = "Cryptopower (%s)"
 "appWallet" = "Cryptopower Wallet"
 "askedEnterSeedWords" = "You will be asked to enter the seed phrase on the next screen."
+"author" = "Author"
 "authorToAuthorizeVoting" = "Waiting for author to authorize voting"
 "automatic" = "Automatic"
 "autoSetUp" = "Auto Setup"
@@ -50,34 +60,56 @@ const EN = `
 "autoTicketInfo" = "Cryptopower must remain running, for tickets to be automatically purchased"
 "autoTicketPurchase" = "Auto ticket purchase"
 "autoTicketWarn" = "Settings can not be modified when ticket buyer is running."
+"autoVote" = "Auto-vote"
+"autoVoteHistory" = "Auto-vote history"
+"autoVoteInfo" = "Votes are cast automatically with this wallet's tickets while proposals are being voted on. The first rule that applies decides the vote. Votes decided while the wallet is locked wait for you to unlock it."
+"autoVotePreview" = "%s on %d tickets: %s"
+"autoVoteRules" = "Rules"
 "backAndRename" = "Go back & rename"
 "backStaking" = "Back to staking"
 "backToWallets" = "Back to Wallets"
+"backupDatabase" = "Back up database"
 "backupInfo" = "%v No backup - no coins! %v In order not to lose your coins when your device is lost or broken, please make a wallet backup %v Now %v and keep it in %v a safe place! %v"
 "backupLater" = "Backup later"
 "backupNow" = "Backup now"
 "backupSeedPhrase" = "Back up seed phrase"
 "backupWarning" = "Wallet backup needed"
+"badgerDB" = "Badger DB"
 "balance" = "Balance:"
 "balanceAfter" = "Balance after send"
 "balanceToMaintain" = "Balance to maintain (%s)"
 "balToMaintain" = "Balance to maintain (DCR)"
 "balToMaintainValue" = "Balance to maintain: %2.f"
+"ban" = "Ban"
+"bandwidthLimit" = "Bandwidth limit (KB/s)"
+"bannedPeers" = "Banned peers"
+"bannedSince" = "Banned since %s"
 "beepForNewBlocks" = "Beep for new blocks"
 "bestBlockAge" = "Best block age"
 "bestBlocks" = "Best block"
 "bestBlockTimestamp" = "Best block timestamp"
 "binanceRate" = "Binance rate: %f"
+"blockbookServers" = "Blockbook servers"
 "blockHeaderFetched" = "Block header fetched"
 "blockHeaderFetchedCount" = "%d of %d"
 "blocksLeft" = "%d blocks left"
+"blocksPerSecond" = "%.1f blocks/s"
 "blocksScanned" = "Blocks scanned"
+"blockstream" = "Blockstream"
+"boltDB" = "Bolt DB"
+"bookmark" = "Bookmark"
+"bookmarked" = "Bookmarked"
+"branchAndBound" = "Branch and bound (no change)"
+"budgetRule" = "Vote %s when the budget is at most $%s"
 "build" = "Build"
 "buildDate" = "Build date"
 "canBuy" = "Can Buy"
 "cancel" = "Cancel"
 "canceling" = "Cancelling..."
 "cancelMixer" = "Cancel mixer?"
+"certFilePath" = "Certificate file (optional)"
+"certFingerprint" = "Certificate SHA-256 fingerprint (optional)"
+"certificatePin" = "SHA-256 of the certificate public key (hex)"
 "change" = "Change"
 "changeAccount" = "Change account"
 "changeSpecificPeer" = "Change specific peer"
@@ -85,16 +117,31 @@ const EN = `
 "changeStartupPassword" = "Change startup password"
 "changeUserAgent" = "Change user agent"
 "changeWalletName" = "Change wallet name"
+"checkEndpoints" = "Check health"
 "checkGovernace" = "Check Governance page"
+"checking" = "Checking..."
 "checkMixerStatus" = "Check mixer status"
 "checkStatistics" = "Check statistics"
+"checkVSPChoices" = "Check VSP choices"
 "checkWalletLog" = "Check wallet logs"
+"choice" = "Choice"
 "clear" = "Clear"
 "clearAll" = "Clear all"
 "clearSelection" = "Clear Selection"
 "closingWallet" = "Shutting down Wallets..."
 "coinSelection" = "Coin Selection"
+"coinSelectionPreview" = "%s: %d inputs, %d bytes, fee %s"
+"coinSelectionStrategy" = "Selection Strategy"
 "colon" = ": "
+"commentCensored" = "This comment was censored"
+"comments" = "Comments"
+"compact" = "Compact"
+"compactDatabase" = "Compact database"
+"compactDatabaseInfo" = "The wallet is closed while its database is rewritten to free unused space. Stop syncing the wallet first."
+"compaction" = "Compaction"
+"compactionNotNeeded" = "Not needed"
+"compactionRecommended" = "Recommended, %s can be freed"
+"compareWithPrevious" = "Changes from version %s to %s"
 "complete" = "Completed"
 "confirm" = "Confirm"
 "confirmations" = "Confirmations"
@@ -120,6 +167,7 @@ const EN = `
 "confirmVote" = "Confirm your vote"
 "confirmYourOrder" = "Confirm your order"
 "confStatus" = "Confirmation Status"
+"connectedPeers" = "Connected peers"
 "connectedPeersCount" = "Connected peers count"
 "connectedTo" = "connected to %v peers"
 "connecting" = "Connecting..."
@@ -127,6 +175,7 @@ const EN = `
 "connectToSpecificPeer" = "Connect to specific peer"
 "consensusChange" = "Consensus Changes"
 "consensusDashboard" = "Consensus Vote Dashboard"
+"consolidation" = "Consolidation (low fee rates)"
 "continue" = "Continue"
 "coordinationServer" = "Coordination server"
 "copied" = "Copied!"
@@ -144,12 +193,26 @@ const EN = `
 "createOrderPageInfo" = "To change the default source and destination wallet/account used for exchange, click the settings icon."
 "createStartupPassword" = "Create a startup password"
 "createWallet" = "Create wallet"
+"csppServerInfo" = "The certificate file is trusted in addition to the default certificate authorities. If a fingerprint is set, the server certificate must match it."
 "currentSpendingPassword" = "Current spending passphrase"
 "currentStartupPass" = "Current startup password"
 "currentTotalBalance" = "Current Total Balance"
+"customEndpoint" = "Custom endpoint"
 "CustomUserAgent" = "Custom user agent"
+"dailyDataLimit" = "Daily data limit (MB)"
 "dangerZone" = "Danger zone"
 "darkMode" = "Dark mode"
+"databaseBackedUp" = "Database backed up to %s"
+"databaseBackend" = "Database backend"
+"databaseBucket" = "Bucket %s"
+"databaseBucketValue" = "%d keys, %d buckets, %s"
+"databaseDriverStats" = "Driver stats"
+"databaseSize" = "Database size"
+"databaseSizeValue" = "%s on disk, %s of data"
+"databaseUpdated" = "Wallet database updated"
+"dataSentReceived" = "%s sent, %s received"
+"dataUsageOn" = "Data usage on %s"
+"dataUsageToday" = "Data usage today"
 "dateCreated" = "Date Created"
 "dateSize" = "Wallet data"
 "dayAgo" = "%d day ago"
@@ -159,10 +222,13 @@ const EN = `
 "daysToVote" = "Days to vote"
 "dcrBtcPair" = "dcr-btc"
 "dcrCaps" = "DCR"
+"dcrdataServers" = "dcrdata servers"
 "dcrDex" = "DCRDEX (Coming soon!)"
+"dcrdRPCHost" = "dcrd RPC host"
 "dcrReceived" = "You have received %s DCR"
 "debug" = "Debug"
 "default" = "default"
+"defaultCoinSelection" = "Default coin selection"
 "delete" = "Delete"
 "descriptionNote" = "Description Note"
 "destAddr" = "Destination Address"
@@ -182,13 +248,22 @@ const EN = `
 "discoveringWalletAddress" = "Discovering wallet address · %v%%"
 "discussions" = "Discussions:   %d comments"
 "documentation" = "Documentation"
+"domain" = "Domain"
 "done" = "Done"
 "duration" = "%s (%d/%d blocks)"
 "edit" = "Edit"
+"edited" = "edited"
+"editSelected" = "Edit selected (%d)"
+"electrumBackendInfo" = "Sync through an Electrum server instead of neutrino. Leave the host empty to use neutrino."
+"electrumServerHost" = "Electrum server (host:port)"
 "emptyMsg" = "Field cannot be empty. Please provide valid signed message."
 "emptySign" = "Field cannot be empty. Please provide valid signature."
 "enableAPI" = "Enable %v API in settings"
 "enabled" = "enabled"
+"endDate" = "End date"
+"endpointOffline" = "Offline: %s"
+"endpointOnline" = "Online · %d ms"
+"endpointURL" = "Server URL"
 "english" = "English"
 "enterAddressToSign" = "Enter an address and message to sign:"
 "enterHex"       = "Enter Hex"
@@ -218,6 +293,7 @@ const EN = `
 "expiredInfoDiscSub" = "If a Stake is not revoked automatically, use the revoke button."
 "expiredOn" = "Expired on"
 "expiresIn" = "Expires in "
+"expiry" = "Expiry"
 "explorerURL" = "Explorer URL for %v Asset"
 "extendedInfo" = "The Extended Public Key is used to import the wallet as a watch-only wallet"
 "extendedKey" = "Extended Public Key"
@@ -226,8 +302,10 @@ const EN = `
 "external" = "External"
 "failed" = "Failed"
 "fee" = "Fee"
+"feeEstimatesURL" = "Fee estimates URL"
 "feeRateAPI" = "Fee Rates API"
 "feerates" = "Fee Rates"
+"feeSource" = "Preferred fee source"
 "fetchingAgenda" = "Fetching agendas..."
 "fetchingBlockHeaders" = "Fetching block headers · %v%%"
 "fetchingOrders" = "Fetching Orders"
@@ -236,10 +314,16 @@ const EN = `
 "fetchProposals" = "Fetch proposals"
 "fetchRateError" = "error fetching rate"
 "fetchRates" = "Fetch Rates"
+"filters" = "Filters"
 "finished" = "Finished"
+"followTrustedVoters" = "Follow trusted voters"
+"followVotersRule" = "Follow %d trusted voters"
+"freeze" = "Freeze"
 "french" = "French"
 "frequency" = "Frequency"
 "from" = "From"
+"fromDate" = "From (YYYY-MM-DD)"
+"frozen" = "Frozen"
 "functionUnavailable" = "This function is unavailable until sync is complete."
 "gapLimit" = "Gap Limit"
 "gapLimitInputErr" = "Invalid input: valid values (1-1000)"
@@ -257,6 +341,7 @@ const EN = `
 "hex" = "Hex"
 "hideDetails" = "Hide details"
 "hideSeedPhrase" = "Anyone with your seed phrase can steal your funds. DO NOT show it to anyone."
+"highPriority" = "High"
 "hint" = "Hint"
 "history" = "History"
 "hourAgo" = "%d hour ago"
@@ -267,6 +352,7 @@ const EN = `
 "howToCopy" = "How to copy"
 "howToStoreSeedPhrase" = "It is recommended to store your seed phrase in a physical format (e.g. write down on a paper)."
 "httpReq" = "For HTTP request"
+"iHaventVoted" = "I haven't voted"
 "imawareOfRisk" = "I am aware of the risks"
 "immature" = "Immature"
 "immatureInfo" = "Mature in %v of %v blocks (%v)"
@@ -285,21 +371,31 @@ const EN = `
 "insufficentFund" = "Insufficient funds"
 "invalidAddress" = "Invalid address"
 "invalidAmount" = "Invalid amount"
+"invalidBirthday" = "Enter a past date as YYYY-MM-DD or a block height"
+"invalidBlockHeight" = "Invalid block height"
+"invalidDate" = "Invalid date, use YYYY-MM-DD"
 "invalidHex"     = "Invalid hex"
+"invalidLimit" = "Invalid limit"
 "invalidPassphrase" = "Password entered was not valid."
+"invalidReminderHours" = "Enter whole numbers of hours, e.g. 72, 24, 6"
+"invalidSchedule" = "Invalid schedule value"
 "invalidSeedPhrase" = "Invalid seed phrase"
 "invalidSignature" = "Invalid signature or message"
 "ipAddress" = "IP address"
+"iVoted" = "I voted"
 "justNow" = "Just now"
 "keepAppOpen" = "Keep this app opened"
 "keepInMind" = "Keep in mind"
 "key" = "Key"
 "labelSpendable" = "Spendable"
 "language" = "Language"
+"largestFirst" = "Largest first"
 "lastBlockHeight" = "Last Block Height"
 "latestBlock" = "Latest block"
 "license" = "License"
 "lifeSpan" = "Life Span"
+"linkedProposals" = "Likely paid proposals"
+"linkedProposalsInfo" = "Treasury spends do not reference proposals on chain. Proposals are linked to a spend when their approved funding period covers it."
 "live" = "Live"
 "liveIn" = "Live in"
 "liveInfo" = "Waiting to be chosen to vote"
@@ -307,7 +403,9 @@ const EN = `
 "liveInfoDiscSub" = "There is a 0.5% chance of expiring before being chosen to vote (this expiration returns the original Stake price without a reward)"
 "liveTickets" = "Live Tickets"
 "loading" = "Loading..."
+"loadingComments" = "Loading comments..."
 "loadingPrice" = "Loading price"
+"localFeeEstimator" = "Local (recent blocks)"
 "locked" = "Locked"
 "lockedByTickets" = "Locked By Tickets"
 "lockedin" = "Locked In"
@@ -319,14 +417,24 @@ const EN = `
 "logLevelOff"    = "Off"
 "logLevelTrace"  = "Trace"
 "logLevelWarn"   = "Warn"
+"lowPriority" = "Low"
+"makePrimary" = "Make primary"
+"managePeers" = "Manage peers"
 "manual" = "Manual"
 "manualSetUp" = "Manual Setup"
 "maturity" = "Maturity"
 "max" = "MAX"
+"maxBudgetUSD" = "Maximum budget (USD)"
+"maxMixes" = "Stop after this many mixes"
+"maxPeers" = "Maximum peers"
+"mediumPriority" = "Medium"
+"mempoolSpace" = "mempool.space"
 "message" = "Message"
+"minBudgetUSD" = "Minimum budget (USD)"
 "minimumAssetType" = "Multiple coin types wallets are required for the exchange functionality."
 "minMax" = "Min: %f . Max: %f"
 "mins" = "Mins"
+"minUnmixedBalance" = "Stop below this unmixed balance (DCR)"
 "minuteAgo" = "%d minute ago"
 "minutesAgo" = "%d minutes ago"
 "missedOn" = "Missed on"
@@ -341,16 +449,27 @@ const EN = `
 "mixerRunning" = "Mixer is running..."
 "mixerShutdown" = "The mixer will automatically stop when unmixed balance are fully mixed."
 "mixerStart" = "Mixer start Successfully"
+"mixerStats" = "Mix rounds: %d attempted, %d succeeded, %.1f peers per round"
 "mixingActivity" = "Mixing Activities"
+"mixingSchedule" = "Mixing schedule"
+"mixingScheduleInfo" = "Mix only between the start and end times (HH:MM, local time) and stop after a number of mixes or when the unmixed balance is low. Leave a field empty to disable it."
+"mixWindowEnd" = "End time (HH:MM)"
+"mixWindowStart" = "Start time (HH:MM)"
 "monthAgo" = "%d month ago"
+"monthlySpending" = "Monthly spending"
 "monthsAgo" = "%d months ago"
 "more" = "More"
 "moveFundsFrmDefaultToUnmixed" = "Automatically move funds from default to unmixed account"
 "moveToUnmixed" = "Move funds to unmixed account"
 "multipleMixerAccNeeded" = "Set up mixer by creating two needed accounts"
 "myAcct" = "My account"
+"myVote" = "My vote"
+"myVotes" = "My votes: %s"
 "nConfirmations" = "%d Confirmations"
 "network" = "Network"
+"networkBackend" = "Network backend"
+"networkBackendInfo" = "Sync through a trusted dcrd node instead of SPV. Leave the host empty to use SPV."
+"neutrino" = "Neutrino"
 "neverSynced" = "Never Synced"
 "newest" = "Newest"
 "newProposalUpdate" = "New update for proposal with Token: %s"
@@ -360,13 +479,21 @@ const EN = `
 "next" = "Next"
 "no" = "No"
 "noActiveTickets" = "No active tickets"
+"noAgendaHistory" = "No past agendas"
 "noAgendaYet" = "No agendas yet"
+"noAutoVoteHistory" = "No auto-votes yet"
+"noAutoVoteRules" = "No rules added, no votes will be cast"
+"noBannedPeers" = "No banned peers"
+"noComments" = "No comments yet"
 "noConnectedPeer" = "no connected peers."
+"noConnectedPeers" = "Not connected to any peer"
 "noExchangeOnTestnet" = "Exchange functionality is not available on the test network""
 "noInternet" = "no Internet Connectivity."
+"noLinkedProposals" = "No approved proposal funding period covers this spend"
 "nonAccSelector" = "This widget isn't set to show accounts"
 "none" = "None"
 "noOrders" = "Orders you create will be shown here."
+"noPendingTSpends" = "No treasury spends are being voted on"
 "noPoliciesYet" = "No policies yet"
 "noProposal" = "No proposals %v"
 "noReward" = "Stakey sees no rewards"
@@ -378,21 +505,33 @@ const EN = `
 "note" = "Note"
 "notEnoughVotes" = "You don't have enough votes"
 "noTickets" = "No tickets yet"
+"noTicketsSelected" = "Select at least one ticket"
 "notifications" = "Notifications"
 "notOwned" = "Valid address not owned by you."
+"notPinned" = "Certificate not pinned"
 "noTransactions" = "No transactions"
+"noTreasuryHistory" = "No treasury history"
 "notSameAccoutMixUnmix" = "Cannot use same account for mixed & unmixed"
 "notSupported" = "%s is currently not suppported"
 "noUTXOs" = "No UTXOs Available"
 "noValidAccountFound" = "no valid account found"
 "noValidWalletFound" = "no valid wallet found"
+"noVersionChanges" = "No changes between these versions"
+"noVote" = "No vote"
+"noVoteChoiceMismatches" = "The VSPs' vote choices match the wallet's"
+"noVoteDeadlines" = "No votes in progress for your tickets"
+"noVoteReceipts" = "No vote receipts saved yet"
+"noVotesCast" = "No votes cast by this wallet"
+"noVSP" = "No VSP"
 "noVSPLoaded" = "No vsp loaded. Check internet connection and try again."
 "noWalletLoaded" = "No wallet loaded"
 "numberOfVotes" = "You have %d votes"
+"off" = "Off"
 "offChainVote" = "Off-chain voting for development and marketing initiatives funded by the Decred treasury."
 "offline" = "Offline, "
 "ok" = "OK"
 "oldest" = "Oldest"
+"oldestFirst" = "Oldest first"
 "onChainVote" = "On-chain voting for upgrading the Decred network consensus rules."
 "online" = "Online, "
 "openingWallet" = "Opening wallets..."
@@ -405,31 +544,49 @@ const EN = `
 "orderSendingFrom" = "From: %s (%s)"
 "orderSettingsSaved" = "Order Settings saved!"
 "orderSubmitted" = "Order Submitted"
+"origin" = "Origin"
+"originChange" = "Change"
+"originExchange" = "KYC exchange"
+"originMixed" = "Mixed"
+"originUnknown" = "Unknown"
 "overview" = "Overview"
 "owned" = "Valid address owned by you."
 "pageWarningNotSync" = "Page cannot be accessed because the wallet is not synced, please sync your wallet and try again"
 "pageWarningSync" = "Page cannot be accessed because the wallet sync is in progress, please wait for the sync to complete"
 "passwordNotMatch" = "Passwords do not match"
 "pasteSeedWords" = "Paste Seed Words"
+"payee" = "Payee %s"
 "peer" = "Peer"
+"peerDetails" = "%s, services %s, starting height %d"
 "peers" = "peers"
 "peersConnected" = "Peers connected"
+"peerTraffic" = "Latency %d ms, sent %.1f kB, received %.1f kB, ban score %d"
 "pending" = "Pending"
+"pendingAutoVotes" = "%d auto-votes are waiting for the wallet to be unlocked"
+"pendingTSpends" = "Pending treasury spends"
 "percentageMixed" = "%v%% Mixed"
 "piKey" = "Pi key"
+"pinCertificate" = "Pin certificate"
+"pinnedKey" = "Pinned key %s"
 "policySetSuccessfully" = "Your treasury policy has been successfully updated!"
+"politeiaServers" = "Politeia servers"
+"preview" = "Preview"
 "priority" = "Priority%v"
 "privacyInfo" = "%v When the mixer is activated, funds will be gradually transfered from the unmixed account to the mixed account. %v Important: keep this app open while mixer is running. %v The mixer routine will automatically stop when the unmixed balance is fully mixed.%v"
 "privacyModeActive" = "(Network Privacy Is Enabled)"
 "privacyModeInfo" = "Network Privacy Info"
 "privacyModeInfoDesc" = "When enabled, all HTTP API calls are disabled, with the exception of Network Check API that is used to check if a wallet has internet access."
 "privacySettings" = "Network Privacy"
+"privacyStrategy" = "Privacy (no address linkage)"
 "propFetching" = "Proposals fetching %s. %s"
 "propNotif" = "Proposal notification"
 "propNotification" = "Proposal notification %s"
 "proposalAddedNotif" = "A new proposal has been added Name: %s"
+"proposalDescription" = "Description"
 "proposalInfo" = "Proposals and politeia notifications can be enabled or disabled from the settings page."
+"proposalName" = "Proposal name"
 "proposals" = "Proposals"
+"proposalVersionUpdatedNotif" = "A new version of proposal %s has been published"
 "proposalVoteDetails" = "Proposal vote details"
 "published" = "Published:   %s"
 "published2" = "Published"
@@ -440,6 +597,8 @@ const EN = `
 "rate" = "Rate"
 "readyToMix" = "Ready to mix"
 "rebroadcast" = "Rebroadcast"
+"receiptSignatureInvalid" = "Receipt signatures do not match the server key"
+"receiptSignatureValid" = "Receipt signatures valid"
 "receive" = "Receive"
 "received" = "Received"
 "receiveInfo" = "To protect your privacy, a new address is generated each time you receive a payment."
@@ -451,7 +610,9 @@ const EN = `
 "reconnect" = "Reconnect"
 "refresh" = "Refresh"
 "rejected" = "Rejected"
+"rejectUnderBudget" = "Reject under budget"
 "remove" = "Remove"
+"removeBookmark" = "Remove bookmark"
 "removePeer" = "Remove specific peer"
 "removePeerWarn" = "Are you sure you want to proceed with removing the specific peer?"
 "removeUserAgent" = "Remove user agent"
@@ -461,18 +622,24 @@ const EN = `
 "rename" = "Rename"
 "renameAcct" = "Rename account"
 "renameWalletSheetTitle" = "Rename wallet"
+"repair" = "Repair"
 "republished" = "Republished unmined transactions to the %s network"
 "rescan" = "Rescan"
 "rescanBlockchain" = "Rescan blockchain"
+"rescanEndHeight" = "End block height (optional)"
 "rescanInfo" = "Rescanning may help resolve some balance errors. This will take some time, as it scans the entire blockchain for transactions"
 "rescanningBlocks" = "Rescanning blocks"
 "rescanningHeaders" = "Rescanning headers · %v%%"
 "rescanProgressNotification" = "Check progress in overview."
+"rescanSpeed" = "Scan speed"
+"rescanStartHeight" = "Start block height (optional)"
+"resetEndpoints" = "Reset to defaults"
 "restore" = "Restore"
 "restoreExistingWallet" = "Restore existing wallet"
 "restoreWallet" = "Restore wallet"
 "restoreWithHex" = "Restore wallet using hex"
 "resumeAccountDiscoveryTitle" = "Unlock to resume restoration"
+"resumePreviousRescan" = "Resume the rescan stopped at block %d"
 "retry" = "Retry"
 "revocation" = "Revocation"
 "revoke" = "Revoke"
@@ -482,6 +649,10 @@ const EN = `
 "revokeInfoDisc" = "The Stake price will become spendable after %d blocks (~%s)"
 "reward" = "Reward"
 "rewardsEarned" = "Rewards Earned"
+"rpcCertPath" = "RPC certificate file (optional)"
+"rpcPassword" = "RPC password"
+"rpcPasswordKeep" = "RPC password (leave empty to keep the saved one)"
+"rpcUsername" = "RPC username"
 "save" = "Save"
 "scheduler" = "Scheduler"
 "schedulerRunning" = "Order Scheduler is running"
@@ -497,6 +668,7 @@ const EN = `
 "seedPhraseVerified" = "Your seed phrase backup is verified"
 "seedValidationFailed" = "Failed to verify. Please go through every wallet seed and try again."
 "selectAcc" = "Select Account"
+"selectAll" = "Select all"
 "selectAServer" = "Select A Server"
 "selectAssetType" = "Select Asset Type"
 "selectChangeAcc" = "%v Select the account you would like to use as your %v unmixed account. %v Note: you can compromise your privacy if you choose the wrong account %v"
@@ -523,22 +695,31 @@ const EN = `
 "sendWarning" = "Your DCR will be sent after this step."
 "sent" = "Sent"
 "server" = "Server"
+"serverAddress" = "Server address (host:port)"
+"serverKeyMismatch" = "Signed with another politeia server key"
+"serverKeyRotated" = "Politeia server key changed since it was pinned"
+"serverKeyUnknown" = "Politeia server key not known yet, verify online"
 "serverRate" = "%s rate: %f"
+"serviceEndpoints" = "Service Endpoints"
 "setchoice" = "Set Choice"
 "setGapLimit" = "Set Gap Limit"
 "setGapLimitInfo" = "%v In some rare circumstances, address may not be discovered with the default gap limit of 20. It's recommended to only use this functionality after trying other options. And be aware that raising the gap limit above 100 will lead to excessive loading times to complete this request. %v"
+"setTicketChoice" = "Set for a ticket"
 "settings" = "Settings"
 "setTreasuryPolicy" = "Set treasury policy"
+"setTSpendPolicy" = "Set vote choice for this treasury spend"
 "setUp" = "Set up"
 "setupMixerInfo" = "%v Two dedicated accounts %v mixed %v & %v unmixed %v will be created in order to use the mixer. %v This action cannot be undone.%v"
 "setUpNeededAccs" = "Set up needed accounts"
 "setUpPrivacy" = "Using StakeShuffle increases the privacy of your wallet transactions."
 "setUpStakeShuffle" = "Set up StakeShuffle"
 "setupStartupPassword" = "Set up startup password"
+"setUTXOOrigin" = "Set origin of the selected outputs"
 "signature" = "Signature"
 "signCopied" = "Signature copied"
 "signMessage" = "Sign message"
 "signMessageInfo" = "%v Signing a message with an address' private key allows you to prove that you are the owner of a given address to a possible counterparty.%v"
+"socks5Proxy" = "SOCKS5 proxy, e.g. Tor (optional)"
 "source" = "Source"
 "sourceModalInfo" = "Wallets that have not completed sync will be hidden from the list. %v Refunds and leftover change will be returned to the selected source account"
 "sourceWalletNotSynced" = "Source wallet is not synced"
@@ -548,13 +729,16 @@ const EN = `
 "spendingPasswordInfo" = "A spending password helps secure your wallet transactions."
 "spendingPasswordInfo2" = "This spending password is for the new wallet only"
 "spendingPasswordUpdated" = "Spending passphrase updated"
+"spv" = "SPV"
 "stake" = "Stake"
 "stakeAge" = "Stake age"
 "staked" = "Staked"
 "stakeShuffle" = "StakeShuffle"
+"stakeVersion" = "Stake version %d"
 "staking" = "Staking"
 "stakingActivity" = "Staking Activities"
 "start" = "Start"
+"startDate" = "Start date"
 "startupPassConfirm" = "Startup password changed"
 "startupPassword" = "Startup Password"
 "startupPasswordEnabled" = "Startup password %v"
@@ -574,7 +758,13 @@ const EN = `
 "syncingProgress" = "Syncing progress"
 "syncingProgressStat" = "%s behind"
 "syncingState" = "Syncing..."
+"syncLimits" = "Sync limits"
+"syncLimitsInfo" = "Leave a field empty for no limit. The peers and bandwidth limits apply from the next sync. The sync stops outside of the schedule and once the daily data limit is reached."
+"syncScheduleEnd" = "Sync until hour (0-23)"
+"syncScheduleStart" = "Sync from hour (0-23)"
 "syncSteps" = "Step %d/3"
+"tag" = "Tag"
+"tagUTXOs" = "Tag selected outputs"
 "takenAccount" = "Account name is taken"
 "tapToCopy" = "(Tap to copy)"
 "ticektVoted" =  "A ticket just voted\nVote reward: %s DCR"
@@ -588,9 +778,12 @@ const EN = `
 "ticketRevokedTitle" = "Ticket, Revoked"
 "tickets" = "Tickets"
 "ticketSettingSaved" = "Auto ticket purchase setting saved successfully."
+"ticketVoteChoices" = "Ticket vote choices"
 "ticketVotedTitle" = "Ticket, Voted"
 "timeLeft" = "%v left"
+"tlsCertPath" = "TLS certificate path (optional)"
 "to" = "To"
+"toDate" = "To (YYYY-MM-DD)"
 "token" = "Token:   %s"
 "total" = "Total"
 "totalAmount" = "Total Amount"
@@ -603,34 +796,59 @@ const EN = `
 "transactions" = "Transactions"
 "transferred" = "Transferred"
 "treasury" = "Treasury"
+"treasuryAnalytics" = "Treasury Analytics"
+"treasuryBalance" = "Treasury balance"
+"treasuryKeyLabel" = "Treasury key %s"
+"treasurySpend" = "Treasury spend"
 "treasurySpending" = "Treasury Spending"
 "treasurySpendingInfo" = "Spending treasury funds now requires stakeholders to vote on the expenditure. You can participate and set a voting policy for treasury spending by a particular Governance Key. The keys can be verified in the dcrd source."
+"treasurySpends" = "Treasury spends"
+"trustedVoterAddresses" = "Voting addresses, comma separated"
+"trustServerKey" = "Trust new server key"
+"trustServerKeyInfo" = "The politeia server now signs vote receipts with the key %s instead of the pinned key %s. Only trust the new key if the server operators announced the change. The receipts signed with the old key remain trusted."
+"tspendExpiry" = "Block %d (%d blocks left)"
+"tspendLabel" = "TSpend %s"
 "txConfModalInfoTxt" = "<b>Unmixed accounts are hidden</b>. Spending from unmixed accounts is disabled by stakeshuffle settings to protect your privacy"
 "txDetailsInfo" = "%v Tap on %v blue text %v to copy the item %v"
 "txEstimateErr" = "Error estimating transaction: %v"
 "txFee" = "Transaction Fee"
 "txHashCopied" = "Transaction Hash copied"
+"txIndexConsistent" = "The transaction index is consistent, %d transactions are indexed"
+"txIndexInconsistent" = "%d missing, %d orphaned and %d mismatched transactions were found in the index of the %d wallet transactions."
+"txIndexMismatched" = "Mismatched %s: %s"
+"txIndexMissing" = "Missing: %s"
+"txIndexOrphaned" = "Orphaned: %s"
+"txIndexRepaired" = "Transaction index repaired"
+"txIndexSize" = "Transactions index size"
 "txNotification" = "Transaction Notification"
 "txOverview" = "Transaction Overview"
 "txSent" = "Transaction sent!"
 "txSize" = "Transaction Size%v"
 "txStatusPending"         = "Pending (%v of %v confirmations)" 
 "type" = "Type"
+"unban" = "Unban"
 "unconfirmedFunds" = "Allow spending unconfirmed funds"
 "unconfirmedTx"    = "Unconfirmed"
 "underReview" = "Under Review"
+"unfreeze" = "Unfreeze"
 "unknown" = "Unknown"
 "unlock" = "Unlock"
+"unlockAndVote" = "Unlock and vote"
+"unlockDcrdRPCInfo" = "Enter your spending password to decrypt the password of the trusted dcrd node."
+"unlockDcrdRPCTitle" = "Unlock dcrd connection"
 "unlockWithPassword" = "Unlock with password"
 "unmined" = "Unmined"
 "unminedInfo" = "Broadcasted %v"
 "unmixed" = "Unmixed"
 "unmixedAccount" = "Unmixed account"
 "unmixedBalance" = "Unmixed balance"
+"unpinCertificate" = "Unpin"
+"unvotedTickets" = "%d of %d tickets not voted"
 "upcomming" = "Upcoming"
 "updated" = "Updated"
 "updatePreference" = "Update Preference"
 "updateVotePref" = "Update Voting Preference"
+"updateVSP" = "Update VSP"
 "uptime" = "Uptime"
 "usdBinance" = "USD (Binance)"
 "usdBittrex" = "USD (Bittrex)"
@@ -638,6 +856,8 @@ const EN = `
 "userAgent" = "User agent"
 "userAgentDialogTitle" = "Set up user agent"
 "userAgentSummary" = "For exchange rate fetching"
+"useTLS" = "Use TLS"
+"utxoTagsMixedWarning" = "The selected outputs combine funds from %s. Spending them together links their history."
 "validAddress" = "Valid address"
 "validate" = "Validate"
 "validateAddr" = "Validate address"
@@ -649,13 +869,16 @@ const EN = `
 "validSignature" = "Valid signature"
 "verify" = "Verify"
 "verifyGovernanceKeys" = "Verify Governance Keys"
+"verifying" = "Verifying..."
 "verifyMessage" = "Verify message"
 "verifyMessageInfo" = "%v You can use this form to verify the signature's validity after you or your counterparty have generated one.%v After you've input the address, message, and signature, you'll see VALID if the signature matches the address and message correctly, and INVALID otherwise.%v"
 "verifyMsgError" = "Error verifying message: %v"
 "verifyMsgNote" = "Enter the address, signature, and message to verify:"
 "verifySeed" = "Verify Seed Phrase"
 "verifySeedInfo" = "Verify your seed phrase backup so you can recover your funds when needed."
+"verifyTxIndex" = "Verify transaction index"
 "version" = "Version"
+"versionHistory" = "Version history"
 "viewAllOrders" = "View all orders"
 "viewAppLog" = "View Application Log"
 "viewDetails" = "View details"
@@ -666,12 +889,25 @@ const EN = `
 "viewTicket" = "View associated ticket"
 "vote" = "Vote"
 "votechoice" = "Vote Choice"
+"voteChoiceMismatch" = "%s: wallet %s, VSP %s"
+"voteChoiceMismatches" = "Choices differing from the VSP"
 "voteConfirm" = "Confirm to vote"
+"voteCounted" = "Vote counted as cast"
+"voteCountedDifferently" = "Vote counted differently than cast"
 "voted" = "Voted"
+"voteDeadlines" = "Vote deadlines"
 "votedInfo" = "Congratulations! This Stake has voted."
 "votedInfoDisc" = "The Stake price + reward will become spendable after %d blocks (~%s)"
 "votedOn" = "Voted on"
 "voteEndedNotif" = "Voting has ended for proposal with Token: %s"
+"voteEndsIn" = "Ends in about %s (%d blocks)"
+"voteNotCounted" = "Vote missing from the vote results"
+"voteOn" = "Vote on"
+"voteOutcome" = "Vote outcome"
+"voteReceipts" = "Vote Receipts"
+"voteReminderHours" = "Hours before a vote ends, comma separated. Leave empty to turn reminders off."
+"voteReminderNotif" = "%d of your %d tickets have not voted on %s. Voting ends in about %s."
+"voteReminders" = "Vote reminders"
 "voteSent" = "Vote sent successfully, refreshing proposals!"
 "voteStartedNotif" = "Voting has started for proposal with Token: %s"
 "voteTooltip" = "%d %% Yes votes required for approval"
@@ -686,9 +922,13 @@ const EN = `
 "vsp" = "VSP"
 "vspAPI" = "VSP API"
 "vspFee" = "VSP Fee"
+"vspListSources" = "VSP list sources"
 "waitingForAdmin" = "Waiting for admin to trigger the start of voting"
 "waitingForAuthor" = "Waiting for author to authorize voting"
 "waitingState" = "Waiting..."
+"walletBirthday" = "Wallet birthday (optional)"
+"walletBirthdayHint" = "Creation date (YYYY-MM-DD) or block height"
+"walletBirthdayInfo" = "Blocks mined before the birthday are skipped on the first sync. Leave empty if unsure."
 "walletCreated" = "Wallet created"
 "walletDirectory" = "Wallet data directory"
 "walletExist" = "Wallet with name: %s already exist"
@@ -127,8 +127,11 @@ const (
 	StrAcctName                        = "acctName"
 	StrAcctNum                         = "acctNum"
 	StrAcctRenamed                     = "accRenamed"
+	StrAdd                             = "add"
 	StrAddAcctWarn                     = "addAcctWarn"
 	StrAddDexServer                    = "addDexServer"
+	StrAddedSpent                      = "addedSpent"
+	StrAddEndpoint                     = "addEndpoint"
 	StrAddNewAccount                   = "addNewAccount"
 	StrAddress                         = "address"
 	StrAddressCopied                   = "addressCopied"
@@ -136,9 +139,11 @@ const (
 	StrAddressDiscoveryStarted         = "addressDiscoveryStarted"
 	StrAddressDiscoveryStartedBody     = "addressDiscoveryStartedBody"
 	StrAddrNotOwned                    = "addrNotOwned"
+	StrAddTSpend                       = "addTSpend"
 	StrAddVSP                          = "addVSP"
 	StrAddWallet                       = "addWallet"
 	StrAdminToTriggerVoting            = "adminToTriggerVoting"
+	StrAgendaHistory                   = "agendaHistory"
 	StrAgendas                         = "agendas"
 	StrAgo                             = "ago"
 	StrAll                             = "all"
@@ -146,12 +151,17 @@ const (
 	StrAllowUnspendUnmixedAcct         = "allowUnspendUnmixedAcct"
 	StrAllTickets                      = "allTickets"
 	StrAmount                          = "amount"
+	StrAndMore                         = "andMore"
+	StrAny                             = "any"
 	StrAppLog                          = "appLog"
+	StrApply                           = "apply"
 	StrAppName                         = "appName"
 	StrApproved                        = "approved"
+	StrApproveUnderBudget              = "approveUnderBudget"
 	StrAppTitle                        = "appTitle"
 	StrAppWallet                       = "appWallet"
 	StrAskedEnterSeedWords             = "askedEnterSeedWords"
+	StrAuthor                          = "author"
 	StrAuthorToAuthorizeVoting         = "authorToAuthorizeVoting"
 	StrAutomatic                       = "automatic"
 	StrAutoSetUp                       = "autoSetUp"
@@ -159,35 +169,57 @@ const (
 	StrAutoTicketInfo                  = "autoTicketInfo"
 	StrAutoTicketPurchase              = "autoTicketPurchase"
 	StrAutoTicketWarn                  = "autoTicketWarn"
+	StrAutoVote                        = "autoVote"
+	StrAutoVoteHistory                 = "autoVoteHistory"
+	StrAutoVoteInfo                    = "autoVoteInfo"
+	StrAutoVotePreview                 = "autoVotePreview"
+	StrAutoVoteRules                   = "autoVoteRules"
 	StrAwareOfRisk                     = "imawareOfRisk"
 	StrBackAndRename                   = "backAndRename"
 	StrBackStaking                     = "backStaking"
 	StrBackToWallets                   = "backToWallets"
+	StrBackupDatabase                  = "backupDatabase"
 	StrBackupInfo                      = "backupInfo"
 	StrBackupLater                     = "backupLater"
 	StrBackupNow                       = "backupNow"
 	StrBackupSeedPhrase                = "backupSeedPhrase"
 	StrBackupWarning                   = "backupWarning"
+	StrBadgerDB                        = "badgerDB"
 	StrBalance                         = "balance"
 	StrBalanceAfter                    = "balanceAfter"
 	StrBalanceToMaintain               = "balanceToMaintain"
 	StrBalToMaintain                   = "balToMaintain"
 	StrBalToMaintainValue              = "balToMaintainValue"
+	StrBan                             = "ban"
+	StrBandwidthLimit                  = "bandwidthLimit"
+	StrBannedPeers                     = "bannedPeers"
+	StrBannedSince                     = "bannedSince"
 	StrBeepForNewBlocks                = "beepForNewBlocks"
 	StrBestBlockAge                    = "bestBlockAge"
 	StrBestBlocks                      = "bestBlocks"
 	StrBestBlockTimestamp              = "bestBlockTimestamp"
 	StrBinanceRate                     = "binanceRate"
+	StrBlockbookServers                = "blockbookServers"
 	StrBlockHeaderFetched              = "blockHeaderFetched"
 	StrBlockHeaderFetchedCount         = "blockHeaderFetchedCount"
 	StrBlocksLeft                      = "blocksLeft"
+	StrBlocksPerSecond                 = "blocksPerSecond"
 	StrBlocksScanned                   = "blocksScanned"
+	StrBlockstream                     = "blockstream"
+	StrBoltDB                          = "boltDB"
+	StrBookmark                        = "bookmark"
+	StrBookmarked                      = "bookmarked"
+	StrBranchAndBound                  = "branchAndBound"
+	StrBudgetRule                      = "budgetRule"
 	StrBuild                           = "build"
 	StrBuildDate                       = "buildDate"
 	StrCanBuy                          = "canBuy"
 	StrCancel                          = "cancel"
 	StrCanceling                       = "canceling"
 	StrCancelMixer                     = "cancelMixer"
+	StrCertFilePath                    = "certFilePath"
+	StrCertFingerprint                 = "certFingerprint"
+	StrCertificatePin                  = "certificatePin"
 	StrChange                          = "change"
 	StrChangeAccount                   = "changeAccount"
 	StrChangeSpecificPeer              = "changeSpecificPeer"
@@ -195,16 +227,31 @@ const (
 	StrChangeStartupPassword           = "changeStartupPassword"
 	StrChangeUserAgent                 = "changeUserAgent"
 	StrChangeWalletName                = "changeWalletName"
+	StrCheckEndpoints                  = "checkEndpoints"
 	StrCheckGovernace                  = "checkGovernace"
+	StrChecking                        = "checking"
 	StrCheckMixerStatus                = "checkMixerStatus"
 	StrCheckStatistics                 = "checkStatistics"
+	StrCheckVSPChoices                 = "checkVSPChoices"
 	StrCheckWalletLog                  = "checkWalletLog"
+	StrChoice                          = "choice"
 	StrClear                           = "clear"
 	StrClearAll                        = "clearAll"
 	StrClearSelection                  = "clearSelection"
 	StrClosingWallet                   = "closingWallet"
 	StrCoinSelection                   = "coinSelection"
+	StrCoinSelectionPreview            = "coinSelectionPreview"
+	StrCoinSelectionStrategy           = "coinSelectionStrategy"
 	StrColon                           = "colon"
+	StrCommentCensored                 = "commentCensored"
+	StrComments                        = "comments"
+	StrCompact                         = "compact"
+	StrCompactDatabase                 = "compactDatabase"
+	StrCompactDatabaseInfo             = "compactDatabaseInfo"
+	StrCompaction                      = "compaction"
+	StrCompactionNotNeeded             = "compactionNotNeeded"
+	StrCompactionRecommended           = "compactionRecommended"
+	StrCompareWithPrevious             = "compareWithPrevious"
 	StrComplete                        = "complete"
 	StrConfirm                         = "confirm"
 	StrConfirmations                   = "confirmations"
@@ -230,6 +277,7 @@ const (
 	StrConfirmVote                     = "confirmVote"
 	StrConfirmYourOrder                = "confirmYourOrder"
 	StrConfStatus                      = "confStatus"
+	StrConnectedPeers                  = "connectedPeers"
 	StrConnectedPeersCount             = "connectedPeersCount"
 	StrConnectedTo                     = "connectedTo"
 	StrConnecting                      = "connecting"
@@ -237,6 +285,7 @@ const (
 	StrConnectToSpecificPeer           = "connectToSpecificPeer"
 	StrConsensusChange                 = "consensusChange"
 	StrConsensusDashboard              = "consensusDashboard"
+	StrConsolidation                   = "consolidation"
 	StrContinue                        = "continue"
 	StrCoordinationServer              = "coordinationServer"
 	StrCopied                          = "copied"
@@ -254,12 +303,26 @@ const (
 	StrCreateOrderPageInfo             = "createOrderPageInfo"
 	StrCreateStartupPassword           = "createStartupPassword"
 	StrCreateWallet                    = "createWallet"
+	StrCSPPServerInfo                  = "csppServerInfo"
 	StrCurrentSpendingPassword         = "currentSpendingPassword"
 	StrCurrentStartupPass              = "currentStartupPass"
 	StrCurrentTotalBalance             = "currentTotalBalance"
+	StrCustomEndpoint                  = "customEndpoint"
 	StrCustomUserAgent                 = "CustomUserAgent"
+	StrDailyDataLimit                  = "dailyDataLimit"
 	StrDangerZone                      = "dangerZone"
 	StrDarkMode                        = "darkMode"
+	StrDatabaseBackedUp                = "databaseBackedUp"
+	StrDatabaseBackend                 = "databaseBackend"
+	StrDatabaseBucket                  = "databaseBucket"
+	StrDatabaseBucketValue             = "databaseBucketValue"
+	StrDatabaseDriverStats             = "databaseDriverStats"
+	StrDatabaseSize                    = "databaseSize"
+	StrDatabaseSizeValue               = "databaseSizeValue"
+	StrDatabaseUpdated                 = "databaseUpdated"
+	StrDataSentReceived                = "dataSentReceived"
+	StrDataUsageOn                     = "dataUsageOn"
+	StrDataUsageToday                  = "dataUsageToday"
 	StrDateCreated                     = "dateCreated"
 	StrDateSize                        = "dateSize"
 	StrDayAgo                          = "dayAgo"
@@ -269,10 +332,13 @@ const (
 	StrDaysToVote                      = "daysToVote"
 	StrDcrBtcPair                      = "dcrBtcPair"
 	StrDCRCaps                         = "dcrCaps"
+	StrDcrdataServers                  = "dcrdataServers"
 	StrDcrDex                          = "dcrDex"
+	StrDcrdRPCHost                     = "dcrdRPCHost"
 	StrDcrReceived                     = "dcrReceived"
 	StrDebug                           = "debug"
 	StrDefault                         = "default"
+	StrDefaultCoinSelection            = "defaultCoinSelection"
 	StrDeleted                         = "delete"
 	StrDescriptionNote                 = "descriptionNote"
 	StrDestAddr                        = "destAddr"
@@ -292,13 +358,22 @@ const (
 	StrDiscoveringWalletAddress        = "discoveringWalletAddress"
 	StrDiscussions                     = "discussions"
 	StrDocumentation                   = "documentation"
+	StrDomain                          = "domain"
 	StrDone                            = "done"
 	StrDuration                        = "duration"
 	StrEdit                            = "edit"
+	StrEdited                          = "edited"
+	StrEditSelected                    = "editSelected"
+	StrElectrumBackendInfo             = "electrumBackendInfo"
+	StrElectrumServerHost              = "electrumServerHost"
 	StrEmptyMsg                        = "emptyMsg"
 	StrEmptySign                       = "emptySign"
 	StrEnableAPI                       = "enableAPI"
 	StrEnabled                         = "enabled"
+	StrEndDate                         = "endDate"
+	StrEndpointOffline                 = "endpointOffline"
+	StrEndpointOnline                  = "endpointOnline"
+	StrEndpointURL                     = "endpointURL"
 	StrEnglish                         = "english"
 	StrEnterAddressToSign              = "enterAddressToSign"
 	StrEnterExtendedPubKey             = "enterXpubKey"
@@ -327,6 +402,7 @@ const (
 	StrExpiredInfoDiscSub              = "expiredInfoDiscSub"
 	StrExpiredOn                       = "expiredOn"
 	StrExpiresIn                       = "expiresIn"
+	StrExpiry                          = "expiry"
 	StrExplorerURL                     = "explorerURL"
 	StrExtendedCopied                  = "extendedKeyCopied"
 	StrExtendedInfo                    = "extendedInfo"
@@ -335,8 +411,10 @@ const (
 	StrExternal                        = "external"
 	StrFailed                          = "failed"
 	StrFee                             = "fee"
+	StrFeeEstimatesURL                 = "feeEstimatesURL"
 	StrFeeRateAPI                      = "feeRateAPI"
 	StrFeeRates                        = "feerates"
+	StrFeeSource                       = "feeSource"
 	StrFetchingAgenda                  = "fetchingAgenda"
 	StrFetchingBlockHeaders            = "fetchingBlockHeaders"
 	StrFetchingOrders                  = "fetchingOrders"
@@ -345,10 +423,16 @@ const (
 	StrFetchProposals                  = "fetchProposals"
 	StrFetchRateError                  = "fetchRateError"
 	StrFetchRates                      = "fetchRates"
+	StrFilters                         = "filters"
 	StrFinished                        = "finished"
+	StrFollowTrustedVoters             = "followTrustedVoters"
+	StrFollowVotersRule                = "followVotersRule"
+	StrFreeze                          = "freeze"
 	StrFrench                          = "french"
 	StrFrequency                       = "frequency"
 	StrFrom                            = "from"
+	StrFromDate                        = "fromDate"
+	StrFrozen                          = "frozen"
 	StrFunctionUnavailable             = "functionUnavailable"
 	StrGapLimit                        = "gapLimit"
 	StrGapLimitInputErr                = "gapLimitInputErr"
@@ -366,6 +450,7 @@ const (
 	StrHex                             = "hex"
 	StrHideDetails                     = "hideDetails"
 	StrHideSeedPhrase                  = "hideSeedPhrase"
+	StrHighPriority                    = "highPriority"
 	StrHint                            = "hint"
 	StrHistory                         = "history"
 	StrHourAgo                         = "hourAgo"
@@ -376,6 +461,7 @@ const (
 	StrHowToCopy                       = "howToCopy"
 	StrHowToStoreSeedPhrase            = "howToStoreSeedPhrase"
 	StrHTTPRequest                     = "httpReq"
+	StrIHaventVoted                    = "iHaventVoted"
 	StrImmature                        = "immature"
 	StrImmatureInfo                    = "immatureInfo"
 	StrImmatureRewards                 = "immatureRewards"
@@ -393,21 +479,31 @@ const (
 	StrInsufficentFund                 = "insufficentFund"
 	StrInvalidAddress                  = "invalidAddress"
 	StrInvalidAmount                   = "invalidAmount"
+	StrInvalidBirthday                 = "invalidBirthday"
+	StrInvalidBlockHeight              = "invalidBlockHeight"
+	StrInvalidDate                     = "invalidDate"
 	StrInvalidHex                      = "invalidHex"
+	StrInvalidLimit                    = "invalidLimit"
 	StrInvalidPassphrase               = "invalidPassphrase"
+	StrInvalidReminderHours            = "invalidReminderHours"
+	StrInvalidSchedule                 = "invalidSchedule"
 	StrInvalidSeedPhrase               = "invalidSeedPhrase"
 	StrInvalidSignature                = "invalidSignature"
 	StrIPAddress                       = "ipAddress"
+	StrIVoted                          = "iVoted"
 	StrJustNow                         = "justNow"
 	StrKeepAppOpen                     = "keepAppOpen"
 	StrKeepInMind                      = "keepInMind"
 	StrKey                             = "key"
 	StrLabelSpendable                  = "labelSpendable"
 	StrLanguage                        = "language"
+	StrLargestFirst                    = "largestFirst"
 	StrLastBlockHeight                 = "lastBlockHeight"
 	StrLatestBlock                     = "latestBlock"
 	StrLicense                         = "license"
 	StrLifeSpan                        = "lifeSpan"
+	StrLinkedProposals                 = "linkedProposals"
+	StrLinkedProposalsInfo             = "linkedProposalsInfo"
 	StrLive                            = "live"
 	StrLiveIn                          = "liveIn"
 	StrLiveInfo                        = "liveInfo"
@@ -415,7 +511,9 @@ const (
 	StrLiveInfoDiscSub                 = "liveInfoDiscSub"
 	StrLiveTickets                     = "liveTickets"
 	StrLoading                         = "loading"
+	StrLoadingComments                 = "loadingComments"
 	StrLoadingPrice                    = "loadingPrice"
+	StrLocalFeeEstimator               = "localFeeEstimator"
 	StrLocked                          = "locked"
 	StrLockedByTickets                 = "lockedByTickets"
 	StrLockedIn                        = "lockedin"
@@ -427,13 +525,23 @@ const (
 	StrLogLevelOff                     = "logLevelOff"
 	StrLogLevelTrace                   = "logLevelTrace"
 	StrLogLevelWarn                    = "logLevelWarn"
+	StrLowPriority                     = "lowPriority"
+	StrMakePrimary                     = "makePrimary"
+	StrManagePeers                     = "managePeers"
 	StrManual                          = "manual"
 	StrManualSetUp                     = "manualSetUp"
 	StrMaturity                        = "maturity"
 	StrMax                             = "max"
+	StrMaxBudgetUSD                    = "maxBudgetUSD"
+	StrMaxMixes                        = "maxMixes"
+	StrMaxPeers                        = "maxPeers"
+	StrMediumPriority                  = "mediumPriority"
+	StrMempoolSpace                    = "mempoolSpace"
 	StrMessage                         = "message"
+	StrMinBudgetUSD                    = "minBudgetUSD"
 	StrMinimumAssetType                = "minimumAssetType"
 	StrMinMax                          = "minMax"
+	StrMinUnmixedBalance               = "minUnmixedBalance"
 	StrMinuteAgo                       = "minuteAgo"
 	StrMinutes                         = "mins"
 	StrMinutesAgo                      = "minutesAgo"
@@ -449,16 +557,27 @@ const (
 	StrMixerRunning                    = "mixerRunning"
 	StrMixerShutdown                   = "mixerShutdown"
 	StrMixerStart                      = "mixerStart"
+	StrMixerStats                      = "mixerStats"
 	StrMixingActivity                  = "mixingActivity"
+	StrMixingSchedule                  = "mixingSchedule"
+	StrMixingScheduleInfo              = "mixingScheduleInfo"
+	StrMixWindowEnd                    = "mixWindowEnd"
+	StrMixWindowStart                  = "mixWindowStart"
 	StrMonthAgo                        = "monthAgo"
+	StrMonthlySpending                 = "monthlySpending"
 	StrMonthsAgo                       = "monthsAgo"
 	StrMore                            = "more"
 	StrMoveFundsFrmDefaultToUnmixed    = "moveFundsFrmDefaultToUnmixed"
 	StrMoveToUnmixed                   = "moveToUnmixed"
 	StrMultipleMixerAccNeeded          = "multipleMixerAccNeeded"
 	StrMyAcct                          = "myAcct"
+	StrMyVote                          = "myVote"
+	StrMyVotes                         = "myVotes"
 	StrNConfirmations                  = "nConfirmations"
 	StrNetwork                         = "network"
+	StrNetworkBackend                  = "networkBackend"
+	StrNetworkBackendInfo              = "networkBackendInfo"
+	StrNeutrino                        = "neutrino"
 	StrNeverSynced                     = "neverSynced"
 	StrNewest                          = "newest"
 	StrNewProposalUpdate               = "newProposalUpdate"
@@ -468,14 +587,22 @@ const (
 	StrNext                            = "next"
 	StrNo                              = "no"
 	StrNoActiveTickets                 = "noActiveTickets"
+	StrNoAgendaHistory                 = "noAgendaHistory"
 	StrNoAgendaYet                     = "noAgendaYet"
+	StrNoAutoVoteHistory               = "noAutoVoteHistory"
+	StrNoAutoVoteRules                 = "noAutoVoteRules"
+	StrNoBannedPeers                   = "noBannedPeers"
+	StrNoComments                      = "noComments"
 	StrNoConnectedPeer                 = "noConnectedPeer"
+	StrNoConnectedPeers                = "noConnectedPeers"
 	StrNoExchangeOnTestnet             = "noExchangeOnTestnet"
 	StrNoInternet                      = "noInternet"
+	StrNoLinkedProposals               = "noLinkedProposals"
 	StrNoMixable                       = "errNoMixable"
 	StrNonAccSelector                  = "nonAccSelector"
 	StrNone                            = "none"
 	StrNoOrders                        = "noOrders"
+	StrNoPendingTSpends                = "noPendingTSpends"
 	StrNoPoliciesYet                   = "noPoliciesYet"
 	StrNoProposals                     = "noProposal"
 	StrNoReward                        = "noReward"
@@ -487,22 +614,34 @@ const (
 	StrNote                            = "note"
 	StrNotEnoughVotes                  = "notEnoughVotes"
 	StrNoTickets                       = "noTickets"
+	StrNoTicketsSelected               = "noTicketsSelected"
 	StrNotifications                   = "notifications"
 	StrNotOwned                        = "notOwned"
+	StrNotPinned                       = "notPinned"
 	StrNoTransactions                  = "noTransactions"
+	StrNoTreasuryHistory               = "noTreasuryHistory"
 	StrNotSameAccoutMixUnmix           = "notSameAccoutMixUnmix"
 	StrNotSupported                    = "notSupported"
 	StrNoUTXOs                         = "noUTXOs"
 	StrNoValidAccountFound             = "noValidAccountFound"
 	StrnoValidWalletFound              = "noValidWalletFound"
+	StrNoVersionChanges                = "noVersionChanges"
+	StrNoVote                          = "noVote"
+	StrNoVoteChoiceMismatches          = "noVoteChoiceMismatches"
+	StrNoVoteDeadlines                 = "noVoteDeadlines"
+	StrNoVoteReceipts                  = "noVoteReceipts"
+	StrNoVotesCast                     = "noVotesCast"
+	StrNoVSP                           = "noVSP"
 	StrNoVSPLoaded                     = "noVSPLoaded"
 	StrNoWalletLoaded                  = "noWalletLoaded"
 	StrNumberOfVotes                   = "numberOfVotes"
+	StrOff                             = "off"
 	StrOffChainVote                    = "offChainVote"
 	StrOffline                         = "offline"
 	StrOk                              = "ok"
 	StrOK                              = "ok"
 	StrOldest                          = "oldest"
+	StrOldestFirst                     = "oldestFirst"
 	StrOnChainVote                     = "onChainVote"
 	StrOnline                          = "online"
 	StrOpeningWallet                   = "openingWallet"
@@ -515,31 +654,49 @@ const (
 	StrOrderSendingFrom                = "orderSendingFrom"
 	StrOrderSettingsSaved              = "orderSettingsSaved"
 	StrOrderSubmitted                  = "orderSubmitted"
+	StrOrigin                          = "origin"
+	StrOriginChange                    = "originChange"
+	StrOriginExchange                  = "originExchange"
+	StrOriginMixed                     = "originMixed"
+	StrOriginUnknown                   = "originUnknown"
 	StrOverview                        = "overview"
 	StrOwned                           = "owned"
 	StrPageWarningNotSync              = "pageWarningNotSync"
 	StrPageWarningSync                 = "pageWarningSync"
 	StrPasswordNotMatch                = "passwordNotMatch"
 	StrPasteSeedWords                  = "pasteSeedWords"
+	StrPayee                           = "payee"
 	StrPeer                            = "peer"
+	StrPeerDetails                     = "peerDetails"
 	StrPeers                           = "peers"
 	StrPeersConnected                  = "peersConnected"
+	StrPeerTraffic                     = "peerTraffic"
 	StrPending                         = "pending"
+	StrPendingAutoVotes                = "pendingAutoVotes"
+	StrPendingTSpends                  = "pendingTSpends"
 	StrPercentageMixed                 = "percentageMixed"
 	StrPiKey                           = "piKey"
+	StrPinCertificate                  = "pinCertificate"
+	StrPinnedKey                       = "pinnedKey"
 	StrPolicySetSuccessful             = "policySetSuccessfully"
+	StrPoliteiaServers                 = "politeiaServers"
+	StrPreview                         = "preview"
 	StrPriority                        = "priority"
 	StrPrivacyInfo                     = "privacyInfo"
 	StrPrivacyModeActive               = "privacyModeActive"
 	StrPrivacyModeInfo                 = "privacyModeInfo"
 	StrPrivacyModeInfoDesc             = "privacyModeInfoDesc"
 	StrPrivacySettings                 = "privacySettings"
+	StrPrivacyStrategy                 = "privacyStrategy"
 	StrPropFetching                    = "propFetching"
 	StrPropNotif                       = "propNotif"
 	StrPropNotification                = "propNotification"
 	StrProposal                        = "proposals"
 	StrProposalAddedNotif              = "proposalAddedNotif"
+	StrProposalDescription             = "proposalDescription"
 	StrProposalInfo                    = "proposalInfo"
+	StrProposalName                    = "proposalName"
+	StrProposalVersionUpdatedNotif     = "proposalVersionUpdatedNotif"
 	StrProposalVoteDetails             = "proposalVoteDetails"
 	StrPublished                       = "published"
 	StrPublished2                      = "published2"
@@ -550,6 +707,8 @@ const (
 	StrRate                            = "rate"
 	StrReadyToMix                      = "readyToMix"
 	StrRebroadcast                     = "rebroadcast"
+	StrReceiptSignatureInvalid         = "receiptSignatureInvalid"
+	StrReceiptSignatureValid           = "receiptSignatureValid"
 	StrReceive                         = "receive"
 	StrReceived                        = "received"
 	StrReceiveInfo                     = "receiveInfo"
@@ -561,7 +720,9 @@ const (
 	StrReconnect                       = "reconnect"
 	StrRefresh                         = "refresh"
 	StrRejected                        = "rejected"
+	StrRejectUnderBudget               = "rejectUnderBudget"
 	StrRemove                          = "remove"
+	StrRemoveBookmark                  = "removeBookmark"
 	StrRemovePeer                      = "removePeer"
 	StrRemovePeerWarn                  = "removePeerWarn"
 	StrRemoveUserAgent                 = "removeUserAgent"
@@ -571,18 +732,24 @@ const (
 	StrRename                          = "rename"
 	StrRenameAcct                      = "renameAcct"
 	StrRenameWalletSheetTitle          = "renameWalletSheetTitle"
+	StrRepair                          = "repair"
 	StrRepublished                     = "republished"
 	StrRescan                          = "rescan"
 	StrRescanBlockchain                = "rescanBlockchain"
+	StrRescanEndHeight                 = "rescanEndHeight"
 	StrRescanInfo                      = "rescanInfo"
 	StrRescanningBlocks                = "rescanningBlocks"
 	StrRescanningHeaders               = "rescanningHeaders"
 	StrRescanProgressNotification      = "rescanProgressNotification"
+	StrRescanSpeed                     = "rescanSpeed"
+	StrRescanStartHeight               = "rescanStartHeight"
+	StrResetEndpoints                  = "resetEndpoints"
 	StrRestore                         = "restore"
 	StrRestoreExistingWallet           = "restoreExistingWallet"
 	StrRestoreWallet                   = "restoreWallet"
 	StrRestoreWithHex                  = "restoreWithHex"
 	StrResumeAccountDiscoveryTitle     = "resumeAccountDiscoveryTitle"
+	StrResumePreviousRescan            = "resumePreviousRescan"
 	StrRetry                           = "retry"
 	StrRevocation                      = "revocation"
 	StrRevoke                          = "revoke"
@@ -592,6 +759,10 @@ const (
 	StrRevokeInfoDisc                  = "revokeInfoDisc"
 	StrReward                          = "reward"
 	StrRewardsEarned                   = "rewardsEarned"
+	StrRPCCertPath                     = "rpcCertPath"
+	StrRPCPassword                     = "rpcPassword"
+	StrRPCPasswordKeep                 = "rpcPasswordKeep"
+	StrRPCUsername                     = "rpcUsername"
 	StrSave                            = "save"
 	StrScheduler                       = "scheduler"
 	StrSchedulerRunning                = "schedulerRunning"
@@ -607,6 +778,7 @@ const (
 	StrSeedPhraseVerified              = "seedPhraseVerified"
 	StrSeedValidationFailed            = "seedValidationFailed"
 	StrSelectAcc                       = "selectAcc"
+	StrSelectAll                       = "selectAll"
 	StrSelectAServer                   = "selectAServer"
 	StrSelectAssetType                 = "selectAssetType"
 	StrSelectChangeAcc                 = "selectChangeAcc"
@@ -633,22 +805,31 @@ const (
 	StrSendWarning                     = "sendWarning"
 	StrSent                            = "sent"
 	StrServer                          = "server"
+	StrServerAddress                   = "serverAddress"
+	StrServerKeyMismatch               = "serverKeyMismatch"
+	StrServerKeyRotated                = "serverKeyRotated"
+	StrServerKeyUnknown                = "serverKeyUnknown"
 	StrServerRate                      = "serverRate"
+	StrServiceEndpoints                = "serviceEndpoints"
 	StrSetChoice                       = "setchoice"
 	StrSetGapLimit                     = "setGapLimit"
 	StrSetGapLimitInfo                 = "setGapLimitInfo"
+	StrSetTicketChoice                 = "setTicketChoice"
 	StrSettings                        = "settings"
 	StrSetTreasuryPolicy               = "setTreasuryPolicy"
+	StrSetTSpendPolicy                 = "setTSpendPolicy"
 	StrSetUp                           = "setUp"
 	StrSetupMixerInfo                  = "setupMixerInfo"
 	StrSetUpNeededAccs                 = "setUpNeededAccs"
 	StrSetUpPrivacy                    = "setUpPrivacy"
 	StrSetupStakeShuffle               = "setUpStakeShuffle"
 	StrSetupStartupPassword            = "setupStartupPassword"
+	StrSetUTXOOrigin                   = "setUTXOOrigin"
 	StrSignature                       = "signature"
 	StrSignCopied                      = "signCopied"
 	StrSignMessage                     = "signMessage"
 	StrSignMessageInfo                 = "signMessageInfo"
+	StrSOCKS5Proxy                     = "socks5Proxy"
 	StrSource                          = "source"
 	StrSourceModalInfo                 = "sourceModalInfo"
 	StrSourceWalletNotSynced           = "sourceWalletNotSynced"
@@ -658,13 +839,16 @@ const (
 	StrSpendingPasswordInfo            = "spendingPasswordInfo"
 	StrSpendingPasswordInfo2           = "spendingPasswordInfo2"
 	StrSpendingPasswordUpdated         = "spendingPasswordUpdated"
+	StrSPV                             = "spv"
 	StrStake                           = "stake"
 	StrStakeAge                        = "stakeAge"
 	StrStaked                          = "staked"
 	StrStakeShuffle                    = "stakeShuffle"
+	StrStakeVersion                    = "stakeVersion"
 	StrStaking                         = "staking"
 	StrStakingActivity                 = "stakingActivity"
 	StrStart                           = "start"
+	StrStartDate                       = "startDate"
 	StrStartupPassConfirm              = "startupPassConfirm"
 	StrStartupPassword                 = "startupPassword"
 	StrStartupPasswordEnabled          = "startupPasswordEnabled"
@@ -684,7 +868,13 @@ const (
 	StrSyncingProgress                 = "syncingProgress"
 	StrSyncingProgressStat             = "syncingProgressStat"
 	StrSyncingState                    = "syncingState"
+	StrSyncLimits                      = "syncLimits"
+	StrSyncLimitsInfo                  = "syncLimitsInfo"
+	StrSyncScheduleEnd                 = "syncScheduleEnd"
+	StrSyncScheduleStart               = "syncScheduleStart"
 	StrSyncSteps                       = "syncSteps"
+	StrTag                             = "tag"
+	StrTagUTXOs                        = "tagUTXOs"
 	StrTakenAccount                    = "takenAccount"
 	StrTapToCopy                       = "tapToCopy"
 	StrTicektVoted                     = "ticektVoted"
@@ -698,9 +888,12 @@ const (
 	StrTicketRevokedTitle              = "ticketRevokedTitle"
 	StrTickets                         = "tickets"
 	StrTicketSettingSaved              = "ticketSettingSaved"
+	StrTicketVoteChoices               = "ticketVoteChoices"
 	StrTicketVotedTitle                = "ticketVotedTitle"
 	StrTimeLeft                        = "timeLeft"
+	StrTLSCertPath                     = "tlsCertPath"
 	StrTo                              = "to"
+	StrToDate                          = "toDate"
 	StrToken                           = "token"
 	StrTotal                           = "total"
 	StrTotalAmount                     = "totalAmount"
@@ -713,13 +906,30 @@ const (
 	StrTransactions                    = "transactions"
 	StrTransferred                     = "transferred"
 	StrTreasury                        = "treasury"
+	StrTreasuryAnalytics               = "treasuryAnalytics"
+	StrTreasuryBalance                 = "treasuryBalance"
+	StrTreasuryKeyLabel                = "treasuryKeyLabel"
+	StrTreasurySpend                   = "treasurySpend"
 	StrTreasurySpending                = "treasurySpending"
 	StrTreasurySpendingInfo            = "treasurySpendingInfo"
+	StrTreasurySpends                  = "treasurySpends"
+	StrTrustedVoterAddresses           = "trustedVoterAddresses"
+	StrTrustServerKey                  = "trustServerKey"
+	StrTrustServerKeyInfo              = "trustServerKeyInfo"
+	StrTSpendExpiry                    = "tspendExpiry"
+	StrTSpendLabel                     = "tspendLabel"
 	StrTxConfModalInfoTxt              = "txConfModalInfoTxt"
 	StrTxdetailsInfo                   = "txDetailsInfo"
 	StrTxEstimateErr                   = "txEstimateErr"
 	StrTxFee                           = "txFee"
 	StrTxHashCopied                    = "txHashCopied"
+	StrTxIndexConsistent               = "txIndexConsistent"
+	StrTxIndexInconsistent             = "txIndexInconsistent"
+	StrTxIndexMismatched               = "txIndexMismatched"
+	StrTxIndexMissing                  = "txIndexMissing"
+	StrTxIndexOrphaned                 = "txIndexOrphaned"
+	StrTxIndexRepaired                 = "txIndexRepaired"
+	StrTxIndexSize                     = "txIndexSize"
 	StrTxNotification                  = "txNotification"
 	StrTxOverview                      = "txOverview"
 	StrTxSent                          = "txSent"
@@ -727,20 +937,28 @@ const (
 	StrTxStatusPending                 = "txStatusPending"
 	StrType                            = "type"
 	StrUmined                          = "unmined"
+	StrUnban                           = "unban"
 	StrUnconfirmedFunds                = "unconfirmedFunds"
 	StrUnconfirmedTx                   = "unconfirmedTx"
 	StrUnderReview                     = "underReview"
+	StrUnfreeze                        = "unfreeze"
 	StrUnknown                         = "unknown"
 	StrUnlock                          = "unlock"
+	StrUnlockAndVote                   = "unlockAndVote"
+	StrUnlockDcrdRPCInfo               = "unlockDcrdRPCInfo"
+	StrUnlockDcrdRPCTitle              = "unlockDcrdRPCTitle"
 	StrUnlockWithPassword              = "unlockWithPassword"
 	StrUnminedInfo                     = "unminedInfo"
 	StrUnmixed                         = "unmixed"
 	StrUnmixedAccount                  = "unmixedAccount"
 	StrUnmixedBalance                  = "unmixedBalance"
+	StrUnpinCertificate                = "unpinCertificate"
+	StrUnvotedTickets                  = "unvotedTickets"
 	StrUpcoming                        = "upcomming"
 	StrUpdated                         = "updated"
 	StrUpdatePreference                = "updatePreference"
 	StrUpdatevotePref                  = "updateVotePref"
+	StrUpdateVSP                       = "updateVSP"
 	StrUptime                          = "uptime"
 	StrUsdBinance                      = "usdBinance"
 	StrUsdBittrex                      = "usdBittrex"
@@ -748,6 +966,8 @@ const (
 	StrUserAgent                       = "userAgent"
 	StrUserAgentDialogTitle            = "userAgentDialogTitle"
 	StrUserAgentSummary                = "userAgentSummary"
+	StrUseTLS                          = "useTLS"
+	StrUTXOTagsMixedWarning            = "utxoTagsMixedWarning"
 	StrValidAddress                    = "validAddress"
 	StrValidate                        = "validate"
 	StrValidateAddr                    = "validateAddr"
@@ -759,13 +979,16 @@ const (
 	StrValidSignature                  = "validSignature"
 	StrVerify                          = "verify"
 	StrVerifyGovernanceKeys            = "verifyGovernanceKeys"
+	StrVerifying                       = "verifying"
 	StrVerifyMessage                   = "verifyMessage"
 	StrVerifyMessageInfo               = "verifyMessageInfo"
 	StrVerifyMsgError                  = "verifyMsgError"
 	StrVerifyMsgNote                   = "verifyMsgNote"
 	StrVerifySeed                      = "verifySeed"
 	StrVerifySeedInfo                  = "verifySeedInfo"
+	StrVerifyTxIndex                   = "verifyTxIndex"
 	StrVersion                         = "version"
+	StrVersionHistory                  = "versionHistory"
 	StrViewAllOrders                   = "viewAllOrders"
 	StrViewAppLog                      = "viewAppLog"
 	StrViewDetails                     = "viewDetails"
@@ -776,12 +999,25 @@ const (
 	StrViewTicket                      = "viewTicket"
 	StrVote                            = "vote"
 	StrVoteChoice                      = "votechoice"
+	StrVoteChoiceMismatch              = "voteChoiceMismatch"
+	StrVoteChoiceMismatches            = "voteChoiceMismatches"
 	StrVoteConfirm                     = "voteConfirm"
+	StrVoteCounted                     = "voteCounted"
+	StrVoteCountedDifferently          = "voteCountedDifferently"
 	StrVoted                           = "voted"
+	StrVoteDeadlines                   = "voteDeadlines"
 	StrVotedInfo                       = "votedInfo"
 	StrVotedInfoDisc                   = "votedInfoDisc"
 	StrVotedOn                         = "votedOn"
 	StrVoteEndedNotif                  = "voteEndedNotif"
+	StrVoteEndsIn                      = "voteEndsIn"
+	StrVoteNotCounted                  = "voteNotCounted"
+	StrVoteOn                          = "voteOn"
+	StrVoteOutcome                     = "voteOutcome"
+	StrVoteReceipts                    = "voteReceipts"
+	StrVoteReminderHours               = "voteReminderHours"
+	StrVoteReminderNotif               = "voteReminderNotif"
+	StrVoteReminders                   = "voteReminders"
 	StrVoteSent                        = "voteSent"
 	StrVoteStartedNotif                = "voteStartedNotif"
 	StrVoteTooltip                     = "voteTooltip"
@@ -796,9 +1032,13 @@ const (
 	StrVsp                             = "vsp"
 	StrVSPAPI                          = "vspAPI"
 	StrVspFee                          = "vspFee"
+	StrVSPListSources                  = "vspListSources"
 	StrWaitingAuthor                   = "waitingForAuthor"
 	StrWaitingForAdmin                 = "waitingForAdmin"
 	StrWaitingState                    = "waitingState"
+	StrWalletBirthday                  = "walletBirthday"
+	StrWalletBirthdayHint              = "walletBirthdayHint"
+	StrWalletBirthdayInfo              = "walletBirthdayInfo"
 	StrWalletCreated                   = "walletCreated"
 	StrWalletDirectory                 = "walletDirectory"
 	StrWalletExist                     = "walletExist"
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/crypto-power/cryptopower/libwallet"
+	"github.com/crypto-power/cryptopower/ui/notification"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const voteReminderListenerID = "window"
+
+// voteReminderNotifier raises an OS notification for every vote reminder,
+// whichever page is displayed.
+type voteReminderNotifier struct {
+	mgr *libwallet.AssetsManager
+}
+
+func (n *voteReminderNotifier) OnVoteReminder(reminder *libwallet.VoteReminder) {
+	notifier, err := notification.NewSystemNotification()
+	if err != nil {
+		log.Errorf("Error creating vote reminder notification: %v", err)
+		return
+	}
+
+	timeLeft := components.TimeFormat(int(reminder.TimeRemaining().Seconds()), true)
+	msg := values.StringF(values.StrVoteReminderNotif, reminder.UnvotedTickets,
+		reminder.EligibleTickets, reminder.Name, timeLeft)
+	if n.mgr.OpenedWalletsCount() > 1 {
+		msg = fmt.Sprintf("[%s] %s", reminder.WalletName, msg)
+	}
+
+	if err := notifier.Notify(msg); err != nil {
+		log.Info("could not initiate vote reminder notification, reason:", err.Error())
+	}
+}
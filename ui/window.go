@@ -38,7 +38,7 @@ type Window struct {
 
 	load *load.Load
 
-	txAuthor dcr.TxAuthor
+	txAuthor *dcr.TxAuthor
 
 	walletAcctMixerStatus chan *wallet.AccountMixer
 
@@ -87,6 +87,11 @@ func CreateWindow(wal *wallet.Wallet) (*Window, error) {
 	}
 	win.load = l
 
+	mgr := wal.GetAssetsManager()
+	if err := mgr.AddVoteReminderListener(&voteReminderNotifier{mgr: mgr}, voteReminderListenerID); err != nil {
+		return nil, err
+	}
+
 	return win, nil
 }
 
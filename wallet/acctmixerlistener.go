@@ -1,14 +1,19 @@
 package wallet
 
+import "github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+
 type RunStatus int
 
 const (
 	MixerEnded RunStatus = iota
 	MixerStarted
+	MixerRunning
 )
 
-// AccountMixer is sent when account mixer started or ended.
+// AccountMixer is sent when account mixer started or ended, and when the
+// statistics of a running mixer are updated.
 type AccountMixer struct {
 	WalletID  int
 	RunStatus RunStatus
+	Stats     *dcr.MixerStats
 }
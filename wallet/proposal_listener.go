@@ -11,6 +11,7 @@ const (
 	VoteStarted
 	NewProposalFound
 	VoteFinished
+	VersionUpdated
 )
 
 type Proposal struct {